@@ -1,6 +1,13 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+
+	// Встраиваем базу часовых поясов, так как в runtime-образе она может отсутствовать:
+	_ "time/tzdata"
+
 	"github.com/DKhorkov/libs/db"
 	"github.com/DKhorkov/libs/loadenv"
 	"github.com/DKhorkov/libs/logging"
@@ -12,8 +19,11 @@ import (
 	cronPreparers "github.com/DKhorkov/plantsCareTelegramBot/internal/cron/preparers"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/handlers"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/metrics"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/server"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/storage"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/storage/memory"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/usecases"
 )
 
@@ -31,53 +41,93 @@ func main() {
 		cfg.Logging.LogFilePath,
 	)
 
-	dbConnector, err := db.New(
-		db.BuildDsn(cfg.Database),
-		cfg.Database.Driver,
-		logger,
-		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
-		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
-		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
-		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	appMetrics := metrics.New()
+
+	var (
+		appStorage interfaces.Storage
+		database   interfaces.DatabasePinger
 	)
-	if err != nil {
-		panic(err)
-	}
 
-	defer func() {
-		err = dbConnector.Close()
+	switch cfg.Storage.Type {
+	case config.StorageTypePostgres:
+		dbConnector, err := db.New(
+			db.BuildDsn(cfg.Database),
+			cfg.Database.Driver,
+			logger,
+			db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+			db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+			db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+			db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+		)
 		if err != nil {
-			logger.Error(
-				"Failed to close db connections pool",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
+			panic(err)
 		}
-	}()
 
-	useCases := usecases.New(
-		storage.New(dbConnector, logger),
-		logger,
-	)
+		defer func() {
+			err = dbConnector.Close()
+			if err != nil {
+				logger.Error(
+					"Failed to close db connections pool",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+			}
+		}()
+
+		if err = appMetrics.RegisterDB(dbConnector.Pool(), cfg.Database.Driver); err != nil {
+			panic(err)
+		}
 
-	b, err := bot.New(cfg.Bot.Token, cfg.Bot.PollTimeout)
+		appStorage = storage.New(dbConnector, cfg.Storage.QueryTimeout, appMetrics.DBQueryDuration, logger)
+		database = dbConnector.Pool()
+	case config.StorageTypeMemory:
+		memoryStorage := memory.New()
+		appStorage = memoryStorage
+		database = memoryStorage
+	default:
+		panic(fmt.Errorf("%w: %q", storage.ErrUnknownStorageType, cfg.Storage.Type))
+	}
+
+	useCases := usecases.New(appStorage, logger)
+
+	// Контекст приложения отменяется в App.Run при получении сигнала остановки:
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	poller, err := bot.NewPoller(cfg.Bot)
+	if err != nil {
+		panic(err)
+	}
+
+	b, err := bot.New(cfg.Bot.Token, poller, middlewares.OnError(logger))
 	if err != nil {
 		panic(err)
 	}
 
-	b.Use(middlewares.Logging(logger))
+	// Metrics подключается после Errors, чтобы учитывать доменные ошибки до того, как о них сообщат пользователю.
+	// Паники Metrics учитывает сам и передает дальше, чтобы Errors восстановил обработчик:
+	b.Use(
+		middlewares.Context(ctx),
+		middlewares.Logging(logger),
+		middlewares.Errors(logger),
+		middlewares.Metrics(appMetrics, handlers.Commands(handlers.Default)),
+	)
 	handlers.Prepare(b, useCases, logger, handlers.Default)
 
 	// Setup crons:
 	var crons []interfaces.Cron
 
+	// Воркеры захватывают сценарии через блокировку строк, поэтому не пересекаются между собой:
 	for i := range cfg.Notifications.CronsCount {
 		callback := cronPreparers.NewNotificationsPreparer(
 			b,
 			useCases,
 			logger,
 			cfg.Notifications.GroupsLimitPerQuery,
-			cfg.Notifications.GroupsLimitPerQuery*i,
+			cfg.Notifications.ClaimTTL,
+			cfg.Notifications.FollowUpDelays,
+			appMetrics,
+			strconv.Itoa(i+1),
 		).GetCallback()
 
 		crons = append(
@@ -90,6 +140,14 @@ func main() {
 		)
 	}
 
-	application := app.New(b, logger, crons)
-	application.Run()
+	adminServer := server.New(
+		cfg.Admin,
+		database,
+		b,
+		appMetrics.Handler(),
+		logger,
+	)
+
+	application := app.New(b, logger, crons, adminServer, cancel)
+	application.Run(ctx)
 }
@@ -1,10 +1,12 @@
 package app
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/DKhorkov/libs/logging"
 
@@ -13,30 +15,55 @@ import (
 
 const (
 	loggingTraceSkipLevel = 1
+
+	// shutdownTimeout ограничивает ожидание обработчиков и кронов при остановке. По его истечении контекст
+	// приложения отменяется, чтобы остановка не ждала медленных запросов к БД:
+	shutdownTimeout = 30 * time.Second
 )
 
 type App struct {
 	bot    interfaces.Bot
 	logger logging.Logger
 	crons  []interfaces.Cron
+	server interfaces.Server
+	cancel context.CancelFunc
 }
 
+// New создает приложение. cancel отменяет контекст, от которого производятся контексты обработчиков и кронов,
+// и вызывается при получении сигнала остановки.
 func New(
 	bot interfaces.Bot,
 	logger logging.Logger,
 	crons []interfaces.Cron,
+	server interfaces.Server,
+	cancel context.CancelFunc,
 ) *App {
 	return &App{
 		bot:    bot,
 		logger: logger,
 		crons:  crons,
+		server: server,
+		cancel: cancel,
 	}
 }
 
-func (application *App) Run() {
+func (application *App) Run(ctx context.Context) {
+	// Служебный сервер запускается первым, чтобы оркестратор мог проверять состояние бота с самого начала:
+	go func() {
+		if err := application.server.Run(); err != nil {
+			application.logger.Error(
+				"Error running admin server",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+		}
+	}()
+
 	// Launch asynchronous for graceful shutdown purpose:
 	go application.bot.Start()
 
+	application.server.SetReady(true)
+
 	// Запускаем кроны для отправки уведомлений
 	wg := new(sync.WaitGroup)
 	for _, cron := range application.crons {
@@ -45,7 +72,7 @@ func (application *App) Run() {
 		go func() {
 			defer wg.Done()
 
-			if err := cron.Run(); err != nil {
+			if err := cron.Run(ctx); err != nil {
 				application.logger.Error(
 					"Error running cron job",
 					"Error", err,
@@ -62,6 +89,14 @@ func (application *App) Run() {
 	signal.Notify(stopChannel, syscall.SIGINT, syscall.SIGTERM)
 	<-stopChannel
 
+	// Сначала снимаем готовность, чтобы оркестратор перестал направлять трафик:
+	application.server.SetReady(false)
+
+	// Контекст отменяется только после остановки бота и кронов, чтобы уже полученные обновления и начатые
+	// отправки уведомлений завершились. Если остановка затянется, прерываем их запросы по таймауту:
+	timer := time.AfterFunc(shutdownTimeout, application.cancel)
+	defer timer.Stop()
+
 	// Убиваем кроны до бота, чтобы не резать отправки:
 	for _, cron := range application.crons {
 		if err := cron.Stop(); err != nil {
@@ -72,6 +107,13 @@ func (application *App) Run() {
 	// Дожидаемся остановки всех горутин:
 	wg.Wait()
 
-	// Останавливаем приложение:
+	// Останавливаем приложение. Бот перестает получать обновления и дожидается обработки уже полученных:
 	application.bot.Stop()
+
+	application.cancel()
+
+	// Служебный сервер останавливаем последним, чтобы /healthz отвечал на протяжении всей остановки:
+	if err := application.server.Stop(); err != nil {
+		application.logger.Error("Error stopping admin server", "Error", err)
+	}
 }
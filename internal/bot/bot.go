@@ -1,17 +1,62 @@
 package bot
 
 import (
+	"sync/atomic"
 	"time"
 
 	"gopkg.in/telebot.v4"
 )
 
-func New(token string, pollTimeout time.Duration) (*telebot.Bot, error) {
+// Bot дополняет telebot.Bot данными о самом боте, которые нужны обработчикам.
+type Bot struct {
+	*telebot.Bot
+
+	lastGetMe atomic.Int64 // Время последнего успешного getMe в наносекундах Unix
+}
+
+// Username возвращает имя бота, по которому строятся ссылки на него.
+func (b *Bot) Username() string {
+	return b.Me.Username
+}
+
+// New создает бота, получающего обновления через poller. Обновления обрабатываются самим poller'ом,
+// поэтому при остановке бот дожидается обработки уже полученных обновлений.
+// onError вызывается для ошибок обработчиков и получения обновлений.
+func New(token string, poller telebot.Poller, onError func(error, telebot.Context)) (*Bot, error) {
 	cfg := telebot.Settings{
 		Token:     token,
-		Poller:    &telebot.LongPoller{Timeout: pollTimeout},
+		Poller:    newGracefulPoller(poller),
 		ParseMode: telebot.ModeHTML,
+		OnError:   onError,
+		// Параллельность обеспечивает gracefulPoller, который должен знать о завершении каждого обработчика:
+		Synchronous: true,
+	}
+
+	b, err := telebot.NewBot(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	bot := &Bot{Bot: b}
+
+	// telebot.NewBot уже выполнил getMe, чтобы заполнить Me:
+	bot.lastGetMe.Store(time.Now().UnixNano())
+
+	return bot, nil
+}
+
+// GetMe проверяет доступность Telegram Bot API и запоминает время успешной проверки.
+func (b *Bot) GetMe() error {
+	if _, err := b.Raw("getMe", nil); err != nil {
+		return err
 	}
 
-	return telebot.NewBot(cfg)
+	b.lastGetMe.Store(time.Now().UnixNano())
+
+	return nil
+}
+
+// LastGetMe возвращает время последнего успешного getMe.
+func (b *Bot) LastGetMe() time.Time {
+	return time.Unix(0, b.lastGetMe.Load())
 }
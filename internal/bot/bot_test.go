@@ -45,7 +45,7 @@ func TestNew_TableDriven_Simple(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b, err := bot.New(tt.token, tt.timeout)
+			b, err := bot.New(tt.token, bot.NewLongPoller(tt.timeout), nil)
 
 			if tt.expectError {
 				assert.Nil(t, b)
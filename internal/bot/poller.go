@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+)
+
+// updatesCapacity совпадает с размером очереди обновлений telebot по умолчанию.
+const updatesCapacity = 100
+
+var ErrUnknownBotMode = errors.New("unknown bot mode")
+
+// NewPoller возвращает способ получения обновлений в соответствии с режимом работы бота.
+func NewPoller(cfg config.BotConfig) (telebot.Poller, error) {
+	switch cfg.Mode {
+	case config.BotModePolling:
+		return NewLongPoller(cfg.PollTimeout), nil
+	case config.BotModeWebhook:
+		return NewWebhookPoller(cfg.Webhook)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBotMode, cfg.Mode)
+	}
+}
+
+// longPoller получает обновления через getUpdates.
+type longPoller struct {
+	*telebot.LongPoller
+}
+
+func NewLongPoller(timeout time.Duration) telebot.Poller {
+	return &longPoller{
+		LongPoller: &telebot.LongPoller{Timeout: timeout},
+	}
+}
+
+// Poll снимает webhook, оставшийся после работы в режиме webhook: пока он установлен, Telegram отклоняет getUpdates.
+func (p *longPoller) Poll(b *telebot.Bot, dest chan telebot.Update, stop chan struct{}) {
+	if err := b.RemoveWebhook(); err != nil {
+		b.OnError(err, nil)
+	}
+
+	p.LongPoller.Poll(b, dest, stop)
+}
+
+// gracefulPoller обрабатывает обновления вместо цикла telebot.Bot.Start, чтобы при остановке бота
+// дождаться обработки обновлений, которые уже получены от Telegram.
+type gracefulPoller struct {
+	poller   telebot.Poller
+	inFlight sync.WaitGroup
+}
+
+func newGracefulPoller(poller telebot.Poller) *gracefulPoller {
+	return &gracefulPoller{poller: poller}
+}
+
+func (p *gracefulPoller) Poll(b *telebot.Bot, _ chan telebot.Update, stop chan struct{}) {
+	updates := make(chan telebot.Update, updatesCapacity)
+	pollerStop := make(chan struct{})
+	pollerDone := make(chan struct{})
+
+	go func() {
+		defer close(pollerDone)
+
+		p.poller.Poll(b, updates, pollerStop)
+	}()
+
+	for {
+		select {
+		case update := <-updates:
+			p.process(b, update)
+		case <-stop:
+			close(pollerStop)
+			p.drain(b, updates, pollerDone)
+
+			return
+		}
+	}
+}
+
+// drain обрабатывает обновления, которые poller успевает получить до своей остановки,
+// и дожидается завершения всех обработчиков.
+func (p *gracefulPoller) drain(b *telebot.Bot, updates chan telebot.Update, pollerDone chan struct{}) {
+	for {
+		select {
+		case update := <-updates:
+			p.process(b, update)
+		case <-pollerDone:
+			for len(updates) > 0 {
+				p.process(b, <-updates)
+			}
+
+			p.inFlight.Wait()
+
+			return
+		}
+	}
+}
+
+func (p *gracefulPoller) process(b *telebot.Bot, update telebot.Update) {
+	p.inFlight.Add(1)
+
+	go func() {
+		defer p.inFlight.Done()
+
+		b.ProcessUpdate(update)
+	}()
+}
@@ -0,0 +1,123 @@
+package bot
+
+import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/telebot.v4"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubPoller отдает обновления до и после сигнала остановки, как webhook с незавершенными запросами.
+type stubPoller struct {
+	before int
+	after  int
+	sent   chan struct{}
+}
+
+func (p *stubPoller) Poll(_ *telebot.Bot, dest chan telebot.Update, stop chan struct{}) {
+	for i := range p.before {
+		dest <- newTextUpdate(i)
+	}
+
+	close(p.sent)
+	<-stop
+
+	for i := range p.after {
+		dest <- newTextUpdate(p.before + i)
+	}
+}
+
+func newTextUpdate(id int) telebot.Update {
+	return telebot.Update{
+		ID: id,
+		Message: &telebot.Message{
+			Text:   "text",
+			Chat:   &telebot.Chat{ID: 1},
+			Sender: &telebot.User{ID: 1},
+		},
+	}
+}
+
+func TestGracefulPoller_DrainsInFlightUpdatesOnStop(t *testing.T) {
+	poller := &stubPoller{before: 5, after: 3, sent: make(chan struct{})}
+
+	b, err := telebot.NewBot(
+		telebot.Settings{
+			Offline:     true,
+			Synchronous: true,
+			Poller:      newGracefulPoller(poller),
+		},
+	)
+	require.NoError(t, err)
+
+	var processed atomic.Int32
+
+	b.Handle(telebot.OnText, func(telebot.Context) error {
+		// Медленный обработчик, который еще выполняется в момент остановки:
+		time.Sleep(50 * time.Millisecond)
+		processed.Add(1)
+
+		return nil
+	})
+
+	go b.Start()
+
+	<-poller.sent
+	b.Stop()
+
+	assert.Equal(t, int32(poller.before+poller.after), processed.Load())
+}
+
+func TestNewPoller(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.BotConfig
+		expectError bool
+	}{
+		{
+			name: "Long polling",
+			cfg:  config.BotConfig{Mode: config.BotModePolling, PollTimeout: time.Second},
+		},
+		{
+			name: "Webhook",
+			cfg: config.BotConfig{
+				Mode: config.BotModeWebhook,
+				Webhook: config.WebhookConfig{
+					Listen:      "0.0.0.0:8443",
+					PublicURL:   "https://example.com/bot",
+					SecretToken: "secret",
+				},
+			},
+		},
+		{
+			name: "Webhook without secret token",
+			cfg: config.BotConfig{
+				Mode:    config.BotModeWebhook,
+				Webhook: config.WebhookConfig{PublicURL: "https://example.com/bot"},
+			},
+			expectError: true,
+		},
+		{
+			name:        "Unknown mode",
+			cfg:         config.BotConfig{Mode: "unknown"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poller, err := NewPoller(tt.cfg)
+
+			if tt.expectError {
+				assert.Nil(t, poller)
+				assert.Error(t, err)
+			} else {
+				assert.NotNil(t, poller)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
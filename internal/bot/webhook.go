@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+)
+
+const (
+	// webhookShutdownTimeout ограничивает ожидание запросов от Telegram, которые обрабатываются в момент остановки:
+	webhookShutdownTimeout = 10 * time.Second
+	webhookReadTimeout     = 10 * time.Second
+)
+
+var (
+	ErrWebhookPublicURLRequired   = errors.New("webhook public URL is required")
+	ErrWebhookSecretTokenRequired = errors.New("webhook secret token is required")
+)
+
+// WebhookPoller принимает обновления от Telegram по HTTP. Подходит для работы за reverse proxy
+// и нескольких реплик: Telegram отправляет каждое обновление только в одну из них.
+// Запросы принимает telebot.Webhook, который отбрасывает запросы без секретного токена, указанного при регистрации.
+type WebhookPoller struct {
+	listen  string
+	webhook *telebot.Webhook
+}
+
+func NewWebhookPoller(cfg config.WebhookConfig) (*WebhookPoller, error) {
+	if cfg.PublicURL == "" {
+		return nil, ErrWebhookPublicURLRequired
+	}
+
+	if cfg.SecretToken == "" {
+		return nil, ErrWebhookSecretTokenRequired
+	}
+
+	return &WebhookPoller{
+		listen: cfg.Listen,
+		webhook: &telebot.Webhook{
+			SecretToken: cfg.SecretToken,
+			Endpoint:    &telebot.WebhookEndpoint{PublicURL: cfg.PublicURL},
+			// Webhook регистрируется в Poll: при ошибке telebot.Webhook закрывает канал остановки сам.
+			IgnoreSetWebhook: true,
+		},
+	}, nil
+}
+
+// Poll регистрирует webhook и принимает запросы, пока бот не остановлен. HTTP-сервер запускается здесь, а не
+// в telebot.Webhook: его сервер при остановке не дожидается запросов, которые еще передают обновления в dest.
+func (p *WebhookPoller) Poll(b *telebot.Bot, dest chan telebot.Update, stop chan struct{}) {
+	if err := b.SetWebhook(p.webhook); err != nil {
+		b.OnError(err, nil)
+		<-stop
+
+		return
+	}
+
+	// telebot.Webhook без Listen только запоминает dest и ждет сигнала остановки. Когда сигнал принят,
+	// dest уже сохранен, и webhook готов принимать запросы:
+	webhookStop := make(chan struct{})
+	go p.webhook.Poll(b, dest, webhookStop)
+	webhookStop <- struct{}{}
+
+	server := &http.Server{
+		Addr:              p.listen,
+		Handler:           p.webhook,
+		ReadHeaderTimeout: webhookReadTimeout,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			b.OnError(err, nil)
+		}
+	}()
+
+	<-stop
+
+	// Shutdown дожидается запросов, которые уже передают обновления в dest:
+	ctx, cancel := context.WithTimeout(context.Background(), webhookShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		b.OnError(err, nil)
+	}
+}
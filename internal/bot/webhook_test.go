@@ -0,0 +1,124 @@
+package bot_test
+
+import (
+	"encoding/json"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/bot"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/telebot.v4"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWebhookPoller(t *testing.T) {
+	_, err := bot.NewWebhookPoller(config.WebhookConfig{SecretToken: "secret"})
+	assert.ErrorIs(t, err, bot.ErrWebhookPublicURLRequired)
+
+	_, err = bot.NewWebhookPoller(config.WebhookConfig{PublicURL: "https://example.com/bot"})
+	assert.ErrorIs(t, err, bot.ErrWebhookSecretTokenRequired)
+}
+
+func TestWebhookPoller_Poll(t *testing.T) {
+	registered := make(chan string, 1)
+
+	// Bot API, который принимает регистрацию webhook:
+	api := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/setWebhook") {
+					var params map[string]string
+					_ = json.NewDecoder(r.Body).Decode(&params)
+					registered <- params["secret_token"]
+				}
+
+				_, _ = w.Write([]byte(`{"ok": true, "result": true}`))
+			},
+		),
+	)
+	defer api.Close()
+
+	b, err := telebot.NewBot(telebot.Settings{URL: api.URL, Token: "token", Offline: true})
+	require.NoError(t, err)
+
+	listen := getFreeAddress(t)
+	poller, err := bot.NewWebhookPoller(
+		config.WebhookConfig{
+			Listen:      listen,
+			PublicURL:   "https://example.com/bot",
+			SecretToken: "secret",
+		},
+	)
+	require.NoError(t, err)
+
+	dest := make(chan telebot.Update, 1)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		poller.Poll(b, dest, stop)
+	}()
+
+	assert.Equal(t, "secret", <-registered)
+
+	send := func(secretToken string) {
+		require.Eventually(
+			t,
+			func() bool {
+				request, err := http.NewRequest(
+					http.MethodPost,
+					"http://"+listen+"/bot",
+					strings.NewReader(`{"update_id": 42, "message": {"text": "/start"}}`),
+				)
+				if err != nil {
+					return false
+				}
+
+				request.Header.Set("X-Telegram-Bot-Api-Secret-Token", secretToken)
+
+				response, err := http.DefaultClient.Do(request)
+				if err != nil {
+					return false
+				}
+
+				_ = response.Body.Close()
+
+				return true
+			},
+			time.Second,
+			10*time.Millisecond,
+		)
+	}
+
+	send("wrong")
+	assert.Empty(t, dest)
+
+	send("secret")
+	require.Len(t, dest, 1)
+
+	update := <-dest
+	assert.Equal(t, 42, update.ID)
+	assert.Equal(t, "/start", update.Message.Text)
+
+	close(stop)
+	<-done
+}
+
+// getFreeAddress возвращает адрес свободного локального порта для сервера webhook.
+func getFreeAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	return address
+}
@@ -0,0 +1,93 @@
+package buttons
+
+import (
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	ManageGroupCareTasks = telebot.InlineButton{
+		Unique: "manageGroupCareTasks",
+		Text:   "Подкормка, опрыскивание и пересадка 🌱",
+	}
+
+	ManagePlantCareTasks = telebot.InlineButton{
+		Unique: "managePlantCareTasks",
+		Text:   "Подкормка, опрыскивание и пересадка 🌱",
+	}
+
+	AddCareTask = telebot.InlineButton{
+		Unique: "addCareTask",
+		Text:   "Добавить задачу ухода ➕",
+	}
+
+	BackToCareTasks = telebot.InlineButton{
+		Unique: "backToCareTasks",
+		Text:   "Назад ↩️",
+	}
+
+	BackToAddCareTaskType = telebot.InlineButton{
+		Unique: "backToAddCareTaskType",
+		Text:   "Назад ↩️",
+	}
+
+	BackToAddCareTaskLastDate = telebot.InlineButton{
+		Unique: "backToAddCareTaskLastDate",
+		Text:   "Назад ↩️",
+	}
+
+	BackToAddCareTaskInterval = telebot.InlineButton{
+		Unique: "backToAddCareTaskInterval",
+		Text:   "Назад ↩️",
+	}
+
+	ConfirmAddCareTask = telebot.InlineButton{
+		Unique: "confirmAddCareTask",
+		Text:   "Все верно ✅",
+	}
+
+	ManageCareTaskChangeLastDate = telebot.InlineButton{
+		Unique: "manageCareTaskChangeLastDate",
+		Text:   "Изменить дату последнего ухода",
+	}
+
+	ManageCareTaskChangeInterval = telebot.InlineButton{
+		Unique: "manageCareTaskChangeInterval",
+		Text:   "Изменить интервал ухода",
+	}
+
+	ManageCareTaskRemoval = telebot.InlineButton{
+		Unique: "manageCareTaskRemoval",
+		Text:   "Удалить задачу ухода 🗑",
+	}
+
+	ConfirmCareTaskRemoval = telebot.InlineButton{
+		Unique: "confirmCareTaskRemoval",
+		Text:   "Подтвердить удаление ✅",
+	}
+
+	BackToManageCareTask = telebot.InlineButton{
+		Unique: "backToManageCareTask",
+		Text:   "Назад ↩️",
+	}
+
+	CareTaskDone = telebot.InlineButton{
+		Unique: "careTaskDone",
+		Text:   "Уход выполнен ✅",
+	}
+
+	ManageCareTask = telebot.InlineButton{
+		Unique: "manageCareTask",
+	}
+
+	AddCareTaskType = telebot.InlineButton{
+		Unique: "addCareTaskType",
+	}
+
+	AddCareTaskInterval = telebot.InlineButton{
+		Unique: "addCareTaskInterval",
+	}
+
+	ChangeCareTaskInterval = telebot.InlineButton{
+		Unique: "changeCareTaskInterval",
+	}
+)
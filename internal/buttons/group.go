@@ -50,6 +50,11 @@ var (
 		Text:   "Просмотр растений в данном сценарии 👀",
 	}
 
+	ManageGroupWateringHistory = telebot.InlineButton{
+		Unique: "manageGroupWateringHistory",
+		Text:   "История поливов 📖",
+	}
+
 	ManageGroupChange = telebot.InlineButton{
 		Unique: "manageGroupChange",
 		Text:   "Редактировать сценарий полива 🛠",
@@ -100,15 +105,49 @@ var (
 		Text:   "Изменить интервал полива сценария",
 	}
 
+	ManageGroupChangeSeasonSchedule = telebot.InlineButton{
+		Unique: "manageGroupChangeSeasonSchedule",
+		Text:   "Изменить сезонный график полива 🍂",
+	}
+
+	BackToChangeGroupSeasonSchedule = telebot.InlineButton{
+		Unique: "backToChangeGroupSeasonSchedule",
+		Text:   "Назад ↩️",
+	}
+
 	GroupWatered = telebot.InlineButton{
 		Unique: "groupWatered",
 		Text:   "Растения в данном сценарии политы ✅",
 	}
 
+	AllGroupsWatered = telebot.InlineButton{
+		Unique: "allGroupsWatered",
+		Text:   "Все сценарии политы 💧",
+	}
+
+	GroupRemindLater = telebot.InlineButton{
+		Unique: "groupRemindLater",
+		Text:   "Напомнить через 3 часа ⏰",
+	}
+
+	GroupRemindTomorrow = telebot.InlineButton{
+		Unique: "groupRemindTomorrow",
+		Text:   "Напомнить завтра 📅",
+	}
+
+	GroupSkipWatering = telebot.InlineButton{
+		Unique: "groupSkipWatering",
+		Text:   "Пропустить этот полив ⏭",
+	}
+
 	ManageGroup = telebot.InlineButton{
 		Unique: "manageGroup",
 	}
 
+	WateringHistoryPage = telebot.InlineButton{
+		Unique: "wateringHistoryPage",
+	}
+
 	AddGroupWateringInterval = telebot.InlineButton{
 		Unique: "addGroupWateringInterval",
 	}
@@ -116,4 +155,12 @@ var (
 	ChangeGroupWateringInterval = telebot.InlineButton{
 		Unique: "changeGroupWateringInterval",
 	}
+
+	ChangeGroupSeason = telebot.InlineButton{
+		Unique: "changeGroupSeason",
+	}
+
+	ChangeGroupSeasonInterval = telebot.InlineButton{
+		Unique: "changeGroupSeasonInterval",
+	}
 )
@@ -0,0 +1,49 @@
+package buttons
+
+import (
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	Handover = telebot.InlineButton{
+		Unique: "handover",
+		Text:   "Передать растения 🧳",
+	}
+
+	NewHandover = telebot.InlineButton{
+		Unique: "newHandover",
+		Text:   "Новая передача ➕",
+	}
+
+	HandoverGroup = telebot.InlineButton{
+		Unique: "handoverGroup",
+	}
+
+	HandoverChooseEndDate = telebot.InlineButton{
+		Unique: "handoverChooseEndDate",
+		Text:   "Далее ➡️",
+	}
+
+	CancelHandover = telebot.InlineButton{
+		Unique: "cancelHandover",
+	}
+
+	BackToHandover = telebot.InlineButton{
+		Unique: "backToHandover",
+		Text:   "Назад ↩️",
+	}
+
+	SitterGroupPlants = telebot.InlineButton{
+		Unique: "sitterGroupPlants",
+		Text:   "Растения 🪴",
+	}
+
+	SitterPlant = telebot.InlineButton{
+		Unique: "sitterPlant",
+	}
+
+	SitterClose = telebot.InlineButton{
+		Unique: "sitterClose",
+		Text:   "Закрыть ✖️",
+	}
+)
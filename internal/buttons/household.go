@@ -0,0 +1,27 @@
+package buttons
+
+import (
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	SettingsHousehold = telebot.InlineButton{
+		Unique: "settingsHousehold",
+		Text:   "Совместный уход 🏡",
+	}
+
+	CreateHouseholdInvite = telebot.InlineButton{
+		Unique: "createHouseholdInvite",
+		Text:   "Пригласить участника ✉️",
+	}
+
+	LeaveHousehold = telebot.InlineButton{
+		Unique: "leaveHousehold",
+		Text:   "Покинуть дом 🚪",
+	}
+
+	BackToHousehold = telebot.InlineButton{
+		Unique: "backToHousehold",
+		Text:   "Назад ↩️",
+	}
+)
@@ -0,0 +1,31 @@
+package buttons
+
+import (
+	"gopkg.in/telebot.v4"
+)
+
+// Кнопки подтверждения загрузки различаются только данными со способом разрешения конфликтов,
+// поэтому обрабатываются одним обработчиком ConfirmImport.
+const confirmImportUnique = "confirmImport"
+
+var (
+	ConfirmImport = telebot.InlineButton{
+		Unique: confirmImportUnique,
+		Text:   "Загрузить ✅",
+	}
+
+	ImportSkipConflicts = telebot.InlineButton{
+		Unique: confirmImportUnique,
+		Text:   "Пропустить совпадающие ⏭",
+	}
+
+	ImportRenameConflicts = telebot.InlineButton{
+		Unique: confirmImportUnique,
+		Text:   "Загрузить под новыми названиями ✏️",
+	}
+
+	ImportOverwriteConflicts = telebot.InlineButton{
+		Unique: confirmImportUnique,
+		Text:   "Заменить совпадающие ♻️",
+	}
+)
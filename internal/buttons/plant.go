@@ -115,6 +115,26 @@ var (
 		Text:   "Изменить фотографию растения",
 	}
 
+	ManagePlantChangeWateringInterval = telebot.InlineButton{
+		Unique: "managePlantChangeWateringInterval",
+		Text:   "Изменить интервал полива растения",
+	}
+
+	ManagePlantGallery = telebot.InlineButton{
+		Unique: "managePlantGallery",
+		Text:   "Фотографии растения 📷",
+	}
+
+	ManagePlantAddPhoto = telebot.InlineButton{
+		Unique: "managePlantAddPhoto",
+		Text:   "Добавить фотографию 📸",
+	}
+
+	BackToPlantGallery = telebot.InlineButton{
+		Unique: "backToPlantGallery",
+		Text:   "Назад ↩️",
+	}
+
 	BackToManagePlantChange = telebot.InlineButton{
 		Unique: "backToManagePlantChange",
 		Text:   "Назад ↩️",
@@ -135,4 +155,20 @@ var (
 	ManagePlant = telebot.InlineButton{
 		Unique: "managePlant",
 	}
+
+	ChangePlantWateringInterval = telebot.InlineButton{
+		Unique: "changePlantWateringInterval",
+	}
+
+	PlantWatered = telebot.InlineButton{
+		Unique: "plantWatered",
+	}
+
+	PlantGalleryPage = telebot.InlineButton{
+		Unique: "plantGalleryPage",
+	}
+
+	SetPlantCoverPhoto = telebot.InlineButton{
+		Unique: "setPlantCoverPhoto",
+	}
 )
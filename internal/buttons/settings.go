@@ -0,0 +1,82 @@
+package buttons
+
+import (
+	"gopkg.in/telebot.v4"
+)
+
+var (
+	Settings = telebot.InlineButton{
+		Unique: "settings",
+		Text:   "Настройки ⚙️",
+	}
+
+	SettingsChangeTimezone = telebot.InlineButton{
+		Unique: "settingsChangeTimezone",
+		Text:   "Изменить часовой пояс 🌍",
+	}
+
+	SettingsChangeNotifyHour = telebot.InlineButton{
+		Unique: "settingsChangeNotifyHour",
+		Text:   "Изменить время уведомлений ⏰",
+	}
+
+	SettingsToggleNotificationsDigest = telebot.InlineButton{
+		Unique: "settingsToggleNotificationsDigest",
+		Text:   "Изменить режим уведомлений 📬",
+	}
+
+	SettingsQuiet = telebot.InlineButton{
+		Unique: "settingsQuiet",
+		Text:   "Не беспокоить 🌙",
+	}
+
+	SettingsChangeQuietHours = telebot.InlineButton{
+		Unique: "settingsChangeQuietHours",
+		Text:   "Изменить тихие часы 🌙",
+	}
+
+	SettingsChangeQuietWeekdays = telebot.InlineButton{
+		Unique: "settingsChangeQuietWeekdays",
+		Text:   "Дни без уведомлений 📵",
+	}
+
+	SettingsChangeVacation = telebot.InlineButton{
+		Unique: "settingsChangeVacation",
+		Text:   "Запланировать отпуск 🏖",
+	}
+
+	SettingsCancelVacation = telebot.InlineButton{
+		Unique: "settingsCancelVacation",
+		Text:   "Отменить отпуск ❌",
+	}
+
+	SettingsQuietHoursStart = telebot.InlineButton{
+		Unique: "settingsQuietHoursStart",
+	}
+
+	SettingsQuietHoursEnd = telebot.InlineButton{
+		Unique: "settingsQuietHoursEnd",
+	}
+
+	SettingsQuietWeekday = telebot.InlineButton{
+		Unique: "settingsQuietWeekday",
+	}
+
+	BackToSettingsQuiet = telebot.InlineButton{
+		Unique: "backToSettingsQuiet",
+		Text:   "Назад ↩️",
+	}
+
+	SettingsTimezone = telebot.InlineButton{
+		Unique: "settingsTimezone",
+	}
+
+	SettingsNotifyHour = telebot.InlineButton{
+		Unique: "settingsNotifyHour",
+	}
+
+	BackToSettings = telebot.InlineButton{
+		Unique: "backToSettings",
+		Text:   "Назад ↩️",
+	}
+)
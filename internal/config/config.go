@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DKhorkov/libs/db"
@@ -18,6 +20,22 @@ func New() Config {
 			PollTimeout: time.Second * time.Duration(
 				loadenv.GetEnvAsInt("BOT_POLL_TIMEOUT", 10),
 			),
+			Mode: loadenv.GetEnv("BOT_MODE", BotModePolling),
+			Webhook: WebhookConfig{
+				Listen:      loadenv.GetEnv("BOT_WEBHOOK_LISTEN", "0.0.0.0:8443"),
+				PublicURL:   loadenv.GetEnv("BOT_WEBHOOK_PUBLIC_URL", ""),
+				SecretToken: loadenv.GetEnv("BOT_WEBHOOK_SECRET_TOKEN", ""),
+			},
+		},
+		Admin: AdminConfig{
+			Listen: loadenv.GetEnv("ADMIN_LISTEN", "0.0.0.0:8080"),
+			GetMeInterval: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("ADMIN_GET_ME_INTERVAL", 30),
+			),
+			// Должно быть больше GetMeInterval, чтобы одна неудачная проверка не делала бота нездоровым:
+			MaxGetMeAge: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("ADMIN_MAX_GET_ME_AGE", 90),
+			),
 		},
 		Logging: logging.Config{
 			Level:       logging.Levels.DEBUG,
@@ -42,32 +60,96 @@ func New() Config {
 				),
 			},
 		},
+		Storage: StorageConfig{
+			Type: loadenv.GetEnv("STORAGE_TYPE", StorageTypePostgres),
+			QueryTimeout: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("STORAGE_QUERY_TIMEOUT", 5),
+			),
+		},
 		Notifications: NotificationsConfig{
 			GroupsLimitPerQuery: loadenv.GetEnvAsInt("GROUPS_LIMIT_PER_QUERY", 10),
 			CronCheckInterval: time.Minute * time.Duration(
 				loadenv.GetEnvAsInt("CRON_CHECK_INTERVAL", 5),
 			),
 			CronsCount: loadenv.GetEnvAsInt("CRONS_COUNT", 3),
+			// Должно быть меньше CronCheckInterval, чтобы на следующей итерации сценарии снова были доступны:
+			ClaimTTL: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("NOTIFICATIONS_CLAIM_TTL", 60),
+			),
+			// Через сколько часов после первого неподтвержденного напоминания отправлять повторные.
+			// Напоминание на следующее утро отправляется ежедневной рассылкой:
+			FollowUpDelays: getEnvAsHours("NOTIFICATIONS_FOLLOW_UP_HOURS", "4"),
 		},
 	}
 }
 
+// Режимы получения обновлений от Telegram.
+const (
+	BotModePolling = "polling"
+	BotModeWebhook = "webhook"
+)
+
 type BotConfig struct {
 	Token       string
 	PollTimeout time.Duration
+	Mode        string
+	Webhook     WebhookConfig
+}
+
+type WebhookConfig struct {
+	Listen      string // Адрес, на котором бот принимает запросы от Telegram или reverse proxy
+	PublicURL   string // Публичный HTTPS-адрес, который регистрируется в Telegram
+	SecretToken string // Передается Telegram в заголовке каждого запроса для проверки его подлинности
+}
+
+type AdminConfig struct {
+	Listen        string        // Адрес HTTP-сервера с /healthz, /readyz и /metrics
+	GetMeInterval time.Duration // Как часто проверять доступность Telegram Bot API
+	MaxGetMeAge   time.Duration // Через сколько после последнего успешного getMe бот считается нездоровым
+}
+
+// Реализации хранилища данных.
+const (
+	StorageTypePostgres = "postgres"
+	StorageTypeMemory   = "memory" // Данные теряются при остановке бота, для локального запуска и тестов
+)
+
+type StorageConfig struct {
+	Type         string
+	QueryTimeout time.Duration // Ограничение времени выполнения одного запроса к БД, 0 - без ограничения
 }
 
 type NotificationsConfig struct {
 	GroupsLimitPerQuery int
 	CronCheckInterval   time.Duration
 	CronsCount          int
+	ClaimTTL            time.Duration
+	FollowUpDelays      []time.Duration
 }
 
 type Config struct {
 	Bot           BotConfig
+	Admin         AdminConfig
 	Database      db.Config
+	Storage       StorageConfig
 	Notifications NotificationsConfig
 	Logging       logging.Config
 	Environment   string
 	Version       string
 }
+
+// getEnvAsHours читает список часов через запятую, например "4,8". Некорректные значения пропускаются.
+func getEnvAsHours(key, defaultValue string) []time.Duration {
+	var durations []time.Duration
+
+	for _, value := range strings.Split(loadenv.GetEnv(key, defaultValue), ",") {
+		hours, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || hours <= 0 {
+			continue
+		}
+
+		durations = append(durations, time.Hour*time.Duration(hours))
+	}
+
+	return durations
+}
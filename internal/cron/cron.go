@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"context"
 	"time"
 
 	"github.com/DKhorkov/libs/logging"
@@ -25,7 +26,9 @@ func New(logger logging.Logger, callback interfaces.Callback, interval time.Dura
 	}
 }
 
-func (c *Cron) Run() (err error) {
+// Run вызывает callback с заданным интервалом до вызова Stop или отмены ctx. Контекст передается в callback,
+// чтобы при остановке приложения прерывались и выполняемые им запросы.
+func (c *Cron) Run(ctx context.Context) (err error) {
 	ticker := time.NewTicker(c.interval)
 	defer ticker.Stop()
 
@@ -33,6 +36,8 @@ func (c *Cron) Run() (err error) {
 		select {
 		case <-c.stopChan:
 			return nil
+		case <-ctx.Done():
+			return nil
 		case <-ticker.C:
 			// recover() работает ТОЛЬКО в той же горутине, где произошла паника.
 			// Оборачиваем вызов callback в recover.
@@ -45,11 +50,16 @@ func (c *Cron) Run() (err error) {
 					}
 				}()
 
-				if err = c.callback(); err != nil {
+				if err = c.callback(ctx); err != nil {
 					return
 				}
 			}()
 
+			// Ошибка из-за отмены ctx означает остановку приложения, а не сбой крона:
+			if err != nil && ctx.Err() != nil {
+				return nil
+			}
+
 			// Возвращаем ошибку только если она существует, чтобы не прерывать цикл крона:
 			if err != nil {
 				return err
@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"context"
 	"errors"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
@@ -25,13 +26,13 @@ func TestNew_CronInitialization(t *testing.T) {
 		{
 			name:     "Valid dependencies",
 			logger:   mocklogging.NewMockLogger(ctrl),
-			callback: func() error { return nil },
+			callback: func(context.Context) error { return nil },
 			interval: 100 * time.Millisecond,
 		},
 		{
 			name:     "Nil logger",
 			logger:   nil,
-			callback: func() error { return nil },
+			callback: func(context.Context) error { return nil },
 			interval: 100 * time.Millisecond,
 		},
 		{
@@ -43,7 +44,7 @@ func TestNew_CronInitialization(t *testing.T) {
 		{
 			name:     "Zero interval",
 			logger:   mocklogging.NewMockLogger(ctrl),
-			callback: func() error { return nil },
+			callback: func(context.Context) error { return nil },
 			interval: 0,
 		},
 	}
@@ -74,7 +75,7 @@ func TestCron_Run_Stop(t *testing.T) {
 	called := false
 	var mu sync.Mutex
 
-	callback := func() error {
+	callback := func(context.Context) error {
 		mu.Lock()
 		called = true
 		mu.Unlock()
@@ -85,7 +86,7 @@ func TestCron_Run_Stop(t *testing.T) {
 
 	// Запускаем Run в отдельной горутине
 	go func() {
-		_ = cron.Run()
+		_ = cron.Run(context.Background())
 	}()
 
 	// Даём время на запуск
@@ -109,13 +110,13 @@ func TestCron_Run_CallbackError(t *testing.T) {
 	logger := mocklogging.NewMockLogger(ctrl)
 	expectedErr := errors.New("callback failed")
 
-	cron := New(logger, func() error {
+	cron := New(logger, func(context.Context) error {
 		return expectedErr
 	}, 10*time.Millisecond)
 
 	done := make(chan error, 1)
 	go func() {
-		done <- cron.Run()
+		done <- cron.Run(context.Background())
 	}()
 
 	select {
@@ -135,12 +136,37 @@ func TestCron_Run_PanicRecovery(t *testing.T) {
 		Error("Recovered from panic", "Recovered", "test panic").
 		Times(1)
 
-	cron := New(logger, func() error {
+	cron := New(logger, func(context.Context) error {
 		panic("test panic")
 	}, 10*time.Millisecond)
 
-	err := cron.Run()
+	err := cron.Run(context.Background())
 
 	assert.Error(t, err)
 	assert.Equal(t, customerrors.ErrPanic, err, "Должна быть возвращена ошибка паники")
 }
+
+func TestCron_Run_ContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := mocklogging.NewMockLogger(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Callback прерывается отменой контекста и возвращает ее ошибку:
+	cron := New(logger, func(ctx context.Context) error {
+		cancel()
+
+		return ctx.Err()
+	}, 10*time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cron.Run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "Отмена контекста не должна считаться ошибкой крона")
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Run не завершился после отмены контекста")
+	}
+}
@@ -1,10 +1,13 @@
 package preparers
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"html"
+	"slices"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/DKhorkov/libs/logging"
@@ -12,14 +15,16 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/metrics"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
 )
 
 const (
 	dateFormat            = "02.01.2006"
-	sendHour              = 12
+	hoursPerDay           = 24
 	loggingTraceSkipLevel = 1
 )
 
@@ -28,8 +33,10 @@ type NotificationsPreparer struct {
 	useCases       interfaces.UseCases
 	logger         logging.Logger
 	limit          int
-	offset         int
-	notifiedGroups *sync.Map
+	claimTTL       time.Duration
+	followUpDelays []time.Duration
+	metrics        *metrics.Metrics
+	cron           string // Имя крона, по которому разбиваются метрики отправки уведомлений
 }
 
 func NewNotificationsPreparer(
@@ -37,81 +44,457 @@ func NewNotificationsPreparer(
 	useCases interfaces.UseCases,
 	logger logging.Logger,
 	limit int,
-	offset int,
+	claimTTL time.Duration,
+	followUpDelays []time.Duration,
+	metrics *metrics.Metrics,
+	cron string,
 ) *NotificationsPreparer {
 	return &NotificationsPreparer{
 		bot:            bot,
 		useCases:       useCases,
 		logger:         logger,
 		limit:          limit,
-		offset:         offset,
-		notifiedGroups: new(sync.Map),
+		claimTTL:       claimTTL,
+		followUpDelays: slices.Sorted(slices.Values(followUpDelays)),
+		metrics:        metrics,
+		cron:           cron,
 	}
 }
 
+// GetCallback возвращает колбэк, который порциями захватывает сценарии и задачи ухода для уведомления,
+// пока они не закончатся. Захваченные записи недоступны другим воркерам в течение claimTTL,
+// поэтому воркеры не пересекаются. Ошибки отдельных записей не прерывают обработку остальных
+// и возвращаются вместе после обработки всех захваченных записей.
 func (p *NotificationsPreparer) GetCallback() interfaces.Callback {
-	return func() error {
-		if !p.canNotifyByTime() {
-			return nil
-		}
+	return func(ctx context.Context) error {
+		return errors.Join(p.prepareGroups(ctx), p.prepareCareTasks(ctx))
+	}
+}
+
+func (p *NotificationsPreparer) prepareGroups(ctx context.Context) error {
+	var errs []error
 
-		groups, err := p.useCases.GetGroupsForNotify(p.limit, p.offset)
+	for {
+		groups, err := p.useCases.ClaimGroupsForNotify(ctx, p.limit, p.claimTTL)
 		if err != nil {
-			return err
+			return errors.Join(append(errs, err)...)
 		}
 
+		now := time.Now()
+
+		// Ошибка одного сценария не должна оставлять без напоминаний остальные захваченные сценарии,
+		// которые иначе стали бы доступны только после истечения claimTTL:
 		for _, group := range groups {
-			if p.alreadyNotified(group) {
-				continue
+			if err = p.process(ctx, group, now); err != nil {
+				p.logger.Error(
+					fmt.Sprintf("Failed to process Group with ID=%d", group.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				errs = append(errs, err)
 			}
+		}
 
-			if err = p.notify(group); err != nil {
-				return err
+		if len(groups) == 0 || len(groups) < p.limit {
+			return errors.Join(errs...)
+		}
+	}
+}
+
+func (p *NotificationsPreparer) prepareCareTasks(ctx context.Context) error {
+	var errs []error
+
+	for {
+		careTasks, err := p.useCases.ClaimCareTasksForNotify(ctx, p.limit, p.claimTTL)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		now := time.Now()
+
+		for _, careTask := range careTasks {
+			if err = p.processCareTask(ctx, careTask, now); err != nil {
+				p.logger.Error(
+					fmt.Sprintf("Failed to process CareTask with ID=%d", careTask.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				errs = append(errs, err)
 			}
 		}
 
+		if len(careTasks) == 0 || len(careTasks) < p.limit {
+			return errors.Join(errs...)
+		}
+	}
+}
+
+func (p *NotificationsPreparer) process(ctx context.Context, group entities.Group, now time.Time) error {
+	// TODO при проблеме с производительностью - сделать кэширование
+	user, err := p.useCases.GetUserByID(ctx, group.UserID)
+	if err != nil {
+		return err
+	}
+
+	userNow, err := p.getUserTime(*user, now)
+	if err != nil {
+		return err
+	}
+
+	// Переданный присматривающему сценарий напоминает только ему, даже во время отпуска владельца:
+	delegation, err := p.getGroupDelegation(ctx, group, userNow)
+	if err != nil {
+		return err
+	}
+
+	if delegation != nil {
+		return p.processDelegated(ctx, group, *user, *delegation, now)
+	}
+
+	if p.isMuted(*user, userNow) {
+		return nil
+	}
+
+	// После отпуска вместо накопившихся напоминаний отправляем одну сводку:
+	if user.IsVacationOver(userNow) {
+		if !p.canNotifyByTime(*user, userNow) {
+			return nil
+		}
+
+		return p.catchUp(ctx, *user, userNow)
+	}
+
+	// Отложенное напоминание отправляем сразу по наступлении выбранного пользователем времени:
+	if group.SnoozedUntil != nil {
+		if now.Before(*group.SnoozedUntil) {
+			return nil
+		}
+
+		notified, err := p.notifiedSince(ctx, group, *group.SnoozedUntil)
+		if err != nil {
+			return err
+		}
+
+		if !notified {
+			return p.notify(ctx, group, *user, false)
+		}
+	}
+
+	if !p.canNotifyByTime(*user, userNow) {
+		return nil
+	}
+
+	if user.NotificationsDigest {
+		return p.processDigest(ctx, *user, userNow)
+	}
+
+	notified, err := p.alreadyNotified(ctx, group, userNow)
+	if err != nil {
+		return err
+	}
+
+	if notified {
+		// Время отложенного напоминания пользователь выбрал сам, поэтому повторно не напоминаем:
+		if group.SnoozedUntil != nil {
+			return nil
+		}
+
+		followUp, err := p.needsFollowUp(ctx, group, userNow)
+		if err != nil {
+			return err
+		}
+
+		if !followUp {
+			return nil
+		}
+
+		return p.notify(ctx, group, *user, true)
+	}
+
+	return p.notify(ctx, group, *user, false)
+}
+
+// processDigest собирает в одну сводку все сценарии пользователя, которые нужно полить сегодня.
+// Сводка собирается не только по захваченным сценариям, поэтому перед сборкой она захватывается для пользователя
+// целиком: воркеры, захватившие разные сценарии одного пользователя, не отправят две сводки. Сценарии, уже вошедшие
+// в сегодняшнюю сводку, пропускаются, поэтому после истечения захвата сводка не повторяется.
+func (p *NotificationsPreparer) processDigest(ctx context.Context, user entities.User, userNow time.Time) error {
+	claimed, err := p.useCases.ClaimUserDigest(ctx, user.ID, p.claimTTL)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	groups, err := p.useCases.GetUserGroups(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	var pending, followUps []entities.Group
+
+	for _, group := range groups {
+		// Сценарии других участников дома попадают в сводки их владельцев:
+		if group.UserID != user.ID || !group.NextWateringDate.Before(userNow) {
+			continue
+		}
+
+		// Напоминания по переданным сценариям получает присматривающий:
+		delegation, err := p.getGroupDelegation(ctx, group, userNow)
+		if err != nil {
+			return err
+		}
+
+		if delegation != nil {
+			continue
+		}
+
+		// Отложенное напоминание отправляется отдельно в выбранное пользователем время:
+		if group.SnoozedUntil != nil && userNow.Before(*group.SnoozedUntil) {
+			continue
+		}
+
+		notified, err := p.alreadyNotified(ctx, group, userNow)
+		if err != nil {
+			return err
+		}
+
+		if !notified {
+			pending = append(pending, group)
+
+			continue
+		}
+
+		if group.SnoozedUntil != nil {
+			continue
+		}
+
+		followUp, err := p.needsFollowUp(ctx, group, userNow)
+		if err != nil {
+			return err
+		}
+
+		if followUp {
+			followUps = append(followUps, group)
+		}
+	}
+
+	switch {
+	case len(pending) > 0:
+		return p.notifyDigest(ctx, user, pending, userNow, "")
+	case len(followUps) > 0:
+		return p.notifyDigest(ctx, user, followUps, userNow, texts.NotifyFollowUp)
+	default:
 		return nil
 	}
 }
 
-func (p *NotificationsPreparer) canNotifyByTime() bool {
-	// Не отправляем уведомления до нужного часа:
-	now := time.Now()
-	sendTimeThreshold := time.Date(now.Year(), now.Month(), now.Day(), sendHour, 0, 0, 0, now.Location())
+// needsFollowUp проверяет, нужно ли повторить напоминание: на отправленные сегодня напоминания
+// никто не отреагировал, а с момента первого из них прошла очередная задержка из followUpDelays.
+func (p *NotificationsPreparer) needsFollowUp(
+	ctx context.Context,
+	group entities.Group,
+	userNow time.Time,
+) (bool, error) {
+	if len(p.followUpDelays) == 0 {
+		return false, nil
+	}
+
+	today := time.Date(userNow.Year(), userNow.Month(), userNow.Day(), 0, 0, 0, 0, userNow.Location())
+
+	notifications, err := p.useCases.GetGroupNotificationsSince(ctx, group.ID, today)
+	if err != nil {
+		return false, err
+	}
+
+	if len(notifications) == 0 {
+		return false, nil
+	}
+
+	for _, notification := range notifications {
+		if notification.AcknowledgedAt != nil {
+			return false, nil
+		}
+	}
+
+	// Уведомления упорядочены по порядку отправки:
+	firstSentAt := notifications[0].SentAt
+	lastSentAt := notifications[len(notifications)-1].SentAt
+
+	for i := len(p.followUpDelays) - 1; i >= 0; i-- {
+		dueAt := firstSentAt.Add(p.followUpDelays[i])
+		if userNow.Before(dueAt) {
+			continue
+		}
+
+		// Повторное напоминание для наступившей задержки еще не отправлялось:
+		return lastSentAt.Before(dueAt), nil
+	}
+
+	return false, nil
+}
+
+// catchUp отправляет одну сводку по всем сценариям пользователя, которые нужно полить после отпуска.
+func (p *NotificationsPreparer) catchUp(ctx context.Context, user entities.User, userNow time.Time) error {
+	// Сначала завершаем отпуск, чтобы при повторном запуске или на другой реплике сводка не отправилась еще раз.
+	// Сохраненные уведомления сводки не дадут продублировать напоминания по сценариям в тот же день:
+	if _, err := p.useCases.UpdateUserVacation(ctx, user.ID, nil, nil); err != nil {
+		return err
+	}
+
+	groups, err := p.useCases.GetUserGroups(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	var due []entities.Group
+
+	for _, group := range groups {
+		// Сценарии других участников дома попадают в сводки их владельцев:
+		if group.UserID != user.ID || !group.NextWateringDate.Before(userNow) {
+			continue
+		}
+
+		// Напоминания по переданным сценариям получает присматривающий:
+		delegation, err := p.getGroupDelegation(ctx, group, userNow)
+		if err != nil {
+			return err
+		}
+
+		if delegation == nil {
+			due = append(due, group)
+		}
+	}
 
-	return now.After(sendTimeThreshold)
+	if len(due) == 0 {
+		return nil
+	}
+
+	return p.notifyDigest(ctx, user, due, userNow, texts.NotifyCatchUp)
 }
 
-func (p *NotificationsPreparer) alreadyNotified(group entities.Group) bool {
-	value, exists := p.notifiedGroups.Load(group.ID)
-	if !exists {
-		return false
+// processDelegated напоминает о поливе переданного сценария присматривающему по его локальному времени
+// и с учетом его настроек тишины. Повторные напоминания и сводки присматривающему не отправляются.
+func (p *NotificationsPreparer) processDelegated(
+	ctx context.Context,
+	group entities.Group,
+	owner entities.User,
+	delegation entities.Delegation,
+	now time.Time,
+) error {
+	sitter, err := p.useCases.GetUserByID(ctx, *delegation.SitterID)
+	if err != nil {
+		return err
 	}
 
-	date, ok := value.(time.Time)
-	if !ok {
+	sitterNow, err := p.getUserTime(*sitter, now)
+	if err != nil {
+		return err
+	}
+
+	if p.isMuted(*sitter, sitterNow) || !p.canNotifyByTime(*sitter, sitterNow) {
+		return nil
+	}
+
+	if group.SnoozedUntil != nil && now.Before(*group.SnoozedUntil) {
+		return nil
+	}
+
+	notified, err := p.alreadyNotified(ctx, group, sitterNow)
+	if err != nil || notified {
+		return err
+	}
+
+	return p.notifySitter(ctx, group, owner, *sitter, sitterNow)
+}
+
+// getGroupDelegation возвращает действующую в текущий день пользователя передачу сценария или nil, если ее нет.
+func (p *NotificationsPreparer) getGroupDelegation(
+	ctx context.Context,
+	group entities.Group,
+	userNow time.Time,
+) (*entities.Delegation, error) {
+	delegation, err := p.useCases.GetGroupDelegation(ctx, group.ID, userNow)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrDelegationNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+// isMuted проверяет, что пользователь попросил не беспокоить его: идет отпуск,
+// выбран день без уведомлений или наступили тихие часы по локальному времени пользователя.
+func (p *NotificationsPreparer) isMuted(user entities.User, userNow time.Time) bool {
+	return user.IsOnVacation(userNow) || user.IsQuietWeekday(userNow.Weekday()) || user.IsQuietHour(userNow.Hour())
+}
+
+// getUserTime переводит время в часовой пояс пользователя.
+func (p *NotificationsPreparer) getUserTime(user entities.User, now time.Time) (time.Time, error) {
+	location, err := user.GetLocation()
+	if err != nil {
 		p.logger.Error(
-			"Failed to parse date",
+			fmt.Sprintf("Failed to load location for User with ID=%d", user.ID),
+			"Error", err,
 			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
 		)
 
-		return false
+		return time.Time{}, err
 	}
 
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return now.In(location), nil
+}
 
-	return date.After(today)
+// canNotifyByTime не дает отправлять уведомления до выбранного пользователем часа по его локальному времени.
+func (p *NotificationsPreparer) canNotifyByTime(user entities.User, userNow time.Time) bool {
+	return userNow.Hour() >= user.NotifyHour
 }
 
-func (p *NotificationsPreparer) notify(group entities.Group) error {
-	// TODO при проблеме с производительностью - сделать кэширование
-	user, err := p.useCases.GetUserByID(group.UserID)
+// alreadyNotified проверяет по базе данных, отправлялось ли уведомление по сценарию в текущий день пользователя.
+// Благодаря этому дедупликация переживает перезапуски и работает при нескольких репликах бота.
+func (p *NotificationsPreparer) alreadyNotified(
+	ctx context.Context,
+	group entities.Group,
+	userNow time.Time,
+) (bool, error) {
+	today := time.Date(userNow.Year(), userNow.Month(), userNow.Day(), 0, 0, 0, 0, userNow.Location())
+
+	return p.notifiedSince(ctx, group, today)
+}
+
+// notifiedSince проверяет, отправлялось ли уведомление по сценарию начиная с указанного момента.
+func (p *NotificationsPreparer) notifiedSince(
+	ctx context.Context,
+	group entities.Group,
+	since time.Time,
+) (bool, error) {
+	notification, err := p.useCases.GetLastNotification(ctx, group.ID)
 	if err != nil {
-		return err
+		if errors.Is(err, customerrors.ErrNotificationNotFound) {
+			return false, nil
+		}
+
+		return false, err
 	}
 
-	groupPlants, err := p.useCases.GetGroupPlants(group.ID)
+	// Время отправки хранится в UTC, поэтому сравниваем моменты времени, а не даты:
+	return !notification.SentAt.Before(since), nil
+}
+
+func (p *NotificationsPreparer) notify(
+	ctx context.Context,
+	group entities.Group,
+	user entities.User,
+	followUp bool,
+) error {
+	groupPlants, err := p.useCases.GetGroupPlants(ctx, group.ID)
 	if err != nil {
 		return err
 	}
@@ -120,71 +503,439 @@ func (p *NotificationsPreparer) notify(group entities.Group) error {
 	// if len(groupPlants) == 0 {
 	//	group.LastWateringDate = group.NextWateringDate
 	//	= group.NextWateringDate.AddDate(0, 0, group.WateringInterval)
-	//	if err = p.useCases.UpdateGroup(group); err != nil {
+	//	if err = p.useCases.UpdateGroup(ctx, group); err != nil {
 	//		return err
 	//	}
 	//
 	//	continue
 	//}
 
-	plantsText, err := p.preparePlantsText(groupPlants)
+	userNow, err := p.getUserTime(user, time.Now())
 	if err != nil {
-		p.logger.Error("Failed to prepare plants text", "Error", err)
-
 		return err
 	}
 
-	btn := telebot.InlineButton{
-		Unique: buttons.GroupWatered.Unique,
-		Text:   buttons.GroupWatered.Text,
-		Data:   strconv.Itoa(group.ID),
+	duePlants := p.getDuePlants(group, groupPlants, userNow)
+
+	plantsText, err := p.preparePlantsText(duePlants)
+	if err != nil {
+		p.logger.Error("Failed to prepare plants text", "Error", err)
+
+		return err
 	}
 
 	menu := &telebot.ReplyMarkup{
 		ResizeKeyboard: true,
 		InlineKeyboard: [][]telebot.InlineButton{
 			{
-				btn,
+				{
+					Unique: buttons.GroupWatered.Unique,
+					Text:   buttons.GroupWatered.Text,
+					Data:   strconv.Itoa(group.ID),
+				},
 			},
 		},
 	}
 
-	msg, err := p.bot.Send(
-		&telebot.Chat{ID: int64(user.TelegramID)},
-		fmt.Sprintf(
-			texts.Notify,
-			group.Title,
-			group.Description,
-			group.LastWateringDate.Format(dateFormat),
-			utils.GetWateringInterval(group.WateringInterval),
-			plantsText,
-		),
-		menu,
+	// Отдельные кнопки полива нужны, только если в сценарии больше одного растения:
+	if len(groupPlants) > 1 {
+		for _, plant := range duePlants {
+			menu.InlineKeyboard = append(
+				menu.InlineKeyboard,
+				[]telebot.InlineButton{
+					{
+						Unique: buttons.PlantWatered.Unique,
+						Text:   fmt.Sprintf(texts.PlantWateredButton, plant.Title),
+						Data:   strconv.Itoa(plant.ID),
+					},
+				},
+			)
+		}
+	}
+
+	for _, btn := range []telebot.InlineButton{
+		buttons.GroupRemindLater,
+		buttons.GroupRemindTomorrow,
+		buttons.GroupSkipWatering,
+	} {
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: btn.Unique,
+					Text:   btn.Text,
+					Data:   strconv.Itoa(group.ID),
+				},
+			},
+		)
+	}
+
+	text := p.getEscalationText(group, userNow, followUp) + fmt.Sprintf(
+		texts.Notify,
+		group.Title,
+		group.Description,
+		group.LastWateringDate.Format(dateFormat),
+		utils.GetGroupWateringInterval(group),
+		plantsText,
 	)
+
+	return p.sendToHousehold(ctx, user, text, menu, entities.Notification{GroupID: group.ID})
+}
+
+// notifySitter отправляет присматривающему напоминание с ограниченным набором кнопок:
+// отметить полив и посмотреть растения сценария.
+func (p *NotificationsPreparer) notifySitter(
+	ctx context.Context,
+	group entities.Group,
+	owner entities.User,
+	sitter entities.User,
+	sitterNow time.Time,
+) error {
+	groupPlants, err := p.useCases.GetGroupPlants(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+
+	plantsText, err := p.preparePlantsText(p.getDuePlants(group, groupPlants, sitterNow))
 	if err != nil {
-		p.logger.Error("Failed to send message", "Error", err)
+		p.logger.Error("Failed to prepare plants text", "Error", err)
 
 		return err
 	}
 
-	// Сохраняем информаци об отправке уведомления пользователю по сценарию:
-	now := time.Now()
-	p.notifiedGroups.Store(group.ID, now)
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	for _, btn := range []telebot.InlineButton{
+		buttons.GroupWatered,
+		buttons.SitterGroupPlants,
+	} {
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: btn.Unique,
+					Text:   btn.Text,
+					Data:   strconv.Itoa(group.ID),
+				},
+			},
+		)
+	}
 
-	notification := &entities.Notification{
-		GroupID:   group.ID,
-		MessageID: msg.ID,
-		Text:      msg.Text,
-		SentAt:    now,
+	text := fmt.Sprintf(texts.NotifySitter, html.EscapeString(owner.GetDisplayName())) + fmt.Sprintf(
+		texts.Notify,
+		group.Title,
+		group.Description,
+		group.LastWateringDate.Format(dateFormat),
+		utils.GetGroupWateringInterval(group),
+		plantsText,
+	)
+
+	return p.sendToUsers(ctx, []entities.User{sitter}, text, menu, entities.Notification{GroupID: group.ID})
+}
+
+// notifyDigest отправляет одну сводку по нескольким сценариям с кнопкой полива для каждого из них.
+// Заголовок добавляется перед сводкой для повторных напоминаний и сводки после отпуска.
+func (p *NotificationsPreparer) notifyDigest(
+	ctx context.Context,
+	user entities.User,
+	groups []entities.Group,
+	userNow time.Time,
+	header string,
+) error {
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
 	}
 
-	if _, err = p.useCases.SaveNotification(*notification); err != nil {
+	builder := strings.Builder{}
+	notifications := make([]entities.Notification, 0, len(groups))
+
+	for i, group := range groups {
+		groupPlants, err := p.useCases.GetGroupPlants(ctx, group.ID)
+		if err != nil {
+			return err
+		}
+
+		plantsText, err := p.preparePlantsText(p.getDuePlants(group, groupPlants, userNow))
+		if err != nil {
+			p.logger.Error("Failed to prepare plants text", "Error", err)
+
+			return err
+		}
+
+		var overdueText string
+		if overdueDays := p.getOverdueDays(group, userNow); overdueDays > 0 {
+			overdueText = fmt.Sprintf(texts.NotifyDigestOverdue, utils.GetWateringInterval(overdueDays))
+		}
+
+		builder.WriteString(
+			fmt.Sprintf(
+				texts.NotifyDigestGroup,
+				i+1,
+				group.Title,
+				overdueText,
+				group.LastWateringDate.Format(dateFormat),
+				utils.GetGroupWateringInterval(group),
+				plantsText,
+			),
+		)
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.GroupWatered.Unique,
+					Text:   fmt.Sprintf(texts.DigestGroupWateredButton, group.Title),
+					Data:   strconv.Itoa(group.ID),
+				},
+			},
+		)
+
+		// Сводка сохраняется отдельным уведомлением для каждого сценария, чтобы дедупликация
+		// и обновление напоминаний после полива работали так же, как для отдельных напоминаний:
+		notifications = append(notifications, entities.Notification{GroupID: group.ID, Digest: true})
+	}
+
+	// Общая кнопка полива нужна, только если в сводке больше одного сценария:
+	if len(groups) > 1 {
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.AllGroupsWatered})
+	}
+
+	text := header + fmt.Sprintf(texts.NotifyDigest, builder.String())
+
+	return p.sendToHousehold(ctx, user, text, menu, notifications...)
+}
+
+// getEscalationText возвращает заголовок напоминания: число дней просрочки полива
+// или пометку о повторном напоминании в тот же день.
+func (p *NotificationsPreparer) getEscalationText(group entities.Group, userNow time.Time, followUp bool) string {
+	if overdueDays := p.getOverdueDays(group, userNow); overdueDays > 0 {
+		return fmt.Sprintf(texts.NotifyOverdue, utils.GetWateringInterval(overdueDays))
+	}
+
+	if followUp {
+		return texts.NotifyFollowUp
+	}
+
+	return ""
+}
+
+// getOverdueDays возвращает, на сколько дней просрочен полив сценария в текущий день пользователя.
+func (p *NotificationsPreparer) getOverdueDays(group entities.Group, userNow time.Time) int {
+	// Даты полива хранятся без часового пояса, поэтому сравниваем только календарные даты:
+	today := time.Date(userNow.Year(), userNow.Month(), userNow.Day(), 0, 0, 0, 0, group.NextWateringDate.Location())
+
+	nextWateringDate := time.Date(
+		group.NextWateringDate.Year(),
+		group.NextWateringDate.Month(),
+		group.NextWateringDate.Day(),
+		0, 0, 0, 0,
+		group.NextWateringDate.Location(),
+	)
+
+	return int(today.Sub(nextWateringDate).Hours() / hoursPerDay)
+}
+
+func (p *NotificationsPreparer) processCareTask(ctx context.Context, careTask entities.CareTask, now time.Time) error {
+	// TODO при проблеме с производительностью - сделать кэширование
+	user, err := p.useCases.GetUserByID(ctx, careTask.UserID)
+	if err != nil {
+		return err
+	}
+
+	userNow, err := p.getUserTime(*user, now)
+	if err != nil {
+		return err
+	}
+
+	if p.isMuted(*user, userNow) || !p.canNotifyByTime(*user, userNow) {
+		return nil
+	}
+
+	notified, err := p.careTaskAlreadyNotified(ctx, careTask, userNow)
+	if err != nil {
 		return err
 	}
 
+	if notified {
+		return nil
+	}
+
+	return p.notifyCareTask(ctx, careTask, *user)
+}
+
+// careTaskAlreadyNotified проверяет по базе данных, отправлялось ли уведомление по задаче ухода
+// в текущий день пользователя.
+func (p *NotificationsPreparer) careTaskAlreadyNotified(
+	ctx context.Context,
+	careTask entities.CareTask,
+	userNow time.Time,
+) (bool, error) {
+	today := time.Date(userNow.Year(), userNow.Month(), userNow.Day(), 0, 0, 0, 0, userNow.Location())
+
+	notification, err := p.useCases.GetLastCareTaskNotification(ctx, careTask.ID)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrNotificationNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !notification.SentAt.Before(today), nil
+}
+
+func (p *NotificationsPreparer) notifyCareTask(
+	ctx context.Context,
+	careTask entities.CareTask,
+	user entities.User,
+) error {
+	var (
+		ownerCaption string
+		groupID      int
+	)
+
+	switch {
+	case careTask.GroupID != nil:
+		group, err := p.useCases.GetGroup(ctx, *careTask.GroupID)
+		if err != nil {
+			return err
+		}
+
+		ownerCaption = fmt.Sprintf(texts.CareTasksOwnerGroup, group.Title)
+		groupID = group.ID
+	case careTask.PlantID != nil:
+		plant, err := p.useCases.GetPlant(ctx, *careTask.PlantID)
+		if err != nil {
+			return err
+		}
+
+		ownerCaption = fmt.Sprintf(texts.CareTasksOwnerPlant, plant.Title)
+		groupID = plant.GroupID
+	default:
+		p.logger.Error(fmt.Sprintf("CareTask with ID=%d has no owner", careTask.ID))
+
+		return errors.New("care task has no owner")
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{
+			{
+				{
+					Unique: buttons.CareTaskDone.Unique,
+					Text:   buttons.CareTaskDone.Text,
+					Data:   strconv.Itoa(careTask.ID),
+				},
+			},
+		},
+	}
+
+	text := fmt.Sprintf(
+		texts.NotifyCareTask,
+		utils.GetCareTaskType(careTask.Type),
+		ownerCaption,
+		careTask.LastCareDate.Format(dateFormat),
+		utils.GetWateringInterval(careTask.Interval),
+	)
+
+	// Уведомление о задаче ухода привязываем и к сценарию, чтобы оно удалялось вместе с ним:
+	return p.sendToHousehold(ctx, user, text, menu, entities.Notification{GroupID: groupID, CareTaskID: &careTask.ID})
+}
+
+// sendToHousehold отправляет напоминание владельцу и всем участникам его дома и сохраняет
+// информацию об отправке в каждый чат, чтобы позже обновить напоминания после выполнения ухода.
+// Для сводки в каждом чате сохраняется по уведомлению на каждый вошедший в нее сценарий.
+func (p *NotificationsPreparer) sendToHousehold(
+	ctx context.Context,
+	user entities.User,
+	text string,
+	menu *telebot.ReplyMarkup,
+	notifications ...entities.Notification,
+) error {
+	members, err := p.useCases.GetHouseholdUsers(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return p.sendToUsers(ctx, members, text, menu, notifications...)
+}
+
+// sendToUsers отправляет напоминание каждому из пользователей и сохраняет информацию об отправке в каждый чат.
+// Ошибка возвращается, только если напоминание не удалось отправить ни одному пользователю.
+func (p *NotificationsPreparer) sendToUsers(
+	ctx context.Context,
+	users []entities.User,
+	text string,
+	menu *telebot.ReplyMarkup,
+	notifications ...entities.Notification,
+) error {
+	var (
+		sent    int
+		sendErr error
+	)
+
+	for _, member := range users {
+		msg, err := p.bot.Send(&telebot.Chat{ID: int64(member.TelegramID)}, text, menu)
+		if err != nil {
+			// Участник мог заблокировать бота, что не должно мешать напомнить остальным:
+			p.logger.Error("Failed to send message", "Error", err)
+			p.metrics.Notifications.WithLabelValues(p.cron, metrics.NotificationStatusFailed).Inc()
+
+			sendErr = err
+
+			continue
+		}
+
+		p.metrics.Notifications.WithLabelValues(p.cron, metrics.NotificationStatusSent).Inc()
+
+		// Время храним в UTC, так как колонка не содержит информации о часовом поясе:
+		sentAt := time.Now().UTC()
+
+		for _, notification := range notifications {
+			notification.MessageID = msg.ID
+			notification.Text = msg.Text
+			notification.SentAt = sentAt
+			notification.ChatID = int64(member.TelegramID)
+
+			if _, err = p.useCases.SaveNotification(ctx, notification); err != nil {
+				return err
+			}
+		}
+
+		sent++
+	}
+
+	if sent == 0 {
+		return sendErr
+	}
+
 	return nil
 }
 
+// getDuePlants возвращает растения сценария, которые нужно полить в текущий день пользователя.
+func (p *NotificationsPreparer) getDuePlants(
+	group entities.Group,
+	plants []entities.Plant,
+	userNow time.Time,
+) []entities.Plant {
+	// Даты полива хранятся без часового пояса, поэтому сравниваем только календарные даты:
+	today := time.Date(userNow.Year(), userNow.Month(), userNow.Day(), 0, 0, 0, 0, group.LastWateringDate.Location())
+
+	var duePlants []entities.Plant
+
+	for _, plant := range plants {
+		if plant.IsWateringDue(group, today) {
+			duePlants = append(duePlants, plant)
+		}
+	}
+
+	return duePlants
+}
+
 func (p *NotificationsPreparer) preparePlantsText(plants []entities.Plant) (string, error) {
 	if len(plants) == 0 {
 		return "В данный сценарий полива пока что не было добавлено ни одно растение!\n", nil
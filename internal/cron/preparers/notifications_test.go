@@ -1,15 +1,21 @@
 package preparers
 
 import (
+	"context"
 	"fmt"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/metrics"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
 	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"gopkg.in/telebot.v4"
-	"sync"
+	"strings"
 	"testing"
 	"time"
 )
@@ -20,93 +26,89 @@ func TestNewNotificationsPreparer(t *testing.T) {
 	mockUsecases := mockusecases.NewMockUseCases(ctrl)
 	mockLogger := mocklogging.NewMockLogger(ctrl)
 
-	preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, 5)
+	preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, metrics.New(), "test")
 
 	assert.NotNil(t, preparer)
 	assert.Equal(t, mockBot, preparer.bot)
 	assert.Equal(t, mockUsecases, preparer.useCases)
 	assert.Equal(t, mockLogger, preparer.logger)
 	assert.Equal(t, 10, preparer.limit)
-	assert.Equal(t, 5, preparer.offset)
-	assert.NotNil(t, preparer.notifiedGroups)
+	assert.Equal(t, time.Minute, preparer.claimTTL)
 }
 
 func TestNotificationsPreparer_alreadyNotified(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockBot := mockbot.NewMockBot(ctrl)
-	mockUsecases := mockusecases.NewMockUseCases(ctrl)
-	mockLogger := mocklogging.NewMockLogger(ctrl)
+	vladivostok, err := time.LoadLocation("Asia/Vladivostok")
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	yesterday := today.AddDate(0, 0, -1)
+	// Полночь по Владивостоку соответствует 14:00 предыдущего дня по UTC:
+	userNow := time.Date(2025, 9, 2, 10, 0, 0, 0, vladivostok)
 
 	tests := []struct {
-		name         string
-		groupID      int
-		storeValue   interface{}
-		storeExists  bool
-		setupMocks   func()
-		want         bool
-		expectLogErr bool
+		name       string
+		setupMocks func(mockUsecases *mockusecases.MockUseCases)
+		want       bool
+		wantErr    bool
 	}{
 		{
-			name:         "not_notified",
-			groupID:      1,
-			storeExists:  false,
-			want:         false,
-			expectLogErr: false,
+			name: "not_notified",
+			setupMocks: func(mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), 1).Return(nil, customerrors.ErrNotificationNotFound).Times(1)
+			},
+			want: false,
 		},
 		{
-			name:         "notified_today",
-			groupID:      1,
-			storeValue:   now,
-			storeExists:  true,
-			want:         true,
-			expectLogErr: false,
+			name: "notified_today",
+			setupMocks: func(mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), 1).Return(
+					&entities.Notification{SentAt: time.Date(2025, 9, 1, 23, 0, 0, 0, time.UTC)},
+					nil,
+				).Times(1)
+			},
+			want: true,
 		},
 		{
-			name:         "notified_yesterday",
-			groupID:      1,
-			storeValue:   yesterday,
-			storeExists:  true,
-			want:         false,
-			expectLogErr: false,
+			name: "notified_yesterday_in_user_timezone",
+			setupMocks: func(mockUsecases *mockusecases.MockUseCases) {
+				// По UTC это тот же день, но по Владивостоку - уже предыдущий:
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), 1).Return(
+					&entities.Notification{SentAt: time.Date(2025, 9, 1, 13, 59, 0, 0, time.UTC)},
+					nil,
+				).Times(1)
+			},
+			want: false,
 		},
 		{
-			name:         "invalid_type_in_map",
-			groupID:      1,
-			storeValue:   "not-a-time",
-			storeExists:  true,
-			want:         false,
-			expectLogErr: true,
-			setupMocks: func() {
-				mockLogger.EXPECT().Error(
-					"Failed to parse date",
-					"Tracing", gomock.Any(),
-				).Times(1)
+			name: "error_get_last_notification",
+			setupMocks: func(mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), 1).Return(nil, fmt.Errorf("db error")).Times(1)
 			},
+			want:    false,
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, 0)
-			preparer.notifiedGroups = new(sync.Map)
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, metrics.New(), "test")
 
 			if tt.setupMocks != nil {
-				tt.setupMocks()
+				tt.setupMocks(mockUsecases)
 			}
 
-			if tt.storeExists {
-				preparer.notifiedGroups.Store(tt.groupID, tt.storeValue)
+			result, err := preparer.alreadyNotified(context.Background(), entities.Group{ID: 1}, userNow)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
 			}
 
-			group := entities.Group{ID: tt.groupID}
-			result := preparer.alreadyNotified(group)
-
 			assert.Equal(t, tt.want, result)
 		})
 	}
@@ -131,77 +133,144 @@ func TestNotificationsPreparer_notify(t *testing.T) {
 	msg := &telebot.Message{ID: 987, Text: "Напоминание: пора поливать!"}
 
 	tests := []struct {
-		name         string
-		setupMocks   func()
-		expectError  bool
-		expectStored bool
+		name           string
+		setupMocks     func()
+		expectError    bool
+		expectedSent   float64
+		expectedFailed float64
 	}{
 		{
 			name: "success_flow",
 			setupMocks: func() {
-				mockUsecases.EXPECT().GetUserByID(group.UserID).Return(&user, nil).Times(1)
-				mockUsecases.EXPECT().GetGroupPlants(group.ID).Return(plants, nil).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(plants, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
 				mockBot.EXPECT().Send(
 					&telebot.Chat{ID: int64(user.TelegramID)},
 					gomock.Any(),
-					gomock.Any(),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						// Напоминание содержит кнопки полива, отложенных напоминаний и пропуска полива:
+						return len(menu.InlineKeyboard) == 4 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.GroupWatered.Unique &&
+							menu.InlineKeyboard[1][0].Unique == buttons.GroupRemindLater.Unique &&
+							menu.InlineKeyboard[2][0].Unique == buttons.GroupRemindTomorrow.Unique &&
+							menu.InlineKeyboard[3][0].Unique == buttons.GroupSkipWatering.Unique &&
+							menu.InlineKeyboard[3][0].Data == "1"
+					}),
 				).Return(msg, nil).Times(1)
-				mockUsecases.EXPECT().SaveNotification(gomock.Any()).Return(&entities.Notification{}, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(
+					gomock.Any(),
+					gomock.Cond(func(notification entities.Notification) bool {
+						return notification.GroupID == group.ID &&
+							notification.MessageID == msg.ID &&
+							notification.SentAt.Location() == time.UTC
+					}),
+				).Return(&entities.Notification{}, nil).Times(1)
 			},
 			expectError:  false,
-			expectStored: true,
+			expectedSent: 1,
 		},
 		{
-			name: "error_get_user",
+			name: "success_flow_household_members_notified",
+			setupMocks: func() {
+				partner := entities.User{ID: 200, TelegramID: 54321}
+
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(plants, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user, partner}, nil).Times(1)
+
+				// Ошибка отправки одному участнику не мешает напомнить остальным:
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Any(),
+					gomock.Any(),
+				).Return(nil, fmt.Errorf("bot was blocked")).Times(1)
+				mockLogger.EXPECT().Error("Failed to send message", "Error", gomock.Any()).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(partner.TelegramID)},
+					gomock.Any(),
+					gomock.Any(),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(
+					gomock.Any(),
+					gomock.Cond(func(notification entities.Notification) bool {
+						return notification.GroupID == group.ID &&
+							notification.MessageID == msg.ID &&
+							notification.ChatID == int64(partner.TelegramID)
+					}),
+				).Return(&entities.Notification{}, nil).Times(1)
+			},
+			expectError:    false,
+			expectedSent:   1,
+			expectedFailed: 1,
+		},
+		{
+			name: "success_flow_with_plant_buttons",
 			setupMocks: func() {
-				mockUsecases.EXPECT().GetUserByID(group.UserID).Return(&entities.User{}, fmt.Errorf("user not found")).Times(1)
+				groupPlants := []entities.Plant{{ID: 1, Title: "Фикус"}, {ID: 2, Title: "Папоротник"}}
+
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(groupPlants, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Cond(func(text string) bool {
+						return strings.Contains(text, "1) Фикус\n2) Папоротник\n")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						// Кнопки полива отдельных растений следуют сразу за кнопкой полива сценария:
+						return len(menu.InlineKeyboard) == 6 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.GroupWatered.Unique &&
+							menu.InlineKeyboard[1][0].Unique == buttons.PlantWatered.Unique &&
+							menu.InlineKeyboard[1][0].Data == "1" &&
+							menu.InlineKeyboard[2][0].Unique == buttons.PlantWatered.Unique &&
+							menu.InlineKeyboard[2][0].Data == "2" &&
+							menu.InlineKeyboard[3][0].Unique == buttons.GroupRemindLater.Unique
+					}),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(&entities.Notification{}, nil).Times(1)
 			},
-			expectError:  true,
-			expectStored: false,
+			expectError:  false,
+			expectedSent: 1,
 		},
 		{
 			name: "error_get_plants",
 			setupMocks: func() {
-				mockUsecases.EXPECT().GetUserByID(group.UserID).Return(&user, nil).Times(1)
-				mockUsecases.EXPECT().GetGroupPlants(group.ID).Return(nil, fmt.Errorf("load error")).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(nil, fmt.Errorf("load error")).Times(1)
 			},
-			expectError:  true,
-			expectStored: false,
+			expectError: true,
 		},
 		{
 			name: "error_send_message",
 			setupMocks: func() {
-				mockUsecases.EXPECT().GetUserByID(group.UserID).Return(&user, nil).Times(1)
-				mockUsecases.EXPECT().GetGroupPlants(group.ID).Return(plants, nil).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(plants, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
 				mockBot.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("send failed")).Times(1)
 				mockLogger.EXPECT().Error("Failed to send message", "Error", gomock.Any()).Times(1)
 			},
-			expectError:  true,
-			expectStored: false,
+			expectError:    true,
+			expectedFailed: 1,
 		},
 		{
 			name: "error_save_notification",
 			setupMocks: func() {
-				mockUsecases.EXPECT().GetUserByID(group.UserID).Return(&user, nil).Times(1)
-				mockUsecases.EXPECT().GetGroupPlants(group.ID).Return(plants, nil).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(plants, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
 				mockBot.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Return(msg, nil).Times(1)
-				mockUsecases.EXPECT().SaveNotification(gomock.Any()).Return(&entities.Notification{}, fmt.Errorf("save failed")).Times(1)
+				mockUsecases.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(&entities.Notification{}, fmt.Errorf("save failed")).Times(1)
 			},
 			expectError:  true,
-			expectStored: true, // Store уже произошёл
+			expectedSent: 1,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, 0)
-			preparer.notifiedGroups = new(sync.Map)
+			preparerMetrics := metrics.New()
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, preparerMetrics, "test")
 
 			if tt.setupMocks != nil {
 				tt.setupMocks()
 			}
 
-			err := preparer.notify(group)
+			err := preparer.notify(context.Background(), group, user, false)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -209,8 +278,194 @@ func TestNotificationsPreparer_notify(t *testing.T) {
 				assert.NoError(t, err)
 			}
 
-			_, stored := preparer.notifiedGroups.Load(group.ID)
-			assert.Equal(t, tt.expectStored, stored)
+			notifications := preparerMetrics.Notifications
+			assert.Equal(t, tt.expectedSent, testutil.ToFloat64(notifications.WithLabelValues("test", metrics.NotificationStatusSent)))
+			assert.Equal(t, tt.expectedFailed, testutil.ToFloat64(notifications.WithLabelValues("test", metrics.NotificationStatusFailed)))
+		})
+	}
+}
+
+func TestNotificationsPreparer_getDuePlants(t *testing.T) {
+	preparer := &NotificationsPreparer{} // не требует зависимостей
+
+	group := entities.Group{
+		ID:               1,
+		WateringInterval: 7,
+		LastWateringDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		NextWateringDate: time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC),
+	}
+
+	shortInterval := 3
+	longInterval := 14
+	ficus := entities.Plant{ID: 1, Title: "Фикус"}
+	fern := entities.Plant{ID: 2, Title: "Папоротник", WateringInterval: &shortInterval}
+	cactus := entities.Plant{ID: 3, Title: "Кактус", WateringInterval: &longInterval}
+
+	// Пользователь в UTC+3, у которого уже наступило 4 сентября:
+	userNow := time.Date(2025, 9, 4, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	// Собственный график сценария тоже наступил 4 сентября:
+	dueGroup := group
+	dueGroup.LastWateringDate = time.Date(2025, 8, 28, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		group  *entities.Group
+		plants []entities.Plant
+		want   []entities.Plant
+	}{
+		{
+			name:   "only_due_plants",
+			plants: []entities.Plant{fern, cactus},
+			want:   []entities.Plant{fern},
+		},
+		{
+			// Сценарий стал ближайшим к поливу из-за папоротника, а по собственному графику поливается 8 сентября:
+			name:   "plants_without_override_inherit_group",
+			plants: []entities.Plant{ficus, fern, cactus},
+			want:   []entities.Plant{fern},
+		},
+		{
+			name:   "plants_without_override_due_with_group",
+			group:  &dueGroup,
+			plants: []entities.Plant{ficus, fern, cactus},
+			want:   []entities.Plant{ficus, fern},
+		},
+		{
+			name:   "no_plants_when_none_due",
+			plants: []entities.Plant{cactus},
+			want:   nil,
+		},
+		{
+			name:   "empty_plants",
+			plants: nil,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testGroup := group
+			if tt.group != nil {
+				testGroup = *tt.group
+			}
+
+			assert.Equal(t, tt.want, preparer.getDuePlants(testGroup, tt.plants, userNow))
+		})
+	}
+}
+
+func TestNotificationsPreparer_getEscalationText(t *testing.T) {
+	preparer := &NotificationsPreparer{} // не требует зависимостей
+	group := entities.Group{NextWateringDate: time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name     string
+		userNow  time.Time
+		followUp bool
+		want     string
+	}{
+		{
+			name:    "due_today",
+			userNow: time.Date(2025, 9, 4, 10, 0, 0, 0, time.UTC),
+			want:    "",
+		},
+		{
+			name:     "due_today_follow_up",
+			userNow:  time.Date(2025, 9, 4, 14, 0, 0, 0, time.UTC),
+			followUp: true,
+			want:     texts.NotifyFollowUp,
+		},
+		{
+			name:     "overdue",
+			userNow:  time.Date(2025, 9, 7, 14, 0, 0, 0, time.UTC),
+			followUp: true,
+			want:     fmt.Sprintf(texts.NotifyOverdue, "3 дня"),
+		},
+		{
+			name:    "overdue_in_user_timezone",
+			userNow: time.Date(2025, 9, 5, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
+			want:    fmt.Sprintf(texts.NotifyOverdue, "1 день"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, preparer.getEscalationText(group, tt.userNow, tt.followUp))
+		})
+	}
+}
+
+func TestNotificationsPreparer_needsFollowUp(t *testing.T) {
+	now := time.Now().UTC()
+	group := entities.Group{ID: 1, UserID: 100}
+	acknowledgedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		delays        []time.Duration
+		notifications []entities.Notification
+		want          bool
+	}{
+		{
+			name:          "follow_up_when_delay_passed",
+			delays:        []time.Duration{12 * time.Hour, 4 * time.Hour},
+			notifications: []entities.Notification{{GroupID: group.ID, SentAt: now.Add(-5 * time.Hour)}},
+			want:          true,
+		},
+		{
+			name:          "skip_when_delay_not_passed",
+			delays:        []time.Duration{12 * time.Hour, 4 * time.Hour},
+			notifications: []entities.Notification{{GroupID: group.ID, SentAt: now.Add(-time.Hour)}},
+		},
+		{
+			name:   "skip_when_follow_up_already_sent",
+			delays: []time.Duration{12 * time.Hour, 4 * time.Hour},
+			notifications: []entities.Notification{
+				{GroupID: group.ID, SentAt: now.Add(-5 * time.Hour)},
+				{GroupID: group.ID, SentAt: now.Add(-time.Hour)},
+			},
+		},
+		{
+			name:   "follow_up_when_next_delay_passed",
+			delays: []time.Duration{12 * time.Hour, 4 * time.Hour},
+			notifications: []entities.Notification{
+				{GroupID: group.ID, SentAt: now.Add(-13 * time.Hour)},
+				{GroupID: group.ID, SentAt: now.Add(-9 * time.Hour)},
+			},
+			want: true,
+		},
+		{
+			name:   "skip_when_acknowledged",
+			delays: []time.Duration{12 * time.Hour, 4 * time.Hour},
+			notifications: []entities.Notification{
+				{GroupID: group.ID, SentAt: now.Add(-5 * time.Hour), AcknowledgedAt: &acknowledgedAt},
+			},
+		},
+		{
+			name: "skip_when_follow_ups_disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, tt.delays, metrics.New(), "test")
+
+			if len(tt.delays) > 0 {
+				mockUsecases.EXPECT().
+					GetGroupNotificationsSince(gomock.Any(), group.ID, gomock.Any()).
+					Return(tt.notifications, nil).
+					Times(1)
+			}
+
+			got, err := preparer.needsFollowUp(context.Background(), group, now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
@@ -263,3 +518,941 @@ func TestNotificationsPreparer_preparePlantsText(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationsPreparer_canNotifyByTime(t *testing.T) {
+	preparer := &NotificationsPreparer{} // не требует зависимостей
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		user    entities.User
+		userNow time.Time
+		want    bool
+	}{
+		{
+			name:    "before_notify_hour",
+			user:    entities.User{Timezone: "Europe/Moscow", NotifyHour: 12},
+			userNow: time.Date(2025, 9, 2, 11, 59, 0, 0, moscow),
+			want:    false,
+		},
+		{
+			name:    "exactly_notify_hour",
+			user:    entities.User{Timezone: "Europe/Moscow", NotifyHour: 12},
+			userNow: time.Date(2025, 9, 2, 12, 0, 0, 0, moscow),
+			want:    true,
+		},
+		{
+			name:    "after_notify_hour",
+			user:    entities.User{Timezone: "Europe/Moscow", NotifyHour: 9},
+			userNow: time.Date(2025, 9, 2, 21, 30, 0, 0, moscow),
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, preparer.canNotifyByTime(tt.user, tt.userNow))
+		})
+	}
+}
+
+func TestNotificationsPreparer_getUserTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+	preparer := &NotificationsPreparer{logger: mockLogger}
+
+	now := time.Date(2025, 9, 2, 6, 0, 0, 0, time.UTC)
+
+	t.Run("user_timezone", func(t *testing.T) {
+		userNow, err := preparer.getUserTime(entities.User{Timezone: "Asia/Vladivostok"}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 16, userNow.Hour())
+		assert.True(t, now.Equal(userNow))
+	})
+
+	t.Run("invalid_timezone", func(t *testing.T) {
+		mockLogger.EXPECT().Error(
+			"Failed to load location for User with ID=1",
+			"Error", gomock.Any(),
+			"Tracing", gomock.Any(),
+		).Times(1)
+
+		_, err := preparer.getUserTime(entities.User{ID: 1, Timezone: "Mars/Olympus"}, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestNotificationsPreparer_GetCallback(t *testing.T) {
+	group := entities.Group{
+		ID:               1,
+		UserID:           100,
+		Title:            "Группа 1",
+		LastWateringDate: time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC),
+		WateringInterval: 7,
+	}
+	msg := &telebot.Message{ID: 987, Text: "Напоминание: пора поливать!"}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases)
+		expectError bool
+	}{
+		{
+			name: "notify_when_notify_hour_passed",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{group}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), group.ID).Return(nil, customerrors.ErrNotificationNotFound).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Any(),
+					gomock.Any(),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(&entities.Notification{}, nil).Times(1)
+			},
+		},
+		{
+			name: "skip_when_notify_hour_not_reached",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				// Подбираем часовой пояс, в котором час отправки еще не наступил:
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 23}
+				if time.Now().UTC().Hour() == 23 {
+					user.Timezone = "Etc/GMT+1"
+				}
+
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{group}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(&user, nil).Times(1)
+			},
+		},
+		{
+			name: "skip_when_already_notified_today",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{group}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), group.ID).Return(
+					&entities.Notification{GroupID: group.ID, SentAt: time.Now().UTC()},
+					nil,
+				).Times(1)
+			},
+		},
+		{
+			name: "skip_when_snoozed",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+				snoozedUntil := time.Now().Add(time.Hour)
+				snoozedGroup := group
+				snoozedGroup.SnoozedUntil = &snoozedUntil
+
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{snoozedGroup}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(&user, nil).Times(1)
+			},
+		},
+		{
+			name: "notify_when_snooze_expired_even_before_notify_hour",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				// Час уведомлений еще не наступил, но отложенное напоминание должно быть отправлено:
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 23}
+				snoozedUntil := time.Now().Add(-time.Minute).UTC()
+				snoozedGroup := group
+				snoozedGroup.SnoozedUntil = &snoozedUntil
+
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{snoozedGroup}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), group.ID).Return(
+					&entities.Notification{GroupID: group.ID, SentAt: snoozedUntil.Add(-3 * time.Hour)},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Any(),
+					gomock.Any(),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(&entities.Notification{}, nil).Times(1)
+			},
+		},
+		{
+			name: "skip_when_snoozed_reminder_already_sent",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+				snoozedUntil := time.Now().Add(-time.Minute).UTC()
+				snoozedGroup := group
+				snoozedGroup.SnoozedUntil = &snoozedUntil
+				lastNotification := &entities.Notification{GroupID: group.ID, SentAt: snoozedUntil.Add(time.Second)}
+
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{snoozedGroup}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), group.ID).Return(lastNotification, nil).Times(2)
+			},
+		},
+		{
+			name: "error_get_last_notification",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{group}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), group.ID).Return(nil, fmt.Errorf("db error")).Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name: "error_get_groups",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return(nil, fmt.Errorf("db error")).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name: "error_get_user",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{group}, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), group.UserID).Return(nil, fmt.Errorf("user not found")).Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, metrics.New(), "test")
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockUsecases)
+			}
+
+			// Сценарии без передачи присматривающему, если тест не задал иное:
+			mockUsecases.EXPECT().
+				GetGroupDelegation(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, customerrors.ErrDelegationNotFound).
+				AnyTimes()
+
+			if tt.expectError {
+				// Ошибка обработки записи логируется, чтобы не прерывать обработку остальных записей:
+				mockLogger.EXPECT().Error(gomock.Any(), "Error", gomock.Any(), "Tracing", gomock.Any()).MaxTimes(1)
+			}
+
+			err := preparer.GetCallback()(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationsPreparer_GetCallback_Digest(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0, NotificationsDigest: true}
+	ficus := entities.Group{ID: 1, UserID: user.ID, Title: "Фикусы", NextWateringDate: today.AddDate(0, 0, -2)}
+	ferns := entities.Group{ID: 2, UserID: user.ID, Title: "Папоротники", NextWateringDate: today}
+	cacti := entities.Group{ID: 3, UserID: user.ID, Title: "Кактусы", NextWateringDate: today.AddDate(0, 0, 5)}
+	partnerGroup := entities.Group{ID: 4, UserID: 200, Title: "Орхидеи", NextWateringDate: today}
+	msg := &telebot.Message{ID: 987, Text: "Сводка"}
+
+	tests := []struct {
+		name        string
+		delays      []time.Duration
+		setupMocks  func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases)
+		expectError bool
+	}{
+		{
+			name: "due_groups_sent_in_one_message",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimUserDigest(gomock.Any(), user.ID, time.Minute).Return(true, nil).Times(1)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), user.ID).Return(
+					[]entities.Group{ficus, ferns, cacti, partnerGroup},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), ficus.ID).Return(nil, customerrors.ErrNotificationNotFound).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), ferns.ID).Return(nil, customerrors.ErrNotificationNotFound).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), ficus.ID).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), ferns.ID).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Cond(func(text string) bool {
+						return strings.Contains(text, "1. Фикусы") &&
+							strings.Contains(text, fmt.Sprintf(texts.NotifyDigestOverdue, "2 дня")) &&
+							strings.Contains(text, "2. Папоротники") &&
+							!strings.Contains(text, "Кактусы") &&
+							!strings.Contains(text, "Орхидеи")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.GroupWatered.Unique &&
+							menu.InlineKeyboard[0][0].Data == "1" &&
+							menu.InlineKeyboard[1][0].Data == "2" &&
+							menu.InlineKeyboard[2][0].Unique == buttons.AllGroupsWatered.Unique
+					}),
+				).Return(msg, nil).Times(1)
+
+				mockUsecases.EXPECT().SaveNotification(
+					gomock.Any(),
+					gomock.Cond(func(notification entities.Notification) bool {
+						return notification.Digest &&
+							notification.MessageID == msg.ID &&
+							notification.ChatID == int64(user.TelegramID)
+					}),
+				).Return(&entities.Notification{}, nil).Times(2)
+			},
+		},
+		{
+			name: "skip_when_digest_already_sent",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimUserDigest(gomock.Any(), user.ID, time.Minute).Return(true, nil).Times(1)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), user.ID).Return([]entities.Group{ficus, ferns}, nil).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), gomock.Any()).Return(
+					&entities.Notification{SentAt: now, Digest: true},
+					nil,
+				).Times(2)
+			},
+		},
+		{
+			name:   "follow_up_for_unacknowledged_groups",
+			delays: []time.Duration{4 * time.Hour},
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimUserDigest(gomock.Any(), user.ID, time.Minute).Return(true, nil).Times(1)
+				acknowledgedAt := now.Add(-time.Hour)
+
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), user.ID).Return([]entities.Group{ficus, ferns}, nil).Times(1)
+				mockUsecases.EXPECT().GetLastNotification(gomock.Any(), gomock.Any()).Return(
+					&entities.Notification{SentAt: now, Digest: true},
+					nil,
+				).Times(2)
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), ficus.ID, gomock.Any()).Return(
+					[]entities.Notification{{GroupID: ficus.ID, SentAt: now.Add(-5 * time.Hour), Digest: true}},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), ferns.ID, gomock.Any()).Return(
+					[]entities.Notification{
+						{GroupID: ferns.ID, SentAt: now.Add(-5 * time.Hour), Digest: true, AcknowledgedAt: &acknowledgedAt},
+					},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), ficus.ID).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Cond(func(text string) bool {
+						return strings.HasPrefix(text, texts.NotifyFollowUp) &&
+							strings.Contains(text, "Фикусы") &&
+							!strings.Contains(text, "Папоротники")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						// Для одного сценария общая кнопка полива не нужна:
+						return len(menu.InlineKeyboard) == 1 &&
+							menu.InlineKeyboard[0][0].Data == "1"
+					}),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(&entities.Notification{}, nil).Times(1)
+			},
+		},
+		{
+			name: "skip_when_digest_claimed_by_another_worker",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimUserDigest(gomock.Any(), user.ID, time.Minute).Return(false, nil).Times(1)
+			},
+		},
+		{
+			name: "error_claim_user_digest",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimUserDigest(gomock.Any(), user.ID, time.Minute).Return(false, fmt.Errorf("db error")).Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name: "error_get_user_groups",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimUserDigest(gomock.Any(), user.ID, time.Minute).Return(true, nil).Times(1)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), user.ID).Return(nil, fmt.Errorf("db error")).Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, tt.delays, metrics.New(), "test")
+
+			mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{ficus}, nil).Times(1)
+			mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).MaxTimes(1)
+			mockUsecases.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&user, nil).Times(1)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockUsecases)
+			}
+
+			// Сценарии без передачи присматривающему, если тест не задал иное:
+			mockUsecases.EXPECT().
+				GetGroupDelegation(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, customerrors.ErrDelegationNotFound).
+				AnyTimes()
+
+			if tt.expectError {
+				mockLogger.EXPECT().Error(gomock.Any(), "Error", gomock.Any(), "Tracing", gomock.Any()).MaxTimes(1)
+			}
+
+			err := preparer.GetCallback()(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationsPreparer_isMuted(t *testing.T) {
+	preparer := &NotificationsPreparer{} // не требует зависимостей
+
+	vacationStart := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	vacationEnd := time.Date(2025, 9, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		user    entities.User
+		userNow time.Time
+		want    bool
+	}{
+		{
+			name:    "no_quiet_settings",
+			user:    entities.User{},
+			userNow: time.Date(2025, 9, 2, 12, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		{
+			name:    "quiet_hours",
+			user:    entities.User{QuietHoursStart: 22, QuietHoursEnd: 8},
+			userNow: time.Date(2025, 9, 2, 23, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "quiet_weekday",
+			user:    entities.User{QuietWeekdays: 1 << time.Tuesday},
+			userNow: time.Date(2025, 9, 2, 12, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "on_vacation",
+			user:    entities.User{VacationStart: &vacationStart, VacationEnd: &vacationEnd},
+			userNow: time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "vacation_over",
+			user:    entities.User{VacationStart: &vacationStart, VacationEnd: &vacationEnd},
+			userNow: time.Date(2025, 9, 8, 12, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, preparer.isMuted(tt.user, tt.userNow))
+		})
+	}
+}
+
+func TestNotificationsPreparer_GetCallback_QuietSettings(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	vacationStart := today.AddDate(0, 0, -10)
+	vacationEnd := today.AddDate(0, 0, -1)
+	ficus := entities.Group{ID: 1, UserID: 100, Title: "Фикусы", NextWateringDate: today.AddDate(0, 0, -5)}
+	ferns := entities.Group{ID: 2, UserID: 100, Title: "Папоротники", NextWateringDate: today.AddDate(0, 0, 3)}
+	msg := &telebot.Message{ID: 987, Text: "Сводка"}
+
+	tests := []struct {
+		name        string
+		user        entities.User
+		setupMocks  func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases)
+		expectError bool
+	}{
+		{
+			name: "skip_during_quiet_hours",
+			user: entities.User{
+				ID:              100,
+				Timezone:        "UTC",
+				QuietHoursStart: now.Hour(),
+				QuietHoursEnd:   (now.Hour() + 1) % 24,
+			},
+		},
+		{
+			name: "skip_on_quiet_weekday",
+			user: entities.User{ID: 100, Timezone: "UTC", QuietWeekdays: 1 << now.Weekday()},
+		},
+		{
+			name: "skip_on_vacation",
+			user: entities.User{ID: 100, Timezone: "UTC", VacationStart: &vacationStart, VacationEnd: &today},
+		},
+		{
+			name: "catch_up_after_vacation",
+			user: entities.User{
+				ID:            100,
+				TelegramID:    12345,
+				Timezone:      "UTC",
+				VacationStart: &vacationStart,
+				VacationEnd:   &vacationEnd,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC"}
+
+				mockUsecases.EXPECT().UpdateUserVacation(gomock.Any(), 100, nil, nil).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 100).Return([]entities.Group{ficus, ferns}, nil).Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), ficus.ID).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 100).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Cond(func(text string) bool {
+						return strings.HasPrefix(text, texts.NotifyCatchUp) &&
+							strings.Contains(text, "Фикусы") &&
+							!strings.Contains(text, "Папоротники")
+					}),
+					gomock.Any(),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(
+					gomock.Any(),
+					gomock.Cond(func(notification entities.Notification) bool {
+						return notification.Digest && notification.GroupID == ficus.ID
+					}),
+				).Return(&entities.Notification{}, nil).Times(1)
+			},
+		},
+		{
+			name: "error_finish_vacation",
+			user: entities.User{ID: 100, Timezone: "UTC", VacationStart: &vacationStart, VacationEnd: &vacationEnd},
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().UpdateUserVacation(gomock.Any(), 100, nil, nil).Return(nil, fmt.Errorf("db error")).Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, metrics.New(), "test")
+
+			mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{ficus}, nil).Times(1)
+			mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).MaxTimes(1)
+			mockUsecases.EXPECT().GetUserByID(gomock.Any(), tt.user.ID).Return(&tt.user, nil).Times(1)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockUsecases)
+			}
+
+			// Сценарии без передачи присматривающему, если тест не задал иное:
+			mockUsecases.EXPECT().
+				GetGroupDelegation(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, customerrors.ErrDelegationNotFound).
+				AnyTimes()
+
+			if tt.expectError {
+				mockLogger.EXPECT().Error(gomock.Any(), "Error", gomock.Any(), "Tracing", gomock.Any()).MaxTimes(1)
+			}
+
+			err := preparer.GetCallback()(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationsPreparer_GetCallback_Delegated(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	vacationStart := today.AddDate(0, 0, -1)
+	vacationEnd := today.AddDate(0, 0, 7)
+	sitterID := 200
+	owner := entities.User{
+		ID:            100,
+		TelegramID:    12345,
+		Username:      "owner",
+		Timezone:      "UTC",
+		VacationStart: &vacationStart,
+		VacationEnd:   &vacationEnd,
+	}
+	sitter := entities.User{ID: sitterID, TelegramID: 54321, Timezone: "UTC"}
+	ficus := entities.Group{ID: 1, UserID: owner.ID, Title: "Фикусы", NextWateringDate: today.AddDate(0, 0, -1)}
+	delegation := &entities.Delegation{ID: 5, OwnerID: owner.ID, SitterID: &sitterID, EndDate: vacationEnd}
+	msg := &telebot.Message{ID: 987, Text: "Напоминание"}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases)
+		expectError bool
+	}{
+		{
+			name: "notify_sitter_during_owner_vacation",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), ficus.ID, gomock.Any()).Return(delegation, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), sitterID).Return(&sitter, nil).Times(1)
+				mockUsecases.EXPECT().
+					GetLastNotification(gomock.Any(), ficus.ID).
+					Return(nil, customerrors.ErrNotificationNotFound).
+					Times(1)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), ficus.ID).Return(nil, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(sitter.TelegramID)},
+					gomock.Cond(func(text string) bool {
+						return strings.HasPrefix(text, fmt.Sprintf(texts.NotifySitter, "@owner")) &&
+							strings.Contains(text, "Фикусы")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 2 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.GroupWatered.Unique &&
+							menu.InlineKeyboard[1][0].Unique == buttons.SitterGroupPlants.Unique
+					}),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().
+					SaveNotification(
+						gomock.Any(),
+						gomock.Cond(func(notification entities.Notification) bool {
+							return notification.GroupID == ficus.ID &&
+								notification.ChatID == int64(sitter.TelegramID)
+						}),
+					).
+					Return(&entities.Notification{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "skip_during_sitter_quiet_hours",
+			setupMocks: func(_ *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				quietSitter := sitter
+				quietSitter.QuietHoursStart = now.Hour()
+				quietSitter.QuietHoursEnd = (now.Hour() + 1) % 24
+
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), ficus.ID, gomock.Any()).Return(delegation, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), sitterID).Return(&quietSitter, nil).Times(1)
+			},
+		},
+		{
+			name: "skip_already_notified_sitter",
+			setupMocks: func(_ *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), ficus.ID, gomock.Any()).Return(delegation, nil).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), sitterID).Return(&sitter, nil).Times(1)
+				mockUsecases.EXPECT().
+					GetLastNotification(gomock.Any(), ficus.ID).
+					Return(&entities.Notification{SentAt: now}, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "error_get_delegation",
+			setupMocks: func(_ *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().
+					GetGroupDelegation(gomock.Any(), ficus.ID, gomock.Any()).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, metrics.New(), "test")
+
+			mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return([]entities.Group{ficus}, nil).Times(1)
+			mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).MaxTimes(1)
+			mockUsecases.EXPECT().GetUserByID(gomock.Any(), owner.ID).Return(&owner, nil).Times(1)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockUsecases)
+			}
+
+			if tt.expectError {
+				mockLogger.EXPECT().Error(gomock.Any(), "Error", gomock.Any(), "Tracing", gomock.Any()).MaxTimes(1)
+			}
+
+			err := preparer.GetCallback()(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationsPreparer_GetCallback_DrainsAllBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBot := mockbot.NewMockBot(ctrl)
+	mockUsecases := mockusecases.NewMockUseCases(ctrl)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+
+	user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+	firstBatch := []entities.Group{{ID: 1, UserID: user.ID}, {ID: 2, UserID: user.ID}}
+	secondBatch := []entities.Group{{ID: 3, UserID: user.ID}}
+
+	gomock.InOrder(
+		mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 2, time.Minute).Return(firstBatch, nil),
+		mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 2, time.Minute).Return(secondBatch, nil),
+	)
+
+	mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 2, time.Minute).Return(nil, nil).Times(1)
+
+	mockUsecases.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&user, nil).Times(3)
+	mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customerrors.ErrDelegationNotFound).Times(3)
+	mockUsecases.EXPECT().GetLastNotification(gomock.Any(), gomock.Any()).Return(nil, customerrors.ErrNotificationNotFound).Times(3)
+	mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+	mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(3)
+	mockBot.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Return(&telebot.Message{ID: 1}, nil).Times(3)
+	mockUsecases.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(&entities.Notification{}, nil).Times(3)
+
+	preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 2, time.Minute, nil, metrics.New(), "test")
+	assert.NoError(t, preparer.GetCallback()(context.Background()))
+}
+
+func TestNotificationsPreparer_GetCallback_ContinuesAfterGroupError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBot := mockbot.NewMockBot(ctrl)
+	mockUsecases := mockusecases.NewMockUseCases(ctrl)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+
+	user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+	failedGroup := entities.Group{ID: 1, UserID: 200}
+	group := entities.Group{ID: 2, UserID: user.ID}
+
+	mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return(
+		[]entities.Group{failedGroup, group},
+		nil,
+	).Times(1)
+	mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+
+	// Первый сценарий захваченной порции не обрабатывается:
+	mockUsecases.EXPECT().GetUserByID(gomock.Any(), failedGroup.UserID).Return(nil, fmt.Errorf("db error")).Times(1)
+	mockLogger.EXPECT().Error(
+		"Failed to process Group with ID=1",
+		"Error", gomock.Any(),
+		"Tracing", gomock.Any(),
+	).Times(1)
+
+	// Остальные сценарии порции все равно получают напоминания:
+	mockUsecases.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&user, nil).Times(1)
+	mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), group.ID, gomock.Any()).Return(nil, customerrors.ErrDelegationNotFound).Times(1)
+	mockUsecases.EXPECT().GetLastNotification(gomock.Any(), group.ID).Return(nil, customerrors.ErrNotificationNotFound).Times(1)
+	mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), group.ID).Return(nil, nil).Times(1)
+	mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+	mockBot.EXPECT().Send(&telebot.Chat{ID: int64(user.TelegramID)}, gomock.Any(), gomock.Any()).Return(
+		&telebot.Message{ID: 1},
+		nil,
+	).Times(1)
+	mockUsecases.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(&entities.Notification{}, nil).Times(1)
+
+	preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, metrics.New(), "test")
+	assert.EqualError(t, preparer.GetCallback()(context.Background()), "db error")
+}
+
+func TestNotificationsPreparer_GetCallback_CareTasks(t *testing.T) {
+	groupID := 1
+	plantID := 2
+	groupCareTask := entities.CareTask{
+		ID:           10,
+		UserID:       100,
+		GroupID:      &groupID,
+		Type:         entities.CareTaskTypeFertilizing,
+		Interval:     30,
+		LastCareDate: time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC),
+	}
+	plantCareTask := entities.CareTask{
+		ID:           11,
+		UserID:       100,
+		PlantID:      &plantID,
+		Type:         entities.CareTaskTypeMisting,
+		Interval:     3,
+		LastCareDate: time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC),
+	}
+	user := entities.User{ID: 100, TelegramID: 12345, Timezone: "UTC", NotifyHour: 0}
+	msg := &telebot.Message{ID: 987, Text: "Пора выполнить уход"}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases)
+		expectError bool
+	}{
+		{
+			name: "notify_group_care_task",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(
+					[]entities.CareTask{groupCareTask},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastCareTaskNotification(gomock.Any(), groupCareTask.ID).Return(
+					nil,
+					customerrors.ErrNotificationNotFound,
+				).Times(1)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), groupID).Return(&entities.Group{ID: groupID, Title: "Группа"}, nil).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Any(),
+					gomock.Any(),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(
+					gomock.Any(),
+					gomock.Cond(func(x any) bool {
+						notification, ok := x.(entities.Notification)
+
+						return ok &&
+							notification.GroupID == groupID &&
+							notification.CareTaskID != nil &&
+							*notification.CareTaskID == groupCareTask.ID
+					}),
+				).Return(&entities.Notification{}, nil).Times(1)
+			},
+		},
+		{
+			name: "notify_plant_care_task",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(
+					[]entities.CareTask{plantCareTask},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastCareTaskNotification(gomock.Any(), plantCareTask.ID).Return(
+					nil,
+					customerrors.ErrNotificationNotFound,
+				).Times(1)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), plantID).Return(
+					&entities.Plant{ID: plantID, GroupID: groupID, Title: "Фикус"},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), user.ID).Return([]entities.User{user}, nil).Times(1)
+				mockBot.EXPECT().Send(
+					&telebot.Chat{ID: int64(user.TelegramID)},
+					gomock.Any(),
+					gomock.Any(),
+				).Return(msg, nil).Times(1)
+				mockUsecases.EXPECT().SaveNotification(
+					gomock.Any(),
+					gomock.Cond(func(x any) bool {
+						notification, ok := x.(entities.Notification)
+
+						return ok && notification.GroupID == groupID && *notification.CareTaskID == plantCareTask.ID
+					}),
+				).Return(&entities.Notification{}, nil).Times(1)
+			},
+		},
+		{
+			name: "skip_when_care_task_already_notified_today",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(
+					[]entities.CareTask{groupCareTask},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastCareTaskNotification(gomock.Any(), groupCareTask.ID).Return(
+					&entities.Notification{CareTaskID: &groupCareTask.ID, SentAt: time.Now().UTC()},
+					nil,
+				).Times(1)
+			},
+		},
+		{
+			name: "error_claim_care_tasks",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(nil, fmt.Errorf("db error")).Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name: "error_get_last_care_task_notification",
+			setupMocks: func(mockBot *mockbot.MockBot, mockUsecases *mockusecases.MockUseCases) {
+				mockUsecases.EXPECT().ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).Return(nil, nil).Times(1)
+				mockUsecases.EXPECT().ClaimCareTasksForNotify(gomock.Any(), 10, time.Minute).Return(
+					[]entities.CareTask{groupCareTask},
+					nil,
+				).Times(1)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(&user, nil).Times(1)
+				mockUsecases.EXPECT().GetLastCareTaskNotification(gomock.Any(), groupCareTask.ID).Return(
+					nil,
+					fmt.Errorf("db error"),
+				).Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			preparer := NewNotificationsPreparer(mockBot, mockUsecases, mockLogger, 10, time.Minute, nil, metrics.New(), "test")
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockUsecases)
+			}
+
+			// Сценарии без передачи присматривающему, если тест не задал иное:
+			mockUsecases.EXPECT().
+				GetGroupDelegation(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, customerrors.ErrDelegationNotFound).
+				AnyTimes()
+
+			if tt.expectError {
+				mockLogger.EXPECT().Error(gomock.Any(), "Error", gomock.Any(), "Tracing", gomock.Any()).MaxTimes(1)
+			}
+
+			err := preparer.GetCallback()(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+const (
+	CareTaskTypeFertilizing = "fertilizing"
+	CareTaskTypeMisting     = "misting"
+	CareTaskTypeRepotting   = "repotting"
+)
+
+// CareTaskTypes перечисляет поддерживаемые виды ухода в порядке отображения пользователю.
+var CareTaskTypes = []string{
+	CareTaskTypeFertilizing,
+	CareTaskTypeMisting,
+	CareTaskTypeRepotting,
+}
+
+// CareTask описывает периодический уход помимо полива. Задача привязывается либо к сценарию, либо к растению.
+type CareTask struct {
+	ID              int        `json:"id"`
+	UserID          int        `json:"userId"`
+	GroupID         *int       `json:"groupId,omitempty"`
+	PlantID         *int       `json:"plantId,omitempty"`
+	Type            string     `json:"type"`
+	Interval        int        `json:"interval"`
+	LastCareDate    time.Time  `json:"lastCareDate"`
+	NextCareDate    time.Time  `json:"nextCareDate"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	NotifyClaimedAt *time.Time `json:"notifyClaimedAt,omitempty"`
+}
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DelegationTokenPrefix отличает приглашение присматривающего от приглашения в дом в параметре /start.
+const DelegationTokenPrefix = "sitter-"
+
+// Delegation - передача напоминаний по сценариям присматривающему за растениями до даты окончания включительно.
+type Delegation struct {
+	ID        int              `json:"id"`
+	OwnerID   int              `json:"ownerId"`
+	SitterID  *int             `json:"sitterId,omitempty"` // Пустой, пока приглашение не принято
+	GroupIDs  DelegationGroups `json:"groupIds"`
+	Token     string           `json:"token"`
+	EndDate   time.Time        `json:"endDate"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// IsAccepted проверяет, принял ли присматривающий приглашение.
+func (d *Delegation) IsAccepted() bool {
+	return d.SitterID != nil
+}
+
+// IsExpired проверяет, закончилась ли передача к указанной дате. Дата окончания хранится без часового пояса.
+func (d *Delegation) IsExpired(date time.Time) bool {
+	return truncateToDay(date).After(truncateToDay(d.EndDate))
+}
+
+// IsDelegationToken проверяет, что токен из /start относится к приглашению присматривающего.
+func IsDelegationToken(token string) bool {
+	return strings.HasPrefix(token, DelegationTokenPrefix)
+}
+
+// DelegationGroups - сценарии, переданные присматривающему, хранится в базе данных в виде JSONB.
+type DelegationGroups []int
+
+// Toggle добавляет сценарий в передачу или убирает его, если он уже выбран.
+func (g DelegationGroups) Toggle(groupID int) DelegationGroups {
+	if index := slices.Index(g, groupID); index >= 0 {
+		return slices.Delete(slices.Clone(g), index, index+1)
+	}
+
+	return append(slices.Clone(g), groupID)
+}
+
+func (g DelegationGroups) Value() (driver.Value, error) {
+	if g == nil {
+		return json.Marshal([]int{})
+	}
+
+	return json.Marshal([]int(g))
+}
+
+func (g *DelegationGroups) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*g = nil
+
+		return nil
+	case []byte:
+		return json.Unmarshal(value, g)
+	case string:
+		return json.Unmarshal([]byte(value), g)
+	default:
+		return fmt.Errorf("unsupported type for DelegationGroups: %T", src)
+	}
+}
@@ -0,0 +1,76 @@
+package entities_test
+
+import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDelegation_IsExpired(t *testing.T) {
+	delegation := entities.Delegation{EndDate: time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)}
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected bool
+	}{
+		{name: "До окончания", date: time.Date(2026, 7, 19, 12, 0, 0, 0, time.UTC), expected: false},
+		{name: "Последний день включительно", date: time.Date(2026, 7, 20, 23, 0, 0, 0, time.UTC), expected: false},
+		{name: "Последний день в другом часовом поясе", date: time.Date(2026, 7, 20, 1, 0, 0, 0, moscow), expected: false},
+		{name: "После окончания", date: time.Date(2026, 7, 21, 0, 0, 0, 0, time.UTC), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, delegation.IsExpired(tt.date))
+		})
+	}
+}
+
+func TestDelegation_IsAccepted(t *testing.T) {
+	sitterID := 2
+
+	assert.False(t, (&entities.Delegation{}).IsAccepted())
+	assert.True(t, (&entities.Delegation{SitterID: &sitterID}).IsAccepted())
+}
+
+func TestIsDelegationToken(t *testing.T) {
+	assert.True(t, entities.IsDelegationToken(entities.DelegationTokenPrefix+"abc"))
+	assert.False(t, entities.IsDelegationToken("abc"))
+	assert.False(t, entities.IsDelegationToken(""))
+}
+
+func TestDelegationGroups_Toggle(t *testing.T) {
+	groups := entities.DelegationGroups{1, 2}
+
+	assert.Equal(t, entities.DelegationGroups{1, 2, 3}, groups.Toggle(3))
+	assert.Equal(t, entities.DelegationGroups{2}, groups.Toggle(1))
+	assert.Equal(t, entities.DelegationGroups{1}, entities.DelegationGroups(nil).Toggle(1))
+
+	// Исходный список не изменяется:
+	assert.Equal(t, entities.DelegationGroups{1, 2}, groups)
+}
+
+func TestDelegationGroups_ValueAndScan(t *testing.T) {
+	groups := entities.DelegationGroups{1, 2}
+
+	value, err := groups.Value()
+	require.NoError(t, err)
+
+	var scanned entities.DelegationGroups
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, groups, scanned)
+
+	// Пустой список хранится как пустой массив, так как колонка NOT NULL:
+	value, err = entities.DelegationGroups(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("[]"), value)
+
+	require.NoError(t, scanned.Scan("[3]"))
+	assert.Equal(t, entities.DelegationGroups{3}, scanned)
+
+	assert.Error(t, scanned.Scan(42))
+}
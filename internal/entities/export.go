@@ -0,0 +1,113 @@
+package entities
+
+import "time"
+
+// ExportVersion - версия схемы выгрузки данных. Увеличивается при любом несовместимом изменении схемы,
+// чтобы при загрузке выгрузки можно было отличить файлы, сделанные старыми версиями бота.
+const ExportVersion = 1
+
+const (
+	ExportDataPath   = "export.json" // Путь к выгрузке в ZIP-архиве с фотографиями
+	ExportPhotosPath = "photos/"     // Каталог с фотографиями в ZIP-архиве
+)
+
+// Export - выгрузка сценариев и растений пользователя, которую бот отправляет по команде /export.
+// Идентификаторы записей в выгрузку не попадают: сценарии и растения однозначно определяются названиями.
+// Схема описана в README.
+type Export struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exportedAt"`
+	Groups     []ExportGroup `json:"groups"`
+
+	Files []ExportFile `json:"-"` // Фотографии, на которые ссылается выгрузка, для ZIP-архива
+}
+
+type ExportGroup struct {
+	Title            string               `json:"title"`
+	Description      string               `json:"description"`
+	LastWateringDate time.Time            `json:"lastWateringDate"`
+	NextWateringDate time.Time            `json:"nextWateringDate"`
+	WateringInterval int                  `json:"wateringInterval"`
+	SeasonSchedule   SeasonSchedule       `json:"seasonSchedule,omitempty"`
+	Plants           []ExportPlant        `json:"plants"`
+	CareTasks        []ExportCareTask     `json:"careTasks"`
+	Waterings        []ExportWatering     `json:"waterings"`
+	Notifications    []ExportNotification `json:"notifications"`
+}
+
+type ExportPlant struct {
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	WateringInterval *int             `json:"wateringInterval,omitempty"` // nil, если интервал наследуется от сценария
+	LastWateringDate *time.Time       `json:"lastWateringDate,omitempty"` // nil, если растение поливалось со сценарием
+	Photo            string           `json:"photo,omitempty"`            // Путь к обложке в ZIP-архиве
+	Photos           []ExportPhoto    `json:"photos"`                     // Галерея в хронологическом порядке
+	CareTasks        []ExportCareTask `json:"careTasks"`
+}
+
+// GetPhotoPaths возвращает пути ко всем фотографиям выгрузки в ZIP-архиве без повторов.
+func (e *Export) GetPhotoPaths() []string {
+	var paths []string
+
+	added := make(map[string]bool)
+	for _, group := range e.Groups {
+		for _, plant := range group.Plants {
+			for _, path := range plant.GetPhotoPaths() {
+				if !added[path] {
+					added[path] = true
+					paths = append(paths, path)
+				}
+			}
+		}
+	}
+
+	return paths
+}
+
+// GetPhotoPaths возвращает пути к обложке и фотографиям галереи растения в ZIP-архиве.
+func (p *ExportPlant) GetPhotoPaths() []string {
+	paths := make([]string, 0, len(p.Photos)+1)
+	if p.Photo != "" {
+		paths = append(paths, p.Photo)
+	}
+
+	for _, photo := range p.Photos {
+		paths = append(paths, photo.File)
+	}
+
+	return paths
+}
+
+type ExportPhoto struct {
+	File    string    `json:"file"` // Путь к файлу в ZIP-архиве
+	Caption string    `json:"caption,omitempty"`
+	TakenAt time.Time `json:"takenAt"`
+}
+
+// ExportFile - фотография, которая кладется в ZIP-архив, отправляемый вместе с выгрузкой. В архив попадает только
+// содержимое фотографии: file_id действителен только для бота, который получил файл, и в выгрузку не попадает.
+type ExportFile struct {
+	Path   string
+	FileID string // file_id в Telegram, по которому фотография скачивается при сборке архива
+	Data   []byte // Содержимое фотографии, если она хранится в БД, а не в Telegram
+}
+
+type ExportCareTask struct {
+	Type         string    `json:"type"`
+	Interval     int       `json:"interval"`
+	LastCareDate time.Time `json:"lastCareDate"`
+	NextCareDate time.Time `json:"nextCareDate"`
+}
+
+type ExportWatering struct {
+	WateredAt time.Time `json:"wateredAt"`
+	Source    string    `json:"source"`
+	Plant     string    `json:"plant,omitempty"` // Название растения, пустое, если полит весь сценарий
+}
+
+type ExportNotification struct {
+	Text           string     `json:"text"`
+	SentAt         time.Time  `json:"sentAt"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"` // nil, если пользователь не отреагировал
+	Digest         bool       `json:"digest"`
+}
@@ -0,0 +1,52 @@
+package entities_test
+
+import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExport_GetPhotoPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		export   entities.Export
+		expected []string
+	}{
+		{
+			name:     "Без фотографий",
+			export:   entities.Export{Groups: []entities.ExportGroup{{Plants: []entities.ExportPlant{{Title: "Фикус"}}}}},
+			expected: nil,
+		},
+		{
+			name: "Обложка из галереи и общие фотографии не повторяются",
+			export: entities.Export{
+				Groups: []entities.ExportGroup{
+					{
+						Plants: []entities.ExportPlant{
+							{
+								Photo: "photos/a.jpg",
+								Photos: []entities.ExportPhoto{
+									{File: "photos/a.jpg"},
+									{File: "photos/b.jpg"},
+								},
+							},
+						},
+					},
+					{
+						Plants: []entities.ExportPlant{
+							{Photo: "photos/b.jpg"},
+							{Photo: "photos/legacy.jpg"},
+						},
+					},
+				},
+			},
+			expected: []string{"photos/a.jpg", "photos/b.jpg", "photos/legacy.jpg"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.export.GetPhotoPaths())
+		})
+	}
+}
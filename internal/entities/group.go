@@ -3,13 +3,54 @@ package entities
 import "time"
 
 type Group struct {
-	ID               int       `json:"id"`
-	UserID           int       `json:"userId"`
-	Title            string    `json:"title"`
-	Description      string    `json:"description"`
-	LastWateringDate time.Time `json:"lastWateringDate"`
-	NextWateringDate time.Time `json:"nextWateringDate"`
-	WateringInterval int       `json:"wateringInterval"`
-	CreatedAt        time.Time `json:"createdAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
+	ID               int            `json:"id"`
+	UserID           int            `json:"userId"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	LastWateringDate time.Time      `json:"lastWateringDate"`
+	NextWateringDate time.Time      `json:"nextWateringDate"`
+	WateringInterval int            `json:"wateringInterval"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
+	NotifyClaimedAt  *time.Time     `json:"notifyClaimedAt,omitempty"`
+	SnoozedUntil     *time.Time     `json:"snoozedUntil,omitempty"` // Хранится в UTC
+	SeasonSchedule   SeasonSchedule `json:"seasonSchedule,omitempty"`
+}
+
+// GetWateringInterval возвращает интервал полива, действующий на указанную дату с учетом сезонного графика.
+func (g *Group) GetWateringInterval(date time.Time) int {
+	if season := g.SeasonSchedule.GetSeason(date); season != nil {
+		return season.WateringInterval
+	}
+
+	return g.WateringInterval
+}
+
+// GetNextWateringDate возвращает ближайшую дату полива сценария с учетом растений с собственным графиком полива.
+func (g *Group) GetNextWateringDate(plants []Plant) time.Time {
+	var nextWateringDate time.Time
+
+	// Собственный график сценария учитываем, только если в нем есть растения, которые его наследуют:
+	inherited := len(plants) == 0
+	for _, plant := range plants {
+		if !plant.HasWateringOverride() {
+			inherited = true
+
+			continue
+		}
+
+		date := plant.GetNextWateringDate(*g)
+		if nextWateringDate.IsZero() || date.Before(nextWateringDate) {
+			nextWateringDate = date
+		}
+	}
+
+	if inherited {
+		date := g.LastWateringDate.AddDate(0, 0, g.GetWateringInterval(g.LastWateringDate))
+		if nextWateringDate.IsZero() || date.Before(nextWateringDate) {
+			nextWateringDate = date
+		}
+	}
+
+	return nextWateringDate
 }
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+type Household struct {
+	ID        int       `json:"id"`
+	OwnerID   int       `json:"ownerId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type HouseholdInvite struct {
+	ID          int       `json:"id"`
+	HouseholdID int       `json:"householdId"`
+	Token       string    `json:"token"`
+	CreatedBy   int       `json:"createdBy"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// IsExpired проверяет, истек ли срок действия приглашения. Время хранится в UTC.
+func (i HouseholdInvite) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,43 @@
+package entities
+
+// Способы разрешения конфликтов, когда у пользователя уже есть сценарий с названием сценария из выгрузки.
+// Совпадающие растения в таком сценарии заменяются вместе с ним или остаются, если сценарий не меняется.
+const (
+	ImportConflictSkip      = "skip"      // Сценарий из выгрузки не загружается, существующий сценарий не меняется
+	ImportConflictRename    = "rename"    // Сценарий из выгрузки загружается под свободным названием
+	ImportConflictOverwrite = "overwrite" // Настройки сценария и совпадающие растения заменяются данными из выгрузки
+)
+
+// Import - выгрузка, подготовленная к загрузке, вместе с фотографиями из ZIP-архива.
+type Import struct {
+	Export *Export                `json:"export"`
+	Photos map[string]ImportPhoto `json:"photos,omitempty"` // Фотографии по пути в ZIP-архиве
+}
+
+// ImportPhoto - фотография из ZIP-архива, заново загруженная в Telegram: file_id из выгрузки действителен
+// только для бота, который получил файл.
+type ImportPhoto struct {
+	FileID       string `json:"fileId"`
+	FileUniqueID string `json:"fileUniqueId"`
+}
+
+// ImportPreview описывает, что будет создано при загрузке выгрузки.
+type ImportPreview struct {
+	Groups         int
+	Plants         int
+	CareTasks      int
+	Waterings      int
+	Photos         int
+	Conflicts      []string // Названия сценариев из выгрузки, которые уже есть у пользователя
+	PlantConflicts []string // Растения из выгрузки вида "Сценарий / Растение", которые уже есть в этих сценариях
+}
+
+// ImportResult описывает, что было загружено из выгрузки.
+type ImportResult struct {
+	Groups            int // Сколько сценариев создано, включая переименованные
+	Plants            int // Сколько растений создано, включая замененные
+	SkippedGroups     int
+	RenamedGroups     int
+	OverwrittenGroups int
+	OverwrittenPlants int
+}
@@ -3,9 +3,13 @@ package entities
 import "time"
 
 type Notification struct {
-	ID        int       `json:"id"`
-	GroupID   int       `json:"groupId"`
-	MessageID int       `json:"messageId"`
-	Text      string    `json:"text"`
-	SentAt    time.Time `json:"sentAt"`
+	ID             int        `json:"id"`
+	GroupID        int        `json:"groupId"`
+	MessageID      int        `json:"messageId"`
+	Text           string     `json:"text"`
+	SentAt         time.Time  `json:"sentAt"`
+	CareTaskID     *int       `json:"careTaskId,omitempty"` // nil для уведомлений о поливе
+	ChatID         int64      `json:"chatId"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"` // nil, пока пользователь не отреагировал
+	Digest         bool       `json:"digest"`                   // Уведомление отправлено в составе сводки
 }
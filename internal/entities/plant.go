@@ -3,12 +3,45 @@ package entities
 import "time"
 
 type Plant struct {
-	ID          int       `json:"id"`
-	GroupID     int       `json:"groupId"`
-	UserID      int       `json:"userId"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Photo       []byte    `json:"photo"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID                int        `json:"id"`
+	GroupID           int        `json:"groupId"`
+	UserID            int        `json:"userId"`
+	Title             string     `json:"title"`
+	Description       string     `json:"description"`
+	Photo             []byte     `json:"photo,omitempty"` // Только для растений, добавленных до хранения file_id
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+	WateringInterval  *int       `json:"wateringInterval,omitempty"`  // nil, если интервал наследуется от сценария
+	LastWateringDate  *time.Time `json:"lastWateringDate,omitempty"`  // nil, если растение поливалось только со сценарием
+	PhotoFileID       string     `json:"photoFileId,omitempty"`       // Пустой, если фотография не добавлена
+	PhotoFileUniqueID string     `json:"photoFileUniqueId,omitempty"` // Пустой, если фотография не добавлена
+}
+
+// HasWateringOverride сообщает, задан ли для растения собственный график полива.
+func (p *Plant) HasWateringOverride() bool {
+	return p.WateringInterval != nil || p.LastWateringDate != nil
+}
+
+// GetNextWateringDate возвращает дату следующего полива растения.
+// Незаданные интервал и дата последнего полива наследуются от сценария.
+func (p *Plant) GetNextWateringDate(group Group) time.Time {
+	// Полив всего сценария считается поливом каждого его растения:
+	lastWateringDate := group.LastWateringDate
+	if p.LastWateringDate != nil && p.LastWateringDate.After(lastWateringDate) {
+		lastWateringDate = *p.LastWateringDate
+	}
+
+	wateringInterval := group.GetWateringInterval(lastWateringDate)
+	if p.WateringInterval != nil {
+		wateringInterval = *p.WateringInterval
+	}
+
+	return lastWateringDate.AddDate(0, 0, wateringInterval)
+}
+
+// IsWateringDue сообщает, нужно ли поливать растение на указанную дату.
+// Растения без собственного графика сравниваются с собственным графиком сценария, а не с ближайшей датой полива
+// сценария, которую может приблизить растение с коротким интервалом.
+func (p *Plant) IsWateringDue(group Group, date time.Time) bool {
+	return !p.GetNextWateringDate(group).After(date)
 }
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+type PlantPhoto struct {
+	ID           int       `json:"id"`
+	PlantID      int       `json:"plantId"`
+	FileID       string    `json:"fileId"`
+	FileUniqueID string    `json:"fileUniqueId"`
+	Caption      string    `json:"caption"`
+	TakenAt      time.Time `json:"takenAt"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
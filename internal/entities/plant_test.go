@@ -0,0 +1,111 @@
+package entities_test
+
+import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPlant_GetNextWateringDate(t *testing.T) {
+	group := entities.Group{
+		WateringInterval: 7,
+		LastWateringDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	interval := 3
+	earlier := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		plant    entities.Plant
+		expected time.Time
+	}{
+		{
+			name:     "Без собственного графика — как у сценария",
+			plant:    entities.Plant{},
+			expected: time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Собственный интервал",
+			plant:    entities.Plant{WateringInterval: &interval},
+			expected: time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Растение полито позже сценария",
+			plant:    entities.Plant{WateringInterval: &interval, LastWateringDate: &later},
+			expected: time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Полив сценария новее полива растения",
+			plant:    entities.Plant{LastWateringDate: &earlier},
+			expected: time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.plant.GetNextWateringDate(group))
+		})
+	}
+}
+
+func TestPlant_IsWateringDue(t *testing.T) {
+	group := entities.Group{
+		WateringInterval: 7,
+		LastWateringDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	shortInterval := 3
+	longInterval := 14
+	today := time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, (&entities.Plant{}).IsWateringDue(group, today))
+	assert.False(t, (&entities.Plant{}).IsWateringDue(group, today.AddDate(0, 0, -1)))
+	assert.True(t, (&entities.Plant{WateringInterval: &shortInterval}).IsWateringDue(group, today))
+	assert.False(t, (&entities.Plant{WateringInterval: &longInterval}).IsWateringDue(group, today))
+}
+
+func TestGroup_GetNextWateringDate(t *testing.T) {
+	group := entities.Group{
+		WateringInterval: 7,
+		LastWateringDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	shortInterval := 3
+	longInterval := 14
+
+	tests := []struct {
+		name     string
+		plants   []entities.Plant
+		expected time.Time
+	}{
+		{
+			name:     "Без растений",
+			plants:   nil,
+			expected: time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Растение с коротким интервалом",
+			plants:   []entities.Plant{{}, {WateringInterval: &shortInterval}},
+			expected: time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Растение с длинным интервалом и растение без собственного графика",
+			plants:   []entities.Plant{{}, {WateringInterval: &longInterval}},
+			expected: time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Все растения с длинным интервалом",
+			plants:   []entities.Plant{{WateringInterval: &longInterval}},
+			expected: time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, group.GetNextWateringDate(tt.plants))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Season - диапазон месяцев, в течение которого действует собственный интервал полива.
+// Диапазон может переходить через новый год, например с декабря по февраль.
+type Season struct {
+	StartMonth       time.Month `json:"startMonth"`
+	EndMonth         time.Month `json:"endMonth"`
+	WateringInterval int        `json:"wateringInterval"`
+}
+
+// Contains проверяет, входит ли месяц в сезон.
+func (s Season) Contains(month time.Month) bool {
+	if s.StartMonth <= s.EndMonth {
+		return month >= s.StartMonth && month <= s.EndMonth
+	}
+
+	return month >= s.StartMonth || month <= s.EndMonth
+}
+
+// SameMonths проверяет, совпадает ли диапазон месяцев двух сезонов.
+func (s Season) SameMonths(other Season) bool {
+	return s.StartMonth == other.StartMonth && s.EndMonth == other.EndMonth
+}
+
+// DefaultSeasons - времена года, для которых пользователь может задать собственный интервал полива.
+var DefaultSeasons = []Season{
+	{StartMonth: time.December, EndMonth: time.February},
+	{StartMonth: time.March, EndMonth: time.May},
+	{StartMonth: time.June, EndMonth: time.August},
+	{StartMonth: time.September, EndMonth: time.November},
+}
+
+// SeasonSchedule - сезонный график полива, хранится в базе данных в виде JSONB.
+type SeasonSchedule []Season
+
+// GetSeason возвращает сезон, действующий на указанную дату, или nil, если дата не попадает ни в один сезон.
+func (s SeasonSchedule) GetSeason(date time.Time) *Season {
+	for _, season := range s {
+		if season.Contains(date.Month()) {
+			return &season
+		}
+	}
+
+	return nil
+}
+
+// WithSeason возвращает копию графика, в которой сезон с таким же диапазоном месяцев заменен переданным.
+// Сезон с нулевым интервалом удаляется из графика.
+func (s SeasonSchedule) WithSeason(season Season) SeasonSchedule {
+	schedule := make(SeasonSchedule, 0, len(s)+1)
+	for _, existing := range s {
+		if !existing.SameMonths(season) {
+			schedule = append(schedule, existing)
+		}
+	}
+
+	if season.WateringInterval > 0 {
+		schedule = append(schedule, season)
+	}
+
+	return schedule
+}
+
+// Value сохраняет пустой график как NULL.
+func (s SeasonSchedule) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(s)
+}
+
+func (s *SeasonSchedule) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s = nil
+
+		return nil
+	case []byte:
+		return json.Unmarshal(value, s)
+	case string:
+		return json.Unmarshal([]byte(value), s)
+	default:
+		return fmt.Errorf("unsupported type for SeasonSchedule: %T", src)
+	}
+}
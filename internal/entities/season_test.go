@@ -0,0 +1,96 @@
+package entities_test
+
+import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSeason_Contains(t *testing.T) {
+	winter := entities.Season{StartMonth: time.December, EndMonth: time.February}
+	summer := entities.Season{StartMonth: time.June, EndMonth: time.August}
+
+	tests := []struct {
+		name     string
+		season   entities.Season
+		month    time.Month
+		expected bool
+	}{
+		{name: "Зима — декабрь", season: winter, month: time.December, expected: true},
+		{name: "Зима — январь (переход через новый год)", season: winter, month: time.January, expected: true},
+		{name: "Зима — февраль", season: winter, month: time.February, expected: true},
+		{name: "Зима — март", season: winter, month: time.March, expected: false},
+		{name: "Зима — ноябрь", season: winter, month: time.November, expected: false},
+		{name: "Лето — июль", season: summer, month: time.July, expected: true},
+		{name: "Лето — сентябрь", season: summer, month: time.September, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.season.Contains(tt.month))
+		})
+	}
+}
+
+func TestGroup_GetWateringInterval(t *testing.T) {
+	group := entities.Group{
+		WateringInterval: 7,
+		SeasonSchedule: entities.SeasonSchedule{
+			{StartMonth: time.December, EndMonth: time.February, WateringInterval: 21},
+		},
+	}
+
+	assert.Equal(t, 21, group.GetWateringInterval(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 7, group.GetWateringInterval(time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 7, (&entities.Group{WateringInterval: 7}).GetWateringInterval(time.Now()))
+}
+
+func TestSeasonSchedule_WithSeason(t *testing.T) {
+	winter := entities.Season{StartMonth: time.December, EndMonth: time.February, WateringInterval: 21}
+	summer := entities.Season{StartMonth: time.June, EndMonth: time.August, WateringInterval: 5}
+	schedule := entities.SeasonSchedule{winter}
+
+	t.Run("Добавление нового сезона", func(t *testing.T) {
+		assert.Equal(t, entities.SeasonSchedule{winter, summer}, schedule.WithSeason(summer))
+	})
+
+	t.Run("Замена интервала существующего сезона", func(t *testing.T) {
+		updated := winter
+		updated.WateringInterval = 14
+
+		assert.Equal(t, entities.SeasonSchedule{updated}, schedule.WithSeason(updated))
+		assert.Equal(t, 21, schedule[0].WateringInterval, "исходный график не должен изменяться")
+	})
+
+	t.Run("Удаление сезона нулевым интервалом", func(t *testing.T) {
+		removed := winter
+		removed.WateringInterval = 0
+
+		assert.Empty(t, schedule.WithSeason(removed))
+	})
+}
+
+func TestSeasonSchedule_ValueAndScan(t *testing.T) {
+	schedule := entities.SeasonSchedule{
+		{StartMonth: time.December, EndMonth: time.February, WateringInterval: 21},
+	}
+
+	value, err := schedule.Value()
+	require.NoError(t, err)
+
+	var scanned entities.SeasonSchedule
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, schedule, scanned)
+
+	// Пустой график хранится как NULL:
+	value, err = entities.SeasonSchedule(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+
+	assert.Error(t, scanned.Scan(42))
+}
@@ -31,3 +31,36 @@ func (t *Temporary) GetPlant() (*Plant, error) {
 
 	return plant, nil
 }
+
+func (t *Temporary) GetCareTask() (*CareTask, error) {
+	careTask := &CareTask{}
+
+	err := json.Unmarshal(t.Data, careTask)
+	if err != nil {
+		return nil, err
+	}
+
+	return careTask, nil
+}
+
+func (t *Temporary) GetDelegation() (*Delegation, error) {
+	delegation := &Delegation{}
+
+	err := json.Unmarshal(t.Data, delegation)
+	if err != nil {
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+func (t *Temporary) GetImport() (*Import, error) {
+	data := &Import{}
+
+	err := json.Unmarshal(t.Data, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
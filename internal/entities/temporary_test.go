@@ -266,3 +266,100 @@ func TestTemporary_GetPlant(t *testing.T) {
 		})
 	}
 }
+
+// TestTemporary_GetCareTask тестирует метод GetCareTask
+func TestTemporary_GetCareTask(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	plantID := 7
+
+	careTask := &entities.CareTask{
+		ID:           1,
+		UserID:       100,
+		PlantID:      &plantID,
+		Type:         entities.CareTaskTypeRepotting,
+		Interval:     365,
+		LastCareDate: now,
+		NextCareDate: now.AddDate(1, 0, 0),
+	}
+
+	validData, _ := json.Marshal(careTask)
+
+	tests := []struct {
+		name        string
+		temporary   *entities.Temporary
+		expectError bool
+		expected    *entities.CareTask
+	}{
+		{
+			name:        "Валидные данные — должен успешно распаковать CareTask",
+			temporary:   &entities.Temporary{ID: 1, UserID: 100, Data: validData},
+			expectError: false,
+			expected:    careTask,
+		},
+		{
+			name:        "Пустые данные — ошибка unmarshal",
+			temporary:   &entities.Temporary{ID: 2, UserID: 101, Data: []byte{}},
+			expectError: true,
+		},
+		{
+			name:        "Некорректный JSON — ошибка парсинга полей",
+			temporary:   &entities.Temporary{ID: 3, UserID: 102, Data: []byte(`{"id": "не число"}`)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.temporary.GetCareTask()
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Ожидалась ошибка, но её не было")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Не ожидалась ошибка, но получена: %v", err)
+			}
+
+			if result.ID != tt.expected.ID {
+				t.Errorf("Ожидался ID=%d, получено=%d", tt.expected.ID, result.ID)
+			}
+
+			if result.Type != tt.expected.Type {
+				t.Errorf("Ожидался Type=%s, получено=%s", tt.expected.Type, result.Type)
+			}
+
+			if result.PlantID == nil || *result.PlantID != *tt.expected.PlantID {
+				t.Errorf("Ожидался PlantID=%d, получено=%v", *tt.expected.PlantID, result.PlantID)
+			}
+
+			if result.GroupID != nil {
+				t.Errorf("Ожидался пустой GroupID, получено=%d", *result.GroupID)
+			}
+
+			if !result.NextCareDate.Equal(tt.expected.NextCareDate) {
+				t.Errorf("Ожидалась NextCareDate=%v, получено=%v", tt.expected.NextCareDate, result.NextCareDate)
+			}
+		})
+	}
+}
+
+func TestTemporary_GetDelegation(t *testing.T) {
+	delegation := &entities.Delegation{OwnerID: 100, GroupIDs: entities.DelegationGroups{1, 2}}
+	validData, _ := json.Marshal(delegation)
+
+	got, err := (&entities.Temporary{UserID: 100, Data: validData}).GetDelegation()
+	if err != nil {
+		t.Fatalf("Ожидалась успешная распаковка, получена ошибка: %v", err)
+	}
+
+	if got.OwnerID != delegation.OwnerID || len(got.GroupIDs) != len(delegation.GroupIDs) {
+		t.Errorf("Ожидалось %+v, получено %+v", delegation, got)
+	}
+
+	if _, err = (&entities.Temporary{UserID: 100, Data: []byte{}}).GetDelegation(); err == nil {
+		t.Error("Ожидалась ошибка для пустых данных, но её нет")
+	}
+}
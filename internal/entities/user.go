@@ -1,14 +1,85 @@
 package entities
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type User struct {
-	ID         int       `json:"id"`
-	TelegramID int       `json:"telegramId"`
-	Username   string    `json:"username"`
-	Firstname  string    `json:"firstname"`
-	Lastname   string    `json:"lastname"`
-	IsBot      bool      `json:"isBot"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID                  int        `json:"id"`
+	TelegramID          int        `json:"telegramId"`
+	Username            string     `json:"username"`
+	Firstname           string     `json:"firstname"`
+	Lastname            string     `json:"lastname"`
+	IsBot               bool       `json:"isBot"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+	Timezone            string     `json:"timezone"`
+	NotifyHour          int        `json:"notifyHour"`
+	NotificationsDigest bool       `json:"notificationsDigest"` // Одна сводка в день вместо напоминания по каждому сценарию
+	QuietHoursStart     int        `json:"quietHoursStart"`
+	QuietHoursEnd       int        `json:"quietHoursEnd"`
+	QuietWeekdays       int        `json:"quietWeekdays"` // Битовая маска, где бит с номером time.Weekday отвечает за день
+	VacationStart       *time.Time `json:"vacationStart,omitempty"`
+	VacationEnd         *time.Time `json:"vacationEnd,omitempty"`
+	DigestClaimedAt     *time.Time `json:"digestClaimedAt,omitempty"` // Когда воркер последний раз захватил сводку
+}
+
+// GetLocation возвращает часовой пояс пользователя для расчета его локального времени.
+func (u *User) GetLocation() (*time.Location, error) {
+	return time.LoadLocation(u.Timezone)
+}
+
+// GetDisplayName возвращает имя пользователя для показа другим участникам дома.
+func (u *User) GetDisplayName() string {
+	name := strings.TrimSpace(u.Firstname + " " + u.Lastname)
+	if name != "" {
+		return name
+	}
+
+	return "@" + u.Username
+}
+
+// IsQuietHour проверяет, попадает ли час в тихие часы пользователя.
+// Тихие часы могут переходить через полночь, равные границы означают, что они отключены.
+func (u *User) IsQuietHour(hour int) bool {
+	switch {
+	case u.QuietHoursStart == u.QuietHoursEnd:
+		return false
+	case u.QuietHoursStart < u.QuietHoursEnd:
+		return hour >= u.QuietHoursStart && hour < u.QuietHoursEnd
+	default:
+		return hour >= u.QuietHoursStart || hour < u.QuietHoursEnd
+	}
+}
+
+// IsQuietWeekday проверяет, отключены ли уведомления в указанный день недели.
+func (u *User) IsQuietWeekday(weekday time.Weekday) bool {
+	return u.QuietWeekdays&(1<<weekday) != 0
+}
+
+// IsOnVacation проверяет, попадает ли дата в отпуск пользователя. Обе границы отпуска включаются.
+func (u *User) IsOnVacation(date time.Time) bool {
+	if u.VacationStart == nil || u.VacationEnd == nil {
+		return false
+	}
+
+	day := truncateToDay(date)
+
+	return !day.Before(truncateToDay(*u.VacationStart)) && !day.After(truncateToDay(*u.VacationEnd))
+}
+
+// IsVacationOver проверяет, закончился ли к указанной дате отпуск пользователя.
+func (u *User) IsVacationOver(date time.Time) bool {
+	if u.VacationStart == nil || u.VacationEnd == nil {
+		return false
+	}
+
+	return truncateToDay(date).After(truncateToDay(*u.VacationEnd))
+}
+
+// truncateToDay возвращает начало календарного дня без учета часового пояса даты.
+// Даты отпуска хранятся в UTC, а текущее время пользователя - в его часовом поясе.
+func truncateToDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
 }
@@ -0,0 +1,93 @@
+package entities_test
+
+import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestUser_IsQuietHour(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     entities.User
+		hour     int
+		expected bool
+	}{
+		{name: "Тихие часы отключены", user: entities.User{QuietHoursStart: 0, QuietHoursEnd: 0}, hour: 0, expected: false},
+		{name: "Внутри дневного интервала", user: entities.User{QuietHoursStart: 13, QuietHoursEnd: 15}, hour: 14, expected: true},
+		{name: "Окончание не входит в интервал", user: entities.User{QuietHoursStart: 13, QuietHoursEnd: 15}, hour: 15, expected: false},
+		{name: "Ночью с переходом через полночь", user: entities.User{QuietHoursStart: 22, QuietHoursEnd: 8}, hour: 2, expected: true},
+		{name: "Начало ночного интервала", user: entities.User{QuietHoursStart: 22, QuietHoursEnd: 8}, hour: 22, expected: true},
+		{name: "Днем при ночном интервале", user: entities.User{QuietHoursStart: 22, QuietHoursEnd: 8}, hour: 12, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.user.IsQuietHour(tt.hour))
+		})
+	}
+}
+
+func TestUser_IsQuietWeekday(t *testing.T) {
+	user := entities.User{QuietWeekdays: 1<<time.Saturday | 1<<time.Sunday}
+
+	assert.True(t, user.IsQuietWeekday(time.Saturday))
+	assert.True(t, user.IsQuietWeekday(time.Sunday))
+	assert.False(t, user.IsQuietWeekday(time.Monday))
+}
+
+func TestUser_Vacation(t *testing.T) {
+	start := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name         string
+		user         entities.User
+		date         time.Time
+		wantVacation bool
+		wantOver     bool
+	}{
+		{
+			name: "Отпуск не запланирован",
+			user: entities.User{},
+			date: start,
+		},
+		{
+			name: "Выбрано только начало отпуска",
+			user: entities.User{VacationStart: &start},
+			date: end.AddDate(0, 0, 1),
+		},
+		{
+			name: "До начала отпуска",
+			user: entities.User{VacationStart: &start, VacationEnd: &end},
+			date: start.AddDate(0, 0, -1),
+		},
+		{
+			name:         "Первый день отпуска",
+			user:         entities.User{VacationStart: &start, VacationEnd: &end},
+			date:         start,
+			wantVacation: true,
+		},
+		{
+			name:         "Последний день отпуска по локальному времени пользователя",
+			user:         entities.User{VacationStart: &start, VacationEnd: &end},
+			date:         time.Date(2026, 7, 20, 23, 30, 0, 0, moscow),
+			wantVacation: true,
+		},
+		{
+			name:     "После отпуска",
+			user:     entities.User{VacationStart: &start, VacationEnd: &end},
+			date:     time.Date(2026, 7, 21, 1, 0, 0, 0, moscow),
+			wantOver: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantVacation, tt.user.IsOnVacation(tt.date))
+			assert.Equal(t, tt.wantOver, tt.user.IsVacationOver(tt.date))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+const (
+	WateringSourceNotification = "notification"
+	WateringSourceManual       = "manual"
+	WateringSourceBackfill     = "backfill"
+)
+
+type Watering struct {
+	ID        int       `json:"id"`
+	GroupID   int       `json:"groupId"`
+	PlantID   *int      `json:"plantId,omitempty"` // nil, если полит весь сценарий
+	WateredAt time.Time `json:"wateredAt"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"createdAt"`
+	UserID    *int      `json:"userId,omitempty"` // nil для поливов, отмеченных до появления общих домов
+}
@@ -0,0 +1,5 @@
+package errors
+
+import "errors"
+
+var ErrInvalidCareTaskType = errors.New("invalid care task type")
@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	ErrDelegationNotFound      = errors.New("delegation not found")
+	ErrDelegationExpired       = errors.New("delegation expired")
+	ErrDelegationToSelf        = errors.New("delegation to self")
+	ErrDelegationWithoutGroups = errors.New("delegation without groups")
+	ErrDelegationEndDateInPast = errors.New("delegation end date in past")
+)
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrHouseholdNotFound       = errors.New("household not found")
+	ErrHouseholdInviteNotFound = errors.New("household invite not found")
+	ErrHouseholdInviteExpired  = errors.New("household invite expired")
+)
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidImport                   = errors.New("invalid import")
+	ErrUnsupportedImportVersion        = errors.New("unsupported import version")
+	ErrUnknownImportConflictResolution = errors.New("unknown import conflict resolution")
+)
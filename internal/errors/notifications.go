@@ -0,0 +1,5 @@
+package errors
+
+import "errors"
+
+var ErrNotificationNotFound = errors.New("notification not found")
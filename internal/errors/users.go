@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidTimezone      = errors.New("invalid timezone")
+	ErrInvalidNotifyHour    = errors.New("invalid notify hour")
+	ErrInvalidQuietHours    = errors.New("invalid quiet hours")
+	ErrInvalidQuietWeekdays = errors.New("invalid quiet weekdays")
+	ErrInvalidVacation      = errors.New("invalid vacation")
+)
@@ -0,0 +1,480 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
+)
+
+func AddCareTaskCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		owner, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		ownerCaption, _, err := getCareTaskOwner(ctx, useCases, *owner)
+		if err != nil {
+			return err
+		}
+
+		careTasks, err := getOwnerCareTasks(ctx, useCases, *owner)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{},
+		}
+
+		// Предлагаем только те виды ухода, которые еще не добавлены:
+		for _, careTaskType := range getAvailableCareTaskTypes(careTasks) {
+			menu.InlineKeyboard = append(
+				menu.InlineKeyboard,
+				[]telebot.InlineButton{
+					{
+						Unique: buttons.AddCareTaskType.Unique,
+						Text:   utils.GetCareTaskType(careTaskType),
+						Data:   careTaskType,
+					},
+				},
+			)
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.BackToCareTasks,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.AddCareTaskTypeImage),
+				Caption: fmt.Sprintf(texts.AddCareTaskType, ownerCaption),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.AddCareTaskType); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func AddCareTaskTypeCallback(
+	bot interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := useCases.AddCareTaskType(ctx, int(context.Sender().ID), context.Data())
+		if err != nil {
+			return err
+		}
+
+		return sendAddCareTaskLastDate(ctx, bot, context, useCases, logger, *careTask)
+	}
+}
+
+func BackToAddCareTaskLastDateCallback(
+	bot interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Получаем задачу ухода для корректного отображения данных прошлых этапов:
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		if err = sendAddCareTaskLastDate(ctx, bot, context, useCases, logger, *careTask); err != nil {
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.AddCareTaskLastDate); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func AddCareTaskLastDate(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		lastCareDate, err := time.Parse(dateFormat, context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse last care date",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Дата последнего ухода не может быть позже текущего дня:
+		if time.Now().Before(lastCareDate) {
+			// Нет context.Callback() для обычного сообщения, поэтому отправляем ответ текстом:
+			if err = context.Send(texts.CareTaskDateInFuture); err != nil {
+				logger.Error(
+					"Failed to send message",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Для календаря используем context.Chat().ID:
+		careTask, err := useCases.AddCareTaskLastDate(ctx, int(context.Chat().ID), lastCareDate)
+		if err != nil {
+			return err
+		}
+
+		return sendAddCareTaskInterval(ctx, context, useCases, logger, *careTask)
+	}
+}
+
+func BackToAddCareTaskIntervalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		if err = sendAddCareTaskInterval(ctx, context, useCases, logger, *careTask); err != nil {
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.AddCareTaskInterval); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func AddCareTaskIntervalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		interval, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse care task interval",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := useCases.AddCareTaskInterval(ctx, int(context.Sender().ID), interval)
+		if err != nil {
+			return err
+		}
+
+		ownerCaption, _, err := getCareTaskOwner(ctx, useCases, *careTask)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.ConfirmAddCareTask,
+				},
+				{
+					buttons.BackToAddCareTaskInterval,
+					buttons.Menu,
+				},
+			},
+		}
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ConfirmAddCareTaskImage),
+				Caption: formatCareTask(texts.ConfirmAddCareTask, ownerCaption, *careTask),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+func ConfirmAddCareTaskCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		if _, err = useCases.CreateCareTask(ctx, *careTask); err != nil {
+			return err
+		}
+
+		return sendCareTasks(
+			ctx,
+			context,
+			useCases,
+			logger,
+			entities.CareTask{GroupID: careTask.GroupID, PlantID: careTask.PlantID},
+		)
+	}
+}
+
+func sendAddCareTaskLastDate(
+	ctx context.Context,
+	bot interfaces.Bot,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	careTask entities.CareTask,
+) error {
+	ownerCaption, _, err := getCareTaskOwner(ctx, useCases, careTask)
+	if err != nil {
+		return err
+	}
+
+	// Пересадка выполняется редко, поэтому позволяем выбрать дату и в прошлом году:
+	now := time.Now()
+
+	cal, err := calendar.NewCalendar(
+		bot,
+		logger,
+		calendar.WithYearsRange([2]int{now.Year() - 1, now.Year()}),
+		calendar.WithBackButton(buttons.BackToAddCareTaskType),
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to create calendar",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: cal.GetKeyboard(),
+	}
+
+	err = context.Send(
+		&telebot.Photo{
+			File: telebot.FromDisk(paths.AddCareTaskLastDateImage),
+			Caption: fmt.Sprintf(
+				texts.AddCareTaskLastDate,
+				ownerCaption,
+				utils.GetCareTaskType(careTask.Type),
+			),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func sendAddCareTaskInterval(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	careTask entities.CareTask,
+) error {
+	ownerCaption, _, err := getCareTaskOwner(ctx, useCases, careTask)
+	if err != nil {
+		return err
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: getCareTaskIntervalsKeyboard(buttons.AddCareTaskInterval),
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			buttons.BackToAddCareTaskLastDate,
+			buttons.Menu,
+		},
+	)
+
+	err = context.Send(
+		&telebot.Photo{
+			File: telebot.FromDisk(paths.AddCareTaskIntervalImage),
+			Caption: fmt.Sprintf(
+				texts.AddCareTaskInterval,
+				ownerCaption,
+				utils.GetCareTaskType(careTask.Type),
+				careTask.LastCareDate.Format(dateFormat),
+			),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
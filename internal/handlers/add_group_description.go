@@ -9,6 +9,7 @@ import (
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -16,6 +17,8 @@ import (
 
 func AddGroupDescription(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -26,7 +29,7 @@ func AddGroupDescription(bot interfaces.Bot, useCases interfaces.UseCases, logge
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -44,7 +47,7 @@ func AddGroupDescription(bot interfaces.Bot, useCases interfaces.UseCases, logge
 			}
 		}
 
-		group, err := useCases.AddGroupDescription(int(context.Sender().ID), context.Message().Text)
+		group, err := useCases.AddGroupDescription(ctx, int(context.Sender().ID), context.Message().Text)
 		if err != nil {
 			return err
 		}
@@ -96,6 +99,8 @@ func BackToAddGroupDescriptionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -106,7 +111,7 @@ func BackToAddGroupDescriptionCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -155,12 +160,8 @@ func BackToAddGroupDescriptionCallback(
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddGroupDescription); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.AddGroupDescription, &msg.ID)
+		if err != nil {
 			return err
 		}
 
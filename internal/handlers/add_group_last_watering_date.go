@@ -11,6 +11,7 @@ import (
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -19,6 +20,7 @@ import (
 
 const (
 	dateFormat                         = "02.01.2006"
+	timeFormat                         = "15:04"
 	groupWateringIntervalButtonsPerRaw = 2
 )
 
@@ -30,6 +32,8 @@ func AddGroupLastWateringDate(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		lastWateringDate, err := time.Parse(dateFormat, context.Data())
 		if err != nil {
 			logger.Error(
@@ -69,7 +73,7 @@ func AddGroupLastWateringDate(
 		}
 
 		// Для календаря используем context.Chat().ID:
-		group, err := useCases.AddGroupLastWateringDate(int(context.Chat().ID), lastWateringDate)
+		group, err := useCases.AddGroupLastWateringDate(ctx, int(context.Chat().ID), lastWateringDate)
 		if err != nil {
 			return err
 		}
@@ -136,6 +140,8 @@ func BackToAddGroupLastWateringDateCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -146,7 +152,7 @@ func BackToAddGroupLastWateringDateCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -201,7 +207,7 @@ func BackToAddGroupLastWateringDateCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddGroupLastWateringDate); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.AddGroupLastWateringDate); err != nil {
 			return err
 		}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
@@ -21,6 +22,8 @@ const (
 
 func AddGroupTitle(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -46,12 +49,12 @@ func AddGroupTitle(_ interfaces.Bot, useCases interfaces.UseCases, logger loggin
 		}
 
 		// Получаем временные данные, пока есть информация о сообщении для удаления до изменений в AddGroupTitle:
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.AddGroupTitle(int(context.Sender().ID), context.Message().Text)
+		group, err := useCases.AddGroupTitle(ctx, int(context.Sender().ID), context.Message().Text)
 
 		switch {
 		case errors.Is(err, customerrors.ErrGroupAlreadyExists):
@@ -116,7 +119,7 @@ func AddGroupTitle(_ interfaces.Bot, useCases interfaces.UseCases, logger loggin
 			return err
 		}
 
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		if err = useCases.SetTemporaryMessage(ctx, int(context.Sender().ID), &msg.ID); err != nil {
 			return err
 		}
 
@@ -130,6 +133,8 @@ func SkipGroupDescriptionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -140,7 +145,7 @@ func SkipGroupDescriptionCallback(
 			return err
 		}
 
-		group, err := useCases.AddGroupDescription(int(context.Sender().ID), "➖")
+		group, err := useCases.AddGroupDescription(ctx, int(context.Sender().ID), "➖")
 		if err != nil {
 			return err
 		}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -21,6 +22,8 @@ func AddGroupWateringIntervalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -42,7 +45,7 @@ func AddGroupWateringIntervalCallback(
 			return err
 		}
 
-		group, err := useCases.AddGroupWateringInterval(int(context.Sender().ID), wateringInterval)
+		group, err := useCases.AddGroupWateringInterval(ctx, int(context.Sender().ID), wateringInterval)
 		if err != nil {
 			return err
 		}
@@ -68,7 +71,7 @@ func AddGroupWateringIntervalCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -94,6 +97,8 @@ func BackToAddGroupWateringIntervalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -104,7 +109,7 @@ func BackToAddGroupWateringIntervalCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -174,7 +179,7 @@ func BackToAddGroupWateringIntervalCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddGroupWateringInterval); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.AddGroupWateringInterval); err != nil {
 			return err
 		}
 
@@ -188,6 +193,8 @@ func ConfirmAddGroupCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -198,7 +205,7 @@ func ConfirmAddGroupCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -214,12 +221,12 @@ func ConfirmAddGroupCallback(
 			return err
 		}
 
-		group, err = useCases.CreateGroup(*group)
+		group, err = useCases.CreateGroup(ctx, *group)
 		if err != nil {
 			return err
 		}
 
-		_ = useCases.ResetTemporary(int(context.Sender().ID))
+		_ = useCases.ResetTemporary(ctx, int(context.Sender().ID))
 
 		menu := &telebot.ReplyMarkup{
 			ResizeKeyboard: true,
@@ -241,7 +248,7 @@ func ConfirmAddGroupCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
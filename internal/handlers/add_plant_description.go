@@ -9,6 +9,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -20,6 +21,8 @@ const (
 
 func AddPlantDescription(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -30,7 +33,7 @@ func AddPlantDescription(_ interfaces.Bot, useCases interfaces.UseCases, logger
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -48,17 +51,17 @@ func AddPlantDescription(_ interfaces.Bot, useCases interfaces.UseCases, logger
 			}
 		}
 
-		plant, err := useCases.AddPlantDescription(int(context.Sender().ID), context.Message().Text)
+		plant, err := useCases.AddPlantDescription(ctx, int(context.Sender().ID), context.Message().Text)
 		if err != nil {
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
-		groups, err := useCases.GetUserGroups(user.ID)
+		groups, err := useCases.GetUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -119,6 +122,8 @@ func BackToAddPlantDescriptionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -129,7 +134,7 @@ func BackToAddPlantDescriptionCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -178,12 +183,8 @@ func BackToAddPlantDescriptionCallback(
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddPlantDescription); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.AddPlantDescription, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -22,6 +23,8 @@ const (
 
 func AddPlantGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		groupID, err := strconv.Atoi(context.Data())
 		if err != nil {
 			logger.Error(
@@ -33,7 +36,7 @@ func AddPlantGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logge
 			return err
 		}
 
-		groupPlantsCount, err := useCases.CountGroupPlants(groupID)
+		groupPlantsCount, err := useCases.CountGroupPlants(ctx, groupID)
 		if err != nil {
 			return err
 		}
@@ -71,7 +74,7 @@ func AddPlantGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logge
 			return nil
 		}
 
-		plant, err := useCases.AddPlantGroup(int(context.Sender().ID), groupID)
+		plant, err := useCases.AddPlantGroup(ctx, int(context.Sender().ID), groupID)
 
 		switch {
 		case errors.Is(err, customerrors.ErrPlantAlreadyExists):
@@ -121,7 +124,7 @@ func AddPlantGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logge
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -176,6 +179,8 @@ func BackToAddPlantGroupCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -186,7 +191,7 @@ func BackToAddPlantGroupCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -203,12 +208,12 @@ func BackToAddPlantGroupCallback(
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
-		groups, err := useCases.GetUserGroups(user.ID)
+		groups, err := useCases.GetUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -260,7 +265,7 @@ func BackToAddPlantGroupCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddPlantGroup); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.AddPlantGroup); err != nil {
 			return err
 		}
 
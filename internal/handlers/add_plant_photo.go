@@ -1,24 +1,25 @@
 package handlers
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 
 	"github.com/DKhorkov/libs/logging"
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
 
 func AddPlantPhoto(
-	bot interfaces.Bot,
+	_ interfaces.Bot,
 	useCases interfaces.UseCases,
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -29,7 +30,7 @@ func AddPlantPhoto(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -47,33 +48,15 @@ func AddPlantPhoto(
 			}
 		}
 
-		photoReader, err := bot.File(context.Message().Photo.MediaFile())
-		if err != nil {
-			return err
-		}
-
-		defer func() {
-			if err = photoReader.Close(); err != nil {
-				logger.Error(
-					"Failed to close photo reader",
-					"Error", err,
-					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-				)
-			}
-		}()
-
-		// После копирования photoReader будет пустым:
-		buffer := new(bytes.Buffer)
-		if _, err = io.Copy(buffer, photoReader); err != nil {
-			return err
-		}
+		// Сохраняем только ссылку на файл, чтобы не хранить фотографию в базе данных:
+		photo := context.Message().Photo
 
-		plant, err := useCases.AddPlantPhoto(int(context.Sender().ID), buffer.Bytes())
+		plant, err := useCases.AddPlantPhoto(ctx, int(context.Sender().ID), photo.FileID, photo.UniqueID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -91,25 +74,21 @@ func AddPlantPhoto(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ConfirmAddPlant,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ConfirmAddPlant,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
@@ -123,6 +102,8 @@ func ConfirmAddPlantCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -133,7 +114,7 @@ func ConfirmAddPlantCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -149,17 +130,17 @@ func ConfirmAddPlantCallback(
 			return err
 		}
 
-		plant, err = useCases.CreatePlant(*plant)
+		plant, err = useCases.CreatePlant(ctx, *plant)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
 
-		_ = useCases.ResetTemporary(int(context.Sender().ID))
+		_ = useCases.ResetTemporary(ctx, int(context.Sender().ID))
 
 		menu := &telebot.ReplyMarkup{
 			ResizeKeyboard: true,
@@ -173,25 +154,21 @@ func ConfirmAddPlantCallback(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.PlantCreated,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.PlantCreated,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
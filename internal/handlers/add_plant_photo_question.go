@@ -1,15 +1,14 @@
 package handlers
 
 import (
-	"bytes"
 	"fmt"
-	"os"
 
 	"github.com/DKhorkov/libs/logging"
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -21,6 +20,8 @@ func AcceptAddPlantPhotoCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -31,7 +32,7 @@ func AcceptAddPlantPhotoCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -48,7 +49,7 @@ func AcceptAddPlantPhotoCallback(
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -88,12 +89,8 @@ func AcceptAddPlantPhotoCallback(
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddPlantPhoto); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.AddPlantPhoto, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -107,6 +104,8 @@ func BackToAddPlantPhotoQuestionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -117,7 +116,7 @@ func BackToAddPlantPhotoQuestionCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -134,7 +133,7 @@ func BackToAddPlantPhotoQuestionCallback(
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -179,13 +178,9 @@ func BackToAddPlantPhotoQuestionCallback(
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddPlantPhotoQuestion); err != nil {
-			return err
-		}
-
 		// Обнуляем сообщение для удаления:
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), nil); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.AddPlantPhotoQuestion, nil)
+		if err != nil {
 			return err
 		}
 
@@ -199,6 +194,8 @@ func RejectAddPlantPhotoCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -209,23 +206,13 @@ func RejectAddPlantPhotoCallback(
 			return err
 		}
 
-		photo, err := os.ReadFile(paths.PlantBasePhotoImage)
-		if err != nil {
-			logger.Error(
-				"Error reading file",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
-			return err
-		}
-
-		plant, err := useCases.AddPlantPhoto(int(context.Sender().ID), photo)
+		// Без фотографии растение отображается с изображением по умолчанию:
+		plant, err := useCases.AddPlantPhoto(ctx, int(context.Sender().ID), "", "")
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -243,25 +230,21 @@ func RejectAddPlantPhotoCallback(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ConfirmAddPlant,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ConfirmAddPlant,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
@@ -19,6 +20,8 @@ const (
 
 func AddPlantTitle(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -43,7 +46,7 @@ func AddPlantTitle(_ interfaces.Bot, useCases interfaces.UseCases, logger loggin
 			return nil
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -61,7 +64,7 @@ func AddPlantTitle(_ interfaces.Bot, useCases interfaces.UseCases, logger loggin
 			}
 		}
 
-		plant, err := useCases.AddPlantTitle(int(context.Sender().ID), context.Message().Text)
+		plant, err := useCases.AddPlantTitle(ctx, int(context.Sender().ID), context.Message().Text)
 		if err != nil {
 			return err
 		}
@@ -98,7 +101,7 @@ func AddPlantTitle(_ interfaces.Bot, useCases interfaces.UseCases, logger loggin
 			return err
 		}
 
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		if err = useCases.SetTemporaryMessage(ctx, int(context.Sender().ID), &msg.ID); err != nil {
 			return err
 		}
 
@@ -112,6 +115,8 @@ func SkipPlantDescriptionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -122,17 +127,17 @@ func SkipPlantDescriptionCallback(
 			return err
 		}
 
-		plant, err := useCases.AddPlantDescription(int(context.Sender().ID), "➖")
+		plant, err := useCases.AddPlantDescription(ctx, int(context.Sender().ID), "➖")
 		if err != nil {
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
-		groups, err := useCases.GetUserGroups(user.ID)
+		groups, err := useCases.GetUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+func CareTaskDoneCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		careTaskID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse careTaskID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := useCases.GetCareTask(ctx, careTaskID)
+		if err != nil {
+			return err
+		}
+
+		user, err := useCases.GetUserByID(ctx, careTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		location, err := user.GetLocation()
+		if err != nil {
+			logger.Error(
+				"Failed to load User location",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Дата ухода определяется по локальному времени пользователя:
+		now := time.Now().In(location)
+		careDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, careTask.NextCareDate.Location())
+
+		careTask, err = useCases.UpdateCareTaskLastDate(ctx, careTaskID, careDate)
+		if err != nil {
+			return err
+		}
+
+		member, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		notifications, err := useCases.GetLastCareTaskNotifications(ctx, careTaskID)
+		if err != nil {
+			return err
+		}
+
+		updated := updateReminders(
+			context,
+			logger,
+			notifications,
+			fmt.Sprintf(texts.CareTaskDoneByMember, html.EscapeString(member.GetDisplayName()), now.Format(timeFormat)),
+		)
+
+		text := fmt.Sprintf(texts.CareTaskDone, careTask.NextCareDate.Format(dateFormat))
+
+		// Если нажатое напоминание не было сохранено, достаточно убрать из него кнопки:
+		if !updated {
+			return respondToReminder(context, logger, text)
+		}
+
+		return respondToCallback(context, logger, text)
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"fmt"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"testing"
+	"time"
+)
+
+func TestCareTaskDoneCallback(t *testing.T) {
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger)
+	}
+
+	sender := &telebot.User{ID: 123}
+	message := &telebot.Message{ID: 789}
+	callback := &telebot.Callback{ID: "callback_123", Sender: sender, Message: message}
+	groupID := 1
+
+	careTask := &entities.CareTask{
+		ID:           10,
+		UserID:       1,
+		GroupID:      &groupID,
+		Type:         entities.CareTaskTypeFertilizing,
+		Interval:     30,
+		LastCareDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		NextCareDate: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	updatedCareTask := *careTask
+	updatedCareTask.NextCareDate = time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — care task done, markup removed, response sent",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+
+				mockUsecases.EXPECT().GetCareTask(gomock.Any(), 10).Return(careTask, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
+				mockUsecases.EXPECT().UpdateCareTaskLastDate(
+					gomock.Any(),
+					10,
+					gomock.AssignableToTypeOf(time.Time{}),
+				).Return(&updatedCareTask, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 2, TelegramID: 123}, nil)
+				mockUsecases.EXPECT().GetLastCareTaskNotifications(gomock.Any(), 10).Return(nil, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       fmt.Sprintf(texts.CareTaskDone, "01.08.2024"),
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "parse careTaskID fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("invalid").AnyTimes()
+
+				mockLogger.EXPECT().Error(
+					"Failed to parse careTaskID",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "get care task fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+
+				mockUsecases.EXPECT().GetCareTask(gomock.Any(), 10).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "invalid user timezone",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+
+				mockUsecases.EXPECT().GetCareTask(gomock.Any(), 10).Return(careTask, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Mars/Olympus"}, nil)
+
+				mockLogger.EXPECT().Error(
+					"Failed to load User location",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "update care task fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+
+				mockUsecases.EXPECT().GetCareTask(gomock.Any(), 10).Return(careTask, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().UpdateCareTaskLastDate(gomock.Any(), 10, gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+			mockBot := mockbot.NewMockBot(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := CareTaskDoneCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
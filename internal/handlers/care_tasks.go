@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
+)
+
+const (
+	careTaskIntervalButtonsPerRow = 2
+)
+
+var careTaskIntervals = []int{3, 7, 10, 14, 21, 30, 60, 90, 180, 365}
+
+func ManageGroupCareTasksCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		group, err := temp.GetGroup()
+		if err != nil {
+			logger.Error(
+				"Failed to get Group from Temporary",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendCareTasks(ctx, context, useCases, logger, entities.CareTask{GroupID: &group.ID})
+	}
+}
+
+func ManagePlantCareTasksCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		plant, err := temp.GetPlant()
+		if err != nil {
+			logger.Error(
+				"Failed to get Plant from Temporary",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendCareTasks(ctx, context, useCases, logger, entities.CareTask{PlantID: &plant.ID})
+	}
+}
+
+func BackToCareTasksCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		return sendCareTasks(ctx, context, useCases, logger, *careTask)
+	}
+}
+
+// sendCareTasks отправляет список задач ухода владельца (сценария или растения) и запоминает владельца в Temporary.
+func sendCareTasks(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	owner entities.CareTask,
+) error {
+	ownerCaption, backButton, err := getCareTaskOwner(ctx, useCases, owner)
+	if err != nil {
+		return err
+	}
+
+	careTasks, err := getOwnerCareTasks(ctx, useCases, owner)
+	if err != nil {
+		return err
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	builder := strings.Builder{}
+	for _, careTask := range careTasks {
+		builder.WriteString(
+			fmt.Sprintf(
+				texts.CareTaskEntry,
+				utils.GetCareTaskType(careTask.Type),
+				utils.GetWateringInterval(careTask.Interval),
+				careTask.NextCareDate.Format(dateFormat),
+			),
+		)
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.ManageCareTask.Unique,
+					Text:   utils.GetCareTaskType(careTask.Type),
+					Data:   strconv.Itoa(careTask.ID),
+				},
+			},
+		)
+	}
+
+	careTasksText := builder.String()
+	if len(careTasks) == 0 {
+		careTasksText = texts.CareTasksEmpty
+	}
+
+	if len(getAvailableCareTaskTypes(careTasks)) > 0 {
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.AddCareTask,
+			},
+		)
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			backButton,
+			buttons.Menu,
+		},
+	)
+
+	err = context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.CareTasksImage),
+			Caption: fmt.Sprintf(texts.CareTasks, ownerCaption, careTasksText),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.ManageCareTasks(ctx, int(context.Sender().ID), owner); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getCareTaskOwner возвращает подпись владельца задачи ухода и кнопку возврата к экрану управления владельцем.
+func getCareTaskOwner(
+	ctx context.Context,
+	useCases interfaces.UseCases,
+	careTask entities.CareTask,
+) (string, telebot.InlineButton, error) {
+	switch {
+	case careTask.GroupID != nil:
+		group, err := useCases.GetGroup(ctx, *careTask.GroupID)
+		if err != nil {
+			return "", telebot.InlineButton{}, err
+		}
+
+		backButton := telebot.InlineButton{
+			Unique: buttons.ManageGroup.Unique,
+			Text:   buttons.BackToManageGroupAction.Text,
+			Data:   strconv.Itoa(group.ID),
+		}
+
+		return fmt.Sprintf(texts.CareTasksOwnerGroup, group.Title), backButton, nil
+	case careTask.PlantID != nil:
+		plant, err := useCases.GetPlant(ctx, *careTask.PlantID)
+		if err != nil {
+			return "", telebot.InlineButton{}, err
+		}
+
+		backButton := telebot.InlineButton{
+			Unique: buttons.ManagePlant.Unique,
+			Text:   buttons.BackToManagePlantAction.Text,
+			Data:   strconv.Itoa(plant.ID),
+		}
+
+		return fmt.Sprintf(texts.CareTasksOwnerPlant, plant.Title), backButton, nil
+	default:
+		return "", telebot.InlineButton{}, errors.New("care task has no owner")
+	}
+}
+
+func getOwnerCareTasks(
+	ctx context.Context,
+	useCases interfaces.UseCases,
+	owner entities.CareTask,
+) ([]entities.CareTask, error) {
+	if owner.GroupID != nil {
+		return useCases.GetGroupCareTasks(ctx, *owner.GroupID)
+	}
+
+	if owner.PlantID != nil {
+		return useCases.GetPlantCareTasks(ctx, *owner.PlantID)
+	}
+
+	return nil, errors.New("care task has no owner")
+}
+
+// getAvailableCareTaskTypes возвращает виды ухода, которые еще не добавлены владельцу.
+func getAvailableCareTaskTypes(careTasks []entities.CareTask) []string {
+	var available []string
+
+	for _, careTaskType := range entities.CareTaskTypes {
+		added := slices.ContainsFunc(careTasks, func(careTask entities.CareTask) bool {
+			return careTask.Type == careTaskType
+		})
+
+		if !added {
+			available = append(available, careTaskType)
+		}
+	}
+
+	return available
+}
+
+// getCareTaskIntervalsKeyboard строит клавиатуру выбора интервала ухода для указанной кнопки.
+func getCareTaskIntervalsKeyboard(button telebot.InlineButton) [][]telebot.InlineButton {
+	var (
+		keyboard [][]telebot.InlineButton
+		row      []telebot.InlineButton
+	)
+
+	for _, value := range careTaskIntervals {
+		btn := telebot.InlineButton{
+			Unique: button.Unique,
+			Text:   utils.GetWateringInterval(value),
+			Data:   strconv.Itoa(value),
+		}
+
+		row = append(row, btn)
+		if len(row) == careTaskIntervalButtonsPerRow {
+			keyboard = append(keyboard, row)
+			row = []telebot.InlineButton{}
+		}
+	}
+
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	return keyboard
+}
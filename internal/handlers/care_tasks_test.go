@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManageGroupCareTasksCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	groupID := 10
+	group := &entities.Group{ID: groupID, Title: "Garden"}
+	fertilizing := entities.CareTask{
+		ID:           1,
+		GroupID:      &groupID,
+		Type:         entities.CareTaskTypeFertilizing,
+		Interval:     30,
+		NextCareDate: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — care tasks listed with add button",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: groupID})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), groupID).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupCareTasks(gomock.Any(), groupID).Return([]entities.CareTask{fertilizing}, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, group.Title) &&
+							strings.Contains(photo.Caption, "01.07.2024")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.ManageCareTask.Unique &&
+							menu.InlineKeyboard[0][0].Data == "1" &&
+							menu.InlineKeyboard[1][0].Unique == buttons.AddCareTask.Unique &&
+							menu.InlineKeyboard[2][0].Unique == buttons.ManageGroup.Unique &&
+							menu.InlineKeyboard[2][0].Data == "10"
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().ManageCareTasks(gomock.Any(), 123, entities.CareTask{GroupID: &groupID}).Return(nil)
+			},
+		},
+		{
+			name:          "success — no add button when all care types added",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: groupID})}
+
+				var careTasks []entities.CareTask
+				for i, careTaskType := range entities.CareTaskTypes {
+					careTasks = append(careTasks, entities.CareTask{ID: i + 1, GroupID: &groupID, Type: careTaskType})
+				}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), groupID).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupCareTasks(gomock.Any(), groupID).Return(careTasks, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Any(),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						for _, row := range menu.InlineKeyboard {
+							for _, btn := range row {
+								if btn.Unique == buttons.AddCareTask.Unique {
+									return false
+								}
+							}
+						}
+
+						return len(menu.InlineKeyboard) == len(careTasks)+1
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().ManageCareTasks(gomock.Any(), 123, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:          "success — empty care tasks",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: groupID})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), groupID).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupCareTasks(gomock.Any(), groupID).Return(nil, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, texts.CareTasksEmpty)
+					}),
+					gomock.Any(),
+				).Return(nil)
+
+				mockUsecases.EXPECT().ManageCareTasks(gomock.Any(), 123, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:          "get care tasks fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: groupID})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), groupID).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupCareTasks(gomock.Any(), groupID).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "delete message fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Delete().Return(assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to delete message",
+					"Error", assert.AnError,
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := ManageGroupCareTasksCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddCareTaskCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	plantID := 20
+	plant := &entities.Plant{ID: plantID, Title: "Ficus"}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — only missing care types offered",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.CareTask{PlantID: &plantID})}
+				careTasks := []entities.CareTask{{ID: 1, PlantID: &plantID, Type: entities.CareTaskTypeMisting}}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), plantID).Return(plant, nil)
+				mockUsecases.EXPECT().GetPlantCareTasks(gomock.Any(), plantID).Return(careTasks, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, plant.Title)
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3 &&
+							menu.InlineKeyboard[0][0].Data == entities.CareTaskTypeFertilizing &&
+							menu.InlineKeyboard[1][0].Data == entities.CareTaskTypeRepotting &&
+							menu.InlineKeyboard[2][0].Unique == buttons.BackToCareTasks.Unique
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:          "get plant fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.CareTask{PlantID: &plantID})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), plantID).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := AddCareTaskCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
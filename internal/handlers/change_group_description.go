@@ -8,6 +8,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -20,6 +21,8 @@ func ChangeGroupDescription(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -30,7 +33,7 @@ func ChangeGroupDescription(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -59,7 +62,7 @@ func ChangeGroupDescription(
 			return err
 		}
 
-		group, err = useCases.UpdateGroupDescription(group.ID, context.Message().Text)
+		group, err = useCases.UpdateGroupDescription(ctx, group.ID, context.Message().Text)
 		if err != nil {
 			return err
 		}
@@ -79,6 +82,9 @@ func ChangeGroupDescription(
 				{
 					buttons.ManageGroupChangeWateringInterval,
 				},
+				{
+					buttons.ManageGroupChangeSeasonSchedule,
+				},
 				{
 					buttons.BackToManageGroupAction,
 					buttons.Menu,
@@ -94,7 +100,7 @@ func ChangeGroupDescription(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -111,7 +117,7 @@ func ChangeGroupDescription(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroupChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroupChange); err != nil {
 			return err
 		}
 
@@ -8,7 +8,9 @@ import (
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -21,6 +23,8 @@ func ChangeGroupLastWateringDate(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		lastWateringDate, err := time.Parse(dateFormat, context.Data())
 		if err != nil {
 			logger.Error(
@@ -60,7 +64,7 @@ func ChangeGroupLastWateringDate(
 		}
 
 		// Для календаря используем context.Chat().ID:
-		temp, err := useCases.GetUserTemporary(int(context.Chat().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Chat().ID))
 		if err != nil {
 			return err
 		}
@@ -76,7 +80,18 @@ func ChangeGroupLastWateringDate(
 			return err
 		}
 
-		group, err = useCases.UpdateGroupLastWateringDate(group.ID, lastWateringDate)
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Chat().ID))
+		if err != nil {
+			return err
+		}
+
+		group, err = useCases.UpdateGroupLastWateringDate(
+			ctx,
+			group.ID,
+			lastWateringDate,
+			entities.WateringSourceManual,
+			user.ID,
+		)
 		if err != nil {
 			return err
 		}
@@ -96,6 +111,9 @@ func ChangeGroupLastWateringDate(
 				{
 					buttons.ManageGroupChangeWateringInterval,
 				},
+				{
+					buttons.ManageGroupChangeSeasonSchedule,
+				},
 				{
 					buttons.BackToManageGroupAction,
 					buttons.Menu,
@@ -111,7 +129,7 @@ func ChangeGroupLastWateringDate(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -128,7 +146,7 @@ func ChangeGroupLastWateringDate(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Chat().ID), steps.ManageGroupChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Chat().ID), steps.ManageGroupChange); err != nil {
 			return err
 		}
 
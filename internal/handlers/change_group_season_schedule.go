@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
+)
+
+const (
+	// Разделитель номера времени года и интервала полива в данных кнопки:
+	seasonIntervalDataSeparator = ":"
+)
+
+var errInvalidSeason = errors.New("invalid season")
+
+func ManageGroupChangeSeasonScheduleCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		group, err := getTemporaryGroup(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		return sendGroupSeasonSchedule(ctx, context, useCases, logger, *group)
+	}
+}
+
+func ChangeGroupSeasonCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		seasonIndex, err := parseSeasonIndex(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse season",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		group, err := getTemporaryGroup(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{},
+		}
+
+		var row []telebot.InlineButton
+
+		for _, value := range wateringIntervals {
+			btn := telebot.InlineButton{
+				Unique: buttons.ChangeGroupSeasonInterval.Unique,
+				Text:   utils.GetWateringInterval(value),
+				Data:   formatSeasonIntervalData(seasonIndex, value),
+			}
+
+			row = append(row, btn)
+			if len(row) == groupWateringIntervalButtonsPerRaw {
+				menu.InlineKeyboard = append(menu.InlineKeyboard, row)
+				row = []telebot.InlineButton{}
+			}
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.ChangeGroupSeasonInterval.Unique,
+					Text:   texts.SeasonScheduleResetInterval,
+					Data:   formatSeasonIntervalData(seasonIndex, 0), // Нулевой интервал удаляет сезон из графика
+				},
+			},
+			[]telebot.InlineButton{
+				buttons.BackToChangeGroupSeasonSchedule,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File: telebot.FromDisk(paths.ChangeGroupSeasonIntervalImage),
+				Caption: fmt.Sprintf(
+					texts.ChangeGroupSeasonInterval,
+					group.Title,
+					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetSeason(entities.DefaultSeasons[seasonIndex]),
+				),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeGroupSeasonInterval); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func ChangeGroupSeasonIntervalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		seasonIndex, wateringInterval, err := parseSeasonIntervalData(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse season watering interval",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		group, err := getTemporaryGroup(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		season := entities.DefaultSeasons[seasonIndex]
+		season.WateringInterval = wateringInterval
+
+		group, err = useCases.UpdateGroupSeasonSchedule(ctx, group.ID, group.SeasonSchedule.WithSeason(season))
+		if err != nil {
+			return err
+		}
+
+		return sendGroupSeasonSchedule(ctx, context, useCases, logger, *group)
+	}
+}
+
+// getTemporaryGroup возвращает актуальные данные сценария, выбранного в ManageGroupCallback.
+func getTemporaryGroup(
+	ctx context.Context,
+	telegramID int,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) (*entities.Group, error) {
+	temp, err := useCases.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := temp.GetGroup()
+	if err != nil {
+		logger.Error(
+			"Failed to get Group from Temporary",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return useCases.GetGroup(ctx, group.ID)
+}
+
+func sendGroupSeasonSchedule(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	group entities.Group,
+) error {
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	builder := strings.Builder{}
+	for i, season := range entities.DefaultSeasons {
+		interval := texts.SeasonScheduleDefaultInterval
+		for _, scheduled := range group.SeasonSchedule {
+			if scheduled.SameMonths(season) {
+				interval = utils.GetWateringInterval(scheduled.WateringInterval)
+			}
+		}
+
+		builder.WriteString(fmt.Sprintf(texts.SeasonScheduleEntry, utils.GetSeason(season), interval))
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.ChangeGroupSeason.Unique,
+					Text:   utils.GetSeason(season),
+					Data:   strconv.Itoa(i),
+				},
+			},
+		)
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			buttons.BackToManageGroupChange,
+			buttons.Menu,
+		},
+	)
+
+	err := context.Send(
+		&telebot.Photo{
+			File: telebot.FromDisk(paths.ChangeGroupSeasonScheduleImage),
+			Caption: fmt.Sprintf(
+				texts.ChangeGroupSeasonSchedule,
+				group.Title,
+				group.Description,
+				group.LastWateringDate.Format(dateFormat),
+				utils.GetGroupWateringInterval(group),
+				group.NextWateringDate.Format(dateFormat),
+				builder.String(),
+			),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeGroupSeasonSchedule); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func parseSeasonIndex(data string) (int, error) {
+	seasonIndex, err := strconv.Atoi(data)
+	if err != nil {
+		return 0, err
+	}
+
+	if seasonIndex < 0 || seasonIndex >= len(entities.DefaultSeasons) {
+		return 0, errInvalidSeason
+	}
+
+	return seasonIndex, nil
+}
+
+func formatSeasonIntervalData(seasonIndex, wateringInterval int) string {
+	return strconv.Itoa(seasonIndex) + seasonIntervalDataSeparator + strconv.Itoa(wateringInterval)
+}
+
+func parseSeasonIntervalData(data string) (int, int, error) {
+	seasonData, intervalData, found := strings.Cut(data, seasonIntervalDataSeparator)
+	if !found {
+		return 0, 0, errInvalidSeason
+	}
+
+	seasonIndex, err := parseSeasonIndex(seasonData)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	wateringInterval, err := strconv.Atoi(intervalData)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if wateringInterval < 0 {
+		return 0, 0, errInvalidSeason
+	}
+
+	return seasonIndex, wateringInterval, nil
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManageGroupChangeSeasonScheduleCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	group := &entities.Group{
+		ID:               10,
+		Title:            "Succulents",
+		WateringInterval: 7,
+		LastWateringDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		NextWateringDate: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		SeasonSchedule: entities.SeasonSchedule{
+			{StartMonth: time.December, EndMonth: time.February, WateringInterval: 21},
+		},
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — season schedule shown",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "21 день, сезон: зима (декабрь — февраль)") &&
+							strings.Contains(photo.Caption, "• лето (июнь — август): основной интервал")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == len(entities.DefaultSeasons)+1 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.ChangeGroupSeason.Unique &&
+							menu.InlineKeyboard[0][0].Data == "0"
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeGroupSeasonSchedule).Return(nil)
+			},
+		},
+		{
+			name:          "get group fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := ManageGroupChangeSeasonScheduleCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChangeGroupSeasonIntervalCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	winter := entities.Season{StartMonth: time.December, EndMonth: time.February, WateringInterval: 21}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		data          string
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — season interval saved",
+			errorExpected: false,
+			data:          "2:5",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+				group := &entities.Group{ID: 10, WateringInterval: 7, SeasonSchedule: entities.SeasonSchedule{winter}}
+				summer := entities.Season{StartMonth: time.June, EndMonth: time.August, WateringInterval: 5}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().UpdateGroupSeasonSchedule(
+					gomock.Any(),
+					10,
+					entities.SeasonSchedule{winter, summer},
+				).Return(group, nil)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeGroupSeasonSchedule).Return(nil)
+			},
+		},
+		{
+			name:          "success — season reset to base interval",
+			errorExpected: false,
+			data:          "0:0",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+				group := &entities.Group{ID: 10, WateringInterval: 7, SeasonSchedule: entities.SeasonSchedule{winter}}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().UpdateGroupSeasonSchedule(gomock.Any(), 10, entities.SeasonSchedule{}).Return(group, nil)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeGroupSeasonSchedule).Return(nil)
+			},
+		},
+		{
+			name:          "invalid season index",
+			errorExpected: true,
+			data:          "7:5",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse season watering interval",
+					"Error", errInvalidSeason,
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "malformed data",
+			errorExpected: true,
+			data:          "5",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse season watering interval",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Data().Return(tc.data).AnyTimes()
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := ChangeGroupSeasonIntervalCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
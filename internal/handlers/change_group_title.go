@@ -10,6 +10,7 @@ import (
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -22,6 +23,8 @@ func ChangeGroupTitle(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -47,7 +50,7 @@ func ChangeGroupTitle(
 			return nil
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -63,7 +66,7 @@ func ChangeGroupTitle(
 			return err
 		}
 
-		group, err = useCases.UpdateGroupTitle(group.ID, context.Message().Text)
+		group, err = useCases.UpdateGroupTitle(ctx, group.ID, context.Message().Text)
 
 		switch {
 		case errors.Is(err, customerrors.ErrGroupAlreadyExists):
@@ -112,6 +115,9 @@ func ChangeGroupTitle(
 				{
 					buttons.ManageGroupChangeWateringInterval,
 				},
+				{
+					buttons.ManageGroupChangeSeasonSchedule,
+				},
 				{
 					buttons.BackToManageGroupAction,
 					buttons.Menu,
@@ -127,7 +133,7 @@ func ChangeGroupTitle(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -144,7 +150,7 @@ func ChangeGroupTitle(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroupChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroupChange); err != nil {
 			return err
 		}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -21,6 +22,8 @@ func ChangeGroupWateringIntervalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -42,7 +45,7 @@ func ChangeGroupWateringIntervalCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -58,7 +61,7 @@ func ChangeGroupWateringIntervalCallback(
 			return err
 		}
 
-		group, err = useCases.UpdateGroupWateringInterval(group.ID, wateringInterval)
+		group, err = useCases.UpdateGroupWateringInterval(ctx, group.ID, wateringInterval)
 		if err != nil {
 			return err
 		}
@@ -78,6 +81,9 @@ func ChangeGroupWateringIntervalCallback(
 				{
 					buttons.ManageGroupChangeWateringInterval,
 				},
+				{
+					buttons.ManageGroupChangeSeasonSchedule,
+				},
 				{
 					buttons.BackToManageGroupAction,
 					buttons.Menu,
@@ -93,7 +99,7 @@ func ChangeGroupWateringIntervalCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -110,7 +116,7 @@ func ChangeGroupWateringIntervalCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroupChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroupChange); err != nil {
 			return err
 		}
 
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/DKhorkov/libs/logging"
@@ -9,6 +8,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
@@ -19,6 +19,8 @@ func ChangePlantDescription(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -29,7 +31,7 @@ func ChangePlantDescription(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -58,12 +60,12 @@ func ChangePlantDescription(
 			return err
 		}
 
-		plant, err = useCases.UpdatePlantDescription(plant.ID, context.Message().Text)
+		plant, err = useCases.UpdatePlantDescription(ctx, plant.ID, context.Message().Text)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -83,6 +85,9 @@ func ChangePlantDescription(
 				{
 					buttons.ManagePlantChangePhoto,
 				},
+				{
+					buttons.ManagePlantChangeWateringInterval,
+				},
 				{
 					buttons.BackToManagePlantAction,
 					buttons.Menu,
@@ -90,30 +95,26 @@ func ChangePlantDescription(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ManagePlantChange,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantChange,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlantChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantChange); err != nil {
 			return err
 		}
 
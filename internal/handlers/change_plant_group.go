@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"strconv"
@@ -12,6 +11,7 @@ import (
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
@@ -22,6 +22,8 @@ func ChangePlantGroupCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		groupID, err := strconv.Atoi(context.Data())
 		if err != nil {
 			logger.Error(
@@ -33,7 +35,7 @@ func ChangePlantGroupCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -49,7 +51,7 @@ func ChangePlantGroupCallback(
 			return err
 		}
 
-		plant, err = useCases.UpdatePlantGroup(plant.ID, groupID)
+		plant, err = useCases.UpdatePlantGroup(ctx, plant.ID, groupID)
 
 		switch {
 		case errors.Is(err, customerrors.ErrPlantAlreadyExists):
@@ -87,7 +89,7 @@ func ChangePlantGroupCallback(
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -119,6 +121,9 @@ func ChangePlantGroupCallback(
 				{
 					buttons.ManagePlantChangePhoto,
 				},
+				{
+					buttons.ManagePlantChangeWateringInterval,
+				},
 				{
 					buttons.BackToManagePlantAction,
 					buttons.Menu,
@@ -126,30 +131,26 @@ func ChangePlantGroupCallback(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ManagePlantChange,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantChange,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlantChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantChange); err != nil {
 			return err
 		}
 
@@ -1,25 +1,26 @@
 package handlers
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 
 	"github.com/DKhorkov/libs/logging"
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
 
 func ChangePlantPhoto(
-	bot interfaces.Bot,
+	_ interfaces.Bot,
 	useCases interfaces.UseCases,
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -30,7 +31,7 @@ func ChangePlantPhoto(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -59,33 +60,15 @@ func ChangePlantPhoto(
 			return err
 		}
 
-		photoReader, err := bot.File(context.Message().Photo.MediaFile())
-		if err != nil {
-			return err
-		}
+		// Сохраняем только ссылку на файл, чтобы не хранить фотографию в базе данных:
+		photo := context.Message().Photo
 
-		defer func() {
-			if err = photoReader.Close(); err != nil {
-				logger.Error(
-					"Failed to close photo reader",
-					"Error", err,
-					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-				)
-			}
-		}()
-
-		// После копирования photoReader будет пустым:
-		buffer := new(bytes.Buffer)
-		if _, err = io.Copy(buffer, photoReader); err != nil {
-			return err
-		}
-
-		plant, err = useCases.UpdatePlantPhoto(plant.ID, buffer.Bytes())
+		plant, err = useCases.UpdatePlantPhoto(ctx, plant.ID, photo.FileID, photo.UniqueID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -105,6 +88,9 @@ func ChangePlantPhoto(
 				{
 					buttons.ManagePlantChangePhoto,
 				},
+				{
+					buttons.ManagePlantChangeWateringInterval,
+				},
 				{
 					buttons.BackToManagePlantAction,
 					buttons.Menu,
@@ -112,30 +98,26 @@ func ChangePlantPhoto(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ManagePlantChange,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantChange,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlantChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantChange); err != nil {
 			return err
 		}
 
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
+)
+
+var errInvalidWateringInterval = errors.New("invalid watering interval")
+
+func ManagePlantChangeWateringIntervalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		plant, err := temp.GetPlant()
+		if err != nil {
+			logger.Error(
+				"Failed to get Plant from Temporary",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		plant, err = useCases.GetPlant(ctx, plant.ID)
+		if err != nil {
+			return err
+		}
+
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{},
+		}
+
+		var row []telebot.InlineButton
+
+		for _, value := range wateringIntervals {
+			btn := telebot.InlineButton{
+				Unique: buttons.ChangePlantWateringInterval.Unique,
+				Text:   utils.GetWateringInterval(value),
+				Data:   strconv.Itoa(value),
+			}
+
+			row = append(row, btn)
+			if len(row) == groupWateringIntervalButtonsPerRaw {
+				menu.InlineKeyboard = append(menu.InlineKeyboard, row)
+				row = []telebot.InlineButton{}
+			}
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.ChangePlantWateringInterval.Unique,
+					Text:   texts.ChangePlantWateringIntervalReset,
+					Data:   "0", // Нулевой интервал возвращает растение к интервалу сценария
+				},
+			},
+			[]telebot.InlineButton{
+				buttons.BackToManagePlantChange,
+				buttons.Menu,
+			},
+		)
+
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ChangePlantWateringInterval,
+				plant.Title,
+				plant.Description,
+				group.Title,
+				getPlantWateringInterval(*plant, *group),
+			),
+			menu,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangePlantWateringInterval); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func ChangePlantWateringIntervalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		wateringInterval, err := strconv.Atoi(context.Data())
+		if err == nil && wateringInterval < 0 {
+			err = errInvalidWateringInterval
+		}
+
+		if err != nil {
+			logger.Error(
+				"Failed to parse wateringInterval",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		plant, err := temp.GetPlant()
+		if err != nil {
+			logger.Error(
+				"Failed to get Plant from Temporary",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		var plantWateringInterval *int
+		if wateringInterval > 0 {
+			plantWateringInterval = &wateringInterval
+		}
+
+		plant, err = useCases.UpdatePlantWateringInterval(ctx, plant.ID, plantWateringInterval)
+		if err != nil {
+			return err
+		}
+
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.ManagePlantChangeTitle,
+				},
+				{
+					buttons.ManagePlantChangeDescription,
+				},
+				{
+					buttons.ManagePlantChangeGroup,
+				},
+				{
+					buttons.ManagePlantChangePhoto,
+				},
+				{
+					buttons.ManagePlantChangeWateringInterval,
+				},
+				{
+					buttons.BackToManagePlantAction,
+					buttons.Menu,
+				},
+			},
+		}
+
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantChange,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
+			menu,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantChange); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// getPlantWateringInterval возвращает собственный интервал полива растения или интервал сценария, если он наследуется.
+func getPlantWateringInterval(plant entities.Plant, group entities.Group) string {
+	if plant.WateringInterval != nil {
+		return utils.GetWateringInterval(*plant.WateringInterval)
+	}
+
+	return fmt.Sprintf("%s (%s)", texts.PlantWateringIntervalInherited, utils.GetGroupWateringInterval(group))
+}
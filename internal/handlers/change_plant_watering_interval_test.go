@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"testing"
+)
+
+func TestChangePlantWateringIntervalCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	interval := 3
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		data          string
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — plant interval saved",
+			errorExpected: false,
+			data:          "3",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+				plant := &entities.Plant{ID: 20, GroupID: 10, WateringInterval: &interval}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().UpdatePlantWateringInterval(gomock.Any(), 20, &interval).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10}, nil)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantChange).Return(nil)
+			},
+		},
+		{
+			name:          "success — plant interval reset to group interval",
+			errorExpected: false,
+			data:          "0",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+				plant := &entities.Plant{ID: 20, GroupID: 10}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().UpdatePlantWateringInterval(gomock.Any(), 20, nil).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10}, nil)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantChange).Return(nil)
+			},
+		},
+		{
+			name:          "negative interval",
+			errorExpected: true,
+			data:          "-1",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse wateringInterval",
+					"Error", errInvalidWateringInterval,
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "update plant fails",
+			errorExpected: true,
+			data:          "3",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().UpdatePlantWateringInterval(gomock.Any(), 20, &interval).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Data().Return(tc.data).AnyTimes()
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := ChangePlantWateringIntervalCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 var Default = map[any]interfaces.Handler{
 	"/start":                                   Start,
 	"/help":                                    Help,
+	"/export":                                  Export,
+	"/import":                                  Import,
 	&buttons.CreateGroup:                       AddGroupCallback,
 	&buttons.ManageGroups:                      ManageGroupsCallback,
 	&buttons.CreatePlant:                       AddPlantCallback,
@@ -42,10 +44,18 @@ var Default = map[any]interfaces.Handler{
 	&buttons.ManagePlantChangeDescription:      ManagePlantChangeDescriptionCallback,
 	&buttons.ManagePlantChangeGroup:            ManagePlantChangeGroupCallback,
 	&buttons.ManagePlantChangePhoto:            ManagePlantChangePhotoCallback,
+	&buttons.ManagePlantChangeWateringInterval: ManagePlantChangeWateringIntervalCallback,
 	&buttons.BackToManagePlantChange:           ManagePlantChangeCallback,
+	&buttons.ManagePlantGallery:                PlantGalleryCallback,
+	&buttons.PlantGalleryPage:                  PlantGalleryCallback,
+	&buttons.BackToPlantGallery:                PlantGalleryCallback,
+	&buttons.ManagePlantAddPhoto:               ManagePlantAddPhotoCallback,
+	&buttons.SetPlantCoverPhoto:                SetPlantCoverPhotoCallback,
 	&buttons.BackToManageGroup:                 ManageGroupsCallback,
 	&buttons.ManageGroupSeePlants:              ManageGroupSeePlantsCallback,
 	&buttons.ManageGroupChange:                 ManageGroupChangeCallback,
+	&buttons.ManageGroupWateringHistory:        WateringHistoryCallback,
+	&buttons.WateringHistoryPage:               WateringHistoryCallback,
 	&buttons.ManageGroupRemoval:                ManageGroupRemovalCallback,
 	&buttons.ConfirmGroupRemoval:               ConfirmGroupRemovalCallback,
 	&buttons.BackToManageGroupAction:           BackToManageGroupActionCallback,
@@ -55,13 +65,71 @@ var Default = map[any]interfaces.Handler{
 	&buttons.ManageGroupChangeLastWateringDate: ManageGroupChangeLastWateringDateCallback,
 	&buttons.ManageGroupChangeWateringInterval: ManageGroupChangeWateringIntervalCallback,
 	&buttons.GroupWatered:                      GroupWateredCallback,
+	&buttons.Handover:                          HandoverCallback,
+	&buttons.BackToHandover:                    HandoverCallback,
+	&buttons.NewHandover:                       NewHandoverCallback,
+	&buttons.HandoverGroup:                     HandoverGroupCallback,
+	&buttons.HandoverChooseEndDate:             HandoverChooseEndDateCallback,
+	&buttons.CancelHandover:                    CancelHandoverCallback,
+	&buttons.SitterGroupPlants:                 SitterGroupPlantsCallback,
+	&buttons.SitterPlant:                       SitterPlantCallback,
+	&buttons.SitterClose:                       SitterCloseCallback,
+	&buttons.AllGroupsWatered:                  AllGroupsWateredCallback,
+	&buttons.GroupRemindLater:                  GroupRemindLaterCallback,
+	&buttons.GroupRemindTomorrow:               GroupRemindTomorrowCallback,
+	&buttons.GroupSkipWatering:                 GroupSkipWateringCallback,
+	&buttons.PlantWatered:                      PlantWateredCallback,
+	&buttons.ManageGroupChangeSeasonSchedule:   ManageGroupChangeSeasonScheduleCallback,
+	&buttons.BackToChangeGroupSeasonSchedule:   ManageGroupChangeSeasonScheduleCallback,
+	&buttons.ChangeGroupSeason:                 ChangeGroupSeasonCallback,
+	&buttons.ChangeGroupSeasonInterval:         ChangeGroupSeasonIntervalCallback,
 	&buttons.ManagePlantsGroup:                 ManagePlantsGroupCallback,
 	&buttons.ManageGroup:                       ManageGroupCallback,
 	&buttons.AddPlantGroup:                     AddPlantGroupCallback,
 	&buttons.ChangePlantGroup:                  ChangePlantGroupCallback,
+	&buttons.ChangePlantWateringInterval:       ChangePlantWateringIntervalCallback,
 	&buttons.AddGroupWateringInterval:          AddGroupWateringIntervalCallback,
 	&buttons.ChangeGroupWateringInterval:       ChangeGroupWateringIntervalCallback,
 	&buttons.ManagePlant:                       ManagePlantCallback,
+	&buttons.Settings:                          SettingsCallback,
+	&buttons.BackToSettings:                    SettingsCallback,
+	&buttons.SettingsChangeTimezone:            SettingsChangeTimezoneCallback,
+	&buttons.SettingsChangeNotifyHour:          SettingsChangeNotifyHourCallback,
+	&buttons.SettingsTimezone:                  SettingsTimezoneCallback,
+	&buttons.SettingsNotifyHour:                SettingsNotifyHourCallback,
+	&buttons.SettingsToggleNotificationsDigest: SettingsToggleNotificationsDigestCallback,
+	&buttons.SettingsQuiet:                     SettingsQuietCallback,
+	&buttons.BackToSettingsQuiet:               SettingsQuietCallback,
+	&buttons.SettingsChangeQuietHours:          SettingsChangeQuietHoursCallback,
+	&buttons.SettingsQuietHoursStart:           SettingsQuietHoursStartCallback,
+	&buttons.SettingsQuietHoursEnd:             SettingsQuietHoursEndCallback,
+	&buttons.SettingsChangeQuietWeekdays:       SettingsChangeQuietWeekdaysCallback,
+	&buttons.SettingsQuietWeekday:              SettingsQuietWeekdayCallback,
+	&buttons.SettingsChangeVacation:            SettingsChangeVacationCallback,
+	&buttons.SettingsCancelVacation:            SettingsCancelVacationCallback,
+	&buttons.SettingsHousehold:                 HouseholdCallback,
+	&buttons.BackToHousehold:                   HouseholdCallback,
+	&buttons.CreateHouseholdInvite:             CreateHouseholdInviteCallback,
+	&buttons.LeaveHousehold:                    LeaveHouseholdCallback,
+	&buttons.ManageGroupCareTasks:              ManageGroupCareTasksCallback,
+	&buttons.ManagePlantCareTasks:              ManagePlantCareTasksCallback,
+	&buttons.BackToCareTasks:                   BackToCareTasksCallback,
+	&buttons.AddCareTask:                       AddCareTaskCallback,
+	&buttons.BackToAddCareTaskType:             AddCareTaskCallback,
+	&buttons.AddCareTaskType:                   AddCareTaskTypeCallback,
+	&buttons.BackToAddCareTaskLastDate:         BackToAddCareTaskLastDateCallback,
+	&buttons.AddCareTaskInterval:               AddCareTaskIntervalCallback,
+	&buttons.BackToAddCareTaskInterval:         BackToAddCareTaskIntervalCallback,
+	&buttons.ConfirmAddCareTask:                ConfirmAddCareTaskCallback,
+	&buttons.ManageCareTask:                    ManageCareTaskCallback,
+	&buttons.BackToManageCareTask:              BackToManageCareTaskCallback,
+	&buttons.ManageCareTaskChangeLastDate:      ManageCareTaskChangeLastDateCallback,
+	&buttons.ManageCareTaskChangeInterval:      ManageCareTaskChangeIntervalCallback,
+	&buttons.ChangeCareTaskInterval:            ChangeCareTaskIntervalCallback,
+	&buttons.ManageCareTaskRemoval:             ManageCareTaskRemovalCallback,
+	&buttons.ConfirmCareTaskRemoval:            ConfirmCareTaskRemovalCallback,
+	&buttons.CareTaskDone:                      CareTaskDoneCallback,
+	&buttons.ConfirmImport:                     ConfirmImportCallback,
 	telebot.OnText:                             OnText,
 	telebot.OnPhoto:                            OnPhoto,
 	telebot.OnMedia:                            OnMedia,
@@ -71,6 +139,6 @@ var Default = map[any]interfaces.Handler{
 	telebot.OnContact:                          Delete,
 	telebot.OnDice:                             Delete,
 	telebot.OnPoll:                             Delete,
-	telebot.OnDocument:                         Delete,
+	telebot.OnDocument:                         OnDocument,
 	telebot.OnLocation:                         Delete,
 }
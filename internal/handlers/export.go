@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+const (
+	exportFileNameFormat        = "plants_%s.json"
+	exportArchiveFileNameFormat = "plants_%s.zip"
+	exportFileNameDateFormat    = "2006-01-02"
+	exportJSONMIME              = "application/json"
+	exportZIPMIME               = "application/zip"
+)
+
+// Export отправляет выгрузку сценариев и растений пользователя JSON-файлом. Если у растений есть фотографии,
+// дополнительно отправляется ZIP-архив с той же выгрузкой и фотографиями, по которому данные восстанавливаются
+// вместе с фотографиями.
+func Export(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete /export message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		export, err := useCases.ExportUserData(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			logger.Error(
+				"Failed to marshal export",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		date := export.ExportedAt.Format(exportFileNameDateFormat)
+
+		err = context.Send(
+			&telebot.Document{
+				File:     telebot.FromReader(bytes.NewReader(data)),
+				FileName: fmt.Sprintf(exportFileNameFormat, date),
+				MIME:     exportJSONMIME,
+				Caption:  texts.Export,
+			},
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if len(export.Files) == 0 {
+			return nil
+		}
+
+		archive, err := archiveExport(bot, data, export.Files)
+		if err != nil {
+			logger.Error(
+				"Failed to archive export photos",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		err = context.Send(
+			&telebot.Document{
+				File:     telebot.FromReader(bytes.NewReader(archive)),
+				FileName: fmt.Sprintf(exportArchiveFileNameFormat, date),
+				MIME:     exportZIPMIME,
+				Caption:  texts.ExportArchive,
+			},
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// archiveExport собирает ZIP-архив из выгрузки и ее фотографий. Фотографии из Telegram скачиваются и кладутся
+// в архив содержимым: file_id действителен только для бота, который получил файл, поэтому при загрузке
+// на другом боте фотографии восстанавливаются из архива.
+func archiveExport(bot interfaces.Bot, data []byte, files []entities.ExportFile) ([]byte, error) {
+	var buf bytes.Buffer
+
+	archive := zip.NewWriter(&buf)
+
+	writer, err := archive.Create(entities.ExportDataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = writer.Write(data); err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if err := archiveExportFile(bot, archive, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func archiveExportFile(bot interfaces.Bot, archive *zip.Writer, file entities.ExportFile) error {
+	writer, err := archive.Create(file.Path)
+	if err != nil {
+		return err
+	}
+
+	if file.FileID == "" {
+		_, err = writer.Write(file.Data)
+
+		return err
+	}
+
+	reader, err := bot.File(&telebot.File{FileID: file.FileID})
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", file.Path, err)
+	}
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	_, err = io.Copy(writer, reader)
+
+	return err
+}
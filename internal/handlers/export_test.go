@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExport(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123}
+	exportedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newExport := func(files ...entities.ExportFile) *entities.Export {
+		return &entities.Export{
+			Version:    entities.ExportVersion,
+			ExportedAt: exportedAt,
+			Groups: []entities.ExportGroup{
+				{
+					Title: "Кухня",
+					Plants: []entities.ExportPlant{
+						{Title: "Фикус", Photo: "photos/unique.jpg"},
+					},
+				},
+			},
+			Files: files,
+		}
+	}
+
+	isExportDocument := func(doc *telebot.Document) bool {
+		data, err := io.ReadAll(doc.FileReader)
+		if err != nil {
+			return false
+		}
+
+		var export entities.Export
+		if err = json.Unmarshal(data, &export); err != nil {
+			return false
+		}
+
+		return doc.FileName == "plants_2025-06-01.json" &&
+			doc.Caption == texts.Export &&
+			export.Version == entities.ExportVersion &&
+			len(export.Groups) == 1 &&
+			export.Groups[0].Plants[0].Photo == "photos/unique.jpg"
+	}
+
+	tests := []struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}{
+		{
+			name:          "success — without photos only json is sent",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ExportUserData(gomock.Any(), 1).Return(newExport(), nil)
+
+				mockCtx.EXPECT().Send(gomock.Cond(isExportDocument)).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "success — photos are sent in zip archive",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				export := newExport(
+					entities.ExportFile{Path: "photos/unique.jpg", FileID: "file-id"},
+					entities.ExportFile{Path: "photos/plant-1.jpg", Data: []byte("legacy")},
+				)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ExportUserData(gomock.Any(), 1).Return(export, nil)
+
+				mockBot.EXPECT().
+					File(&telebot.File{FileID: "file-id"}).
+					Return(io.NopCloser(strings.NewReader("telegram")), nil)
+
+				gomock.InOrder(
+					mockCtx.EXPECT().Send(gomock.Cond(isExportDocument)).Return(nil),
+					mockCtx.EXPECT().Send(
+						gomock.Cond(func(doc *telebot.Document) bool {
+							data, err := io.ReadAll(doc.FileReader)
+							if err != nil {
+								return false
+							}
+
+							archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+							if err != nil {
+								return false
+							}
+
+							files := make(map[string]string)
+							for _, file := range archive.File {
+								reader, err := file.Open()
+								if err != nil {
+									return false
+								}
+
+								content, err := io.ReadAll(reader)
+								if err != nil {
+									return false
+								}
+
+								files[file.Name] = string(content)
+							}
+
+							// Архив содержит ту же выгрузку, чтобы по нему можно было восстановить данные с фотографиями:
+							var archived entities.Export
+							if err = json.Unmarshal([]byte(files[entities.ExportDataPath]), &archived); err != nil {
+								return false
+							}
+
+							return doc.FileName == "plants_2025-06-01.zip" &&
+								doc.Caption == texts.ExportArchive &&
+								archived.Version == entities.ExportVersion &&
+								archived.Groups[0].Plants[0].Photo == "photos/unique.jpg" &&
+								files["photos/unique.jpg"] == "telegram" &&
+								files["photos/plant-1.jpg"] == "legacy"
+						}),
+					).Return(nil),
+				)
+			},
+		},
+		{
+			name:          "export fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ExportUserData(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "photo download fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				export := newExport(entities.ExportFile{Path: "photos/unique.jpg", FileID: "file-id"})
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ExportUserData(gomock.Any(), 1).Return(export, nil)
+
+				mockCtx.EXPECT().Send(gomock.Any()).Return(nil).Times(1)
+
+				mockBot.EXPECT().File(gomock.Any()).Return(nil, assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to archive export photos",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "delete fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Delete().Return(assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to delete /export message",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			handler := Export(mockBot, mockUsecases, mockLogger)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			err := handler(mockCtx)
+
+			if tt.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
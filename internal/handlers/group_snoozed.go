@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+const remindLaterDelay = 3 * time.Hour
+
+func GroupRemindLaterCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		groupID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse groupID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if _, err = useCases.SnoozeGroup(ctx, groupID, time.Now().Add(remindLaterDelay)); err != nil {
+			return err
+		}
+
+		return respondToReminder(context, logger, fmt.Sprintf(texts.GroupRemindLater, int(remindLaterDelay.Hours())))
+	}
+}
+
+func GroupRemindTomorrowCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		groupID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse groupID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		group, err := useCases.GetGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		user, err := useCases.GetUserByID(ctx, group.UserID)
+		if err != nil {
+			return err
+		}
+
+		location, err := user.GetLocation()
+		if err != nil {
+			logger.Error(
+				"Failed to load User location",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Напоминаем завтра в выбранный пользователем час по его локальному времени:
+		now := time.Now().In(location)
+		tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, user.NotifyHour, 0, 0, 0, location)
+
+		if _, err = useCases.SnoozeGroup(ctx, groupID, tomorrow); err != nil {
+			return err
+		}
+
+		return respondToReminder(context, logger, fmt.Sprintf(texts.GroupRemindTomorrow, user.NotifyHour))
+	}
+}
+
+func GroupSkipWateringCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		groupID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse groupID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		group, err := useCases.SkipGroupWatering(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		return respondToReminder(
+			context,
+			logger,
+			fmt.Sprintf(texts.GroupWateringSkipped, group.NextWateringDate.Format(dateFormat)),
+		)
+	}
+}
+
+// respondToReminder убирает кнопки из напоминания о поливе и отвечает пользователю на нажатие.
+func respondToReminder(context telebot.Context, logger logging.Logger, text string) error {
+	if context.Callback() == nil {
+		logger.Warn(
+			"Failed to send Response due to nil callback",
+			"Message", context.Message(),
+			"Sender", context.Sender(),
+			"Chat", context.Chat(),
+			"Callback", context.Callback(),
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return errors.New("failed to send Response due to nil callback")
+	}
+
+	if _, err := context.Bot().EditReplyMarkup(context.Message(), &telebot.ReplyMarkup{}); err != nil {
+		logger.Error(
+			"Failed to delete ReplyMarkup",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return respondToCallback(context, logger, text)
+}
+
+// respondToCallback отвечает пользователю на нажатие кнопки, не изменяя сообщение.
+func respondToCallback(context telebot.Context, logger logging.Logger, text string) error {
+	if context.Callback() == nil {
+		logger.Warn(
+			"Failed to send Response due to nil callback",
+			"Message", context.Message(),
+			"Sender", context.Sender(),
+			"Chat", context.Chat(),
+			"Callback", context.Callback(),
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return errors.New("failed to send Response due to nil callback")
+	}
+
+	err := context.Respond(
+		&telebot.CallbackResponse{
+			CallbackID: context.Callback().ID,
+			Text:       text,
+		},
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send Response",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
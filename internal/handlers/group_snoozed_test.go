@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"testing"
+	"time"
+)
+
+func TestGroupRemindLaterCallback(t *testing.T) {
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger)
+	}
+
+	sender := &telebot.User{ID: 123}
+	message := &telebot.Message{ID: 789}
+	callback := &telebot.Callback{ID: "callback_123", Sender: sender, Message: message}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — reminder snoozed for 3 hours",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+
+				mockUsecases.EXPECT().SnoozeGroup(
+					gomock.Any(),
+					10,
+					gomock.Cond(func(until time.Time) bool {
+						return time.Until(until) > 2*time.Hour+59*time.Minute && time.Until(until) <= 3*time.Hour
+					}),
+				).Return(&entities.Group{ID: 10}, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       "Хорошо, напомню через 3 часа ⏰",
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "parse groupID fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("invalid").AnyTimes()
+
+				mockLogger.EXPECT().Error(
+					"Failed to parse groupID",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "snooze fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+
+				mockUsecases.EXPECT().SnoozeGroup(gomock.Any(), 10, gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "callback is nil",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Callback().Return((*telebot.Callback)(nil)).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(nil).AnyTimes()
+
+				mockUsecases.EXPECT().SnoozeGroup(gomock.Any(), 10, gomock.Any()).Return(&entities.Group{ID: 10}, nil)
+
+				mockLogger.EXPECT().Warn(
+					"Failed to send Response due to nil callback",
+					"Message", message,
+					"Sender", sender,
+					"Chat", gomock.Any(),
+					"Callback", (*telebot.Callback)(nil),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+			mockBot := mockbot.NewMockBot(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := GroupRemindLaterCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGroupRemindTomorrowCallback(t *testing.T) {
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger)
+	}
+
+	sender := &telebot.User{ID: 123}
+	message := &telebot.Message{ID: 789}
+	callback := &telebot.Callback{ID: "callback_123", Sender: sender, Message: message}
+	group := &entities.Group{ID: 10, UserID: 1}
+
+	vladivostok, err := time.LoadLocation("Asia/Vladivostok")
+	require.NoError(t, err)
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — reminder snoozed until tomorrow at notify hour",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(
+					&entities.User{ID: 1, Timezone: "Asia/Vladivostok", NotifyHour: 9},
+					nil,
+				)
+
+				now := time.Now().In(vladivostok)
+				expected := time.Date(now.Year(), now.Month(), now.Day()+1, 9, 0, 0, 0, vladivostok)
+
+				mockUsecases.EXPECT().SnoozeGroup(
+					gomock.Any(),
+					10,
+					gomock.Cond(func(until time.Time) bool {
+						return until.Equal(expected)
+					}),
+				).Return(group, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       "Хорошо, напомню завтра в 09:00 📅",
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "get user fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "load user location fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Invalid/Zone"}, nil)
+
+				mockLogger.EXPECT().Error(
+					"Failed to load User location",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+			mockBot := mockbot.NewMockBot(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := GroupRemindTomorrowCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGroupSkipWateringCallback(t *testing.T) {
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger)
+	}
+
+	sender := &telebot.User{ID: 123}
+	message := &telebot.Message{ID: 789}
+	callback := &telebot.Callback{ID: "callback_123", Sender: sender, Message: message}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — watering skipped",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+
+				mockUsecases.EXPECT().SkipGroupWatering(gomock.Any(), 10).Return(
+					&entities.Group{ID: 10, NextWateringDate: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)},
+					nil,
+				)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       "Полив пропущен. Следующий полив: 15.06.2024 ⏭",
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "skip watering fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+
+				mockUsecases.EXPECT().SkipGroupWatering(gomock.Any(), 10).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "edit reply markup fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+
+				mockUsecases.EXPECT().SkipGroupWatering(gomock.Any(), 10).Return(&entities.Group{ID: 10}, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(nil, assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to delete ReplyMarkup",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "respond fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+
+				mockUsecases.EXPECT().SkipGroupWatering(gomock.Any(), 10).Return(&entities.Group{ID: 10}, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
+
+				mockCtx.EXPECT().Respond(gomock.Any()).Return(assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to send Response",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+			mockBot := mockbot.NewMockBot(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := GroupSkipWateringCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
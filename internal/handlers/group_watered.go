@@ -1,19 +1,30 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"html"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DKhorkov/libs/logging"
 	"gopkg.in/telebot.v4"
 
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
 
+var errWateringAccessDenied = errors.New("watering access denied")
+
 func GroupWateredCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		groupID, err := strconv.Atoi(context.Data())
 		if err != nil {
 			logger.Error(
@@ -25,58 +36,338 @@ func GroupWateredCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger
 			return err
 		}
 
-		group, err := useCases.GetGroup(groupID)
+		updated, err := waterGroup(ctx, context, useCases, logger, groupID)
 		if err != nil {
+			if errors.Is(err, errWateringAccessDenied) {
+				return respondToCallback(context, logger, texts.SitterAccessDenied)
+			}
+
 			return err
 		}
 
-		now := time.Now()
-		wateredDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, group.NextWateringDate.Location())
+		// Если нажатое напоминание не было сохранено, достаточно убрать из него кнопки:
+		if !updated {
+			return respondToReminder(context, logger, texts.GroupWatered)
+		}
+
+		return respondToCallback(context, logger, texts.GroupWatered)
+	}
+}
 
-		_, err = useCases.UpdateGroupLastWateringDate(groupID, wateredDate)
+// AllGroupsWateredCallback отмечает политыми все сценарии из сводки, которые еще не были политы.
+func AllGroupsWateredCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		// Без нажатой кнопки невозможно определить сводку, respondToCallback залогирует ошибку:
+		if context.Callback() == nil || context.Message() == nil {
+			return respondToCallback(context, logger, texts.AllGroupsWatered)
+		}
+
+		notifications, err := useCases.GetMessageNotifications(ctx, context.Chat().ID, context.Message().ID)
 		if err != nil {
 			return err
 		}
 
-		if context.Callback() == nil {
-			logger.Warn(
-				"Failed to send Response due to nil callback",
-				"Message", context.Message(),
-				"Sender", context.Sender(),
-				"Chat", context.Chat(),
-				"Callback", context.Callback(),
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
+		var updated bool
+
+		for _, notification := range notifications {
+			if notification.AcknowledgedAt != nil {
+				continue
+			}
+
+			groupUpdated, err := waterGroup(ctx, context, useCases, logger, notification.GroupID)
+			if err != nil {
+				if errors.Is(err, errWateringAccessDenied) {
+					return respondToCallback(context, logger, texts.SitterAccessDenied)
+				}
+
+				return err
+			}
 
-			return errors.New("failed to send Response due to nil callback")
+			updated = updated || groupUpdated
 		}
 
-		if _, err = context.Bot().EditReplyMarkup(context.Message(), &telebot.ReplyMarkup{}); err != nil {
-			logger.Error(
-				"Failed to delete ReplyMarkup",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
+		if !updated {
+			return respondToReminder(context, logger, texts.AllGroupsWatered)
+		}
 
-			return err
+		return respondToCallback(context, logger, texts.AllGroupsWatered)
+	}
+}
+
+// waterGroup записывает полив сценария участником дома или присматривающим, нажавшим кнопку, и обновляет
+// все напоминания текущего цикла полива. Возвращает true, если среди обновленных напоминаний было нажатое.
+// Если у нажавшего кнопку больше нет доступа к сценарию, полив не записывается и возвращается
+// errWateringAccessDenied.
+func waterGroup(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	groupID int,
+) (bool, error) {
+	group, err := useCases.GetGroup(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+
+	user, err := useCases.GetUserByID(ctx, group.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	location, err := user.GetLocation()
+	if err != nil {
+		logger.Error(
+			"Failed to load User location",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return false, err
+	}
+
+	// Дата полива определяется по локальному времени пользователя:
+	now := time.Now().In(location)
+	wateredDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, group.NextWateringDate.Location())
+
+	// Напоминания текущего цикла отправляются начиная со дня, следующего за предыдущим поливом:
+	cycleStart := time.Date(
+		group.LastWateringDate.Year(),
+		group.LastWateringDate.Month(),
+		group.LastWateringDate.Day()+1,
+		0, 0, 0, 0,
+		location,
+	)
+
+	// Полив записывается на участника дома, который нажал кнопку:
+	member, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+	if err != nil {
+		return false, err
+	}
+
+	allowed, err := hasWateringAccess(ctx, useCases, *group, *member, now)
+	if err != nil {
+		return false, err
+	}
+
+	if !allowed {
+		return false, errWateringAccessDenied
+	}
+
+	_, err = useCases.UpdateGroupLastWateringDate(
+		ctx,
+		groupID,
+		wateredDate,
+		entities.WateringSourceNotification,
+		member.ID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// Подтвержденные напоминания больше не повторяются:
+	if err = useCases.AcknowledgeGroupNotifications(ctx, groupID); err != nil {
+		return false, err
+	}
+
+	notifications, err := useCases.GetGroupNotificationsSince(ctx, groupID, cycleStart)
+	if err != nil {
+		return false, err
+	}
+
+	return updateGroupReminders(
+		ctx,
+		context,
+		useCases,
+		logger,
+		notifications,
+		fmt.Sprintf(texts.GroupWateredByMember, html.EscapeString(member.GetDisplayName()), now.Format(timeFormat)),
+	)
+}
+
+// hasWateringAccess проверяет, что полить сценарий может пользователь: участник дома владельца сценария
+// или присматривающий, передача которому еще действует. Напоминания могли остаться у присматривающего
+// после окончания или отмены передачи, поэтому доступ проверяется при каждом нажатии.
+func hasWateringAccess(
+	ctx context.Context,
+	useCases interfaces.UseCases,
+	group entities.Group,
+	user entities.User,
+	now time.Time,
+) (bool, error) {
+	if group.UserID == user.ID {
+		return true, nil
+	}
+
+	members, err := useCases.GetHouseholdUsers(ctx, group.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range members {
+		if member.ID == user.ID {
+			return true, nil
+		}
+	}
+
+	return isActiveSitter(ctx, useCases, group, user, now)
+}
+
+// updateGroupReminders обновляет напоминания о поливе сценария. Сводки обновляются отдельно,
+// так как в них остаются кнопки еще не политых сценариев.
+func updateGroupReminders(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	notifications []entities.Notification,
+	doneText string,
+) (bool, error) {
+	var (
+		reminders []entities.Notification
+		updated   bool
+	)
+
+	for _, notification := range notifications {
+		if !notification.Digest {
+			reminders = append(reminders, notification)
+
+			continue
+		}
+
+		digestUpdated, err := updateDigest(ctx, context, useCases, logger, notification)
+		if err != nil {
+			return false, err
+		}
+
+		updated = updated || digestUpdated
+	}
+
+	return updateReminders(context, logger, reminders, doneText) || updated, nil
+}
+
+// updateDigest отмечает в сводке политые сценарии и оставляет кнопки только для еще не политых.
+// Возвращает true, если сводка является нажатым сообщением.
+func updateDigest(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	notification entities.Notification,
+) (bool, error) {
+	digest, err := useCases.GetMessageNotifications(ctx, notification.ChatID, notification.MessageID)
+	if err != nil {
+		return false, err
+	}
+
+	builder := strings.Builder{}
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	for _, groupNotification := range digest {
+		group, err := useCases.GetGroup(ctx, groupNotification.GroupID)
+		if err != nil {
+			return false, err
+		}
+
+		if groupNotification.AcknowledgedAt != nil {
+			builder.WriteString(fmt.Sprintf(texts.DigestGroupWatered, html.EscapeString(group.Title)))
+
+			continue
 		}
 
-		err = context.Respond(
-			&telebot.CallbackResponse{
-				CallbackID: context.Callback().ID,
-				Text:       texts.GroupWatered,
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.GroupWatered.Unique,
+					Text:   fmt.Sprintf(texts.DigestGroupWateredButton, group.Title),
+					Data:   strconv.Itoa(group.ID),
+				},
 			},
 		)
+	}
+
+	// Общая кнопка полива нужна, только если не полито больше одного сценария:
+	if len(menu.InlineKeyboard) > 1 {
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.AllGroupsWatered})
+	}
+
+	// Когда политы все сценарии, кнопки из сводки убираются:
+	var options []any
+	if len(menu.InlineKeyboard) > 0 {
+		options = append(options, menu)
+	}
+
+	_, err = context.Bot().Edit(
+		&telebot.Message{ID: notification.MessageID, Chat: &telebot.Chat{ID: notification.ChatID}},
+		html.EscapeString(notification.Text)+builder.String(),
+		options...,
+	)
+	if err != nil {
+		// Участник мог удалить сводку, поэтому ошибка не прерывает обработку:
+		logger.Warn(
+			fmt.Sprintf("Failed to update digest in Chat with ID=%d", notification.ChatID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return false, nil
+	}
+
+	return isCurrentMessage(context, notification), nil
+}
+
+// updateReminders дополняет все отправленные напоминания пометкой о выполнении и убирает из них кнопки,
+// чтобы участники дома не выполняли уход повторно. Возвращает true, если среди напоминаний было нажатое.
+func updateReminders(
+	context telebot.Context,
+	logger logging.Logger,
+	notifications []entities.Notification,
+	doneText string,
+) bool {
+	var updated bool
+
+	for _, notification := range notifications {
+		// Для уведомлений, отправленных до появления общих домов, чат неизвестен:
+		if notification.ChatID == 0 {
+			continue
+		}
+
+		_, err := context.Bot().Edit(
+			&telebot.Message{ID: notification.MessageID, Chat: &telebot.Chat{ID: notification.ChatID}},
+			html.EscapeString(notification.Text)+doneText,
+		)
 		if err != nil {
-			logger.Error(
-				"Failed to send Response",
+			// Участник мог удалить напоминание, поэтому ошибка не прерывает обработку:
+			logger.Warn(
+				fmt.Sprintf("Failed to update reminder in Chat with ID=%d", notification.ChatID),
 				"Error", err,
 				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
 			)
 
-			return err
+			continue
 		}
 
-		return nil
+		if isCurrentMessage(context, notification) {
+			updated = true
+		}
 	}
+
+	return updated
+}
+
+// isCurrentMessage проверяет, относится ли уведомление к нажатому сообщению.
+func isCurrentMessage(context telebot.Context, notification entities.Notification) bool {
+	return context.Message() != nil &&
+		context.Message().ID == notification.MessageID &&
+		context.Chat().ID == notification.ChatID
 }
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"fmt"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
 	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
@@ -10,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gopkg.in/telebot.v4"
+	"strings"
 	"testing"
 	"time"
 )
@@ -27,6 +31,7 @@ func TestGroupWateredCallback(t *testing.T) {
 	chat := &telebot.Chat{ID: 456}
 	message := &telebot.Message{ID: 789}
 	callbackID := "callback_123"
+	member := &entities.User{ID: 2, TelegramID: 123, Firstname: "Anna"}
 
 	for _, tc := range []testCase{
 		{
@@ -41,6 +46,7 @@ func TestGroupWateredCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				group := &entities.Group{
 					ID:               10,
+					UserID:           1,
 					Title:            "Orchids",
 					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
 					WateringInterval: 7,
@@ -58,14 +64,30 @@ func TestGroupWateredCallback(t *testing.T) {
 				}).AnyTimes()
 
 				// Получаем группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				// Получаем владельца группы для определения его часового пояса
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
+
+				// Получаем участника дома, нажавшего кнопку
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+
+				// Нажавший кнопку состоит в доме владельца сценария
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
 
 				// Обновляем дату полива
 				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
 					10,
 					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
 				).Return(group, nil)
 
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(nil)
+
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
 
@@ -76,6 +98,294 @@ func TestGroupWateredCallback(t *testing.T) {
 				}).Return(nil)
 			},
 		},
+		{
+			name:          "success — all reminders of the cycle updated",
+			errorExpected: false,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				group := &entities.Group{
+					ID:               10,
+					UserID:           1,
+					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
+				}
+
+				notifications := []entities.Notification{
+					{ID: 1, GroupID: 10, MessageID: 700, ChatID: chat.ID, Text: "Пора полить"},
+					{ID: 2, GroupID: 10, MessageID: 789, ChatID: chat.ID, Text: "Пора полить"},
+					{ID: 3, GroupID: 10, MessageID: 790, ChatID: 999, Text: "Пора <полить>"},
+					{ID: 4, GroupID: 10, MessageID: 791, ChatID: 1000, Text: "Пора полить"},
+					{ID: 5, GroupID: 10, MessageID: 600, Text: "Пора полить"},
+				}
+
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{
+					ID:      callbackID,
+					Sender:  sender,
+					Message: message,
+				}).AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
+				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
+					10,
+					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
+				).Return(group, nil)
+
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(nil)
+
+				// Цикл начинается на следующий день после предыдущего полива
+				mockUsecases.EXPECT().GetGroupNotificationsSince(
+					gomock.Any(),
+					10,
+					gomock.Cond(func(since time.Time) bool {
+						return since.Equal(time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC))
+					}),
+				).Return(notifications, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+
+				// Старые и текущее напоминания, а также напоминания других участников дополняются именем полившего
+				for _, messageID := range []int{700, 789} {
+					mockBot.EXPECT().Edit(
+						gomock.Cond(func(msg *telebot.Message) bool {
+							return msg.ID == messageID && msg.Chat.ID == chat.ID
+						}),
+						gomock.Cond(func(text string) bool {
+							return strings.HasPrefix(text, "Пора полить") &&
+								strings.Contains(text, fmt.Sprintf(texts.GroupWateredByMember, "Anna", ""))
+						}),
+					).Return(&telebot.Message{}, nil)
+				}
+
+				mockBot.EXPECT().Edit(
+					gomock.Cond(func(msg *telebot.Message) bool {
+						return msg.ID == 790 && msg.Chat.ID == 999
+					}),
+					gomock.Cond(func(text string) bool {
+						return strings.HasPrefix(text, "Пора &lt;полить&gt;")
+					}),
+				).Return(&telebot.Message{}, nil)
+
+				// Ошибка обновления чужого напоминания не прерывает обработку
+				mockBot.EXPECT().Edit(
+					gomock.Cond(func(msg *telebot.Message) bool {
+						return msg.ID == 791 && msg.Chat.ID == 1000
+					}),
+					gomock.Any(),
+				).Return(nil, assert.AnError)
+
+				mockLogger.EXPECT().Warn(
+					"Failed to update reminder in Chat with ID=1000",
+					"Error", assert.AnError,
+					"Tracing", gomock.Any(),
+				).Times(1)
+
+				// Кнопки нажатого напоминания уже убраны при его обновлении, поэтому только отвечаем
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callbackID,
+					Text:       texts.GroupWatered,
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "success — digest keeps buttons of groups not watered yet",
+			errorExpected: false,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				group := &entities.Group{
+					ID:               10,
+					UserID:           1,
+					Title:            "Orchids",
+					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
+				}
+				acknowledgedAt := time.Now().UTC()
+
+				digest := []entities.Notification{
+					{ID: 1, GroupID: 10, MessageID: 789, ChatID: chat.ID, Text: "Сводка", Digest: true, AcknowledgedAt: &acknowledgedAt},
+					{ID: 2, GroupID: 11, MessageID: 789, ChatID: chat.ID, Text: "Сводка", Digest: true},
+					{ID: 3, GroupID: 12, MessageID: 789, ChatID: chat.ID, Text: "Сводка", Digest: true},
+				}
+
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{
+					ID:      callbackID,
+					Sender:  sender,
+					Message: message,
+				}).AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil).Times(2)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
+				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
+					10,
+					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
+				).Return(group, nil)
+
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(nil)
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), 10, gomock.Any()).Return(digest[:1], nil)
+				mockUsecases.EXPECT().GetMessageNotifications(gomock.Any(), chat.ID, message.ID).Return(digest, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 11).Return(&entities.Group{ID: 11, Title: "Ferns"}, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 12).Return(&entities.Group{ID: 12, Title: "Cacti"}, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+
+				// Политый сценарий отмечается в сводке, а кнопки остаются только для остальных
+				mockBot.EXPECT().Edit(
+					gomock.Cond(func(msg *telebot.Message) bool {
+						return msg.ID == message.ID && msg.Chat.ID == chat.ID
+					}),
+					gomock.Cond(func(text string) bool {
+						return text == "Сводка"+fmt.Sprintf(texts.DigestGroupWatered, "Orchids")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3 &&
+							menu.InlineKeyboard[0][0].Data == "11" &&
+							menu.InlineKeyboard[1][0].Data == "12" &&
+							menu.InlineKeyboard[2][0].Unique == buttons.AllGroupsWatered.Unique
+					}),
+				).Return(&telebot.Message{}, nil)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callbackID,
+					Text:       texts.GroupWatered,
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "success — sitter with active delegation waters group",
+			errorExpected: false,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				group := &entities.Group{
+					ID:               10,
+					UserID:           1,
+					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
+				}
+				sitterID := 3
+
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{
+					ID:      callbackID,
+					Sender:  sender,
+					Message: message,
+				}).AnyTimes()
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: sitterID, TelegramID: 123}, nil)
+
+				// Присматривающий не состоит в доме владельца, но передача еще действует
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}}, nil)
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), 10, gomock.Any()).Return(
+					&entities.Delegation{ID: 1, OwnerID: 1, SitterID: &sitterID},
+					nil,
+				)
+
+				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
+					10,
+					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					sitterID,
+				).Return(group, nil)
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(nil)
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callbackID,
+					Text:       texts.GroupWatered,
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "sitter delegation expired — watering not recorded",
+			errorExpected: false,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{
+					ID:      callbackID,
+					Sender:  sender,
+					Message: message,
+				}).AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10, UserID: 1}, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 3, TelegramID: 123}, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}}, nil)
+
+				// Передача закончилась или отменена, поэтому полив не записывается
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), 10, gomock.Any()).Return(nil, customerrors.ErrDelegationNotFound)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callbackID,
+					Text:       texts.SitterAccessDenied,
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "get household users fails",
+			errorExpected: true,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10, UserID: 1}, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 3, TelegramID: 123}, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
 		{
 			name:          "parse groupID fails",
 			errorExpected: true,
@@ -118,7 +428,56 @@ func TestGroupWateredCallback(t *testing.T) {
 				}).AnyTimes()
 
 				// Ошибка получения группы
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "get user fails",
+			errorExpected: true,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				// Общие ожидания
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+
+				// Получаем группу
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10, UserID: 1}, nil)
+
+				// Ошибка получения владельца группы
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "load user location fails",
+			errorExpected: true,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				// Общие ожидания
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+
+				// Получаем группу
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10, UserID: 1}, nil)
+
+				// Владелец с некорректным часовым поясом
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Invalid/Zone"}, nil)
+
+				// Логгируем ошибку
+				mockLogger.EXPECT().Error(
+					"Failed to load User location",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
 			},
 		},
 		{
@@ -133,6 +492,7 @@ func TestGroupWateredCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				group := &entities.Group{
 					ID:               10,
+					UserID:           1,
 					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
 				}
 
@@ -147,15 +507,73 @@ func TestGroupWateredCallback(t *testing.T) {
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 
 				// Получаем группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				// Получаем владельца группы для определения его часового пояса
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
+
+				// Получаем участника дома, нажавшего кнопку
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
 
 				// Ошибка обновления даты
 				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
 					10,
 					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
 				).Return(nil, assert.AnError)
 			},
 		},
+		{
+			name:          "acknowledge notifications fails",
+			errorExpected: true,
+			contextData:   "10",
+			callback: &telebot.Callback{
+				ID:      callbackID,
+				Sender:  sender,
+				Message: message,
+			},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				group := &entities.Group{
+					ID:               10,
+					UserID:           1,
+					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
+				}
+
+				// Общие ожидания
+				mockCtx.EXPECT().Data().Return("10").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{
+					ID:      callbackID,
+					Sender:  sender,
+					Message: message,
+				}).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+
+				// Получаем группу
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				// Получаем владельца группы для определения его часового пояса
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
+
+				// Получаем участника дома, нажавшего кнопку
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
+
+				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
+					10,
+					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
+				).Return(group, nil)
+
+				// Ошибка подтверждения напоминаний
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(assert.AnError)
+			},
+		},
 		{
 			name:          "callback is nil",
 			errorExpected: true,
@@ -170,22 +588,37 @@ func TestGroupWateredCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
 				// Получаем группу
-				mockUsecases.EXPECT().GetGroup(10).Return(&entities.Group{ID: 10}, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10, UserID: 1}, nil)
+
+				// Получаем владельца группы для определения его часового пояса
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
 
 				group := &entities.Group{
 					ID:               10,
+					UserID:           1,
 					Title:            "Orchids",
 					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
 					WateringInterval: 7,
 					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
 				}
 
+				// Получаем участника дома, нажавшего кнопку
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
+
 				// Обновляем дату полива
 				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
 					10,
 					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
 				).Return(group, nil)
 
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(nil)
+
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+
 				// Логгируем предупреждение
 				mockLogger.EXPECT().Warn(
 					"Failed to send Response due to nil callback",
@@ -209,6 +642,7 @@ func TestGroupWateredCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				group := &entities.Group{
 					ID:               10,
+					UserID:           1,
 					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
 				}
 
@@ -223,14 +657,28 @@ func TestGroupWateredCallback(t *testing.T) {
 				}).AnyTimes()
 
 				// Получаем группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				// Получаем владельца группы для определения его часового пояса
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
+
+				// Получаем участника дома, нажавшего кнопку
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
 
 				// Обновляем дату
 				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
 					10,
 					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
 				).Return(group, nil)
 
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(nil)
+
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, assert.AnError)
 
@@ -254,6 +702,7 @@ func TestGroupWateredCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				group := &entities.Group{
 					ID:               10,
+					UserID:           1,
 					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
 				}
 
@@ -268,14 +717,28 @@ func TestGroupWateredCallback(t *testing.T) {
 				}).AnyTimes()
 
 				// Получаем группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				// Получаем владельца группы для определения его часового пояса
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
+
+				// Получаем участника дома, нажавшего кнопку
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
 
 				// Обновляем дату
 				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
 					10,
 					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
 				).Return(group, nil)
 
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 10).Return(nil)
+
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), 10, gomock.Any()).Return(nil, nil)
+
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
 
@@ -296,7 +759,7 @@ func TestGroupWateredCallback(t *testing.T) {
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 			mockBot := mockbot.NewMockBot(ctrl)
@@ -316,3 +779,127 @@ func TestGroupWateredCallback(t *testing.T) {
 		})
 	}
 }
+
+func TestAllGroupsWateredCallback(t *testing.T) {
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger)
+	}
+
+	sender := &telebot.User{ID: 123}
+	chat := &telebot.Chat{ID: 456}
+	message := &telebot.Message{ID: 789}
+	callback := &telebot.Callback{ID: "callback_123", Sender: sender, Message: message}
+	member := &entities.User{ID: 2, TelegramID: 123, Firstname: "Anna"}
+	acknowledgedAt := time.Now().UTC()
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — only groups not watered yet are watered",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				group := &entities.Group{
+					ID:               11,
+					UserID:           1,
+					Title:            "Ferns",
+					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
+				}
+				digest := []entities.Notification{
+					{ID: 1, GroupID: 10, MessageID: 789, ChatID: chat.ID, Text: "Сводка", Digest: true, AcknowledgedAt: &acknowledgedAt},
+					{ID: 2, GroupID: 11, MessageID: 789, ChatID: chat.ID, Text: "Сводка", Digest: true},
+				}
+				watered := []entities.Notification{digest[0], digest[1]}
+				watered[1].AcknowledgedAt = &acknowledgedAt
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+
+				mockUsecases.EXPECT().GetMessageNotifications(gomock.Any(), chat.ID, message.ID).Return(digest, nil)
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 11).Return(group, nil).Times(2)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(member, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{{ID: 1}, *member}, nil)
+				mockUsecases.EXPECT().UpdateGroupLastWateringDate(
+					gomock.Any(),
+					11,
+					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
+				).Return(group, nil)
+				mockUsecases.EXPECT().AcknowledgeGroupNotifications(gomock.Any(), 11).Return(nil)
+				mockUsecases.EXPECT().GetGroupNotificationsSince(gomock.Any(), 11, gomock.Any()).Return(watered[1:], nil)
+				mockUsecases.EXPECT().GetMessageNotifications(gomock.Any(), chat.ID, message.ID).Return(watered, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(&entities.Group{ID: 10, Title: "Orchids"}, nil)
+
+				// Все сценарии политы, поэтому кнопки из сводки убираются
+				mockBot.EXPECT().Edit(
+					gomock.Cond(func(msg *telebot.Message) bool {
+						return msg.ID == message.ID && msg.Chat.ID == chat.ID
+					}),
+					"Сводка"+fmt.Sprintf(texts.DigestGroupWatered, "Orchids")+fmt.Sprintf(texts.DigestGroupWatered, "Ferns"),
+				).Return(&telebot.Message{}, nil)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       texts.AllGroupsWatered,
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "success — digest not found, markup removed",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+
+				mockUsecases.EXPECT().GetMessageNotifications(gomock.Any(), chat.ID, message.ID).Return(nil, nil)
+
+				mockBot.EXPECT().EditReplyMarkup(message, &telebot.ReplyMarkup{}).Return(message, nil)
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       texts.AllGroupsWatered,
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "get message notifications fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+
+				mockUsecases.EXPECT().GetMessageNotifications(gomock.Any(), chat.ID, message.ID).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+			mockBot := mockbot.NewMockBot(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := AllGroupsWateredCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,728 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+// HandoverCallback показывает действующие передачи растений пользователя присматривающим.
+func HandoverCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		return sendHandover(ctx, context, useCases, logger, *user)
+	}
+}
+
+// NewHandoverCallback начинает выбор сценариев для новой передачи растений.
+func NewHandoverCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		if err = useCases.ManageDelegation(ctx, int(context.Sender().ID)); err != nil {
+			return err
+		}
+
+		return sendHandoverGroups(ctx, context, useCases, logger, *user, entities.Delegation{OwnerID: user.ID})
+	}
+}
+
+// HandoverGroupCallback добавляет сценарий в передачу или убирает его из нее.
+func HandoverGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		groupID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse groupID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		delegation, err := useCases.ToggleDelegationGroup(ctx, int(context.Sender().ID), groupID)
+		if err != nil {
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendHandoverGroups(ctx, context, useCases, logger, *user, *delegation)
+	}
+}
+
+func HandoverChooseEndDateCallback(
+	bot interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		now := time.Now()
+
+		cal, err := calendar.NewCalendar(
+			bot,
+			logger,
+			calendar.WithYearsRange([2]int{now.Year(), now.Year() + 1}),
+			calendar.WithBackButton(buttons.BackToHandover),
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to create calendar",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: cal.GetKeyboard(),
+		}
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.HandoverEndDateImage),
+				Caption: texts.HandoverEndDate,
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.HandoverEndDate); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// HandoverEndDate создает передачу с выбранной датой окончания и показывает ссылку для присматривающего.
+func HandoverEndDate(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		endDate, err := time.Parse(dateFormat, context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse handover end date",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Для календаря используем context.Chat().ID:
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Chat().ID))
+		if err != nil {
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Chat().ID))
+		if err != nil {
+			return err
+		}
+
+		temporaryDelegation, err := temp.GetDelegation()
+		if err != nil {
+			logger.Error(
+				"Failed to get Delegation from Temporary",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		delegation, err := useCases.CreateDelegation(ctx, user.ID, temporaryDelegation.GroupIDs, endDate)
+		if err != nil {
+			if !errors.Is(err, customerrors.ErrDelegationEndDateInPast) {
+				return err
+			}
+
+			// Нет context.Callback() для обычного сообщения, поэтому отправляем ответ текстом:
+			if err = context.Send(texts.HandoverEndDateInPast); err != nil {
+				logger.Error(
+					"Failed to send message",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.BackToHandover,
+					buttons.Menu,
+				},
+			},
+		}
+
+		err = context.Send(
+			&telebot.Photo{
+				File: telebot.FromDisk(paths.HandoverInviteImage),
+				Caption: fmt.Sprintf(
+					texts.HandoverInvite,
+					fmt.Sprintf(inviteLinkFormat, bot.Username(), delegation.Token),
+					delegation.EndDate.Format(dateFormat),
+				),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Chat().ID), steps.HandoverInvite); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// CancelHandoverCallback досрочно завершает передачу растений.
+func CancelHandoverCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		delegationID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse delegationID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		// Передача могла уже закончиться или быть отменена в другом сообщении:
+		err = useCases.CancelDelegation(ctx, user.ID, delegationID)
+		if err != nil && !errors.Is(err, customerrors.ErrDelegationNotFound) {
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendHandover(ctx, context, useCases, logger, *user)
+	}
+}
+
+// SitterGroupPlantsCallback показывает растения сценария присматривающему отдельным сообщением,
+// чтобы напоминание с кнопкой полива оставалось в чате.
+func SitterGroupPlantsCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		groupID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse groupID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		group, err := useCases.GetGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		allowed, err := hasSitterAccess(ctx, context, useCases, logger, *group)
+		if err != nil {
+			return err
+		}
+
+		if !allowed {
+			return respondToCallback(context, logger, texts.SitterAccessDenied)
+		}
+
+		plants, err := useCases.GetGroupPlants(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{},
+		}
+
+		for _, plant := range plants {
+			menu.InlineKeyboard = append(
+				menu.InlineKeyboard,
+				[]telebot.InlineButton{
+					{
+						Unique: buttons.SitterPlant.Unique,
+						Text:   plant.Title,
+						Data:   strconv.Itoa(plant.ID),
+					},
+				},
+			)
+		}
+
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.SitterClose})
+
+		caption := fmt.Sprintf(texts.SitterGroupPlants, html.EscapeString(group.Title))
+		if len(plants) == 0 {
+			caption = fmt.Sprintf(texts.SitterGroupPlantsEmpty, html.EscapeString(group.Title))
+		}
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.SitterGroupPlantsImage),
+				Caption: caption,
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// SitterPlantCallback показывает присматривающему фотографию и описание растения.
+func SitterPlantCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		plantID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse plantID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		plant, err := useCases.GetPlant(ctx, plantID)
+		if err != nil {
+			return err
+		}
+
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
+		if err != nil {
+			return err
+		}
+
+		allowed, err := hasSitterAccess(ctx, context, useCases, logger, *group)
+		if err != nil {
+			return err
+		}
+
+		if !allowed {
+			return respondToCallback(context, logger, texts.SitterAccessDenied)
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.SitterClose,
+				},
+			},
+		}
+
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.SitterPlant,
+				html.EscapeString(plant.Title),
+				html.EscapeString(plant.Description),
+			),
+			menu,
+		)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// SitterCloseCallback удаляет сообщение с растениями, не затрагивая напоминание.
+func SitterCloseCallback(_ interfaces.Bot, _ interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// hasSitterAccess проверяет, что растения сценария может смотреть нажавший кнопку пользователь:
+// владелец сценария или присматривающий, передача которому еще действует.
+func hasSitterAccess(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	group entities.Group,
+) (bool, error) {
+	user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+	if err != nil {
+		return false, err
+	}
+
+	if group.UserID == user.ID {
+		return true, nil
+	}
+
+	location, err := user.GetLocation()
+	if err != nil {
+		logger.Error(
+			"Failed to load User location",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return false, err
+	}
+
+	return isActiveSitter(ctx, useCases, group, *user, time.Now().In(location))
+}
+
+// isActiveSitter проверяет, что сценарий передан пользователю и передача действует на момент now.
+func isActiveSitter(
+	ctx context.Context,
+	useCases interfaces.UseCases,
+	group entities.Group,
+	user entities.User,
+	now time.Time,
+) (bool, error) {
+	delegation, err := useCases.GetGroupDelegation(ctx, group.ID, now)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrDelegationNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return *delegation.SitterID == user.ID, nil
+}
+
+func sendHandover(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	user entities.User,
+) error {
+	delegations, err := useCases.GetOwnerDelegations(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	groups, err := useCases.GetUserGroups(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	titles := make(map[int]string, len(groups))
+	for _, group := range groups {
+		titles[group.ID] = group.Title
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	builder := strings.Builder{}
+	if len(delegations) == 0 {
+		builder.WriteString(texts.HandoverEmpty)
+	}
+
+	for _, delegation := range delegations {
+		var delegatedTitles []string
+
+		for _, groupID := range delegation.GroupIDs {
+			// Удаленные сценарии остаются в передаче, но не показываются:
+			if title, ok := titles[groupID]; ok {
+				delegatedTitles = append(delegatedTitles, html.EscapeString(title))
+			}
+		}
+
+		status := texts.HandoverPending
+		if delegation.IsAccepted() {
+			sitter, err := useCases.GetUserByID(ctx, *delegation.SitterID)
+			if err != nil {
+				return err
+			}
+
+			status = fmt.Sprintf(texts.HandoverAccepted, html.EscapeString(sitter.GetDisplayName()))
+		}
+
+		builder.WriteString(
+			fmt.Sprintf(
+				texts.HandoverEntry,
+				strings.Join(delegatedTitles, texts.HandoverGroupsSeparator),
+				delegation.EndDate.Format(dateFormat),
+				status,
+			),
+		)
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.CancelHandover.Unique,
+					Text:   fmt.Sprintf(texts.CancelHandover, delegation.EndDate.Format(dateFormat)),
+					Data:   strconv.Itoa(delegation.ID),
+				},
+			},
+		)
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{buttons.NewHandover},
+		[]telebot.InlineButton{buttons.Menu},
+	)
+
+	err = context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.HandoverImage),
+			Caption: fmt.Sprintf(texts.Handover, builder.String()),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.Handover); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sendHandoverGroups показывает сценарии пользователя для передачи. Передать можно только свои сценарии,
+// поэтому сценарии других участников дома не показываются.
+func sendHandoverGroups(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	user entities.User,
+	delegation entities.Delegation,
+) error {
+	groups, err := useCases.GetUserGroups(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	for _, group := range groups {
+		if group.UserID != user.ID {
+			continue
+		}
+
+		text := group.Title
+		if slices.Contains(delegation.GroupIDs, group.ID) {
+			text = fmt.Sprintf(texts.HandoverGroupSelected, group.Title)
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.HandoverGroup.Unique,
+					Text:   text,
+					Data:   strconv.Itoa(group.ID),
+				},
+			},
+		)
+	}
+
+	if len(delegation.GroupIDs) > 0 {
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.HandoverChooseEndDate})
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			buttons.BackToHandover,
+			buttons.Menu,
+		},
+	)
+
+	err = context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.HandoverGroupsImage),
+			Caption: texts.HandoverGroups,
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
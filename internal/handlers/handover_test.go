@@ -0,0 +1,430 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandoverCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123}
+	sitter := &entities.User{ID: 2, TelegramID: 456, Firstname: "Boris"}
+	sitterID := sitter.ID
+	groups := []entities.Group{{ID: 3, UserID: 1, Title: "Фикусы"}, {ID: 4, UserID: 1, Title: "Кактусы"}}
+	endDate := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — delegations listed with cancel buttons",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetOwnerDelegations(gomock.Any(), 1).Return(
+					[]entities.Delegation{
+						{ID: 5, OwnerID: 1, SitterID: &sitterID, GroupIDs: entities.DelegationGroups{3, 4}, EndDate: endDate},
+						{ID: 6, OwnerID: 1, GroupIDs: entities.DelegationGroups{4}, EndDate: endDate},
+					},
+					nil,
+				)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 2).Return(sitter, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "Фикусы, Кактусы — до 20.07.2026, присматривает Boris") &&
+							strings.Contains(photo.Caption, texts.HandoverPending)
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 4 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.CancelHandover.Unique &&
+							menu.InlineKeyboard[0][0].Data == "5" &&
+							menu.InlineKeyboard[1][0].Data == "6" &&
+							menu.InlineKeyboard[2][0].Unique == buttons.NewHandover.Unique
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.Handover).Return(nil)
+			},
+		},
+		{
+			name:          "success — no delegations",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetOwnerDelegations(gomock.Any(), 1).Return(nil, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, texts.HandoverEmpty)
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 2
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.Handover).Return(nil)
+			},
+		},
+		{
+			name:          "get delegations fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetOwnerDelegations(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := HandoverCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHandoverGroupCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123}
+	groups := []entities.Group{
+		{ID: 3, UserID: 1, Title: "Фикусы"},
+		{ID: 4, UserID: 1, Title: "Кактусы"},
+		{ID: 5, UserID: 9, Title: "Чужой сценарий"},
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — selected group marked and end date button shown",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Data().Return("3")
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ToggleDelegationGroup(gomock.Any(), 123, 3).Return(
+					&entities.Delegation{OwnerID: 1, GroupIDs: entities.DelegationGroups{3}},
+					nil,
+				)
+
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Any(),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 4 &&
+							menu.InlineKeyboard[0][0].Text == "✅ Фикусы" &&
+							menu.InlineKeyboard[1][0].Text == "Кактусы" &&
+							menu.InlineKeyboard[2][0].Unique == buttons.HandoverChooseEndDate.Unique &&
+							menu.InlineKeyboard[3][0].Unique == buttons.BackToHandover.Unique
+					}),
+				).Return(nil)
+			},
+		},
+		{
+			name:          "success — no end date button without selected groups",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Data().Return("3")
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ToggleDelegationGroup(gomock.Any(), 123, 3).Return(&entities.Delegation{OwnerID: 1}, nil)
+
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Any(),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3
+					}),
+				).Return(nil)
+			},
+		},
+		{
+			name:          "invalid group id",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("invalid")
+				mockLogger.EXPECT().Error("Failed to parse groupID", "Error", gomock.Any(), "Tracing", gomock.Any())
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := HandoverGroupCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHandoverEndDate(t *testing.T) {
+	chat := &telebot.Chat{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123}
+	endDate := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+	temp := &entities.Temporary{
+		UserID: 1,
+		Step:   steps.HandoverEndDate,
+		Data:   []byte(`{"ownerId":1,"groupIds":[3,4]}`),
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — sitter invite link sent",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Data().Return("20.07.2026")
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().CreateDelegation(gomock.Any(), 1, []int{3, 4}, endDate).Return(
+					&entities.Delegation{ID: 5, OwnerID: 1, Token: "sitter-abc", EndDate: endDate},
+					nil,
+				)
+
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockBot.EXPECT().Username().Return("plants_bot")
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "https://t.me/plants_bot?start=sitter-abc") &&
+							strings.Contains(photo.Caption, "20.07.2026")
+					}),
+					gomock.Any(),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.HandoverInvite).Return(nil)
+			},
+		},
+		{
+			name:          "end date in past — text reply",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Data().Return("20.07.2026")
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().
+					CreateDelegation(gomock.Any(), 1, []int{3, 4}, endDate).
+					Return(nil, customerrors.ErrDelegationEndDateInPast)
+
+				mockCtx.EXPECT().Send(texts.HandoverEndDateInPast).Return(nil)
+			},
+		},
+		{
+			name:          "invalid date",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("invalid")
+				mockLogger.EXPECT().Error("Failed to parse handover end date", "Error", gomock.Any(), "Tracing", gomock.Any())
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := HandoverEndDate(mockBot, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSitterGroupPlantsCallback(t *testing.T) {
+	sender := &telebot.User{ID: 456}
+	callback := &telebot.Callback{ID: "cb"}
+	sitter := &entities.User{ID: 2, TelegramID: 456, Timezone: "UTC"}
+	sitterID := sitter.ID
+	otherSitterID := 7
+	group := &entities.Group{ID: 3, UserID: 1, Title: "Фикусы"}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — plants shown to active sitter",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Data().Return("3")
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 3).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 456).Return(sitter, nil)
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), 3, gomock.Any()).Return(
+					&entities.Delegation{ID: 5, OwnerID: 1, SitterID: &sitterID},
+					nil,
+				)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 3).Return(
+					[]entities.Plant{{ID: 10, Title: "Фикус"}, {ID: 11, Title: "Монстера"}},
+					nil,
+				)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "Фикусы")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.SitterPlant.Unique &&
+							menu.InlineKeyboard[0][0].Data == "10" &&
+							menu.InlineKeyboard[2][0].Unique == buttons.SitterClose.Unique
+					}),
+				).Return(nil)
+			},
+		},
+		{
+			name:          "access denied — delegation of another sitter",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Data().Return("3")
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 3).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 456).Return(sitter, nil)
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), 3, gomock.Any()).Return(
+					&entities.Delegation{ID: 5, OwnerID: 1, SitterID: &otherSitterID},
+					nil,
+				)
+
+				mockCtx.EXPECT().Respond(
+					&telebot.CallbackResponse{CallbackID: "cb", Text: texts.SitterAccessDenied},
+				).Return(nil)
+			},
+		},
+		{
+			name:          "access denied — delegation finished",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Data().Return("3")
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 3).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 456).Return(sitter, nil)
+				mockUsecases.EXPECT().GetGroupDelegation(gomock.Any(), 3, gomock.Any()).Return(nil, customerrors.ErrDelegationNotFound)
+
+				mockCtx.EXPECT().Respond(
+					&telebot.CallbackResponse{CallbackID: "cb", Text: texts.SitterAccessDenied},
+				).Return(nil)
+			},
+		},
+		{
+			name:          "get group fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("3")
+
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 3).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := SitterGroupPlantsCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
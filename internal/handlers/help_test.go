@@ -60,7 +60,7 @@ func TestHelp(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+const (
+	inviteLinkFormat          = "https://t.me/%s?start=%s"
+	householdInviteTimeFormat = "02.01.2006 15:04"
+)
+
+// HouseholdCallback показывает участников дома пользователя.
+func HouseholdCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		return sendHousehold(ctx, context, useCases, logger, *user)
+	}
+}
+
+func CreateHouseholdInviteCallback(
+	bot interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		invite, err := useCases.CreateHouseholdInvite(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.BackToHousehold,
+					buttons.Menu,
+				},
+			},
+		}
+
+		err = context.Send(
+			&telebot.Photo{
+				File: telebot.FromDisk(paths.HouseholdInviteImage),
+				Caption: fmt.Sprintf(
+					texts.HouseholdInvite,
+					fmt.Sprintf(inviteLinkFormat, bot.Username(), invite.Token),
+					invite.ExpiresAt.Format(householdInviteTimeFormat),
+				),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.HouseholdInvite); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// LeaveHouseholdCallback исключает пользователя из дома. Общие сценарии остаются у их владельцев.
+func LeaveHouseholdCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		if err = useCases.LeaveHousehold(ctx, user.ID); err != nil {
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendHousehold(ctx, context, useCases, logger, *user)
+	}
+}
+
+func sendHousehold(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	user entities.User,
+) error {
+	members, err := useCases.GetHouseholdUsers(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	builder := strings.Builder{}
+	for _, member := range members {
+		builder.WriteString(fmt.Sprintf(texts.HouseholdMember, html.EscapeString(member.GetDisplayName())))
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{
+			{
+				buttons.CreateHouseholdInvite,
+			},
+		},
+	}
+
+	// Покинуть дом можно, только если в нем есть кто-то кроме пользователя:
+	if len(members) > 1 {
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.LeaveHousehold})
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			buttons.BackToSettings,
+			buttons.Menu,
+		},
+	)
+
+	err = context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.HouseholdImage),
+			Caption: fmt.Sprintf(texts.Household, builder.String()),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.Household); err != nil {
+		return err
+	}
+
+	return nil
+}
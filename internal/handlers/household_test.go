@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHouseholdCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123, Firstname: "Anna"}
+	partner := entities.User{ID: 2, TelegramID: 456, Username: "boris"}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — members listed with leave button",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{*user, partner}, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "Anna") && strings.Contains(photo.Caption, "@boris")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.CreateHouseholdInvite.Unique &&
+							menu.InlineKeyboard[1][0].Unique == buttons.LeaveHousehold.Unique &&
+							menu.InlineKeyboard[2][0].Unique == buttons.BackToSettings.Unique
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.Household).Return(nil)
+			},
+		},
+		{
+			name:          "success — no leave button without other members",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{*user}, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Any(),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 2
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.Household).Return(nil)
+			},
+		},
+		{
+			name:          "get household users fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := HouseholdCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateHouseholdInviteCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123}
+	invite := &entities.HouseholdInvite{
+		ID:          3,
+		HouseholdID: 7,
+		Token:       "abcdef",
+		ExpiresAt:   time.Date(2024, 6, 3, 12, 30, 0, 0, time.UTC),
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — invite link sent",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CreateHouseholdInvite(gomock.Any(), 1).Return(invite, nil)
+				mockBot.EXPECT().Username().Return("plants_bot")
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "https://t.me/plants_bot?start=abcdef") &&
+							strings.Contains(photo.Caption, "03.06.2024 12:30")
+					}),
+					gomock.Any(),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.HouseholdInvite).Return(nil)
+			},
+		},
+		{
+			name:          "create invite fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CreateHouseholdInvite(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := CreateHouseholdInviteCallback(mockBot, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLeaveHouseholdCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — left household",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().LeaveHousehold(gomock.Any(), 1).Return(nil)
+
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().GetHouseholdUsers(gomock.Any(), 1).Return([]entities.User{*user}, nil)
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.Household).Return(nil)
+			},
+		},
+		{
+			name:          "leave household fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().LeaveHousehold(gomock.Any(), 1).Return(assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := LeaveHouseholdCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
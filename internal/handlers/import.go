@@ -0,0 +1,489 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+const (
+	// Выгрузка с многолетней историей поливов занимает сотни килобайт, файлы больше точно не выгрузка:
+	maxImportFileSize = 5 << 20
+
+	// Архив с фотографиями ограничен размером файла, который бот может скачать из Telegram,
+	// а фотографии в нем - размером фотографии, которую бот может отправить:
+	maxImportArchiveSize = 20 << 20
+	maxImportPhotoSize   = 10 << 20
+
+	// Фотографии загружаются альбомами, пока пользователь ждет ответа на присланный файл. Ограничение количества
+	// и пауза между альбомами не дают обработчику надолго упереться в ограничения Telegram на отправку сообщений:
+	maxImportPhotos           = 100
+	importPhotoAlbumSize      = 10
+	importPhotoUploadInterval = 2 * time.Second
+
+	importConflictsSeparator = ", "
+)
+
+// importArchiveSignature - начало любого ZIP-архива. Архив определяется по содержимому, так как при пересылке
+// файла название и MIME-тип могут измениться.
+var importArchiveSignature = []byte("PK\x03\x04")
+
+// Import просит прислать файл выгрузки, сделанной командой /export.
+func Import(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete /import message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.Menu,
+				},
+			},
+		}
+
+		// Получаем бота, чтобы при отправке получить messageID для дальнейшего удаления:
+		msg, err := context.Bot().Send(context.Chat(), texts.ImportData, menu)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.ImportData, &msg.ID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// ImportDocument проверяет присланную выгрузку или ZIP-архив с фотографиями и показывает, что будет загружено.
+// Если у пользователя уже есть сценарии или растения с такими названиями, предлагает способы разрешения конфликтов.
+func ImportDocument(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		document := context.Message().Document
+		if document.FileSize > maxImportArchiveSize {
+			return fmt.Errorf("%w: file size %d", customerrors.ErrInvalidImport, document.FileSize)
+		}
+
+		data, err := downloadImportFile(bot, document.File)
+		if err != nil {
+			logger.Error(
+				"Failed to download import file",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		var photos map[string]entities.ImportPhoto
+		if bytes.HasPrefix(data, importArchiveSignature) {
+			var files map[string]*zip.File
+			if data, files, err = readImportArchive(data); err != nil {
+				logger.Error(
+					"Failed to read import archive",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			// Фотографии загружаются только после проверки выгрузки и только те, на которые она ссылается:
+			export, err := useCases.ParseImport(data)
+			if err != nil {
+				return err
+			}
+
+			photos, err = uploadImportPhotos(ctx, bot, context.Chat(), files, export.GetPhotoPaths())
+			if err != nil {
+				logger.Error(
+					"Failed to upload import photos",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+		} else if len(data) > maxImportFileSize {
+			return fmt.Errorf("%w: file size %d", customerrors.ErrInvalidImport, len(data))
+		}
+
+		// При некорректном файле шаг не меняется, и пользователь может прислать другой файл:
+		preview, err := useCases.PrepareImport(ctx, int(context.Sender().ID), data, photos)
+		if err != nil {
+			return err
+		}
+
+		if temp.MessageID != nil {
+			err = context.Bot().Delete(&telebot.Message{ID: *temp.MessageID, Chat: context.Chat()})
+			if err != nil {
+				logger.Error(
+					"Failed to delete message",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+		}
+
+		text := fmt.Sprintf(
+			texts.ImportPreview,
+			preview.Groups,
+			preview.Plants,
+			preview.CareTasks,
+			preview.Waterings,
+			preview.Photos,
+		)
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+		}
+
+		if len(preview.Conflicts) == 0 {
+			text += texts.ConfirmImport
+			menu.InlineKeyboard = [][]telebot.InlineButton{
+				{
+					withImportConflictResolution(buttons.ConfirmImport, entities.ImportConflictSkip),
+				},
+			}
+		} else {
+			text += fmt.Sprintf(texts.ImportConflicts, formatImportConflicts(preview.Conflicts))
+			if len(preview.PlantConflicts) > 0 {
+				text += fmt.Sprintf(texts.ImportPlantConflicts, formatImportConflicts(preview.PlantConflicts))
+			}
+
+			text += texts.ImportConflictsQuestion
+			menu.InlineKeyboard = [][]telebot.InlineButton{
+				{
+					withImportConflictResolution(buttons.ImportSkipConflicts, entities.ImportConflictSkip),
+				},
+				{
+					withImportConflictResolution(buttons.ImportRenameConflicts, entities.ImportConflictRename),
+				},
+				{
+					withImportConflictResolution(buttons.ImportOverwriteConflicts, entities.ImportConflictOverwrite),
+				},
+			}
+		}
+
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.Menu})
+
+		if err = context.Send(text, menu); err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// ConfirmImportCallback загружает выгрузку способом разрешения конфликтов из данных кнопки.
+func ConfirmImportCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		result, err := useCases.ImportUserData(ctx, int(context.Sender().ID), context.Data())
+		if err != nil {
+			return err
+		}
+
+		text := fmt.Sprintf(texts.ImportDone, result.Groups, result.Plants)
+		if result.SkippedGroups > 0 {
+			text += fmt.Sprintf(texts.ImportSkipped, result.SkippedGroups)
+		}
+
+		if result.RenamedGroups > 0 {
+			text += fmt.Sprintf(texts.ImportRenamed, result.RenamedGroups)
+		}
+
+		if result.OverwrittenGroups > 0 {
+			text += fmt.Sprintf(texts.ImportOverwritten, result.OverwrittenGroups)
+		}
+
+		if result.OverwrittenPlants > 0 {
+			text += fmt.Sprintf(texts.ImportOverwrittenPlants, result.OverwrittenPlants)
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.Menu,
+				},
+			},
+		}
+
+		if err = context.Send(text, menu); err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+func withImportConflictResolution(button telebot.InlineButton, conflictResolution string) telebot.InlineButton {
+	button.Data = conflictResolution
+
+	return button
+}
+
+func formatImportConflicts(titles []string) string {
+	conflicts := make([]string, 0, len(titles))
+	for _, title := range titles {
+		conflicts = append(conflicts, fmt.Sprintf("<b>%s</b>", html.EscapeString(title)))
+	}
+
+	return strings.Join(conflicts, importConflictsSeparator)
+}
+
+// downloadImportFile скачивает присланный файл, не читая больше maxImportArchiveSize байт.
+func downloadImportFile(bot interfaces.Bot, file telebot.File) ([]byte, error) {
+	reader, err := bot.File(&file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxImportArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxImportArchiveSize {
+		return nil, fmt.Errorf("%w: file is too large", customerrors.ErrInvalidImport)
+	}
+
+	return data, nil
+}
+
+// readImportArchive достает выгрузку из ZIP-архива, сделанного командой /export, и возвращает ее вместе
+// с фотографиями из архива по путям.
+func readImportArchive(archiveData []byte) ([]byte, map[string]*zip.File, error) {
+	archive, err := zip.NewReader(bytes.NewReader(archiveData), int64(len(archiveData)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImport, err)
+	}
+
+	var data []byte
+
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		switch {
+		case file.Name == entities.ExportDataPath:
+			if data, err = readImportArchiveFile(file, maxImportFileSize); err != nil {
+				return nil, nil, err
+			}
+		case strings.HasPrefix(file.Name, entities.ExportPhotosPath) && !file.FileInfo().IsDir():
+			files[file.Name] = file
+		}
+	}
+
+	if data == nil {
+		return nil, nil, fmt.Errorf("%w: %s is missing", customerrors.ErrInvalidImport, entities.ExportDataPath)
+	}
+
+	return data, files, nil
+}
+
+// uploadImportPhotos заново загружает в Telegram фотографии из архива, на которые ссылается выгрузка: file_id
+// из выгрузки действителен только для бота, который получил файл. Фотографии отправляются в чат пользователя
+// альбомами с паузой между ними, чтобы не упираться в ограничения Telegram, и сразу удаляются. Возвращает
+// загруженные фотографии по путям в архиве.
+func uploadImportPhotos(
+	ctx context.Context,
+	bot interfaces.Bot,
+	chat *telebot.Chat,
+	files map[string]*zip.File,
+	paths []string,
+) (map[string]entities.ImportPhoto, error) {
+	// Фотографии, которых нет в архиве, пропускаются, как и при загрузке выгрузки без архива:
+	archived := make([]*zip.File, 0, len(paths))
+	for _, path := range paths {
+		if file, ok := files[path]; ok {
+			archived = append(archived, file)
+		}
+	}
+
+	switch {
+	case len(archived) == 0:
+		return nil, nil
+	case len(archived) > maxImportPhotos:
+		return nil, fmt.Errorf("%w: too many photos: %d", customerrors.ErrInvalidImport, len(archived))
+	}
+
+	photos := make(map[string]entities.ImportPhoto, len(archived))
+	for start := 0; start < len(archived); start += importPhotoAlbumSize {
+		if start > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(importPhotoUploadInterval):
+			}
+		}
+
+		album := archived[start:min(start+importPhotoAlbumSize, len(archived))]
+
+		msgs, err := uploadImportAlbum(bot, chat, album)
+		if err != nil {
+			return nil, err
+		}
+
+		editables := make([]telebot.Editable, 0, len(msgs))
+		for i := range msgs {
+			editables = append(editables, &msgs[i])
+		}
+
+		if err = bot.DeleteMany(editables); err != nil {
+			return nil, err
+		}
+
+		for i, msg := range msgs {
+			if msg.Photo == nil {
+				return nil, fmt.Errorf("%w: %s is not a photo", customerrors.ErrInvalidImport, album[i].Name)
+			}
+
+			photos[album[i].Name] = entities.ImportPhoto{FileID: msg.Photo.FileID, FileUniqueID: msg.Photo.UniqueID}
+		}
+	}
+
+	return photos, nil
+}
+
+// uploadImportAlbum отправляет фотографии из архива одним альбомом. Telegram не принимает альбомы из одной
+// фотографии, поэтому она отправляется отдельным сообщением.
+func uploadImportAlbum(bot interfaces.Bot, chat *telebot.Chat, files []*zip.File) ([]telebot.Message, error) {
+	// Файлы читаются при отправке, поэтому закрываются после нее:
+	readers := make([]io.Closer, 0, len(files))
+	defer func() {
+		for _, reader := range readers {
+			_ = reader.Close()
+		}
+	}()
+
+	album := make(telebot.Album, 0, len(files))
+	for _, file := range files {
+		if file.UncompressedSize64 > maxImportPhotoSize {
+			return nil, fmt.Errorf("%w: %s is too large", customerrors.ErrInvalidImport, file.Name)
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImport, err)
+		}
+
+		readers = append(readers, reader)
+
+		// Размер в заголовке архива может не совпадать с содержимым, поэтому чтение тоже ограничивается:
+		album = append(album, &telebot.Photo{File: telebot.FromReader(io.LimitReader(reader, maxImportPhotoSize))})
+	}
+
+	if len(album) > 1 {
+		return bot.SendAlbum(chat, album)
+	}
+
+	msg, err := bot.Send(chat, album[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []telebot.Message{*msg}, nil
+}
+
+// readImportArchiveFile читает файл из архива, не распаковывая больше maxSize байт.
+func readImportArchiveFile(file *zip.File, maxSize int) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImport, err)
+	}
+
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(reader, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImport, err)
+	}
+
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("%w: %s is too large", customerrors.ErrInvalidImport, file.Name)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,647 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	chat := &telebot.Chat{ID: 123}
+
+	tests := []struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}{
+		{
+			name:          "success",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockBot.EXPECT().
+					Send(chat, texts.ImportData, gomock.Any()).
+					Return(&telebot.Message{ID: 10}, nil)
+
+				messageID := 10
+				mockUsecases.EXPECT().
+					SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ImportData, &messageID).
+					Return(nil)
+			},
+		},
+		{
+			name:          "send fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockBot.EXPECT().Send(chat, texts.ImportData, gomock.Any()).Return(nil, assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to send message",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "delete fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Delete().Return(assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to delete /import message",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			handler := Import(mockBot, mockUsecases, mockLogger)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			err := handler(mockCtx)
+			if tt.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestImportDocument(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	chat := &telebot.Chat{ID: 123}
+	promptID := 10
+	temp := &entities.Temporary{Step: steps.ImportData, MessageID: &promptID}
+	data := `{"version":1}`
+	archive := newImportArchive(
+		t,
+		map[string]string{entities.ExportDataPath: data, "photos/cover.jpg": "jpeg", "readme.txt": "text"},
+	)
+	albumArchive := newImportArchive(
+		t,
+		map[string]string{
+			entities.ExportDataPath: data,
+			"photos/cover.jpg":      "jpeg",
+			"photos/summer.jpg":     "jpeg",
+			"photos/unused.jpg":     "jpeg",
+		},
+	)
+	archiveWithoutData := newImportArchive(t, map[string]string{"photos/cover.jpg": "jpeg"})
+	uploadedPhoto := &telebot.Message{
+		ID:    11,
+		Photo: &telebot.Photo{File: telebot.File{FileID: "new-file-id", UniqueID: "new-unique-id"}},
+	}
+	uploadedAlbum := []telebot.Message{
+		*uploadedPhoto,
+		{ID: 12, Photo: &telebot.Photo{File: telebot.File{FileID: "summer-file-id", UniqueID: "summer-unique-id"}}},
+	}
+
+	// Выгрузка ссылается на обложку, две фотографии галереи и фотографию, которой нет в архиве:
+	exportWithPhotos := &entities.Export{
+		Groups: []entities.ExportGroup{
+			{
+				Plants: []entities.ExportPlant{
+					{
+						Photo: "photos/cover.jpg",
+						Photos: []entities.ExportPhoto{
+							{File: "photos/cover.jpg"},
+							{File: "photos/summer.jpg"},
+							{File: "photos/missing.jpg"},
+						},
+					},
+				},
+			},
+		},
+	}
+	exportWithCover := &entities.Export{
+		Groups: []entities.ExportGroup{{Plants: []entities.ExportPlant{{Photo: "photos/cover.jpg"}}}},
+	}
+	exportWithoutPhotos := &entities.Export{Groups: []entities.ExportGroup{{Plants: []entities.ExportPlant{{}}}}}
+
+	newMessage := func(fileSize int64) *telebot.Message {
+		return &telebot.Message{
+			Document: &telebot.Document{
+				File:     telebot.File{FileID: "file-id", FileSize: fileSize},
+				FileName: "plants.json",
+			},
+		}
+	}
+
+	isImportFile := gomock.Cond(func(file *telebot.File) bool { return file.FileID == "file-id" })
+
+	hasButtons := func(markup *telebot.ReplyMarkup, data ...string) bool {
+		if len(markup.InlineKeyboard) != len(data)+1 {
+			return false
+		}
+
+		for i, value := range data {
+			if markup.InlineKeyboard[i][0].Unique != buttons.ConfirmImport.Unique ||
+				markup.InlineKeyboard[i][0].Data != value {
+				return false
+			}
+		}
+
+		return markup.InlineKeyboard[len(data)][0].Unique == buttons.Menu.Unique
+	}
+
+	tests := []struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}{
+		{
+			name:          "success — without conflicts",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(data)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(data)), nil)
+				mockUsecases.EXPECT().
+					PrepareImport(gomock.Any(), 123, []byte(data), gomock.Nil()).
+					Return(&entities.ImportPreview{Groups: 1, Plants: 2}, nil)
+
+				mockBot.EXPECT().Delete(&telebot.Message{ID: promptID, Chat: chat}).Return(nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(text string) bool {
+						return strings.HasSuffix(text, texts.ConfirmImport)
+					}),
+					gomock.Cond(func(markup *telebot.ReplyMarkup) bool {
+						return hasButtons(markup, entities.ImportConflictSkip)
+					}),
+				).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "success — conflicts are escaped and resolutions are offered",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(data)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(data)), nil)
+				mockUsecases.EXPECT().
+					PrepareImport(gomock.Any(), 123, []byte(data), gomock.Nil()).
+					Return(&entities.ImportPreview{Groups: 2, Conflicts: []string{"Кухня", "<Балкон>"}}, nil)
+
+				mockBot.EXPECT().Delete(&telebot.Message{ID: promptID, Chat: chat}).Return(nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(text string) bool {
+						return strings.Contains(text, "<b>Кухня</b>, <b>&lt;Балкон&gt;</b>")
+					}),
+					gomock.Cond(func(markup *telebot.ReplyMarkup) bool {
+						return hasButtons(
+							markup,
+							entities.ImportConflictSkip,
+							entities.ImportConflictRename,
+							entities.ImportConflictOverwrite,
+						)
+					}),
+				).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "success — plant conflicts are shown",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(data)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(data)), nil)
+				mockUsecases.EXPECT().
+					PrepareImport(gomock.Any(), 123, []byte(data), gomock.Nil()).
+					Return(
+						&entities.ImportPreview{
+							Groups:         1,
+							Conflicts:      []string{"Кухня"},
+							PlantConflicts: []string{"Кухня / Алоэ"},
+						},
+						nil,
+					)
+
+				mockBot.EXPECT().Delete(&telebot.Message{ID: promptID, Chat: chat}).Return(nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(text string) bool {
+						return strings.Contains(text, fmt.Sprintf(texts.ImportPlantConflicts, "<b>Кухня / Алоэ</b>")) &&
+							strings.HasSuffix(text, texts.ImportConflictsQuestion)
+					}),
+					gomock.Any(),
+				).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "success — archive photos are uploaded again",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(archive)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(archive)), nil)
+				mockUsecases.EXPECT().ParseImport([]byte(data)).Return(exportWithCover, nil)
+
+				// Telegram не принимает альбомы из одной фотографии:
+				mockBot.EXPECT().
+					Send(chat, gomock.Cond(func(photo *telebot.Photo) bool { return photo.File.FileReader != nil })).
+					Return(uploadedPhoto, nil).
+					Times(1)
+
+				mockBot.EXPECT().DeleteMany([]telebot.Editable{uploadedPhoto}).Return(nil).Times(1)
+
+				mockUsecases.EXPECT().
+					PrepareImport(
+						gomock.Any(),
+						123,
+						[]byte(data),
+						map[string]entities.ImportPhoto{
+							"photos/cover.jpg": {FileID: "new-file-id", FileUniqueID: "new-unique-id"},
+						},
+					).
+					Return(&entities.ImportPreview{Groups: 1, Photos: 1}, nil)
+
+				mockBot.EXPECT().Delete(&telebot.Message{ID: promptID, Chat: chat}).Return(nil)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "success — only referenced archive photos are uploaded as album",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(albumArchive)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(albumArchive)), nil)
+				mockUsecases.EXPECT().ParseImport([]byte(data)).Return(exportWithPhotos, nil)
+
+				mockBot.EXPECT().
+					SendAlbum(chat, gomock.Len(2)).
+					Return(uploadedAlbum, nil).
+					Times(1)
+
+				mockBot.EXPECT().DeleteMany(gomock.Len(2)).Return(nil).Times(1)
+
+				mockUsecases.EXPECT().
+					PrepareImport(
+						gomock.Any(),
+						123,
+						[]byte(data),
+						map[string]entities.ImportPhoto{
+							"photos/cover.jpg":  {FileID: "new-file-id", FileUniqueID: "new-unique-id"},
+							"photos/summer.jpg": {FileID: "summer-file-id", FileUniqueID: "summer-unique-id"},
+						},
+					).
+					Return(&entities.ImportPreview{Groups: 1, Photos: 2}, nil)
+
+				mockBot.EXPECT().Delete(&telebot.Message{ID: promptID, Chat: chat}).Return(nil)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "success — unreferenced archive photos are not uploaded",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(archive)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(archive)), nil)
+				mockUsecases.EXPECT().ParseImport([]byte(data)).Return(exportWithoutPhotos, nil)
+
+				mockUsecases.EXPECT().
+					PrepareImport(gomock.Any(), 123, []byte(data), gomock.Nil()).
+					Return(&entities.ImportPreview{Groups: 1}, nil)
+
+				mockBot.EXPECT().Delete(&telebot.Message{ID: promptID, Chat: chat}).Return(nil)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "invalid export in archive — photos are not uploaded",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(albumArchive)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(albumArchive)), nil)
+				mockUsecases.EXPECT().ParseImport([]byte(data)).Return(nil, customerrors.ErrInvalidImport)
+			},
+		},
+		{
+			name:          "archive without export",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(archiveWithoutData)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(archiveWithoutData)), nil)
+
+				mockLogger.EXPECT().Error(
+					"Failed to read import archive",
+					"Error", gomock.Cond(func(err error) bool { return errors.Is(err, customerrors.ErrInvalidImport) }),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "file is too large",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(maxImportArchiveSize + 1)).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+			},
+		},
+		{
+			name:          "export without archive is too large",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				large := strings.Repeat(" ", maxImportFileSize+1)
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(large)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(large)), nil)
+			},
+		},
+		{
+			name:          "invalid export",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(data)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(io.NopCloser(strings.NewReader(data)), nil)
+				mockUsecases.EXPECT().
+					PrepareImport(gomock.Any(), 123, []byte(data), gomock.Nil()).
+					Return(nil, customerrors.ErrInvalidImport)
+			},
+		},
+		{
+			name:          "download fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(newMessage(int64(len(data)))).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockBot.EXPECT().File(isImportFile).Return(nil, assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to download import file",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			handler := ImportDocument(mockBot, mockUsecases, mockLogger)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			err := handler(mockCtx)
+			if tt.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfirmImportCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+
+	tests := []struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}{
+		{
+			name:          "success",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Data().Return(entities.ImportConflictRename).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().
+					ImportUserData(gomock.Any(), 123, entities.ImportConflictRename).
+					Return(&entities.ImportResult{Groups: 2, Plants: 3, RenamedGroups: 1}, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(text string) bool {
+						return text == fmt.Sprintf(texts.ImportDone, 2, 3)+fmt.Sprintf(texts.ImportRenamed, 1)
+					}),
+					gomock.Any(),
+				).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "success — plant conflicts are reported",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Data().Return(entities.ImportConflictOverwrite).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().
+					ImportUserData(gomock.Any(), 123, entities.ImportConflictOverwrite).
+					Return(
+						&entities.ImportResult{Groups: 0, Plants: 2, OverwrittenGroups: 1, OverwrittenPlants: 1},
+						nil,
+					)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(text string) bool {
+						return text == fmt.Sprintf(texts.ImportDone, 0, 2)+
+							fmt.Sprintf(texts.ImportOverwritten, 1)+
+							fmt.Sprintf(texts.ImportOverwrittenPlants, 1)
+					}),
+					gomock.Any(),
+				).Return(nil).Times(1)
+			},
+		},
+		{
+			name:          "import fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Data().Return(entities.ImportConflictSkip).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().
+					ImportUserData(gomock.Any(), 123, entities.ImportConflictSkip).
+					Return(nil, customerrors.ErrInvalidImport)
+			},
+		},
+		{
+			name:          "delete fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Delete().Return(assert.AnError)
+
+				mockLogger.EXPECT().Error(
+					"Failed to delete message",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			handler := ConfirmImportCallback(mockBot, mockUsecases, mockLogger)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			err := handler(mockCtx)
+			if tt.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// newImportArchive собирает ZIP-архив с указанными файлами.
+func newImportArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		writer, err := archive.Create(name)
+		require.NoError(t, err)
+
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, archive.Close())
+
+	return buf.String()
+}
+
+func TestUploadImportPhotos_TooManyPhotos(t *testing.T) {
+	files := map[string]string{entities.ExportDataPath: `{"version":1}`}
+	paths := make([]string, 0, maxImportPhotos+1)
+
+	for i := range maxImportPhotos + 1 {
+		path := fmt.Sprintf("photos/%d.jpg", i)
+		files[path] = "jpeg"
+		paths = append(paths, path)
+	}
+
+	_, archiveFiles, err := readImportArchive([]byte(newImportArchive(t, files)))
+	require.NoError(t, err)
+
+	// Ни одна фотография не загружается, если их больше ограничения:
+	mockBot := mockbot.NewMockBot(gomock.NewController(t))
+
+	photos, err := uploadImportPhotos(context.Background(), mockBot, &telebot.Chat{ID: 123}, archiveFiles, paths)
+	require.ErrorIs(t, err, customerrors.ErrInvalidImport)
+	assert.Nil(t, photos)
+}
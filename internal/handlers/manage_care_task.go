@@ -0,0 +1,537 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
+)
+
+func ManageCareTaskCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTaskID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse careTaskID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendManageCareTask(ctx, context, useCases, logger, int(context.Sender().ID), careTaskID)
+	}
+}
+
+func BackToManageCareTaskCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		return sendManageCareTask(ctx, context, useCases, logger, int(context.Sender().ID), careTask.ID)
+	}
+}
+
+func ManageCareTaskChangeLastDateCallback(
+	bot interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		careTask, err = useCases.GetCareTask(ctx, careTask.ID)
+		if err != nil {
+			return err
+		}
+
+		ownerCaption, _, err := getCareTaskOwner(ctx, useCases, *careTask)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		cal, err := calendar.NewCalendar(
+			bot,
+			logger,
+			calendar.WithYearsRange([2]int{now.Year() - 1, now.Year()}),
+			calendar.WithBackButton(buttons.BackToManageCareTask),
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to create calendar",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: cal.GetKeyboard(),
+		}
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ChangeCareTaskLastDateImage),
+				Caption: formatCareTask(texts.ChangeCareTaskLastDate, ownerCaption, *careTask),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeCareTaskLastDate); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func ChangeCareTaskLastDate(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		lastCareDate, err := time.Parse(dateFormat, context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse last care date",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Дата последнего ухода не может быть позже текущего дня:
+		if time.Now().Before(lastCareDate) {
+			// Нет context.Callback() для обычного сообщения, поэтому отправляем ответ текстом:
+			if err = context.Send(texts.CareTaskDateInFuture); err != nil {
+				logger.Error(
+					"Failed to send message",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Для календаря используем context.Chat().ID:
+		careTask, err := getTemporaryCareTask(ctx, int(context.Chat().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		if _, err = useCases.UpdateCareTaskLastDate(ctx, careTask.ID, lastCareDate); err != nil {
+			return err
+		}
+
+		return sendManageCareTask(ctx, context, useCases, logger, int(context.Chat().ID), careTask.ID)
+	}
+}
+
+func ManageCareTaskChangeIntervalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		careTask, err = useCases.GetCareTask(ctx, careTask.ID)
+		if err != nil {
+			return err
+		}
+
+		ownerCaption, _, err := getCareTaskOwner(ctx, useCases, *careTask)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: getCareTaskIntervalsKeyboard(buttons.ChangeCareTaskInterval),
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.BackToManageCareTask,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ChangeCareTaskIntervalImage),
+				Caption: formatCareTask(texts.ChangeCareTaskInterval, ownerCaption, *careTask),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeCareTaskInterval); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func ChangeCareTaskIntervalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		interval, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse care task interval",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		if _, err = useCases.UpdateCareTaskInterval(ctx, careTask.ID, interval); err != nil {
+			return err
+		}
+
+		return sendManageCareTask(ctx, context, useCases, logger, int(context.Sender().ID), careTask.ID)
+	}
+}
+
+func ManageCareTaskRemovalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		careTask, err = useCases.GetCareTask(ctx, careTask.ID)
+		if err != nil {
+			return err
+		}
+
+		ownerCaption, _, err := getCareTaskOwner(ctx, useCases, *careTask)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.ConfirmCareTaskRemoval,
+				},
+				{
+					buttons.BackToManageCareTask,
+					buttons.Menu,
+				},
+			},
+		}
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ManageCareTaskRemovalImage),
+				Caption: formatCareTask(texts.ManageCareTaskRemoval, ownerCaption, *careTask),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+func ConfirmCareTaskRemovalCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		careTask, err := getTemporaryCareTask(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		if err = useCases.DeleteCareTask(ctx, careTask.ID); err != nil {
+			return err
+		}
+
+		return sendCareTasks(
+			ctx,
+			context,
+			useCases,
+			logger,
+			entities.CareTask{GroupID: careTask.GroupID, PlantID: careTask.PlantID},
+		)
+	}
+}
+
+// sendManageCareTask отправляет экран управления задачей ухода и запоминает ее в Temporary.
+func sendManageCareTask(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	telegramID int,
+	careTaskID int,
+) error {
+	careTask, err := useCases.GetCareTask(ctx, careTaskID)
+	if err != nil {
+		return err
+	}
+
+	ownerCaption, _, err := getCareTaskOwner(ctx, useCases, *careTask)
+	if err != nil {
+		return err
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{
+			{
+				buttons.ManageCareTaskChangeLastDate,
+			},
+			{
+				buttons.ManageCareTaskChangeInterval,
+			},
+			{
+				buttons.ManageCareTaskRemoval,
+			},
+			{
+				buttons.BackToCareTasks,
+				buttons.Menu,
+			},
+		},
+	}
+
+	err = context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.ManageCareTaskImage),
+			Caption: formatCareTask(texts.ManageCareTask, ownerCaption, *careTask),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.ManageCareTask(ctx, telegramID, careTaskID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func getTemporaryCareTask(
+	ctx context.Context,
+	telegramID int,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) (*entities.CareTask, error) {
+	temp, err := useCases.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask, err := temp.GetCareTask()
+	if err != nil {
+		logger.Error(
+			"Failed to get CareTask from Temporary",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return careTask, nil
+}
+
+// formatCareTask подставляет данные задачи ухода в текст экрана управления ею.
+func formatCareTask(text, ownerCaption string, careTask entities.CareTask) string {
+	return fmt.Sprintf(
+		text,
+		ownerCaption,
+		utils.GetCareTaskType(careTask.Type),
+		careTask.LastCareDate.Format(dateFormat),
+		utils.GetWateringInterval(careTask.Interval),
+		careTask.NextCareDate.Format(dateFormat),
+	)
+}
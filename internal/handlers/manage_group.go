@@ -9,6 +9,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
@@ -20,6 +21,8 @@ func ManageGroupCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -41,12 +44,12 @@ func ManageGroupCallback(
 			return err
 		}
 
-		group, err := useCases.GetGroup(groupID)
+		group, err := useCases.GetGroup(ctx, groupID)
 		if err != nil {
 			return err
 		}
 
-		plants, err := useCases.GetGroupPlants(group.ID)
+		plants, err := useCases.GetGroupPlants(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -67,6 +70,12 @@ func ManageGroupCallback(
 
 		menu.InlineKeyboard = append(
 			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.ManageGroupWateringHistory,
+			},
+			[]telebot.InlineButton{
+				buttons.ManageGroupCareTasks,
+			},
 			[]telebot.InlineButton{
 				buttons.ManageGroupChange,
 			},
@@ -87,7 +96,7 @@ func ManageGroupCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -103,7 +112,7 @@ func ManageGroupCallback(
 			return err
 		}
 
-		if err = useCases.ManageGroup(int(context.Sender().ID), groupID); err != nil {
+		if err = useCases.ManageGroup(ctx, int(context.Sender().ID), groupID); err != nil {
 			return err
 		}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -22,6 +23,8 @@ func ManageGroupChangeCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -32,7 +35,7 @@ func ManageGroupChangeCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -48,7 +51,7 @@ func ManageGroupChangeCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -68,6 +71,9 @@ func ManageGroupChangeCallback(
 				{
 					buttons.ManageGroupChangeWateringInterval,
 				},
+				{
+					buttons.ManageGroupChangeSeasonSchedule,
+				},
 				{
 					buttons.BackToManageGroupAction,
 					buttons.Menu,
@@ -83,7 +89,7 @@ func ManageGroupChangeCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -100,7 +106,7 @@ func ManageGroupChangeCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroupChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroupChange); err != nil {
 			return err
 		}
 
@@ -114,6 +120,8 @@ func ManageGroupChangeTitleCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -124,7 +132,7 @@ func ManageGroupChangeTitleCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -140,7 +148,7 @@ func ManageGroupChangeTitleCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -165,7 +173,7 @@ func ManageGroupChangeTitleCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -181,12 +189,8 @@ func ManageGroupChangeTitleCallback(
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangeGroupTitle); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.ChangeGroupTitle, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -200,6 +204,8 @@ func ManageGroupChangeDescriptionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -210,7 +216,7 @@ func ManageGroupChangeDescriptionCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -226,7 +232,7 @@ func ManageGroupChangeDescriptionCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -251,7 +257,7 @@ func ManageGroupChangeDescriptionCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -267,12 +273,8 @@ func ManageGroupChangeDescriptionCallback(
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangeGroupDescription); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.ChangeGroupDescription, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -286,6 +288,8 @@ func ManageGroupChangeLastWateringDateCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -296,7 +300,7 @@ func ManageGroupChangeLastWateringDateCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -312,7 +316,7 @@ func ManageGroupChangeLastWateringDateCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -345,7 +349,7 @@ func ManageGroupChangeLastWateringDateCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -362,7 +366,7 @@ func ManageGroupChangeLastWateringDateCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangeGroupLastWateringDate); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeGroupLastWateringDate); err != nil {
 			return err
 		}
 
@@ -376,6 +380,8 @@ func ManageGroupChangeWateringIntervalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -386,7 +392,7 @@ func ManageGroupChangeWateringIntervalCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -402,7 +408,7 @@ func ManageGroupChangeWateringIntervalCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -444,7 +450,7 @@ func ManageGroupChangeWateringIntervalCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -461,7 +467,7 @@ func ManageGroupChangeWateringIntervalCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangeGroupWateringInterval); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeGroupWateringInterval); err != nil {
 			return err
 		}
 
@@ -51,13 +51,13 @@ func TestManageGroupChangeCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем временные данные
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				// Десериализуем группу
 				// → temp.GetGroup() — не юзкейс, не мокируется
 
 				// Получаем полную группу из БД
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// Отправляем фото с меню
 				mockCtx.EXPECT().Send(
@@ -66,7 +66,7 @@ func TestManageGroupChangeCallback(t *testing.T) {
 				).Return(nil)
 
 				// Устанавливаем шаг
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupChange).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupChange).Return(nil)
 			},
 		},
 		{
@@ -89,7 +89,7 @@ func TestManageGroupChangeCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -102,7 +102,7 @@ func TestManageGroupChangeCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
@@ -121,8 +121,8 @@ func TestManageGroupChangeCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -144,8 +144,8 @@ func TestManageGroupChangeCallback(t *testing.T) {
 
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -178,22 +178,22 @@ func TestManageGroupChangeCallback(t *testing.T) {
 
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupChange).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupChange).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -253,12 +253,12 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем временные данные
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				// Десериализуем группу → temp.GetGroup() — не мокируется
 
 				// Получаем полную группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// Отправляем сообщение — ожидаем, что Bot.Send вернёт msg
 				mockBot.EXPECT().Send(
@@ -267,11 +267,8 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				// Устанавливаем шаг
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupTitle).Return(nil)
-
-				// Сохраняем ID сообщения
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(nil)
+				// Устанавливаем шаг и сохраняем ID сообщения
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangeGroupTitle, &sentMessage.ID).Return(nil)
 			},
 		},
 		{
@@ -294,7 +291,7 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -307,7 +304,7 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
@@ -326,8 +323,8 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -352,8 +349,8 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -369,42 +366,7 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 			},
 		},
 		{
-			name:          "set temporary step fails",
-			errorExpected: true,
-			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
-				group := &entities.Group{
-					ID:               10,
-					Title:            "Orchids",
-					Description:      "White and pink",
-					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
-					WateringInterval: 7,
-					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
-				}
-				temp := &entities.Temporary{
-					UserID: 123,
-					Data:   mustMarshal(t, &entities.Group{ID: 10}),
-				}
-				sentMessage := &telebot.Message{ID: 789}
-
-				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockCtx.EXPECT().Delete().Return(nil)
-				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
-				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
-
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-
-				mockBot.EXPECT().Send(
-					chat,
-					gomock.AssignableToTypeOf(&telebot.Photo{}),
-					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
-				).Return(sentMessage, nil)
-
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupTitle).Return(assert.AnError)
-			},
-		},
-		{
-			name:          "set temporary message fails",
+			name:          "set temporary step and message fails",
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				group := &entities.Group{
@@ -426,8 +388,8 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -435,15 +397,14 @@ func TestManageGroupChangeTitleCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupTitle).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangeGroupTitle, &sentMessage.ID).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -503,12 +464,12 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем временные данные
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				// Десериализуем группу → temp.GetGroup() — не мокируется
 
 				// Получаем полную группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// Отправляем сообщение
 				mockBot.EXPECT().Send(
@@ -517,11 +478,8 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				// Устанавливаем шаг
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupDescription).Return(nil)
-
-				// Сохраняем ID сообщения
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(nil)
+				// Устанавливаем шаг и сохраняем ID сообщения
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangeGroupDescription, &sentMessage.ID).Return(nil)
 			},
 		},
 		{
@@ -544,7 +502,7 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -557,7 +515,7 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
@@ -576,8 +534,8 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -602,8 +560,8 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -619,42 +577,7 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 			},
 		},
 		{
-			name:          "set temporary step fails",
-			errorExpected: true,
-			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
-				group := &entities.Group{
-					ID:               10,
-					Title:            "Orchids",
-					Description:      "White and pink",
-					LastWateringDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
-					WateringInterval: 7,
-					NextWateringDate: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC),
-				}
-				temp := &entities.Temporary{
-					UserID: 123,
-					Data:   mustMarshal(t, &entities.Group{ID: 10}),
-				}
-				sentMessage := &telebot.Message{ID: 789}
-
-				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockCtx.EXPECT().Delete().Return(nil)
-				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
-				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
-
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-
-				mockBot.EXPECT().Send(
-					chat,
-					gomock.AssignableToTypeOf(&telebot.Photo{}),
-					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
-				).Return(sentMessage, nil)
-
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupDescription).Return(assert.AnError)
-			},
-		},
-		{
-			name:          "set temporary message fails",
+			name:          "set temporary step and message fails",
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				group := &entities.Group{
@@ -676,8 +599,8 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -685,15 +608,14 @@ func TestManageGroupChangeDescriptionCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupDescription).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangeGroupDescription, &sentMessage.ID).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -751,10 +673,10 @@ func TestManageGroupChangeLastWateringDateCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем временные данные
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				// Получаем полную группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// Бот передаётся в NewCalendar — ожидаем, что он будет использован
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
@@ -766,7 +688,7 @@ func TestManageGroupChangeLastWateringDateCallback(t *testing.T) {
 				).Return(nil)
 
 				// Устанавливаем шаг
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupLastWateringDate).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeGroupLastWateringDate).Return(nil)
 			},
 		},
 		{
@@ -789,7 +711,7 @@ func TestManageGroupChangeLastWateringDateCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -802,7 +724,7 @@ func TestManageGroupChangeLastWateringDateCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
@@ -821,8 +743,8 @@ func TestManageGroupChangeLastWateringDateCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -849,8 +771,8 @@ func TestManageGroupChangeLastWateringDateCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -888,22 +810,22 @@ func TestManageGroupChangeLastWateringDateCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupLastWateringDate).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeGroupLastWateringDate).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -959,12 +881,12 @@ func TestManageGroupChangeWateringIntervalCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем временные данные
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				// Десериализуем группу → temp.GetGroup() — не мокируется
 
 				// Получаем полную группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// Ожидаем отправку сообщения
 				mockCtx.EXPECT().Send(
@@ -973,7 +895,7 @@ func TestManageGroupChangeWateringIntervalCallback(t *testing.T) {
 				).Return(nil)
 
 				// Устанавливаем шаг
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupWateringInterval).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeGroupWateringInterval).Return(nil)
 			},
 		},
 		{
@@ -996,7 +918,7 @@ func TestManageGroupChangeWateringIntervalCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -1009,7 +931,7 @@ func TestManageGroupChangeWateringIntervalCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
@@ -1028,8 +950,8 @@ func TestManageGroupChangeWateringIntervalCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -1053,8 +975,8 @@ func TestManageGroupChangeWateringIntervalCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -1089,22 +1011,22 @@ func TestManageGroupChangeWateringIntervalCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangeGroupWateringInterval).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeGroupWateringInterval).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -20,6 +21,8 @@ func ManageGroupRemovalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -30,7 +33,7 @@ func ManageGroupRemovalCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -46,7 +49,7 @@ func ManageGroupRemovalCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -72,7 +75,7 @@ func ManageGroupRemovalCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -89,7 +92,7 @@ func ManageGroupRemovalCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroupRemoval); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroupRemoval); err != nil {
 			return err
 		}
 
@@ -103,6 +106,8 @@ func BackToManageGroupActionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -113,7 +118,7 @@ func BackToManageGroupActionCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -129,12 +134,12 @@ func BackToManageGroupActionCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
 
-		plants, err := useCases.GetGroupPlants(group.ID)
+		plants, err := useCases.GetGroupPlants(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -155,6 +160,12 @@ func BackToManageGroupActionCallback(
 
 		menu.InlineKeyboard = append(
 			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.ManageGroupWateringHistory,
+			},
+			[]telebot.InlineButton{
+				buttons.ManageGroupCareTasks,
+			},
 			[]telebot.InlineButton{
 				buttons.ManageGroupChange,
 			},
@@ -175,7 +186,7 @@ func BackToManageGroupActionCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -192,7 +203,7 @@ func BackToManageGroupActionCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroupAction); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroupAction); err != nil {
 			return err
 		}
 
@@ -206,7 +217,9 @@ func ConfirmGroupRemovalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		ctx := middlewares.GetContext(context)
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -222,7 +235,7 @@ func ConfirmGroupRemovalCallback(
 			return err
 		}
 
-		if err = useCases.DeleteGroup(group.ID); err != nil {
+		if err = useCases.DeleteGroup(ctx, group.ID); err != nil {
 			return err
 		}
 
@@ -253,7 +266,7 @@ func ConfirmGroupRemovalCallback(
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -267,7 +280,7 @@ func ConfirmGroupRemovalCallback(
 			},
 		}
 
-		groupsCount, err := useCases.CountUserGroups(user.ID)
+		groupsCount, err := useCases.CountUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -277,7 +290,7 @@ func ConfirmGroupRemovalCallback(
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.ManageGroups})
 		}
 
-		plantsCount, err := useCases.CountUserPlants(user.ID)
+		plantsCount, err := useCases.CountUserPlants(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -304,7 +317,7 @@ func ConfirmGroupRemovalCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции.
-		if err = useCases.ResetTemporary(int(context.Sender().ID)); err != nil {
+		if err = useCases.ResetTemporary(ctx, int(context.Sender().ID)); err != nil {
 			return err
 		}
 
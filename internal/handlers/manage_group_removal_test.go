@@ -45,15 +45,15 @@ func TestManageGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupRemoval).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupRemoval).Return(nil)
 			},
 		},
 		{
@@ -77,7 +77,7 @@ func TestManageGroupRemovalCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -91,7 +91,7 @@ func TestManageGroupRemovalCallback(t *testing.T) {
 					Data:   []byte(`{"id": "invalid"}`),
 				}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
 					"Error", gomock.Any(),
@@ -110,8 +110,8 @@ func TestManageGroupRemovalCallback(t *testing.T) {
 					Data:   mustMarshal(t, &entities.Group{ID: 10}),
 				}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -127,8 +127,8 @@ func TestManageGroupRemovalCallback(t *testing.T) {
 				}
 
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -155,22 +155,22 @@ func TestManageGroupRemovalCallback(t *testing.T) {
 				}
 
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupRemoval).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupRemoval).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -225,9 +225,9 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				// Act: проверка Send
 				mockCtx.EXPECT().Send(
@@ -235,7 +235,7 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupAction).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupAction).Return(nil)
 			},
 		},
 		{
@@ -260,16 +260,16 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupAction).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupAction).Return(nil)
 			},
 		},
 		{
@@ -291,7 +291,7 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes() // ✅
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -304,7 +304,7 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes() // ✅
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
 					"Error", gomock.Any(),
@@ -322,8 +322,8 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes() // ✅
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -337,9 +337,9 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes() // ✅
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -354,9 +354,9 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes() // ✅
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
@@ -380,21 +380,21 @@ func TestBackToManageGroupActionCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes() // ✅
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupAction).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupAction).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -440,24 +440,24 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
 					CallbackID: "callback_123",
 					Text:       texts.GroupDeleted,
 				}).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(500).Return(5, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 500).Return(5, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
 			},
 		},
 		{
@@ -474,21 +474,21 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(1, nil)
-				mockUsecases.EXPECT().CountUserPlants(500).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(1, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 500).Return(0, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
 			},
 		},
 		{
@@ -505,21 +505,21 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(500).Return(3, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 500).Return(3, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
 			},
 		},
 		{
@@ -536,21 +536,21 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(500).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 500).Return(0, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
 			},
 		},
 		{
@@ -567,14 +567,14 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(500).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 500).Return(0, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -602,14 +602,14 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(500).Return(0, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 500).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -617,7 +617,7 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -629,7 +629,7 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 					Data:   []byte(`{"id": "invalid"}`),
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
 					"Error", gomock.Any(),
@@ -646,8 +646,8 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 					Data:   mustMarshal(t, &entities.Group{ID: 10}),
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(assert.AnError)
 			},
 		},
 		{
@@ -660,8 +660,8 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(assert.AnError)
 				mockLogger.EXPECT().Error(
 					"Failed to send Response",
@@ -680,8 +680,8 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(assert.AnError)
 				mockLogger.EXPECT().Error(
@@ -701,11 +701,11 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -719,12 +719,12 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				user := &entities.User{ID: 500, TelegramID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(0, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -741,28 +741,28 @@ func TestConfirmGroupRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeleteGroup(10).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeleteGroup(gomock.Any(), 10).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.Any()).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(500).Return(1, nil)
-				mockUsecases.EXPECT().CountUserPlants(500).Return(1, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 500).Return(1, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 500).Return(1, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ResetTemporary(123).Return(assert.AnError)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
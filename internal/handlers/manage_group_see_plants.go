@@ -9,6 +9,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -25,6 +26,8 @@ func ManageGroupSeePlantsCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -35,7 +38,7 @@ func ManageGroupSeePlantsCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -51,12 +54,12 @@ func ManageGroupSeePlantsCallback(
 			return err
 		}
 
-		group, err = useCases.GetGroup(group.ID)
+		group, err = useCases.GetGroup(ctx, group.ID)
 		if err != nil {
 			return err
 		}
 
-		plants, err := useCases.GetGroupPlants(group.ID)
+		plants, err := useCases.GetGroupPlants(ctx, group.ID)
 		if err != nil {
 			return err
 		}
@@ -98,7 +101,7 @@ func ManageGroupSeePlantsCallback(
 					group.Title,
 					group.Description,
 					group.LastWateringDate.Format(dateFormat),
-					utils.GetWateringInterval(group.WateringInterval),
+					utils.GetGroupWateringInterval(*group),
 					group.NextWateringDate.Format(dateFormat),
 				),
 			},
@@ -115,7 +118,7 @@ func ManageGroupSeePlantsCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroupSeePlants); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroupSeePlants); err != nil {
 			return err
 		}
 
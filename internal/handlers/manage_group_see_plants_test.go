@@ -47,16 +47,16 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupSeePlants).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupSeePlants).Return(nil)
 			},
 		},
 		{
@@ -80,16 +80,16 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupSeePlants).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupSeePlants).Return(nil)
 			},
 		},
 		{
@@ -107,16 +107,16 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return([]entities.Plant{}, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return([]entities.Plant{}, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupSeePlants).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupSeePlants).Return(nil)
 			},
 		},
 		{
@@ -138,7 +138,7 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -149,7 +149,7 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Group from Temporary",
 					"Error", gomock.Any(),
@@ -165,8 +165,8 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -178,9 +178,9 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				group := &entities.Group{ID: 10}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -193,9 +193,9 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				plants := []entities.Plant{{ID: 1, Title: "Rose", GroupID: 10}}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
@@ -217,21 +217,21 @@ func TestManageGroupSeePlantsCallback(t *testing.T) {
 				plants := []entities.Plant{{ID: 1, Title: "Rose", GroupID: 10}}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroupSeePlants).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroupSeePlants).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
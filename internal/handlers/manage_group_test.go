@@ -51,10 +51,10 @@ func TestManageGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// Получаем растения
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				// Ожидаем отправку сообщения
 				mockCtx.EXPECT().Send(
@@ -63,7 +63,7 @@ func TestManageGroupCallback(t *testing.T) {
 				).Return(nil)
 
 				// Вызываем ManageGroup
-				mockUsecases.EXPECT().ManageGroup(123, 10).Return(nil)
+				mockUsecases.EXPECT().ManageGroup(gomock.Any(), 123, 10).Return(nil)
 			},
 		},
 		{
@@ -90,10 +90,10 @@ func TestManageGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем группу
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// Получаем растения
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				// Ожидаем отправку сообщения
 				mockCtx.EXPECT().Send(
@@ -102,7 +102,7 @@ func TestManageGroupCallback(t *testing.T) {
 				).Return(nil)
 
 				// Вызываем ManageGroup
-				mockUsecases.EXPECT().ManageGroup(123, 10).Return(nil)
+				mockUsecases.EXPECT().ManageGroup(gomock.Any(), 123, 10).Return(nil)
 			},
 		},
 		{
@@ -145,7 +145,7 @@ func TestManageGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Data().Return("10").AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -162,8 +162,8 @@ func TestManageGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Data().Return("10").AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -185,8 +185,8 @@ func TestManageGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Data().Return("10").AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -219,21 +219,21 @@ func TestManageGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Data().Return("10").AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ManageGroup(123, 10).Return(assert.AnError)
+				mockUsecases.EXPECT().ManageGroup(gomock.Any(), 123, 10).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 			mockBot := mockbot.NewMockBot(ctrl)
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strconv"
 
@@ -9,7 +10,9 @@ import (
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -21,6 +24,8 @@ func ManagePlantCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -42,12 +47,12 @@ func ManagePlantCallback(
 			return err
 		}
 
-		plant, err := useCases.GetPlant(plantID)
+		plant, err := useCases.GetPlant(ctx, plantID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -55,6 +60,15 @@ func ManagePlantCallback(
 		menu := &telebot.ReplyMarkup{
 			ResizeKeyboard: true,
 			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.ManagePlantCareTasks,
+				},
+				{
+					buttons.ManagePlantGallery,
+				},
+				{
+					buttons.ManagePlantAddPhoto,
+				},
 				{
 					buttons.ManagePlantChange,
 				},
@@ -68,29 +82,25 @@ func ManagePlantCallback(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ManagePlantAction,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantAction,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
-		if err = useCases.ManagePlant(int(context.Sender().ID), plantID); err != nil {
+		if err = useCases.ManagePlant(ctx, int(context.Sender().ID), plantID); err != nil {
 			return err
 		}
 
@@ -104,6 +114,8 @@ func BackToManagePlantCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -114,7 +126,7 @@ func BackToManagePlantCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -130,12 +142,12 @@ func BackToManagePlantCallback(
 			return err
 		}
 
-		previouslySelectedPlant, err = useCases.GetPlant(previouslySelectedPlant.ID)
+		previouslySelectedPlant, err = useCases.GetPlant(ctx, previouslySelectedPlant.ID)
 		if err != nil {
 			return err
 		}
 
-		plants, err := useCases.GetGroupPlants(previouslySelectedPlant.GroupID)
+		plants, err := useCases.GetGroupPlants(ctx, previouslySelectedPlant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -187,10 +199,127 @@ func BackToManagePlantCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlant); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlant); err != nil {
 			return err
 		}
 
 		return nil
 	}
 }
+
+// getPlantPhotoFile возвращает фотографию растения по ссылке на файл в Telegram. Байты из базы данных
+// используются только для растений, добавленных до хранения file_id.
+func getPlantPhotoFile(plant entities.Plant) telebot.File {
+	switch {
+	case plant.PhotoFileID != "":
+		return telebot.File{FileID: plant.PhotoFileID}
+	case len(plant.Photo) > 0:
+		return telebot.FromReader(bytes.NewReader(plant.Photo))
+	default:
+		return telebot.FromDisk(paths.PlantBasePhotoImage)
+	}
+}
+
+// sendPlantPhoto отправляет фотографию растения. Все экраны с фотографией растения отправляют ее через
+// sendPlantPhoto или sendPlantPhotoMessage, чтобы фотографии из базы данных переносились в Telegram при первом показе.
+func sendPlantPhoto(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	plant entities.Plant,
+	caption string,
+	menu *telebot.ReplyMarkup,
+) error {
+	if plant.PhotoFileID == "" && len(plant.Photo) > 0 {
+		_, err := sendPlantPhotoMessage(ctx, context, useCases, logger, plant, caption, menu)
+
+		return err
+	}
+
+	err := context.Send(
+		&telebot.Photo{
+			File:    getPlantPhotoFile(plant),
+			Caption: caption,
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// sendPlantPhotoMessage отправляет фотографию растения и возвращает отправленное сообщение. Если фотография растения
+// хранится в базе данных, после отправки сохраняем полученный от Telegram file_id, чтобы больше не загружать ее заново.
+func sendPlantPhotoMessage(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	plant entities.Plant,
+	caption string,
+	menu *telebot.ReplyMarkup,
+) (*telebot.Message, error) {
+	// Получаем бота, чтобы при отправке получить messageID и file_id загруженной фотографии:
+	msg, err := context.Bot().Send(
+		context.Chat(),
+		&telebot.Photo{
+			File:    getPlantPhotoFile(plant),
+			Caption: caption,
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	if plant.PhotoFileID != "" || len(plant.Photo) == 0 || msg.Photo == nil {
+		return msg, nil
+	}
+
+	// Фотографии из базы данных не переносились в историю фотографий миграцией, поэтому добавляем исходную
+	// фотографию растения в историю до того, как UpdatePlantPhoto удалит ее из базы данных. Ошибка переноса
+	// не мешает пользователю, поэтому повторим его при следующем показе растения:
+	_, err = useCases.SavePlantPhoto(
+		ctx,
+		entities.PlantPhoto{
+			PlantID:      plant.ID,
+			FileID:       msg.Photo.FileID,
+			FileUniqueID: msg.Photo.UniqueID,
+			TakenAt:      plant.CreatedAt,
+		},
+	)
+	if err != nil {
+		logger.Warn(
+			"Failed to save Plant photo to history",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return msg, nil
+	}
+
+	if _, err = useCases.UpdatePlantPhoto(ctx, plant.ID, msg.Photo.FileID, msg.Photo.UniqueID); err != nil {
+		logger.Warn(
+			"Failed to save Plant photo file_id",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return msg, nil
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"bytes"
 	"fmt"
 	"strconv"
 
@@ -10,6 +9,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
@@ -20,6 +20,8 @@ func ManagePlantChangeCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -30,7 +32,7 @@ func ManagePlantChangeCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -46,12 +48,12 @@ func ManagePlantChangeCallback(
 			return err
 		}
 
-		plant, err = useCases.GetPlant(plant.ID)
+		plant, err = useCases.GetPlant(ctx, plant.ID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -71,6 +73,9 @@ func ManagePlantChangeCallback(
 				{
 					buttons.ManagePlantChangePhoto,
 				},
+				{
+					buttons.ManagePlantChangeWateringInterval,
+				},
 				{
 					buttons.BackToManagePlantAction,
 					buttons.Menu,
@@ -78,30 +83,26 @@ func ManagePlantChangeCallback(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ManagePlantChange,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantChange,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlantChange); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantChange); err != nil {
 			return err
 		}
 
@@ -115,6 +116,8 @@ func ManagePlantChangeTitleCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -125,7 +128,7 @@ func ManagePlantChangeTitleCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -141,12 +144,12 @@ func ManagePlantChangeTitleCallback(
 			return err
 		}
 
-		plant, err = useCases.GetPlant(plant.ID)
+		plant, err = useCases.GetPlant(ctx, plant.ID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -161,36 +164,26 @@ func ManagePlantChangeTitleCallback(
 			},
 		}
 
-		// Получаем бота, чтобы при отправке получить messageID для дальнейшего удаления:
-		msg, err := context.Bot().Send(
-			context.Chat(),
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ChangePlantTitle,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		msg, err := sendPlantPhotoMessage(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ChangePlantTitle,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
-			return err
-		}
-
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangePlantTitle); err != nil {
 			return err
 		}
 
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.ChangePlantTitle, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -204,6 +197,8 @@ func ManagePlantChangeDescriptionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -214,7 +209,7 @@ func ManagePlantChangeDescriptionCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -230,12 +225,12 @@ func ManagePlantChangeDescriptionCallback(
 			return err
 		}
 
-		plant, err = useCases.GetPlant(plant.ID)
+		plant, err = useCases.GetPlant(ctx, plant.ID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -250,36 +245,26 @@ func ManagePlantChangeDescriptionCallback(
 			},
 		}
 
-		// Получаем бота, чтобы при отправке получить messageID для дальнейшего удаления:
-		msg, err := context.Bot().Send(
-			context.Chat(),
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ChangePlantDescription,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		msg, err := sendPlantPhotoMessage(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ChangePlantDescription,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangePlantDescription); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.ChangePlantDescription, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -293,6 +278,8 @@ func ManagePlantChangeGroupCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -303,7 +290,7 @@ func ManagePlantChangeGroupCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -319,17 +306,17 @@ func ManagePlantChangeGroupCallback(
 			return err
 		}
 
-		plant, err = useCases.GetPlant(plant.ID)
+		plant, err = useCases.GetPlant(ctx, plant.ID)
 		if err != nil {
 			return err
 		}
 
-		currentGroup, err := useCases.GetGroup(plant.GroupID)
+		currentGroup, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
 
-		groups, err := useCases.GetUserGroups(plant.UserID)
+		groups, err := useCases.GetUserGroups(ctx, plant.UserID)
 		if err != nil {
 			return err
 		}
@@ -368,30 +355,26 @@ func ManagePlantChangeGroupCallback(
 			},
 		)
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ChangePlantGroup,
-					plant.Title,
-					plant.Description,
-					currentGroup.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ChangePlantGroup,
+				plant.Title,
+				plant.Description,
+				currentGroup.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangePlantGroup); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangePlantGroup); err != nil {
 			return err
 		}
 
@@ -405,6 +388,8 @@ func ManagePlantChangePhotoCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -415,7 +400,7 @@ func ManagePlantChangePhotoCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -431,12 +416,12 @@ func ManagePlantChangePhotoCallback(
 			return err
 		}
 
-		plant, err = useCases.GetPlant(plant.ID)
+		plant, err = useCases.GetPlant(ctx, plant.ID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -451,36 +436,26 @@ func ManagePlantChangePhotoCallback(
 			},
 		}
 
-		// Получаем бота, чтобы при отправке получить messageID для дальнейшего удаления:
-		msg, err := context.Bot().Send(
-			context.Chat(),
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ChangePlantPhoto,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		msg, err := sendPlantPhotoMessage(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ChangePlantPhoto,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
-			return err
-		}
-
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ChangePlantPhoto); err != nil {
 			return err
 		}
 
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.ChangePlantPhoto, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -29,7 +29,7 @@ func TestManagePlantChangeCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{
@@ -41,16 +41,16 @@ func TestManagePlantChangeCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantChange).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantChange).Return(nil)
 			},
 		},
 		{
@@ -74,7 +74,7 @@ func TestManagePlantChangeCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -85,7 +85,7 @@ func TestManagePlantChangeCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -101,8 +101,8 @@ func TestManagePlantChangeCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -114,9 +114,9 @@ func TestManagePlantChangeCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -129,7 +129,7 @@ func TestManagePlantChangeCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
@@ -137,9 +137,9 @@ func TestManagePlantChangeCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -163,7 +163,7 @@ func TestManagePlantChangeCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
@@ -171,16 +171,16 @@ func TestManagePlantChangeCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantChange).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantChange).Return(assert.AnError)
 			},
 		},
 	}
@@ -189,7 +189,7 @@ func TestManagePlantChangeCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -228,7 +228,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{
@@ -243,9 +243,9 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -253,8 +253,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantTitle).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangePlantTitle, &sentMessage.ID).Return(nil)
 			},
 		},
 		{
@@ -278,7 +277,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -289,7 +288,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -305,8 +304,8 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -318,9 +317,9 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -334,7 +333,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
@@ -344,9 +343,9 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -362,42 +361,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 			},
 		},
 		{
-			name:          "set temporary step fails",
-			errorExpected: true,
-			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
-				sender := &telebot.User{ID: 123}
-				chat := &telebot.Chat{ID: 456}
-				plant := &entities.Plant{
-					ID:          1,
-					Title:       "Rose",
-					Description: "Beautiful flower",
-					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
-				}
-				group := &entities.Group{ID: 10, Title: "Garden"}
-				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
-				sentMessage := &telebot.Message{ID: 789}
-
-				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockCtx.EXPECT().Delete().Return(nil)
-				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
-				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
-
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-
-				mockBot.EXPECT().Send(
-					chat,
-					gomock.AssignableToTypeOf(&telebot.Photo{}),
-					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
-				).Return(sentMessage, nil)
-
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantTitle).Return(assert.AnError)
-			},
-		},
-		{
-			name:          "set temporary message fails",
+			name:          "set temporary step and message fails",
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
@@ -407,7 +371,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
@@ -418,9 +382,9 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -428,8 +392,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantTitle).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangePlantTitle, &sentMessage.ID).Return(assert.AnError)
 			},
 		},
 	}
@@ -438,7 +401,7 @@ func TestManagePlantChangeTitleCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -477,7 +440,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{
@@ -492,9 +455,9 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -502,8 +465,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantDescription).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangePlantDescription, &sentMessage.ID).Return(nil)
 			},
 		},
 		{
@@ -527,7 +489,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -538,7 +500,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -554,8 +516,8 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -567,9 +529,9 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -583,7 +545,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
@@ -593,9 +555,9 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -611,7 +573,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 			},
 		},
 		{
-			name:          "set temporary step fails",
+			name:          "set temporary step and message fails",
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
@@ -621,7 +583,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
@@ -632,9 +594,9 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -642,43 +604,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantDescription).Return(assert.AnError)
-			},
-		},
-		{
-			name:          "set temporary message fails",
-			errorExpected: true,
-			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
-				sender := &telebot.User{ID: 123}
-				chat := &telebot.Chat{ID: 456}
-				plant := &entities.Plant{
-					ID:          1,
-					Title:       "Rose",
-					Description: "Beautiful flower",
-					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
-				}
-				group := &entities.Group{ID: 10, Title: "Garden"}
-				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
-				sentMessage := &telebot.Message{ID: 789}
-
-				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockCtx.EXPECT().Delete().Return(nil)
-				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
-				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
-
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-
-				mockBot.EXPECT().Send(
-					chat,
-					gomock.AssignableToTypeOf(&telebot.Photo{}),
-					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
-				).Return(sentMessage, nil)
-
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantDescription).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangePlantDescription, &sentMessage.ID).Return(assert.AnError)
 			},
 		},
 	}
@@ -687,7 +613,7 @@ func TestManagePlantChangeDescriptionCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -727,7 +653,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				currentGroup := &entities.Group{ID: 10, Title: "Garden"}
 				otherGroups := []entities.Group{
@@ -745,17 +671,17 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(currentGroup, nil)
-				mockUsecases.EXPECT().GetUserGroups(123).Return(otherGroups, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(currentGroup, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 123).Return(otherGroups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangePlantGroup).Return(nil)
 			},
 		},
 		{
@@ -770,7 +696,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				currentGroup := &entities.Group{ID: 10, Title: "Garden"}
 				otherGroups := []entities.Group{
@@ -789,17 +715,17 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(currentGroup, nil)
-				mockUsecases.EXPECT().GetUserGroups(123).Return(otherGroups, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(currentGroup, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 123).Return(otherGroups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangePlantGroup).Return(nil)
 			},
 		},
 		{
@@ -823,7 +749,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -834,7 +760,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -850,8 +776,8 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -863,9 +789,9 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -879,10 +805,10 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(currentGroup, nil)
-				mockUsecases.EXPECT().GetUserGroups(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(currentGroup, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -897,7 +823,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				currentGroup := &entities.Group{ID: 10, Title: "Garden"}
 				otherGroups := []entities.Group{
@@ -909,10 +835,10 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(currentGroup, nil)
-				mockUsecases.EXPECT().GetUserGroups(123).Return(otherGroups, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(currentGroup, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 123).Return(otherGroups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -938,7 +864,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				currentGroup := &entities.Group{ID: 10, Title: "Garden"}
 				otherGroups := []entities.Group{
@@ -950,17 +876,17 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(currentGroup, nil)
-				mockUsecases.EXPECT().GetUserGroups(123).Return(otherGroups, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(currentGroup, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 123).Return(otherGroups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantGroup).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangePlantGroup).Return(assert.AnError)
 			},
 		},
 		{
@@ -975,7 +901,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				currentGroup := &entities.Group{ID: 10, Title: "Garden"}
 				var otherGroups []entities.Group // Нет других групп
@@ -985,17 +911,17 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(currentGroup, nil)
-				mockUsecases.EXPECT().GetUserGroups(123).Return(otherGroups, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(currentGroup, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 123).Return(otherGroups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangePlantGroup).Return(nil)
 			},
 		},
 		{
@@ -1010,7 +936,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				currentGroup := &entities.Group{ID: 10, Title: "Garden"}
 				otherGroups := []entities.Group{
@@ -1022,17 +948,17 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(currentGroup, nil)
-				mockUsecases.EXPECT().GetUserGroups(123).Return(otherGroups, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(currentGroup, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 123).Return(otherGroups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangePlantGroup).Return(nil)
 			},
 		},
 	}
@@ -1041,7 +967,7 @@ func TestManagePlantChangeGroupCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -1081,7 +1007,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{
@@ -1095,9 +1021,9 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -1105,8 +1031,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantPhoto).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangePlantPhoto, &sentMessage.ID).Return(nil)
 			},
 		},
 		{
@@ -1130,7 +1055,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -1141,7 +1066,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -1157,8 +1082,8 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -1170,9 +1095,9 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -1187,7 +1112,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10, UserID: 123})}
@@ -1197,9 +1122,9 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -1215,43 +1140,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 			},
 		},
 		{
-			name:          "set temporary step fails",
-			errorExpected: true,
-			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
-				sender := &telebot.User{ID: 123}
-				chat := &telebot.Chat{ID: 456}
-				plant := &entities.Plant{
-					ID:          1,
-					UserID:      123,
-					Title:       "Rose",
-					Description: "Beautiful flower",
-					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
-				}
-				group := &entities.Group{ID: 10, Title: "Garden"}
-				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10, UserID: 123})}
-				sentMessage := &telebot.Message{ID: 789}
-
-				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockCtx.EXPECT().Delete().Return(nil)
-				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
-				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
-
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
-
-				mockBot.EXPECT().Send(
-					chat,
-					gomock.AssignableToTypeOf(&telebot.Photo{}),
-					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
-				).Return(sentMessage, nil)
-
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantPhoto).Return(assert.AnError)
-			},
-		},
-		{
-			name:          "set temporary message fails",
+			name:          "set temporary step and message fails",
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
@@ -1262,7 +1151,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10, UserID: 123})}
@@ -1273,9 +1162,9 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockBot.EXPECT().Send(
 					chat,
@@ -1283,8 +1172,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(sentMessage, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ChangePlantPhoto).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, &sentMessage.ID).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.ChangePlantPhoto, &sentMessage.ID).Return(assert.AnError)
 			},
 		},
 	} {
@@ -1292,7 +1180,7 @@ func TestManagePlantChangePhotoCallback(t *testing.T) {
 			// Arrange
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
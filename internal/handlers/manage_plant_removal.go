@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/DKhorkov/libs/logging"
@@ -9,6 +8,7 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -20,6 +20,8 @@ func ManagePlantRemovalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -30,7 +32,7 @@ func ManagePlantRemovalCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -46,12 +48,12 @@ func ManagePlantRemovalCallback(
 			return err
 		}
 
-		plant, err = useCases.GetPlant(plant.ID)
+		plant, err = useCases.GetPlant(ctx, plant.ID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -69,30 +71,26 @@ func ManagePlantRemovalCallback(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ManagePlantRemoval,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantRemoval,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlantRemoval); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantRemoval); err != nil {
 			return err
 		}
 
@@ -106,6 +104,8 @@ func BackToManagePlantActionCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -116,7 +116,7 @@ func BackToManagePlantActionCallback(
 			return err
 		}
 
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -132,12 +132,12 @@ func BackToManagePlantActionCallback(
 			return err
 		}
 
-		plant, err = useCases.GetPlant(plant.ID)
+		plant, err = useCases.GetPlant(ctx, plant.ID)
 		if err != nil {
 			return err
 		}
 
-		group, err := useCases.GetGroup(plant.GroupID)
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
 		if err != nil {
 			return err
 		}
@@ -145,6 +145,15 @@ func BackToManagePlantActionCallback(
 		menu := &telebot.ReplyMarkup{
 			ResizeKeyboard: true,
 			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.ManagePlantCareTasks,
+				},
+				{
+					buttons.ManagePlantGallery,
+				},
+				{
+					buttons.ManagePlantAddPhoto,
+				},
 				{
 					buttons.ManagePlantChange,
 				},
@@ -158,30 +167,26 @@ func BackToManagePlantActionCallback(
 			},
 		}
 
-		err = context.Send(
-			&telebot.Photo{
-				File: telebot.FromReader(bytes.NewReader(plant.Photo)),
-				Caption: fmt.Sprintf(
-					texts.ManagePlantAction,
-					plant.Title,
-					plant.Description,
-					group.Title,
-				),
-			},
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(
+				texts.ManagePlantAction,
+				plant.Title,
+				plant.Description,
+				group.Title,
+			),
 			menu,
 		)
 		if err != nil {
-			logger.Error(
-				"Failed to send message",
-				"Error", err,
-				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-			)
-
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlantAction); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantAction); err != nil {
 			return err
 		}
 
@@ -195,7 +200,9 @@ func ConfirmPlantRemovalCallback(
 	logger logging.Logger,
 ) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		ctx := middlewares.GetContext(context)
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -211,7 +218,7 @@ func ConfirmPlantRemovalCallback(
 			return err
 		}
 
-		if err = useCases.DeletePlant(plant.ID); err != nil {
+		if err = useCases.DeletePlant(ctx, plant.ID); err != nil {
 			return err
 		}
 
@@ -241,7 +248,7 @@ func ConfirmPlantRemovalCallback(
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
@@ -255,7 +262,7 @@ func ConfirmPlantRemovalCallback(
 			},
 		}
 
-		groupsCount, err := useCases.CountUserGroups(user.ID)
+		groupsCount, err := useCases.CountUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -265,7 +272,7 @@ func ConfirmPlantRemovalCallback(
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.ManageGroups})
 		}
 
-		plantsCount, err := useCases.CountUserPlants(user.ID)
+		plantsCount, err := useCases.CountUserPlants(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -292,7 +299,7 @@ func ConfirmPlantRemovalCallback(
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции.
-		if err = useCases.ResetTemporary(int(context.Sender().ID)); err != nil {
+		if err = useCases.ResetTemporary(ctx, int(context.Sender().ID)); err != nil {
 			return err
 		}
 
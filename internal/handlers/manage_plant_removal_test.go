@@ -23,6 +23,15 @@ func mustMarshal(t *testing.T, v interface{}) []byte {
 	return data
 }
 
+// newMockContext создает мок telebot.Context без контекста обработки обновления, как при отсутствии
+// middlewares.Context, поэтому обработчики выполняют запросы с context.Background().
+func newMockContext(ctrl *gomock.Controller) *mockbot.MockContext {
+	mockCtx := mockbot.NewMockContext(ctrl)
+	mockCtx.EXPECT().Get(gomock.Any()).Return(nil).AnyTimes()
+
+	return mockCtx
+}
+
 func TestConfirmPlantRemovalCallback(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -44,10 +53,10 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 
 				// GetUserTemporary → возвращает Temporary с Data
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 
 				// Удаляем растение по ID из десериализованного объекта
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 
 				// Подтверждаем callback
 				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
@@ -59,11 +68,11 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// Получаем пользователя
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
 
 				// Считаем группы и растения
-				mockUsecases.EXPECT().CountUserGroups(1).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(3, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(3, nil)
 
 				// Отправляем фото с меню
 				mockCtx.EXPECT().Send(
@@ -72,7 +81,7 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				).Return(nil)
 
 				// Сбрасываем временные данные
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
 			},
 		},
 		{
@@ -81,7 +90,7 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -92,7 +101,7 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "not-a-number"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -108,8 +117,8 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(assert.AnError)
 			},
 		},
 		{
@@ -122,8 +131,8 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(assert.AnError)
 				mockLogger.EXPECT().Error(
 					"Failed to send Response",
@@ -142,8 +151,8 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(nil)
 				mockCtx.EXPECT().Delete().Return(assert.AnError)
 				mockLogger.EXPECT().Error(
@@ -163,11 +172,11 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -181,12 +190,12 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -200,13 +209,13 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -220,13 +229,13 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(3, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(3, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
@@ -249,18 +258,18 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().DeletePlant(1).Return(nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().DeletePlant(gomock.Any(), 1).Return(nil)
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(nil)
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(3, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(3, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(assert.AnError)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(assert.AnError)
 			},
 		},
 	}
@@ -269,7 +278,7 @@ func TestConfirmPlantRemovalCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -307,7 +316,7 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 			tempData:      mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10}),
 			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
-				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, PhotoFileID: "file_id"}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
@@ -315,16 +324,16 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantRemoval).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantRemoval).Return(nil)
 			},
 		},
 		{
@@ -348,7 +357,7 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -360,7 +369,7 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -377,8 +386,8 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -391,9 +400,9 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -402,16 +411,16 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 			tempData:      mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10}),
 			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
-				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, PhotoFileID: "file_id"}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -431,23 +440,23 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 			tempData:      mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10}),
 			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
-				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, PhotoFileID: "file_id"}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantRemoval).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantRemoval).Return(assert.AnError)
 			},
 		},
 	}
@@ -456,7 +465,7 @@ func TestManagePlantRemovalCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -487,7 +496,7 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 			errorExpected: false,
 			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
-				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, PhotoFileID: "file_id"}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{
 					UserID: 123,
@@ -498,16 +507,16 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantAction).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantAction).Return(nil)
 			},
 		},
 		{
@@ -531,7 +540,7 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -542,7 +551,7 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -558,8 +567,8 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -571,9 +580,9 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -581,16 +590,16 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 			errorExpected: true,
 			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
-				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, PhotoFileID: "file_id"}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -609,23 +618,23 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 			errorExpected: true,
 			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
-				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				plant := &entities.Plant{ID: 1, Title: "Rose", Description: "Beautiful", GroupID: 10, PhotoFileID: "file_id"}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantAction).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantAction).Return(assert.AnError)
 			},
 		},
 	}
@@ -634,7 +643,7 @@ func TestBackToManagePlantActionCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
@@ -12,6 +13,7 @@ import (
 	"go.uber.org/mock/gomock"
 	"gopkg.in/telebot.v4"
 	"testing"
+	"time"
 )
 
 func TestManagePlantCallback(t *testing.T) {
@@ -33,7 +35,7 @@ func TestManagePlantCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "photo_file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 
@@ -42,8 +44,8 @@ func TestManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Data().Return("1")
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// ✅ Мокаем context.Send, а не bot.Send
 				mockCtx.EXPECT().Send(
@@ -51,7 +53,69 @@ func TestManagePlantCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ManagePlant(123, 1).Return(nil)
+				mockUsecases.EXPECT().ManagePlant(gomock.Any(), 123, 1).Return(nil)
+			},
+		},
+		{
+			name:          "success — legacy photo sent from database and file_id saved",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123}
+				chat := &telebot.Chat{ID: 123}
+				plant := &entities.Plant{ID: 1, UserID: 123, Title: "Rose", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				group := &entities.Group{ID: 10, Title: "Garden"}
+				msg := &telebot.Message{ID: 5, Photo: &telebot.Photo{File: telebot.File{FileID: "file_id", UniqueID: "unique_id"}}}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Data().Return("1")
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				mockBot.EXPECT().Send(
+					chat,
+					gomock.AssignableToTypeOf(&telebot.Photo{}),
+					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
+				).Return(msg, nil)
+
+				mockUsecases.EXPECT().SavePlantPhoto(gomock.Any(), gomock.Any()).Return(&entities.PlantPhoto{}, nil)
+				mockUsecases.EXPECT().UpdatePlantPhoto(gomock.Any(), 1, "file_id", "unique_id").Return(plant, nil)
+				mockUsecases.EXPECT().ManagePlant(gomock.Any(), 123, 1).Return(nil)
+			},
+		},
+		{
+			name:          "success — legacy photo file_id not saved, error only logged",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123}
+				chat := &telebot.Chat{ID: 123}
+				plant := &entities.Plant{ID: 1, UserID: 123, Title: "Rose", GroupID: 10, Photo: []byte{0xFF, 0xD8}}
+				group := &entities.Group{ID: 10, Title: "Garden"}
+				msg := &telebot.Message{ID: 5, Photo: &telebot.Photo{File: telebot.File{FileID: "file_id", UniqueID: "unique_id"}}}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Data().Return("1")
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+
+				mockBot.EXPECT().Send(chat, gomock.Any(), gomock.Any()).Return(msg, nil)
+
+				mockUsecases.EXPECT().SavePlantPhoto(gomock.Any(), gomock.Any()).Return(&entities.PlantPhoto{}, nil)
+				mockUsecases.EXPECT().UpdatePlantPhoto(gomock.Any(), 1, "file_id", "unique_id").Return(nil, assert.AnError)
+				mockLogger.EXPECT().Warn(
+					"Failed to save Plant photo file_id",
+					"Error", assert.AnError,
+					"Tracing", gomock.Any(),
+				).Times(1)
+
+				mockUsecases.EXPECT().ManagePlant(gomock.Any(), 123, 1).Return(nil)
 			},
 		},
 		{
@@ -85,7 +149,7 @@ func TestManagePlantCallback(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Data().Return("1")
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -95,8 +159,8 @@ func TestManagePlantCallback(t *testing.T) {
 				plant := &entities.Plant{ID: 1, GroupID: 10}
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Data().Return("1")
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -110,7 +174,7 @@ func TestManagePlantCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "photo_file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 
@@ -119,8 +183,8 @@ func TestManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Data().Return("1")
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				// ✅ context.Send возвращает ошибку
 				mockCtx.EXPECT().Send(
@@ -146,7 +210,7 @@ func TestManagePlantCallback(t *testing.T) {
 					Title:       "Rose",
 					Description: "Beautiful flower",
 					GroupID:     10,
-					Photo:       []byte{0xFF, 0xD8},
+					PhotoFileID: "photo_file_id",
 				}
 				group := &entities.Group{ID: 10, Title: "Garden"}
 
@@ -155,15 +219,15 @@ func TestManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Data().Return("1")
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroup(10).Return(group, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().ManagePlant(123, 1).Return(assert.AnError)
+				mockUsecases.EXPECT().ManagePlant(gomock.Any(), 123, 1).Return(assert.AnError)
 			},
 		},
 	} {
@@ -171,7 +235,7 @@ func TestManagePlantCallback(t *testing.T) {
 			// Arrange
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -230,16 +294,16 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(&plant, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(groupPlants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(&plant, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(groupPlants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlant).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlant).Return(nil)
 			},
 		},
 		{
@@ -263,16 +327,16 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(&plant, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(&plant, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlant).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlant).Return(nil)
 			},
 		},
 		{
@@ -290,9 +354,9 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return([]entities.Plant{}, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return([]entities.Plant{}, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -305,7 +369,7 @@ func TestBackToManagePlantCallback(t *testing.T) {
 					return nil
 				})
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlant).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlant).Return(nil)
 			},
 		},
 		{
@@ -327,7 +391,7 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -338,7 +402,7 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				temp := &entities.Temporary{UserID: 123, Data: []byte(`{"id": "invalid"}`)}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
 				mockLogger.EXPECT().Error(
 					"Failed to get Plant from Temporary",
 					"Error", gomock.Any(),
@@ -354,8 +418,8 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -367,9 +431,9 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				plant := &entities.Plant{ID: 1, GroupID: 10}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(plant, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -382,9 +446,9 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				plants := []entities.Plant{plant}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(&plant, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(&plant, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
@@ -406,21 +470,21 @@ func TestBackToManagePlantCallback(t *testing.T) {
 				plants := []entities.Plant{plant}
 				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 1, GroupID: 10})}
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().GetUserTemporary(123).Return(temp, nil)
-				mockUsecases.EXPECT().GetPlant(1).Return(&plant, nil)
-				mockUsecases.EXPECT().GetGroupPlants(10).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 1).Return(&plant, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 10).Return(plants, nil)
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlant).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlant).Return(assert.AnError)
 			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -440,3 +504,103 @@ func TestBackToManagePlantCallback(t *testing.T) {
 		})
 	}
 }
+
+func TestSendPlantPhotoMessage(t *testing.T) {
+	chat := &telebot.Chat{ID: 123}
+	menu := &telebot.ReplyMarkup{}
+	msg := &telebot.Message{ID: 5, Photo: &telebot.Photo{File: telebot.File{FileID: "file_id", UniqueID: "unique_id"}}}
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		plant         entities.Plant
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}{
+		{
+			name:  "legacy photo — added to history and file_id saved",
+			plant: entities.Plant{ID: 1, Photo: []byte{0xFF, 0xD8}, CreatedAt: createdAt},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockBot.EXPECT().Send(chat, gomock.AssignableToTypeOf(&telebot.Photo{}), menu).Return(msg, nil)
+
+				// Исходная фотография попадает в историю с датой создания растения
+				mockUsecases.EXPECT().SavePlantPhoto(
+					gomock.Any(),
+					entities.PlantPhoto{PlantID: 1, FileID: "file_id", FileUniqueID: "unique_id", TakenAt: createdAt},
+				).Return(&entities.PlantPhoto{ID: 7}, nil)
+				mockUsecases.EXPECT().UpdatePlantPhoto(gomock.Any(), 1, "file_id", "unique_id").Return(nil, nil)
+			},
+		},
+		{
+			name:  "legacy photo — saving to history fails, bytes kept for retry",
+			plant: entities.Plant{ID: 1, Photo: []byte{0xFF, 0xD8}, CreatedAt: createdAt},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockBot.EXPECT().Send(chat, gomock.AssignableToTypeOf(&telebot.Photo{}), menu).Return(msg, nil)
+				mockUsecases.EXPECT().SavePlantPhoto(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+				mockLogger.EXPECT().Warn(
+					"Failed to save Plant photo to history",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:  "photo with file_id — nothing to save",
+			plant: entities.Plant{ID: 1, PhotoFileID: "file_id"},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockBot.EXPECT().Send(chat, gomock.AssignableToTypeOf(&telebot.Photo{}), menu).Return(msg, nil)
+			},
+		},
+		{
+			name:  "legacy photo — saving file_id fails",
+			plant: entities.Plant{ID: 1, Photo: []byte{0xFF, 0xD8}},
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockBot.EXPECT().Send(chat, gomock.AssignableToTypeOf(&telebot.Photo{}), menu).Return(msg, nil)
+				mockUsecases.EXPECT().SavePlantPhoto(gomock.Any(), gomock.Any()).Return(&entities.PlantPhoto{}, nil)
+				mockUsecases.EXPECT().UpdatePlantPhoto(gomock.Any(), 1, "file_id", "unique_id").Return(nil, assert.AnError)
+				mockLogger.EXPECT().Warn(
+					"Failed to save Plant photo file_id",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "send fails",
+			plant:         entities.Plant{ID: 1, Photo: []byte{0xFF, 0xD8}},
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockBot.EXPECT().Send(chat, gomock.Any(), menu).Return(nil, assert.AnError)
+				mockLogger.EXPECT().Error(
+					"Failed to send message",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBot := mockbot.NewMockBot(ctrl)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+			mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+
+			tt.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+
+			sent, err := sendPlantPhotoMessage(context.Background(), mockCtx, mockUsecases, mockLogger, tt.plant, "caption", menu)
+			if tt.errorExpected {
+				require.Error(t, err)
+				assert.Nil(t, sent)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, msg, sent)
+			}
+		})
+	}
+}
@@ -37,14 +37,14 @@ func TestManagePlantsGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Data().Return(strconv.Itoa(groupID)).Times(1)
 
-				mockUsecases.EXPECT().GetGroupPlants(groupID).Return(plants, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), groupID).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlant).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlant).Return(nil)
 			},
 		},
 		{
@@ -60,14 +60,14 @@ func TestManagePlantsGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Data().Return(strconv.Itoa(groupID)).Times(1)
 
-				mockUsecases.EXPECT().GetGroupPlants(groupID).Return([]entities.Plant{}, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), groupID).Return([]entities.Plant{}, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlant).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlant).Return(nil)
 			},
 		},
 		{
@@ -108,7 +108,7 @@ func TestManagePlantsGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Data().Return(strconv.Itoa(groupID)).Times(1)
 
-				mockUsecases.EXPECT().GetGroupPlants(groupID).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), groupID).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -125,7 +125,7 @@ func TestManagePlantsGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Data().Return(strconv.Itoa(groupID)).Times(1)
 
-				mockUsecases.EXPECT().GetGroupPlants(groupID).Return(plants, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), groupID).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -153,14 +153,14 @@ func TestManagePlantsGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 				mockCtx.EXPECT().Data().Return(strconv.Itoa(groupID)).Times(1)
 
-				mockUsecases.EXPECT().GetGroupPlants(groupID).Return(plants, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), groupID).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlant).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlant).Return(assert.AnError)
 			},
 		},
 	}
@@ -169,7 +169,7 @@ func TestManagePlantsGroupCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -6,12 +6,15 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
 
 func BackToMenu(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -22,13 +25,13 @@ func BackToMenu(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.L
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.ResetTemporary(int(context.Sender().ID)); err != nil {
+		if err = useCases.ResetTemporary(ctx, int(context.Sender().ID)); err != nil {
 			return err
 		}
 
@@ -41,7 +44,7 @@ func BackToMenu(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.L
 			},
 		}
 
-		groupsCount, err := useCases.CountUserGroups(user.ID)
+		groupsCount, err := useCases.CountUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -49,9 +52,10 @@ func BackToMenu(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.L
 		if groupsCount > 0 {
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.CreatePlant})
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.ManageGroups})
+			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.Handover})
 		}
 
-		plantsCount, err := useCases.CountUserPlants(user.ID)
+		plantsCount, err := useCases.CountUserPlants(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -60,6 +64,8 @@ func BackToMenu(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.L
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.ManagePlants})
 		}
 
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.Settings})
+
 		err = context.Send(
 			&telebot.Photo{
 				File:    telebot.FromDisk(paths.StartImage),
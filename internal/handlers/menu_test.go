@@ -30,10 +30,10 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(3, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(3, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -53,10 +53,10 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -76,10 +76,10 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(3, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(3, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -99,10 +99,10 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -132,7 +132,7 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return((*entities.User)(nil), assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return((*entities.User)(nil), assert.AnError)
 			},
 		},
 		{
@@ -145,8 +145,8 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(assert.AnError)
 			},
 		},
 		{
@@ -159,9 +159,9 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -174,10 +174,10 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -192,10 +192,10 @@ func TestBackToMenu(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -215,7 +215,7 @@ func TestBackToMenu(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+)
+
+// OnDocument принимает файлы только на шаге загрузки выгрузки, остальные файлы удаляются.
+func OnDocument(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			// Ошибка уже заллогирована, удаляем сообщение.
+			// Может быть, когда пользователь не жал /start и отправил что-то боту:
+			return Delete(bot, useCases, logger)(context)
+		}
+
+		switch temp.Step {
+		case steps.ImportData:
+			return ImportDocument(bot, useCases, logger)(context)
+		default:
+			return Delete(bot, useCases, logger)(context)
+		}
+	}
+}
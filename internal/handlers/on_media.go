@@ -5,13 +5,16 @@ import (
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 )
 
 func OnMedia(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		// Для календаря используем context.Chat().ID:
-		temp, err := useCases.GetUserTemporary(int(context.Chat().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Chat().ID))
 		if err != nil {
 			// Ошибка уже заллогирована, удаляем сообщение.
 			// Может быть, когда пользователь не жал /start и отправил что-то боту:
@@ -21,6 +24,8 @@ func OnMedia(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Lo
 		switch temp.Step {
 		case steps.AddGroupLastWateringDate:
 			return AddGroupLastWateringDate(bot, useCases, logger)(context)
+		case steps.AddCareTaskLastDate:
+			return AddCareTaskLastDate(bot, useCases, logger)(context)
 		default:
 			return Delete(bot, useCases, logger)(context)
 		}
@@ -5,14 +5,17 @@ import (
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 )
 
 // OnPhoto После добавления бот считает колбэк на калндарь как фото, а не медиа.
 func OnPhoto(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		// Для календаря используем context.Chat().ID. В случае с фото будет равен context.Sender().ID:
-		temp, err := useCases.GetUserTemporary(int(context.Chat().ID))
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Chat().ID))
 		if err != nil {
 			// Ошибка уже заллогирована, удаляем сообщение.
 			// Может быть, когда пользователь не жал /start и отправил что-то боту:
@@ -24,10 +27,22 @@ func OnPhoto(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Lo
 			return AddGroupLastWateringDate(bot, useCases, logger)(context)
 		case steps.ChangeGroupLastWateringDate: // Логика обработки ответа от календаря с сообщением с картинкой
 			return ChangeGroupLastWateringDate(bot, useCases, logger)(context)
+		case steps.AddCareTaskLastDate: // Логика обработки ответа от календаря с сообщением с картинкой
+			return AddCareTaskLastDate(bot, useCases, logger)(context)
+		case steps.ChangeCareTaskLastDate: // Логика обработки ответа от календаря с сообщением с картинкой
+			return ChangeCareTaskLastDate(bot, useCases, logger)(context)
+		case steps.ChangeVacationStart: // Логика обработки ответа от календаря с сообщением с картинкой
+			return ChangeVacationStart(bot, useCases, logger)(context)
+		case steps.ChangeVacationEnd: // Логика обработки ответа от календаря с сообщением с картинкой
+			return ChangeVacationEnd(bot, useCases, logger)(context)
+		case steps.HandoverEndDate: // Логика обработки ответа от календаря с сообщением с картинкой
+			return HandoverEndDate(bot, useCases, logger)(context)
 		case steps.AddPlantPhoto:
 			return AddPlantPhoto(bot, useCases, logger)(context)
 		case steps.ChangePlantPhoto:
 			return ChangePlantPhoto(bot, useCases, logger)(context)
+		case steps.AddPlantGalleryPhoto:
+			return AddPlantGalleryPhoto(bot, useCases, logger)(context)
 		default:
 			return Delete(bot, useCases, logger)(context)
 		}
@@ -5,12 +5,15 @@ import (
 	"gopkg.in/telebot.v4"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 )
 
 func OnText(bot interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
-		temp, err := useCases.GetUserTemporary(int(context.Sender().ID))
+		ctx := middlewares.GetContext(context)
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
 		if err != nil {
 			// Ошибка уже заллогирована, удаляем сообщение.
 			// Может быть, когда пользователь не жал /start и написал что-то боту:
@@ -0,0 +1,418 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+var errPlantPhotoNotFound = errors.New("plant photo not found")
+
+const (
+	// Индекс фотографии в галерее, при котором показывается последний снимок растения:
+	latestPlantPhotoIndex = -1
+)
+
+// PlantGalleryCallback показывает галерею фотографий растения, выбранного в ManagePlantCallback.
+// Номер фотографии передается в данных кнопки пагинации, без данных открывается последний снимок.
+func PlantGalleryCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		var (
+			index = latestPlantPhotoIndex
+			err   error
+		)
+
+		if context.Data() != "" {
+			if index, err = strconv.Atoi(context.Data()); err != nil {
+				logger.Error(
+					"Failed to parse photo index",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		plant, err := getTemporaryPlant(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		return sendPlantGallery(ctx, context, useCases, logger, *plant, index)
+	}
+}
+
+func ManagePlantAddPhotoCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		plant, err := getTemporaryPlant(ctx, int(context.Sender().ID), useCases, logger)
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{
+				{
+					buttons.BackToPlantGallery,
+					buttons.Menu,
+				},
+			},
+		}
+
+		msg, err := sendPlantPhotoMessage(
+			ctx,
+			context,
+			useCases,
+			logger,
+			*plant,
+			fmt.Sprintf(texts.AddPlantGalleryPhoto, plant.Title),
+			menu,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.AddPlantGalleryPhoto, &msg.ID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// AddPlantGalleryPhoto сохраняет присланную фотографию в галерею растения вместе с подписью к ней.
+// Если у растения еще нет обложки, фотография становится обложкой.
+func AddPlantGalleryPhoto(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		if temp.MessageID != nil {
+			err = context.Bot().Delete(&telebot.Message{ID: *temp.MessageID, Chat: context.Chat()})
+			if err != nil {
+				logger.Error(
+					"Failed to delete message",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+		}
+
+		plant, err := temp.GetPlant()
+		if err != nil {
+			logger.Error(
+				"Failed to get Plant from Temporary",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		plant, err = useCases.GetPlant(ctx, plant.ID)
+		if err != nil {
+			return err
+		}
+
+		message := context.Message()
+
+		_, err = useCases.SavePlantPhoto(
+			ctx,
+			entities.PlantPhoto{
+				PlantID:      plant.ID,
+				FileID:       message.Photo.FileID,
+				FileUniqueID: message.Photo.UniqueID,
+				Caption:      message.Caption,
+				TakenAt:      message.Time().UTC(),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		if plant.PhotoFileID == "" && len(plant.Photo) == 0 {
+			plant, err = useCases.UpdatePlantPhoto(ctx, plant.ID, message.Photo.FileID, message.Photo.UniqueID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return sendPlantGallery(ctx, context, useCases, logger, *plant, latestPlantPhotoIndex)
+	}
+}
+
+// SetPlantCoverPhotoCallback делает выбранную в галерее фотографию обложкой растения.
+func SetPlantCoverPhotoCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		photoID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse photoID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		photo, err := useCases.GetPlantPhoto(ctx, photoID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = useCases.UpdatePlantPhoto(ctx, photo.PlantID, photo.FileID, photo.FileUniqueID); err != nil {
+			return err
+		}
+
+		err = context.Respond(
+			&telebot.CallbackResponse{
+				CallbackID: context.Callback().ID,
+				Text:       texts.PlantCoverPhotoUpdated,
+			},
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send Response",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// getTemporaryPlant возвращает актуальные данные растения, выбранного в ManagePlantCallback.
+func getTemporaryPlant(
+	ctx context.Context,
+	telegramID int,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) (*entities.Plant, error) {
+	temp, err := useCases.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	plant, err := temp.GetPlant()
+	if err != nil {
+		logger.Error(
+			"Failed to get Plant from Temporary",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return useCases.GetPlant(ctx, plant.ID)
+}
+
+func sendPlantGallery(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	plant entities.Plant,
+	index int,
+) error {
+	count, err := useCases.CountPlantPhotos(ctx, plant.ID)
+	if err != nil {
+		return err
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	// Пока в галерее нет фотографий, показываем обложку растения:
+	var photo *telebot.Photo
+
+	if count > 0 {
+		if index == latestPlantPhotoIndex {
+			index = count - 1
+		}
+
+		index = min(max(index, 0), count-1)
+
+		photos, err := useCases.GetPlantPhotos(ctx, plant.ID, 1, index)
+		if err != nil {
+			return err
+		}
+
+		if len(photos) == 0 {
+			return errPlantPhotoNotFound
+		}
+
+		caption := ""
+		if photos[0].Caption != "" {
+			caption = fmt.Sprintf(texts.PlantGalleryCaption, photos[0].Caption)
+		}
+
+		photo = &telebot.Photo{
+			File: telebot.File{FileID: photos[0].FileID},
+			Caption: fmt.Sprintf(
+				texts.PlantGallery,
+				plant.Title,
+				index+1,
+				count,
+				photos[0].TakenAt.Format(dateFormat),
+				caption,
+			),
+		}
+
+		var pagination []telebot.InlineButton
+		if index > 0 {
+			pagination = append(
+				pagination,
+				telebot.InlineButton{
+					Unique: buttons.PlantGalleryPage.Unique,
+					Text:   texts.PlantGalleryPreviousPhoto,
+					Data:   strconv.Itoa(index - 1),
+				},
+			)
+		}
+
+		if index < count-1 {
+			pagination = append(
+				pagination,
+				telebot.InlineButton{
+					Unique: buttons.PlantGalleryPage.Unique,
+					Text:   texts.PlantGalleryNextPhoto,
+					Data:   strconv.Itoa(index + 1),
+				},
+			)
+		}
+
+		if len(pagination) > 0 {
+			menu.InlineKeyboard = append(menu.InlineKeyboard, pagination)
+		}
+
+		if photos[0].FileUniqueID != plant.PhotoFileUniqueID {
+			menu.InlineKeyboard = append(
+				menu.InlineKeyboard,
+				[]telebot.InlineButton{
+					{
+						Unique: buttons.SetPlantCoverPhoto.Unique,
+						Text:   texts.SetPlantCoverPhoto,
+						Data:   strconv.Itoa(photos[0].ID),
+					},
+				},
+			)
+		}
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			buttons.ManagePlantAddPhoto,
+		},
+		[]telebot.InlineButton{
+			buttons.BackToManagePlantAction,
+			buttons.Menu,
+		},
+	)
+
+	if photo == nil {
+		err = sendPlantPhoto(
+			ctx,
+			context,
+			useCases,
+			logger,
+			plant,
+			fmt.Sprintf(texts.PlantGalleryEmpty, plant.Title),
+			menu,
+		)
+		if err != nil {
+			return err
+		}
+	} else if err = context.Send(photo, menu); err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.PlantGallery); err != nil {
+		return err
+	}
+
+	return nil
+}
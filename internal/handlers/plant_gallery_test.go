@@ -0,0 +1,386 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlantGalleryCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	plant := &entities.Plant{ID: 20, Title: "Ficus", PhotoFileID: "cover", PhotoFileUniqueID: "cover_unique"}
+	photo := entities.PlantPhoto{
+		ID:           5,
+		PlantID:      20,
+		FileID:       "file",
+		FileUniqueID: "file_unique",
+		Caption:      "Новый лист",
+		TakenAt:      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		data          string
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — latest photo opened by default",
+			errorExpected: false,
+			data:          "",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().CountPlantPhotos(gomock.Any(), 20).Return(3, nil)
+				mockUsecases.EXPECT().GetPlantPhotos(gomock.Any(), 20, 1, 2).Return([]entities.PlantPhoto{photo}, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(p *telebot.Photo) bool {
+						return p.File.FileID == "file" &&
+							strings.Contains(p.Caption, "Фотография 3 из 3") &&
+							strings.Contains(p.Caption, "01.06.2024") &&
+							strings.Contains(p.Caption, "Новый лист")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 4 &&
+							len(menu.InlineKeyboard[0]) == 1 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.PlantGalleryPage.Unique &&
+							menu.InlineKeyboard[0][0].Data == "1" &&
+							menu.InlineKeyboard[1][0].Unique == buttons.SetPlantCoverPhoto.Unique &&
+							menu.InlineKeyboard[1][0].Data == "5" &&
+							menu.InlineKeyboard[2][0].Unique == buttons.ManagePlantAddPhoto.Unique
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.PlantGallery).Return(nil)
+			},
+		},
+		{
+			name:          "success — cover photo in the middle of the timeline",
+			errorExpected: false,
+			data:          "1",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+				cover := photo
+				cover.FileUniqueID = plant.PhotoFileUniqueID
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().CountPlantPhotos(gomock.Any(), 20).Return(3, nil)
+				mockUsecases.EXPECT().GetPlantPhotos(gomock.Any(), 20, 1, 1).Return([]entities.PlantPhoto{cover}, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Any(),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 3 &&
+							len(menu.InlineKeyboard[0]) == 2 &&
+							menu.InlineKeyboard[0][0].Data == "0" &&
+							menu.InlineKeyboard[0][1].Data == "2"
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.PlantGallery).Return(nil)
+			},
+		},
+		{
+			name:          "success — empty gallery",
+			errorExpected: false,
+			data:          "",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().CountPlantPhotos(gomock.Any(), 20).Return(0, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(p *telebot.Photo) bool {
+						return p.File.FileID == "cover" &&
+							strings.Contains(p.Caption, "пока нет фотографий")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 2 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.ManagePlantAddPhoto.Unique
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.PlantGallery).Return(nil)
+			},
+		},
+		{
+			name:          "invalid photo index",
+			errorExpected: true,
+			data:          "invalid",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse photo index",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "count photos fails",
+			errorExpected: true,
+			data:          "",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().CountPlantPhotos(gomock.Any(), 20).Return(0, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Data().Return(tc.data).AnyTimes()
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := PlantGalleryCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddPlantGalleryPhoto(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	chat := &telebot.Chat{ID: 123}
+	messageID := 456
+	takenAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	message := &telebot.Message{
+		Unixtime: takenAt.Unix(),
+		Caption:  "Новый лист",
+		Photo: &telebot.Photo{
+			File: telebot.File{FileID: "file", UniqueID: "file_unique"},
+		},
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — photo saved to gallery",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, MessageID: &messageID, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+				plant := &entities.Plant{ID: 20, Title: "Ficus", PhotoFileID: "cover", PhotoFileUniqueID: "cover_unique"}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Bot().Return(mockBot)
+				mockBot.EXPECT().Delete(&telebot.Message{ID: messageID, Chat: chat}).Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().SavePlantPhoto(
+					gomock.Any(),
+					entities.PlantPhoto{
+						PlantID:      20,
+						FileID:       "file",
+						FileUniqueID: "file_unique",
+						Caption:      "Новый лист",
+						TakenAt:      takenAt,
+					},
+				).Return(&entities.PlantPhoto{ID: 5}, nil)
+
+				mockUsecases.EXPECT().CountPlantPhotos(gomock.Any(), 20).Return(2, nil)
+				mockUsecases.EXPECT().GetPlantPhotos(gomock.Any(), 20, 1, 1).Return(
+					[]entities.PlantPhoto{{ID: 5, FileID: "file", FileUniqueID: "file_unique", TakenAt: takenAt}},
+					nil,
+				)
+
+				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.PlantGallery).Return(nil)
+			},
+		},
+		{
+			name:          "success — first photo becomes plant cover",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+				plant := &entities.Plant{ID: 20, Title: "Ficus"}
+				updatedPlant := &entities.Plant{ID: 20, Title: "Ficus", PhotoFileID: "file", PhotoFileUniqueID: "file_unique"}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().SavePlantPhoto(gomock.Any(), gomock.Any()).Return(&entities.PlantPhoto{ID: 5}, nil)
+				mockUsecases.EXPECT().UpdatePlantPhoto(gomock.Any(), 20, "file", "file_unique").Return(updatedPlant, nil)
+
+				mockUsecases.EXPECT().CountPlantPhotos(gomock.Any(), 20).Return(1, nil)
+				mockUsecases.EXPECT().GetPlantPhotos(gomock.Any(), 20, 1, 0).Return(
+					[]entities.PlantPhoto{{ID: 5, FileID: "file", FileUniqueID: "file_unique", TakenAt: takenAt}},
+					nil,
+				)
+
+				mockCtx.EXPECT().Send(
+					gomock.Any(),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						// Фотография уже обложка, а листать некуда:
+						return len(menu.InlineKeyboard) == 2
+					}),
+				).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.PlantGallery).Return(nil)
+			},
+		},
+		{
+			name:          "save photo fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Plant{ID: 20})}
+
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(&entities.Plant{ID: 20}, nil)
+				mockUsecases.EXPECT().SavePlantPhoto(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+			mockBot := mockbot.NewMockBot(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := AddPlantGalleryPhoto(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetPlantCoverPhotoCallback(t *testing.T) {
+	callback := &telebot.Callback{ID: "callback_123"}
+	photo := &entities.PlantPhoto{ID: 5, PlantID: 20, FileID: "file", FileUniqueID: "file_unique"}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		data          string
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — cover updated",
+			errorExpected: false,
+			data:          "5",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockUsecases.EXPECT().GetPlantPhoto(gomock.Any(), 5).Return(photo, nil)
+				mockUsecases.EXPECT().UpdatePlantPhoto(gomock.Any(), 20, "file", "file_unique").Return(&entities.Plant{ID: 20}, nil)
+
+				mockCtx.EXPECT().Callback().Return(callback)
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       texts.PlantCoverPhotoUpdated,
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "invalid photoID",
+			errorExpected: true,
+			data:          "invalid",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse photoID",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "update plant photo fails",
+			errorExpected: true,
+			data:          "5",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockUsecases.EXPECT().GetPlantPhoto(gomock.Any(), 5).Return(photo, nil)
+				mockUsecases.EXPECT().UpdatePlantPhoto(gomock.Any(), 20, "file", "file_unique").Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Data().Return(tc.data).AnyTimes()
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := SetPlantCoverPhotoCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+func PlantWateredCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		plantID, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse plantID",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		plant, err := useCases.GetPlant(ctx, plantID)
+		if err != nil {
+			return err
+		}
+
+		group, err := useCases.GetGroup(ctx, plant.GroupID)
+		if err != nil {
+			return err
+		}
+
+		user, err := useCases.GetUserByID(ctx, plant.UserID)
+		if err != nil {
+			return err
+		}
+
+		location, err := user.GetLocation()
+		if err != nil {
+			logger.Error(
+				"Failed to load User location",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Дата полива определяется по локальному времени пользователя:
+		now := time.Now().In(location)
+		wateredDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, group.LastWateringDate.Location())
+
+		// Полив записывается на участника дома, который нажал кнопку:
+		member, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		_, err = useCases.UpdatePlantLastWateringDate(
+			ctx,
+			plantID,
+			wateredDate,
+			entities.WateringSourceNotification,
+			member.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if context.Callback() == nil {
+			logger.Warn(
+				"Failed to send Response due to nil callback",
+				"Message", context.Message(),
+				"Sender", context.Sender(),
+				"Chat", context.Chat(),
+				"Callback", context.Callback(),
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return errors.New("failed to send Response due to nil callback")
+		}
+
+		// Убираем из напоминания только кнопку политого растения, чтобы можно было отметить остальные:
+		menu := &telebot.ReplyMarkup{}
+		if context.Message() != nil && context.Message().ReplyMarkup != nil {
+			for _, row := range context.Message().ReplyMarkup.InlineKeyboard {
+				var buttonsRow []telebot.InlineButton
+
+				for _, btn := range row {
+					if btn.Unique == buttons.PlantWatered.Unique && btn.Data == context.Data() {
+						continue
+					}
+
+					buttonsRow = append(buttonsRow, btn)
+				}
+
+				if len(buttonsRow) > 0 {
+					menu.InlineKeyboard = append(menu.InlineKeyboard, buttonsRow)
+				}
+			}
+		}
+
+		if _, err = context.Bot().EditReplyMarkup(context.Message(), menu); err != nil {
+			logger.Error(
+				"Failed to edit ReplyMarkup",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		err = context.Respond(
+			&telebot.CallbackResponse{
+				CallbackID: context.Callback().ID,
+				Text:       fmt.Sprintf(texts.PlantWatered, plant.Title),
+			},
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send Response",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return nil
+	}
+}
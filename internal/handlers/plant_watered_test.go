@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"testing"
+	"time"
+)
+
+func TestPlantWateredCallback(t *testing.T) {
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger)
+	}
+
+	sender := &telebot.User{ID: 123}
+	groupWatered := telebot.InlineButton{Unique: buttons.GroupWatered.Unique, Data: "1"}
+	fernWatered := telebot.InlineButton{Unique: buttons.PlantWatered.Unique, Data: "20"}
+	ficusWatered := telebot.InlineButton{Unique: buttons.PlantWatered.Unique, Data: "21"}
+	message := &telebot.Message{
+		ID: 789,
+		ReplyMarkup: &telebot.ReplyMarkup{
+			InlineKeyboard: [][]telebot.InlineButton{{groupWatered}, {fernWatered}, {ficusWatered}},
+		},
+	}
+	callback := &telebot.Callback{ID: "callback_123", Sender: sender, Message: message}
+
+	plant := &entities.Plant{ID: 20, GroupID: 1, UserID: 1, Title: "Папоротник"}
+	group := &entities.Group{ID: 1, UserID: 1, LastWateringDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — plant watered, only its button removed",
+			errorExpected: false,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("20").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Message().Return(message).AnyTimes()
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 1).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "Europe/Moscow"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 2, TelegramID: 123}, nil)
+				mockUsecases.EXPECT().UpdatePlantLastWateringDate(
+					gomock.Any(),
+					20,
+					gomock.AssignableToTypeOf(time.Time{}),
+					entities.WateringSourceNotification,
+					2,
+				).Return(plant, nil)
+
+				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
+				mockBot.EXPECT().EditReplyMarkup(
+					message,
+					&telebot.ReplyMarkup{
+						InlineKeyboard: [][]telebot.InlineButton{{groupWatered}, {ficusWatered}},
+					},
+				).Return(message, nil)
+
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{
+					CallbackID: callback.ID,
+					Text:       fmt.Sprintf(texts.PlantWatered, plant.Title),
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "parse plantID fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("invalid").AnyTimes()
+
+				mockLogger.EXPECT().Error(
+					"Failed to parse plantID",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "update plant fails",
+			errorExpected: true,
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("20").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+
+				mockUsecases.EXPECT().GetPlant(gomock.Any(), 20).Return(plant, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 1).Return(group, nil)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 1).Return(&entities.User{ID: 1, Timezone: "UTC"}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 2, TelegramID: 123}, nil)
+				mockUsecases.EXPECT().
+					UpdatePlantLastWateringDate(gomock.Any(), 20, gomock.Any(), gomock.Any(), 2).
+					Return(nil, assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+			mockBot := mockbot.NewMockBot(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := PlantWateredCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
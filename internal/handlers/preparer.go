@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/DKhorkov/libs/logging"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
@@ -16,3 +19,18 @@ func Prepare(
 		bot.Handle(cmd, h(bot, useCases, logger))
 	}
 }
+
+// Commands возвращает команды, для которых зарегистрированы обработчики, например "/start".
+func Commands(handlers map[any]interfaces.Handler) []string {
+	var commands []string
+
+	for endpoint := range handlers {
+		if command, ok := endpoint.(string); ok && strings.HasPrefix(command, "/") {
+			commands = append(commands, command)
+		}
+	}
+
+	slices.Sort(commands)
+
+	return commands
+}
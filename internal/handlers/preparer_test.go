@@ -99,3 +99,33 @@ func TestPrepare(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		handlers map[any]interfaces.Handler
+		expected []string
+	}{
+		{
+			name: "only_commands",
+			handlers: map[any]interfaces.Handler{
+				"/start":       nil,
+				"/help":        nil,
+				"btn_menu":     nil,
+				telebot.OnText: nil,
+			},
+			expected: []string{"/help", "/start"},
+		},
+		{
+			name:     "nil_handlers",
+			handlers: nil,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Commands(tt.handlers))
+		})
+	}
+}
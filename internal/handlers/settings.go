@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+const (
+	settingsTimezoneButtonsPerRaw   = 2
+	settingsNotifyHourButtonsPerRaw = 4
+	hoursPerDay                     = 24
+)
+
+// timezones - часовые пояса, доступные для выбора пользователем.
+var timezones = []string{
+	"Europe/Kaliningrad",
+	"Europe/Moscow",
+	"Europe/Samara",
+	"Asia/Yekaterinburg",
+	"Asia/Omsk",
+	"Asia/Novosibirsk",
+	"Asia/Krasnoyarsk",
+	"Asia/Irkutsk",
+	"Asia/Yakutsk",
+	"Asia/Vladivostok",
+	"Asia/Magadan",
+	"Asia/Kamchatka",
+	"UTC",
+}
+
+func SettingsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		return sendSettings(ctx, context, useCases, logger, user)
+	}
+}
+
+func SettingsChangeTimezoneCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{},
+		}
+
+		var row []telebot.InlineButton
+
+		for _, timezone := range timezones {
+			btn := telebot.InlineButton{
+				Unique: buttons.SettingsTimezone.Unique,
+				Text:   timezone,
+				Data:   timezone,
+			}
+
+			row = append(row, btn)
+			if len(row) == settingsTimezoneButtonsPerRaw {
+				menu.InlineKeyboard = append(menu.InlineKeyboard, row)
+				row = []telebot.InlineButton{}
+			}
+		}
+
+		if len(row) > 0 {
+			menu.InlineKeyboard = append(menu.InlineKeyboard, row)
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.BackToSettings,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ChangeTimezoneImage),
+				Caption: fmt.Sprintf(texts.ChangeTimezone, user.Timezone),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeTimezone); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func SettingsTimezoneCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		user, err = useCases.UpdateUserTimezone(ctx, user.ID, context.Data())
+		if err != nil {
+			return err
+		}
+
+		return sendSettings(ctx, context, useCases, logger, user)
+	}
+}
+
+func SettingsChangeNotifyHourCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{},
+		}
+
+		var row []telebot.InlineButton
+
+		for hour := range hoursPerDay {
+			btn := telebot.InlineButton{
+				Unique: buttons.SettingsNotifyHour.Unique,
+				Text:   fmt.Sprintf("%02d:00", hour),
+				Data:   strconv.Itoa(hour),
+			}
+
+			row = append(row, btn)
+			if len(row) == settingsNotifyHourButtonsPerRaw {
+				menu.InlineKeyboard = append(menu.InlineKeyboard, row)
+				row = []telebot.InlineButton{}
+			}
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.BackToSettings,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ChangeNotifyHourImage),
+				Caption: fmt.Sprintf(texts.ChangeNotifyHour, user.NotifyHour),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeNotifyHour); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func SettingsNotifyHourCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		notifyHour, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse notify hour",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		user, err = useCases.UpdateUserNotifyHour(ctx, user.ID, notifyHour)
+		if err != nil {
+			return err
+		}
+
+		return sendSettings(ctx, context, useCases, logger, user)
+	}
+}
+
+// SettingsToggleNotificationsDigestCallback переключает режим уведомлений между отдельными напоминаниями
+// по каждому сценарию и одной сводкой по всем сценариям.
+func SettingsToggleNotificationsDigestCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		user, err = useCases.UpdateUserNotificationsDigest(ctx, user.ID, !user.NotificationsDigest)
+		if err != nil {
+			return err
+		}
+
+		return sendSettings(ctx, context, useCases, logger, user)
+	}
+}
+
+// sendSettings отправляет пользователю экран с его текущими настройками.
+func sendSettings(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	user *entities.User,
+) error {
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{
+			{
+				buttons.SettingsChangeTimezone,
+			},
+			{
+				buttons.SettingsChangeNotifyHour,
+			},
+			{
+				buttons.SettingsToggleNotificationsDigest,
+			},
+			{
+				buttons.SettingsQuiet,
+			},
+			{
+				buttons.SettingsHousehold,
+			},
+			{
+				buttons.Menu,
+			},
+		},
+	}
+
+	err := context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.SettingsImage),
+			Caption: fmt.Sprintf(texts.Settings, user.Timezone, user.NotifyHour, getNotificationsMode(*user)),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.Settings); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getNotificationsMode возвращает текстовое описание выбранного пользователем режима уведомлений.
+func getNotificationsMode(user entities.User) string {
+	if user.NotificationsDigest {
+		return texts.NotificationsModeDigest
+	}
+
+	return texts.NotificationsModeSeparate
+}
@@ -0,0 +1,784 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/calendar"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/utils"
+)
+
+const (
+	// Разделитель начала и окончания тихих часов в данных кнопки:
+	quietHoursDataSeparator = ":"
+)
+
+var (
+	errInvalidQuietHours = errors.New("invalid quiet hours")
+
+	// Дни недели в порядке, привычном пользователю:
+	quietWeekdaysOrder = []time.Weekday{
+		time.Monday,
+		time.Tuesday,
+		time.Wednesday,
+		time.Thursday,
+		time.Friday,
+		time.Saturday,
+		time.Sunday,
+	}
+)
+
+// SettingsQuietCallback показывает настройки тихих часов, дней без уведомлений и отпуска.
+func SettingsQuietCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		return sendQuietSettings(ctx, context, useCases, logger, int(context.Sender().ID), *user)
+	}
+}
+
+func SettingsChangeQuietHoursCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		menu := getQuietHoursMenu(
+			buttons.SettingsQuietHoursStart.Unique,
+			func(hour int) (string, bool) {
+				return strconv.Itoa(hour), true
+			},
+		)
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.SettingsQuietHoursEnd.Unique,
+					Text:   texts.DisableQuietHours,
+					Data:   formatQuietHoursData(0, 0), // Равные границы отключают тихие часы
+				},
+			},
+			[]telebot.InlineButton{
+				buttons.BackToSettingsQuiet,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ChangeQuietHoursImage),
+				Caption: fmt.Sprintf(texts.ChangeQuietHoursStart, getQuietHours(*user)),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeQuietHoursStart); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// SettingsQuietHoursStartCallback запоминает начало тихих часов в данных кнопок выбора их окончания.
+func SettingsQuietHoursStartCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		start, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse quiet hours start",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		menu := getQuietHoursMenu(
+			buttons.SettingsQuietHoursEnd.Unique,
+			func(hour int) (string, bool) {
+				// Окончание, равное началу, отключило бы тихие часы:
+				return formatQuietHoursData(start, hour), hour != start
+			},
+		)
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.SettingsChangeQuietHours,
+			},
+			[]telebot.InlineButton{
+				buttons.BackToSettingsQuiet,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File:    telebot.FromDisk(paths.ChangeQuietHoursImage),
+				Caption: fmt.Sprintf(texts.ChangeQuietHoursEnd, start),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeQuietHoursEnd); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func SettingsQuietHoursEndCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		start, end, err := parseQuietHoursData(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse quiet hours",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		user, err = useCases.UpdateUserQuietHours(ctx, user.ID, start, end)
+		if err != nil {
+			return err
+		}
+
+		return sendQuietSettings(ctx, context, useCases, logger, int(context.Sender().ID), *user)
+	}
+}
+
+func SettingsChangeQuietWeekdaysCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		return sendQuietWeekdays(ctx, context, useCases, logger, *user)
+	}
+}
+
+// SettingsQuietWeekdayCallback включает или выключает уведомления в выбранный день недели.
+func SettingsQuietWeekdayCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		weekday, err := strconv.Atoi(context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse weekday",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		user, err = useCases.UpdateUserQuietWeekdays(ctx, user.ID, user.QuietWeekdays^(1<<weekday))
+		if err != nil {
+			return err
+		}
+
+		return sendQuietWeekdays(ctx, context, useCases, logger, *user)
+	}
+}
+
+func SettingsChangeVacationCallback(
+	bot interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		if err := context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		now := time.Now()
+
+		err := sendVacationCalendar(
+			context,
+			bot,
+			logger,
+			[2]int{now.Year(), now.Year() + 1},
+			texts.ChangeVacationStart,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeVacationStart); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// ChangeVacationStart сохраняет начало отпуска. Пока не выбрано окончание, отпуск не действует.
+func ChangeVacationStart(
+	bot interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		vacationStart, err := time.Parse(dateFormat, context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse vacation start",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Для календаря используем context.Chat().ID:
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Chat().ID))
+		if err != nil {
+			return err
+		}
+
+		if _, err = useCases.UpdateUserVacation(ctx, user.ID, &vacationStart, nil); err != nil {
+			return err
+		}
+
+		err = sendVacationCalendar(
+			context,
+			bot,
+			logger,
+			[2]int{vacationStart.Year(), vacationStart.Year() + 1},
+			fmt.Sprintf(texts.ChangeVacationEnd, vacationStart.Format(dateFormat)),
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Chat().ID), steps.ChangeVacationEnd); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func ChangeVacationEnd(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		vacationEnd, err := time.Parse(dateFormat, context.Data())
+		if err != nil {
+			logger.Error(
+				"Failed to parse vacation end",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		// Для календаря используем context.Chat().ID:
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Chat().ID))
+		if err != nil {
+			return err
+		}
+
+		user, err = useCases.UpdateUserVacation(ctx, user.ID, user.VacationStart, &vacationEnd)
+		if err != nil {
+			if !errors.Is(err, customerrors.ErrInvalidVacation) {
+				return err
+			}
+
+			// Нет context.Callback() для обычного сообщения, поэтому отправляем ответ текстом:
+			if err = context.Send(texts.VacationEndBeforeStart); err != nil {
+				logger.Error(
+					"Failed to send message",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendQuietSettings(ctx, context, useCases, logger, int(context.Chat().ID), *user)
+	}
+}
+
+func SettingsCancelVacationCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		user, err = useCases.UpdateUserVacation(ctx, user.ID, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		return sendQuietSettings(ctx, context, useCases, logger, int(context.Sender().ID), *user)
+	}
+}
+
+// sendQuietSettings отправляет пользователю экран с настройками тихих часов, дней без уведомлений и отпуска.
+func sendQuietSettings(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	telegramID int,
+	user entities.User,
+) error {
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{
+			{
+				buttons.SettingsChangeQuietHours,
+			},
+			{
+				buttons.SettingsChangeQuietWeekdays,
+			},
+			{
+				buttons.SettingsChangeVacation,
+			},
+		},
+	}
+
+	if user.VacationStart != nil {
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.SettingsCancelVacation})
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			buttons.BackToSettings,
+			buttons.Menu,
+		},
+	)
+
+	err := context.Send(
+		&telebot.Photo{
+			File: telebot.FromDisk(paths.QuietSettingsImage),
+			Caption: fmt.Sprintf(
+				texts.QuietSettings,
+				getQuietHours(user),
+				getQuietWeekdays(user),
+				getVacation(user),
+			),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.SetTemporaryStep(ctx, telegramID, steps.QuietSettings); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func sendQuietWeekdays(
+	ctx context.Context,
+	context telebot.Context,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+	user entities.User,
+) error {
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	for _, weekday := range quietWeekdaysOrder {
+		text := utils.GetWeekday(weekday)
+		if user.IsQuietWeekday(weekday) {
+			text = fmt.Sprintf(texts.QuietWeekdaySelected, text)
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				{
+					Unique: buttons.SettingsQuietWeekday.Unique,
+					Text:   text,
+					Data:   strconv.Itoa(int(weekday)),
+				},
+			},
+		)
+	}
+
+	menu.InlineKeyboard = append(
+		menu.InlineKeyboard,
+		[]telebot.InlineButton{
+			buttons.BackToSettingsQuiet,
+			buttons.Menu,
+		},
+	)
+
+	err := context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.ChangeQuietDaysImage),
+			Caption: fmt.Sprintf(texts.ChangeQuietWeekdays, getQuietWeekdays(user)),
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ChangeQuietWeekdays); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func sendVacationCalendar(
+	context telebot.Context,
+	bot interfaces.Bot,
+	logger logging.Logger,
+	yearsRange [2]int,
+	caption string,
+) error {
+	cal, err := calendar.NewCalendar(
+		bot,
+		logger,
+		calendar.WithYearsRange(yearsRange),
+		calendar.WithBackButton(buttons.BackToSettingsQuiet),
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to create calendar",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: cal.GetKeyboard(),
+	}
+
+	err = context.Send(
+		&telebot.Photo{
+			File:    telebot.FromDisk(paths.ChangeVacationImage),
+			Caption: caption,
+		},
+		menu,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to send message",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// getQuietHoursMenu строит клавиатуру выбора часа. Функция data возвращает данные кнопки
+// и признак того, что час можно выбрать.
+func getQuietHoursMenu(unique string, data func(hour int) (string, bool)) *telebot.ReplyMarkup {
+	menu := &telebot.ReplyMarkup{
+		ResizeKeyboard: true,
+		InlineKeyboard: [][]telebot.InlineButton{},
+	}
+
+	var row []telebot.InlineButton
+
+	for hour := range hoursPerDay {
+		hourData, ok := data(hour)
+		if !ok {
+			continue
+		}
+
+		btn := telebot.InlineButton{
+			Unique: unique,
+			Text:   fmt.Sprintf("%02d:00", hour),
+			Data:   hourData,
+		}
+
+		row = append(row, btn)
+		if len(row) == settingsNotifyHourButtonsPerRaw {
+			menu.InlineKeyboard = append(menu.InlineKeyboard, row)
+			row = []telebot.InlineButton{}
+		}
+	}
+
+	if len(row) > 0 {
+		menu.InlineKeyboard = append(menu.InlineKeyboard, row)
+	}
+
+	return menu
+}
+
+// getQuietHours возвращает текстовое описание тихих часов пользователя.
+func getQuietHours(user entities.User) string {
+	if user.QuietHoursStart == user.QuietHoursEnd {
+		return texts.QuietHoursDisabled
+	}
+
+	return fmt.Sprintf(texts.QuietHoursRange, user.QuietHoursStart, user.QuietHoursEnd)
+}
+
+// getQuietWeekdays возвращает перечисление дней недели, в которые пользователь не получает уведомления.
+func getQuietWeekdays(user entities.User) string {
+	var weekdays []string
+
+	for _, weekday := range quietWeekdaysOrder {
+		if user.IsQuietWeekday(weekday) {
+			weekdays = append(weekdays, utils.GetWeekday(weekday))
+		}
+	}
+
+	if len(weekdays) == 0 {
+		return texts.QuietWeekdaysEmpty
+	}
+
+	return strings.Join(weekdays, texts.QuietWeekdaysSeparator)
+}
+
+// getVacation возвращает текстовое описание отпуска пользователя. Отпуск без даты окончания не действует.
+func getVacation(user entities.User) string {
+	if user.VacationStart == nil || user.VacationEnd == nil {
+		return texts.VacationNotPlanned
+	}
+
+	return fmt.Sprintf(texts.VacationRange, user.VacationStart.Format(dateFormat), user.VacationEnd.Format(dateFormat))
+}
+
+func formatQuietHoursData(start, end int) string {
+	return strconv.Itoa(start) + quietHoursDataSeparator + strconv.Itoa(end)
+}
+
+func parseQuietHoursData(data string) (int, int, error) {
+	startData, endData, found := strings.Cut(data, quietHoursDataSeparator)
+	if !found {
+		return 0, 0, errInvalidQuietHours
+	}
+
+	start, err := strconv.Atoi(startData)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := strconv.Atoi(endData)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start, end, nil
+}
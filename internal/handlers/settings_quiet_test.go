@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSettingsQuietHoursStartCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		data          string
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — end hours offered without start hour",
+			errorExpected: false,
+			data:          "22",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "22:00")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						for _, row := range menu.InlineKeyboard {
+							for _, btn := range row {
+								if btn.Data == "22:22" {
+									return false
+								}
+							}
+						}
+
+						return menu.InlineKeyboard[0][0].Unique == buttons.SettingsQuietHoursEnd.Unique &&
+							menu.InlineKeyboard[0][0].Data == "22:0"
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeQuietHoursEnd).Return(nil)
+			},
+		},
+		{
+			name:          "malformed data",
+			errorExpected: true,
+			data:          "night",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse quiet hours start",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Data().Return(tc.data).AnyTimes()
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := SettingsQuietHoursStartCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSettingsQuietHoursEndCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		data          string
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — quiet hours saved",
+			errorExpected: false,
+			data:          "22:8",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().UpdateUserQuietHours(gomock.Any(), 1, 22, 8).Return(
+					&entities.User{ID: 1, TelegramID: 123, QuietHoursStart: 22, QuietHoursEnd: 8},
+					nil,
+				)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "22:00 — 08:00") &&
+							strings.Contains(photo.Caption, texts.VacationNotPlanned)
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						// Кнопка отмены отпуска не нужна, пока он не запланирован:
+						return len(menu.InlineKeyboard) == 4
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.QuietSettings).Return(nil)
+			},
+		},
+		{
+			name:          "update quiet hours fails",
+			errorExpected: true,
+			data:          "22:8",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().UpdateUserQuietHours(gomock.Any(), 1, 22, 8).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "malformed data",
+			errorExpected: true,
+			data:          "22",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse quiet hours",
+					"Error", errInvalidQuietHours,
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Data().Return(tc.data).AnyTimes()
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := SettingsQuietHoursEndCallback(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSettingsQuietWeekdayCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	user := &entities.User{ID: 1, TelegramID: 123, QuietWeekdays: 1 << time.Sunday}
+	weekend := 1<<time.Saturday | 1<<time.Sunday
+
+	ctrl := gomock.NewController(t)
+	mockCtx := newMockContext(ctrl)
+	mockUsecases := mockusecases.NewMockUseCases(ctrl)
+	mockLogger := mocklogging.NewMockLogger(ctrl)
+
+	mockCtx.EXPECT().Data().Return("6").AnyTimes()
+	mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+	mockCtx.EXPECT().Delete().Return(nil)
+
+	mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+	mockUsecases.EXPECT().UpdateUserQuietWeekdays(gomock.Any(), 1, weekend).Return(
+		&entities.User{ID: 1, TelegramID: 123, QuietWeekdays: weekend},
+		nil,
+	)
+
+	mockCtx.EXPECT().Send(
+		gomock.Cond(func(photo *telebot.Photo) bool {
+			return strings.Contains(photo.Caption, "суббота, воскресенье")
+		}),
+		gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+			return len(menu.InlineKeyboard) == len(quietWeekdaysOrder)+1 &&
+				menu.InlineKeyboard[0][0].Text == texts.WeekdayMonday &&
+				menu.InlineKeyboard[5][0].Text == "✅ "+texts.WeekdaySaturday &&
+				menu.InlineKeyboard[6][0].Data == "0"
+		}),
+	).Return(nil)
+
+	mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ChangeQuietWeekdays).Return(nil)
+
+	err := SettingsQuietWeekdayCallback(nil, mockUsecases, mockLogger)(mockCtx)
+	require.NoError(t, err)
+}
+
+func TestChangeVacationEnd(t *testing.T) {
+	chat := &telebot.Chat{ID: 123}
+	vacationStart := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	vacationEnd := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+	user := &entities.User{ID: 1, TelegramID: 123, VacationStart: &vacationStart}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		data          string
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — vacation planned",
+			errorExpected: false,
+			data:          "20.07.2026",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().UpdateUserVacation(gomock.Any(), 1, &vacationStart, &vacationEnd).Return(
+					&entities.User{ID: 1, TelegramID: 123, VacationStart: &vacationStart, VacationEnd: &vacationEnd},
+					nil,
+				)
+
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "10.07.2026 — 20.07.2026")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 5 &&
+							menu.InlineKeyboard[3][0].Unique == buttons.SettingsCancelVacation.Unique
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.QuietSettings).Return(nil)
+			},
+		},
+		{
+			name:          "end before start",
+			errorExpected: false,
+			data:          "01.07.2026",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().UpdateUserVacation(gomock.Any(), 1, &vacationStart, gomock.Any()).Return(
+					nil,
+					customerrors.ErrInvalidVacation,
+				)
+
+				mockCtx.EXPECT().Send(texts.VacationEndBeforeStart).Return(nil)
+			},
+		},
+		{
+			name:          "update vacation fails",
+			errorExpected: true,
+			data:          "20.07.2026",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().UpdateUserVacation(gomock.Any(), 1, &vacationStart, &vacationEnd).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "malformed date",
+			errorExpected: true,
+			data:          "tomorrow",
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockLogger.EXPECT().Error(
+					"Failed to parse vacation end",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockCtx.EXPECT().Data().Return(tc.data).AnyTimes()
+			mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := ChangeVacationEnd(nil, mockUsecases, mockLogger)
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"html"
 	"strconv"
 
 	"github.com/DKhorkov/libs/logging"
@@ -10,7 +12,9 @@ import (
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
@@ -24,6 +28,8 @@ const (
 
 func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete /start message",
@@ -35,6 +41,7 @@ func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger
 		}
 
 		userID, err := useCases.SaveUser(
+			ctx,
 			entities.User{
 				TelegramID: int(context.Sender().ID),
 				Username:   context.Sender().Username,
@@ -47,6 +54,18 @@ func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger
 			return err
 		}
 
+		caption := texts.OnStart
+
+		// Для /start по ссылке-приглашению Data содержит токен приглашения в дом или присматривающего:
+		if token := context.Data(); token != "" {
+			prefix, err := acceptInvite(ctx, useCases, userID, token)
+			if err != nil {
+				return err
+			}
+
+			caption = prefix + caption
+		}
+
 		menu := &telebot.ReplyMarkup{
 			ResizeKeyboard: true,
 			InlineKeyboard: [][]telebot.InlineButton{
@@ -56,7 +75,7 @@ func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger
 			},
 		}
 
-		groupsCount, err := useCases.CountUserGroups(userID)
+		groupsCount, err := useCases.CountUserGroups(ctx, userID)
 		if err != nil {
 			return err
 		}
@@ -64,9 +83,10 @@ func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger
 		if groupsCount > 0 {
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.CreatePlant})
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.ManageGroups})
+			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.Handover})
 		}
 
-		plantsCount, err := useCases.CountUserPlants(userID)
+		plantsCount, err := useCases.CountUserPlants(ctx, userID)
 		if err != nil {
 			return err
 		}
@@ -75,10 +95,12 @@ func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger
 			menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.ManagePlants})
 		}
 
+		menu.InlineKeyboard = append(menu.InlineKeyboard, []telebot.InlineButton{buttons.Settings})
+
 		err = context.Send(
 			&telebot.Photo{
 				File:    telebot.FromDisk(paths.StartImage),
-				Caption: texts.OnStart,
+				Caption: caption,
 			},
 			menu,
 		)
@@ -92,9 +114,8 @@ func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции.
-		// TODO Тут повторяем вне юзкейсов, чтобы работало даже вне повторной регистрации.
-		if err = useCases.ResetTemporary(int(context.Sender().ID)); err != nil {
+		// SaveUser гарантирует наличие временных данных, поэтому сброс не упадет и для повторного /start:
+		if err = useCases.ResetTemporary(ctx, int(context.Sender().ID)); err != nil {
 			return err
 		}
 
@@ -102,14 +123,55 @@ func Start(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger
 	}
 }
 
+// acceptInvite принимает приглашение по токену из /start и возвращает текст с результатом для приветствия.
+func acceptInvite(ctx context.Context, useCases interfaces.UseCases, userID int, token string) (string, error) {
+	if entities.IsDelegationToken(token) {
+		delegation, err := useCases.AcceptDelegation(ctx, userID, token)
+		switch {
+		case err == nil:
+			owner, err := useCases.GetUserByID(ctx, delegation.OwnerID)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf(
+				texts.DelegationAccepted,
+				html.EscapeString(owner.GetDisplayName()),
+				delegation.EndDate.Format(dateFormat),
+			), nil
+		case errors.Is(err, customerrors.ErrDelegationNotFound):
+			return texts.DelegationNotFound, nil
+		case errors.Is(err, customerrors.ErrDelegationExpired):
+			return texts.DelegationExpired, nil
+		case errors.Is(err, customerrors.ErrDelegationToSelf):
+			return texts.DelegationToSelf, nil
+		default:
+			return "", err
+		}
+	}
+
+	switch err := useCases.JoinHousehold(ctx, userID, token); {
+	case err == nil:
+		return texts.HouseholdJoined, nil
+	case errors.Is(err, customerrors.ErrHouseholdInviteNotFound):
+		return texts.HouseholdInviteNotFound, nil
+	case errors.Is(err, customerrors.ErrHouseholdInviteExpired):
+		return texts.HouseholdInviteExpired, nil
+	default:
+		return "", err
+	}
+}
+
 func AddGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		ctx := middlewares.GetContext(context)
+
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
-		groupsCount, err := useCases.CountUserGroups(user.ID)
+		groupsCount, err := useCases.CountUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -185,12 +247,8 @@ func AddGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger log
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddGroupTitle); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.AddGroupTitle, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -200,6 +258,8 @@ func AddGroupCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger log
 
 func AddPlantCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -238,12 +298,8 @@ func AddPlantCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger log
 			return err
 		}
 
-		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.AddPlantTitle); err != nil {
-			return err
-		}
-
-		if err = useCases.SetTemporaryMessage(int(context.Sender().ID), &msg.ID); err != nil {
+		err = useCases.SetTemporaryStepAndMessage(ctx, int(context.Sender().ID), steps.AddPlantTitle, &msg.ID)
+		if err != nil {
 			return err
 		}
 
@@ -253,6 +309,8 @@ func AddPlantCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger log
 
 func ManagePlantsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -263,12 +321,12 @@ func ManagePlantsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
-		groups, err := useCases.GetUserGroups(user.ID)
+		groups, err := useCases.GetUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -277,7 +335,7 @@ func ManagePlantsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger
 		var groupsWithPlants []entities.Group
 
 		for _, group := range groups {
-			plants, err := useCases.GetGroupPlants(group.ID)
+			plants, err := useCases.GetGroupPlants(ctx, group.ID)
 			if err != nil {
 				return err
 			}
@@ -333,7 +391,7 @@ func ManagePlantsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManagePlantsChooseGroup); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManagePlantsChooseGroup); err != nil {
 			return err
 		}
 
@@ -343,6 +401,8 @@ func ManagePlantsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger
 
 func ManageGroupsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger logging.Logger) telebot.HandlerFunc {
 	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
 		if err := context.Delete(); err != nil {
 			logger.Error(
 				"Failed to delete message",
@@ -353,12 +413,12 @@ func ManageGroupsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger
 			return err
 		}
 
-		user, err := useCases.GetUserByTelegramID(int(context.Sender().ID))
+		user, err := useCases.GetUserByTelegramID(ctx, int(context.Sender().ID))
 		if err != nil {
 			return err
 		}
 
-		groups, err := useCases.GetUserGroups(user.ID)
+		groups, err := useCases.GetUserGroups(ctx, user.ID)
 		if err != nil {
 			return err
 		}
@@ -409,7 +469,7 @@ func ManageGroupsCallback(_ interfaces.Bot, useCases interfaces.UseCases, logger
 		}
 
 		// TODO при проблемах логики следует сделать в рамках транзакции
-		if err = useCases.SetTemporaryStep(int(context.Sender().ID), steps.ManageGroup); err != nil {
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.ManageGroup); err != nil {
 			return err
 		}
 
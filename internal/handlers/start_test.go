@@ -1,23 +1,29 @@
 package handlers
 
 import (
+	"fmt"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	"github.com/DKhorkov/libs/pointers"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
 	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gopkg.in/telebot.v4"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestStart(t *testing.T) {
 	tests := []struct {
 		name          string
 		errorExpected bool
+		data          string
 		setupMocks    func(*mockbot.MockBot, *mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
 	}{
 		{
@@ -27,11 +33,132 @@ func TestStart(t *testing.T) {
 				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().SaveUser(gomock.Any()).Return(1, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
 				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name:          "success joined household by invite",
+			errorExpected: false,
+			data:          "invite-token",
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().JoinHousehold(gomock.Any(), 1, "invite-token").Return(nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(1, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.HasPrefix(photo.Caption, texts.HouseholdJoined)
+					}),
+					gomock.Any(),
+				).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name:          "invite not found",
+			errorExpected: false,
+			data:          "invite-token",
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().JoinHousehold(gomock.Any(), 1, "invite-token").Return(customerrors.ErrHouseholdInviteNotFound)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(1, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.HasPrefix(photo.Caption, texts.HouseholdInviteNotFound)
+					}),
+					gomock.Any(),
+				).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name:          "invite expired",
+			errorExpected: false,
+			data:          "invite-token",
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().JoinHousehold(gomock.Any(), 1, "invite-token").Return(customerrors.ErrHouseholdInviteExpired)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(1, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.HasPrefix(photo.Caption, texts.HouseholdInviteExpired)
+					}),
+					gomock.Any(),
+				).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name:          "success accepted plant sitter invite",
+			errorExpected: false,
+			data:          "sitter-token",
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().AcceptDelegation(gomock.Any(), 1, "sitter-token").Return(
+					&entities.Delegation{ID: 5, OwnerID: 2, EndDate: time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)},
+					nil,
+				)
+				mockUsecases.EXPECT().GetUserByID(gomock.Any(), 2).Return(&entities.User{ID: 2, Firstname: "Anna"}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.HasPrefix(photo.Caption, fmt.Sprintf(texts.DelegationAccepted, "Anna", "20.07.2026"))
+					}),
+					gomock.Any(),
+				).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name:          "plant sitter invite not found",
+			errorExpected: false,
+			data:          "sitter-token",
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().AcceptDelegation(gomock.Any(), 1, "sitter-token").Return(nil, customerrors.ErrDelegationNotFound)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.HasPrefix(photo.Caption, texts.DelegationNotFound)
+					}),
+					gomock.Any(),
+				).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
+			},
+		},
+		{
+			name:          "join household fails",
+			errorExpected: true,
+			data:          "invite-token",
+			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().JoinHousehold(gomock.Any(), 1, "invite-token").Return(assert.AnError)
 			},
 		},
 		{
@@ -41,8 +168,8 @@ func TestStart(t *testing.T) {
 				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().SaveUser(gomock.Any()).Return(1, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, assert.AnError)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -52,9 +179,9 @@ func TestStart(t *testing.T) {
 				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().SaveUser(gomock.Any()).Return(1, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, assert.AnError)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -64,11 +191,11 @@ func TestStart(t *testing.T) {
 				sender := &telebot.User{ID: 123, Username: "testuser", FirstName: "Test", LastName: "User", IsBot: false}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().SaveUser(gomock.Any()).Return(1, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
 				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(assert.AnError)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(assert.AnError)
 			},
 		},
 		{
@@ -85,7 +212,7 @@ func TestStart(t *testing.T) {
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				mockCtx.EXPECT().Sender().Return(&telebot.User{ID: 123}).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().SaveUser(gomock.Any()).Return(0, assert.AnError)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -95,9 +222,9 @@ func TestStart(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().SaveUser(gomock.Any()).Return(1, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(0, nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(0, nil)
 				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(assert.AnError)
 				mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).MinTimes(1)
 			},
@@ -109,11 +236,11 @@ func TestStart(t *testing.T) {
 				sender := &telebot.User{ID: 123}
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
-				mockUsecases.EXPECT().SaveUser(gomock.Any()).Return(1, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(2, nil)
-				mockUsecases.EXPECT().CountUserPlants(1).Return(5, nil)
+				mockUsecases.EXPECT().SaveUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(2, nil)
+				mockUsecases.EXPECT().CountUserPlants(gomock.Any(), 1).Return(5, nil)
 				mockCtx.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
-				mockUsecases.EXPECT().ResetTemporary(123).Return(nil)
+				mockUsecases.EXPECT().ResetTemporary(gomock.Any(), 123).Return(nil)
 			},
 		},
 	}
@@ -122,12 +249,14 @@ func TestStart(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
 			handler := Start(mockBot, mockUsecases, mockLogger)
 
+			mockCtx.EXPECT().Data().Return(tt.data).AnyTimes()
+
 			if tt.setupMocks != nil {
 				tt.setupMocks(mockBot, mockCtx, mockUsecases, mockLogger)
 			}
@@ -165,10 +294,10 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 
 				// UseCases: GetUserByTelegramID → *User
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
 
 				// CountUserGroups → не достигнут лимит
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
 
 				// Успешное удаление сообщения
 				mockCtx.EXPECT().Delete().Return(nil)
@@ -182,9 +311,8 @@ func TestAddGroupCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(&telebot.Message{ID: 789}, nil)
 
-				// SetTemporaryStep и SetTemporaryMessage
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.AddGroupTitle).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, pointers.New(789)).Return(nil)
+				// SetTemporaryStepAndMessage
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.AddGroupTitle, pointers.New(789)).Return(nil)
 			},
 		},
 		{
@@ -201,8 +329,8 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
 
 				// Удаление падает
 				mockCtx.EXPECT().Delete().Return(assert.AnError)
@@ -228,7 +356,7 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 
 				// Ошибка получения пользователя
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return((*entities.User)(nil), assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return((*entities.User)(nil), assert.AnError)
 			},
 		},
 		{
@@ -245,8 +373,8 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, assert.AnError)
 			},
 		},
 		{
@@ -262,8 +390,8 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 				mockCtx.EXPECT().Chat().Return(&telebot.Chat{ID: 456}).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(groupsPerUserLimit, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(groupsPerUserLimit, nil)
 
 				// Respond успешный
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(nil)
@@ -284,8 +412,8 @@ func TestAddGroupCallback(t *testing.T) {
 				// Callback == nil
 				mockCtx.EXPECT().Callback().Return((*telebot.Callback)(nil)).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(groupsPerUserLimit, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(groupsPerUserLimit, nil)
 
 				// Логируем предупреждение
 				mockLogger.EXPECT().Warn(
@@ -311,8 +439,8 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 				mockCtx.EXPECT().Chat().Return(&telebot.Chat{ID: 456}).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(groupsPerUserLimit, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(groupsPerUserLimit, nil)
 
 				// Respond возвращает ошибку
 				mockCtx.EXPECT().Respond(gomock.AssignableToTypeOf(&telebot.CallbackResponse{})).Return(assert.AnError)
@@ -339,8 +467,8 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				mockCtx.EXPECT().Bot().Return(mockBot)
@@ -361,7 +489,7 @@ func TestAddGroupCallback(t *testing.T) {
 			},
 		},
 		{
-			name:          "set temporary step fails",
+			name:          "set temporary step and message fails",
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
@@ -374,8 +502,8 @@ func TestAddGroupCallback(t *testing.T) {
 				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
 				mockCtx.EXPECT().Message().Return(message).AnyTimes()
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(&entities.User{ID: 1}, nil)
+				mockUsecases.EXPECT().CountUserGroups(gomock.Any(), 1).Return(0, nil)
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				mockCtx.EXPECT().Bot().Return(mockBot)
@@ -386,38 +514,8 @@ func TestAddGroupCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(&telebot.Message{ID: 789}, nil)
 
-				// SetTemporaryStep падает
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.AddGroupTitle).Return(assert.AnError)
-			},
-		},
-		{
-			name:          "set temporary message fails",
-			errorExpected: true,
-			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
-				sender := &telebot.User{ID: 123}
-				chat := &telebot.Chat{ID: 456}
-				message := &telebot.Message{ID: 789}
-				callback := &telebot.Callback{ID: "abc", Message: message}
-
-				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
-				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
-				mockCtx.EXPECT().Message().Return(message).AnyTimes()
-
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(&entities.User{ID: 1}, nil)
-				mockUsecases.EXPECT().CountUserGroups(1).Return(0, nil)
-				mockCtx.EXPECT().Delete().Return(nil)
-
-				mockCtx.EXPECT().Bot().Return(mockBot)
-
-				mockBot.EXPECT().Send(
-					chat,
-					gomock.AssignableToTypeOf(&telebot.Photo{}),
-					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
-				).Return(&telebot.Message{ID: 789}, nil)
-
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.AddGroupTitle).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, pointers.New(789)).Return(assert.AnError)
+				// SetTemporaryStepAndMessage падает
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.AddGroupTitle, pointers.New(789)).Return(assert.AnError)
 			},
 		},
 	}
@@ -426,7 +524,7 @@ func TestAddGroupCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -473,8 +571,7 @@ func TestAddPlantCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(message, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.AddPlantTitle).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, pointers.New(789)).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.AddPlantTitle, pointers.New(789)).Return(nil)
 			},
 		},
 		{
@@ -517,30 +614,7 @@ func TestAddPlantCallback(t *testing.T) {
 			},
 		},
 		{
-			name:          "set temporary step fails",
-			errorExpected: true,
-			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
-				sender := &telebot.User{ID: 123}
-				chat := &telebot.Chat{ID: 456}
-				message := &telebot.Message{ID: 789}
-
-				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
-				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
-				mockCtx.EXPECT().Bot().Return(mockBot).AnyTimes()
-
-				mockCtx.EXPECT().Delete().Return(nil)
-
-				mockBot.EXPECT().Send(
-					chat,
-					gomock.AssignableToTypeOf(&telebot.Photo{}),
-					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
-				).Return(message, nil)
-
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.AddPlantTitle).Return(assert.AnError)
-			},
-		},
-		{
-			name:          "set temporary message fails",
+			name:          "set temporary step and message fails",
 			errorExpected: true,
 			setupMocks: func(mockBot *mockbot.MockBot, mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
 				sender := &telebot.User{ID: 123}
@@ -559,8 +633,7 @@ func TestAddPlantCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(message, nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.AddPlantTitle).Return(nil)
-				mockUsecases.EXPECT().SetTemporaryMessage(123, pointers.New(789)).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStepAndMessage(gomock.Any(), 123, steps.AddPlantTitle, pointers.New(789)).Return(assert.AnError)
 			},
 		},
 	}
@@ -569,7 +642,7 @@ func TestAddPlantCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -614,19 +687,19 @@ func TestManagePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
 
 				// Мокаем GetGroupPlants для каждой группы
-				mockUsecases.EXPECT().GetGroupPlants(1).Return(plants1, nil)
-				mockUsecases.EXPECT().GetGroupPlants(2).Return(plants2, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(plants1, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 2).Return(plants2, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantsChooseGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantsChooseGroup).Return(nil)
 			},
 		},
 		{
@@ -645,16 +718,16 @@ func TestManagePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
-				mockUsecases.EXPECT().GetGroupPlants(1).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantsChooseGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantsChooseGroup).Return(nil)
 			},
 		},
 		{
@@ -679,7 +752,7 @@ func TestManagePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return((*entities.User)(nil), assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return((*entities.User)(nil), assert.AnError)
 			},
 		},
 		{
@@ -692,8 +765,8 @@ func TestManagePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -707,9 +780,9 @@ func TestManagePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
-				mockUsecases.EXPECT().GetGroupPlants(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -726,9 +799,9 @@ func TestManagePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
-				mockUsecases.EXPECT().GetGroupPlants(1).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -756,16 +829,16 @@ func TestManagePlantsCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
-				mockUsecases.EXPECT().GetGroupPlants(1).Return(plants, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
+				mockUsecases.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(plants, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManagePlantsChooseGroup).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManagePlantsChooseGroup).Return(assert.AnError)
 			},
 		},
 	}
@@ -774,7 +847,7 @@ func TestManagePlantsCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -819,10 +892,10 @@ func TestManageGroupsCallback(t *testing.T) {
 				mockCtx.EXPECT().Delete().Return(nil)
 
 				// UseCases: GetUserByTelegramID
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
 
 				// GetUserGroups
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
 
 				// context.Send — с фото и меню
 				mockCtx.EXPECT().Send(
@@ -831,7 +904,7 @@ func TestManageGroupsCallback(t *testing.T) {
 				).Return(nil)
 
 				// SetTemporaryStep
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroup).Return(nil)
 			},
 		},
 		{
@@ -847,8 +920,8 @@ func TestManageGroupsCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
 
 				// Даже без групп — отправляем меню с одной кнопкой BackToStart
 				mockCtx.EXPECT().Send(
@@ -856,7 +929,7 @@ func TestManageGroupsCallback(t *testing.T) {
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroup).Return(nil)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroup).Return(nil)
 			},
 		},
 		{
@@ -881,7 +954,7 @@ func TestManageGroupsCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return((*entities.User)(nil), assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return((*entities.User)(nil), assert.AnError)
 			},
 		},
 		{
@@ -894,8 +967,8 @@ func TestManageGroupsCallback(t *testing.T) {
 				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(nil, assert.AnError)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -911,8 +984,8 @@ func TestManageGroupsCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
@@ -939,15 +1012,15 @@ func TestManageGroupsCallback(t *testing.T) {
 				mockCtx.EXPECT().Chat().Return(chat).AnyTimes()
 				mockCtx.EXPECT().Delete().Return(nil)
 
-				mockUsecases.EXPECT().GetUserByTelegramID(123).Return(user, nil)
-				mockUsecases.EXPECT().GetUserGroups(1).Return(groups, nil)
+				mockUsecases.EXPECT().GetUserByTelegramID(gomock.Any(), 123).Return(user, nil)
+				mockUsecases.EXPECT().GetUserGroups(gomock.Any(), 1).Return(groups, nil)
 
 				mockCtx.EXPECT().Send(
 					gomock.AssignableToTypeOf(&telebot.Photo{}),
 					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
 				).Return(nil)
 
-				mockUsecases.EXPECT().SetTemporaryStep(123, steps.ManageGroup).Return(assert.AnError)
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.ManageGroup).Return(assert.AnError)
 			},
 		},
 	}
@@ -956,7 +1029,7 @@ func TestManageGroupsCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockBot := mockbot.NewMockBot(ctrl)
-			mockCtx := mockbot.NewMockContext(ctrl)
+			mockCtx := newMockContext(ctrl)
 			mockUsecases := mockusecases.NewMockUseCases(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/paths"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+const (
+	wateringHistoryPageSize = 10
+)
+
+var wateringSources = map[string]string{
+	entities.WateringSourceNotification: texts.WateringSourceNotification,
+	entities.WateringSourceManual:       texts.WateringSourceManual,
+	entities.WateringSourceBackfill:     texts.WateringSourceBackfill,
+}
+
+// WateringHistoryCallback показывает историю поливов сценария, выбранного в ManageGroupCallback.
+// Номер страницы передается в данных кнопки пагинации, первая страница открывается без данных.
+func WateringHistoryCallback(
+	_ interfaces.Bot,
+	useCases interfaces.UseCases,
+	logger logging.Logger,
+) telebot.HandlerFunc {
+	return func(context telebot.Context) error {
+		ctx := middlewares.GetContext(context)
+
+		var (
+			page int
+			err  error
+		)
+
+		if context.Data() != "" {
+			if page, err = strconv.Atoi(context.Data()); err != nil {
+				logger.Error(
+					"Failed to parse page",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+		}
+
+		if err = context.Delete(); err != nil {
+			logger.Error(
+				"Failed to delete message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		temp, err := useCases.GetUserTemporary(ctx, int(context.Sender().ID))
+		if err != nil {
+			return err
+		}
+
+		group, err := temp.GetGroup()
+		if err != nil {
+			logger.Error(
+				"Failed to get Group from Temporary",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		group, err = useCases.GetGroup(ctx, group.ID)
+		if err != nil {
+			return err
+		}
+
+		count, err := useCases.CountGroupWaterings(ctx, group.ID)
+		if err != nil {
+			return err
+		}
+
+		pagesCount := max((count+wateringHistoryPageSize-1)/wateringHistoryPageSize, 1)
+		page = min(max(page, 0), pagesCount-1)
+
+		waterings, err := useCases.GetGroupWaterings(ctx, group.ID, wateringHistoryPageSize, page*wateringHistoryPageSize)
+		if err != nil {
+			return err
+		}
+
+		history := texts.WateringHistoryEmpty
+		if len(waterings) > 0 {
+			var builder strings.Builder
+			for _, watering := range waterings {
+				builder.WriteString(
+					fmt.Sprintf(
+						texts.WateringHistoryEntry,
+						watering.WateredAt.Format(dateFormat),
+						wateringSources[watering.Source],
+					),
+				)
+			}
+
+			history = builder.String()
+		}
+
+		menu := &telebot.ReplyMarkup{
+			ResizeKeyboard: true,
+			InlineKeyboard: [][]telebot.InlineButton{},
+		}
+
+		var pagination []telebot.InlineButton
+		if page > 0 {
+			pagination = append(
+				pagination,
+				telebot.InlineButton{
+					Unique: buttons.WateringHistoryPage.Unique,
+					Text:   texts.WateringHistoryPreviousPage,
+					Data:   strconv.Itoa(page - 1),
+				},
+			)
+		}
+
+		if page < pagesCount-1 {
+			pagination = append(
+				pagination,
+				telebot.InlineButton{
+					Unique: buttons.WateringHistoryPage.Unique,
+					Text:   texts.WateringHistoryNextPage,
+					Data:   strconv.Itoa(page + 1),
+				},
+			)
+		}
+
+		if len(pagination) > 0 {
+			menu.InlineKeyboard = append(menu.InlineKeyboard, pagination)
+		}
+
+		menu.InlineKeyboard = append(
+			menu.InlineKeyboard,
+			[]telebot.InlineButton{
+				buttons.BackToManageGroupAction,
+				buttons.Menu,
+			},
+		)
+
+		err = context.Send(
+			&telebot.Photo{
+				File: telebot.FromDisk(paths.WateringHistoryImage),
+				Caption: fmt.Sprintf(
+					texts.WateringHistory,
+					group.Title,
+					page+1,
+					pagesCount,
+					history,
+				),
+			},
+			menu,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to send message",
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return err
+		}
+
+		if err = useCases.SetTemporaryStep(ctx, int(context.Sender().ID), steps.WateringHistory); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
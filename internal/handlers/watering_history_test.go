@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/buttons"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	mockusecases "github.com/DKhorkov/plantsCareTelegramBot/mocks/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWateringHistoryCallback(t *testing.T) {
+	sender := &telebot.User{ID: 123}
+	group := &entities.Group{ID: 10, Title: "Garden"}
+	waterings := []entities.Watering{
+		{ID: 2, GroupID: 10, WateredAt: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC), Source: entities.WateringSourceNotification},
+		{ID: 1, GroupID: 10, WateredAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Source: entities.WateringSourceManual},
+	}
+
+	type testCase struct {
+		name          string
+		errorExpected bool
+		setupMocks    func(*mockbot.MockContext, *mockusecases.MockUseCases, *mocklogging.MockLogger)
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:          "success — first page with next page button",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Data().Return("").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().CountGroupWaterings(gomock.Any(), 10).Return(15, nil)
+				mockUsecases.EXPECT().GetGroupWaterings(gomock.Any(), 10, wateringHistoryPageSize, 0).Return(waterings, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "страница 1 из 2") &&
+							strings.Contains(photo.Caption, "08.06.2024 — "+texts.WateringSourceNotification) &&
+							strings.Contains(photo.Caption, "01.06.2024 — "+texts.WateringSourceManual)
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 2 &&
+							len(menu.InlineKeyboard[0]) == 1 &&
+							menu.InlineKeyboard[0][0].Unique == buttons.WateringHistoryPage.Unique &&
+							menu.InlineKeyboard[0][0].Data == "1"
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.WateringHistory).Return(nil)
+			},
+		},
+		{
+			name:          "success — last page with previous page button",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Data().Return("1").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().CountGroupWaterings(gomock.Any(), 10).Return(15, nil)
+				mockUsecases.EXPECT().GetGroupWaterings(gomock.Any(), 10, wateringHistoryPageSize, wateringHistoryPageSize).Return(waterings, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, "страница 2 из 2")
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 2 &&
+							len(menu.InlineKeyboard[0]) == 1 &&
+							menu.InlineKeyboard[0][0].Data == "0"
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.WateringHistory).Return(nil)
+			},
+		},
+		{
+			name:          "success — empty history without pagination",
+			errorExpected: false,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Data().Return("").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().CountGroupWaterings(gomock.Any(), 10).Return(0, nil)
+				mockUsecases.EXPECT().GetGroupWaterings(gomock.Any(), 10, wateringHistoryPageSize, 0).Return(nil, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.Cond(func(photo *telebot.Photo) bool {
+						return strings.Contains(photo.Caption, texts.WateringHistoryEmpty)
+					}),
+					gomock.Cond(func(menu *telebot.ReplyMarkup) bool {
+						return len(menu.InlineKeyboard) == 1
+					}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.WateringHistory).Return(nil)
+			},
+		},
+		{
+			name:          "invalid page data",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("invalid").AnyTimes()
+				mockLogger.EXPECT().Error(
+					"Failed to parse page",
+					"Error", gomock.Any(),
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "delete message fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				mockCtx.EXPECT().Data().Return("").AnyTimes()
+				mockCtx.EXPECT().Delete().Return(assert.AnError)
+				mockLogger.EXPECT().Error(
+					"Failed to delete message",
+					"Error", assert.AnError,
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "count waterings fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Data().Return("").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().CountGroupWaterings(gomock.Any(), 10).Return(0, assert.AnError)
+			},
+		},
+		{
+			name:          "send message fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Data().Return("").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().CountGroupWaterings(gomock.Any(), 10).Return(2, nil)
+				mockUsecases.EXPECT().GetGroupWaterings(gomock.Any(), 10, wateringHistoryPageSize, 0).Return(waterings, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.AssignableToTypeOf(&telebot.Photo{}),
+					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
+				).Return(assert.AnError)
+				mockLogger.EXPECT().Error(
+					"Failed to send message",
+					"Error", assert.AnError,
+					"Tracing", gomock.Any(),
+				).Times(1)
+			},
+		},
+		{
+			name:          "set temporary step fails",
+			errorExpected: true,
+			setupMocks: func(mockCtx *mockbot.MockContext, mockUsecases *mockusecases.MockUseCases, mockLogger *mocklogging.MockLogger) {
+				temp := &entities.Temporary{UserID: 123, Data: mustMarshal(t, &entities.Group{ID: 10})}
+
+				mockCtx.EXPECT().Data().Return("").AnyTimes()
+				mockCtx.EXPECT().Sender().Return(sender).AnyTimes()
+				mockCtx.EXPECT().Delete().Return(nil)
+
+				mockUsecases.EXPECT().GetUserTemporary(gomock.Any(), 123).Return(temp, nil)
+				mockUsecases.EXPECT().GetGroup(gomock.Any(), 10).Return(group, nil)
+				mockUsecases.EXPECT().CountGroupWaterings(gomock.Any(), 10).Return(2, nil)
+				mockUsecases.EXPECT().GetGroupWaterings(gomock.Any(), 10, wateringHistoryPageSize, 0).Return(waterings, nil)
+
+				mockCtx.EXPECT().Send(
+					gomock.AssignableToTypeOf(&telebot.Photo{}),
+					gomock.AssignableToTypeOf(&telebot.ReplyMarkup{}),
+				).Return(nil)
+
+				mockUsecases.EXPECT().SetTemporaryStep(gomock.Any(), 123, steps.WateringHistory).Return(assert.AnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := newMockContext(ctrl)
+			mockUsecases := mockusecases.NewMockUseCases(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCtx, mockUsecases, mockLogger)
+			}
+
+			handler := WateringHistoryCallback(nil, mockUsecases, mockLogger)
+
+			err := handler(mockCtx)
+
+			if tc.errorExpected {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ type Bot interface {
 	Start()
 	Stop()
 	ProcessUpdate(u telebot.Update)
+	Username() string
 	telebot.API
 }
 
@@ -1,10 +1,12 @@
 package interfaces
 
-type Callback = func() error
+import "context"
+
+type Callback = func(ctx context.Context) error
 
 //go:generate mockgen -source=cron.go -destination=../../mocks/cron/cron.go -package=mockcron
 type Cron interface {
-	Run() error
+	Run(ctx context.Context) error
 	Stop() error
 }
 
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=server.go -destination=../../mocks/server/server.go -package=mockserver
+type Server interface {
+	Run() error
+	Stop() error
+	SetReady(ready bool)
+}
+
+// DatabasePinger проверяет доступность БД.
+type DatabasePinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// TelegramChecker проверяет доступность Telegram Bot API.
+type TelegramChecker interface {
+	GetMe() error
+	LastGetMe() time.Time
+}
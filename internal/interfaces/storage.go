@@ -1,44 +1,105 @@
 package interfaces
 
-import "github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
 
 //go:generate mockgen -source=storage.go -destination=../../mocks/storage/storage.go -package=mockstorage
 type Storage interface {
+	// WithTx выполняет fn в транзакции, фиксируя все изменения tx вместе или откатывая их при ошибке:
+	WithTx(ctx context.Context, fn func(tx Storage) error) error
+
 	// Users:
 
-	SaveUser(user entities.User) (int, error)
-	GetUserByID(id int) (*entities.User, error)
-	GetUserByTelegramID(telegramID int) (*entities.User, error)
+	SaveUser(ctx context.Context, user entities.User) (int, error)
+	GetUserByID(ctx context.Context, id int) (*entities.User, error)
+	GetUserByTelegramID(ctx context.Context, telegramID int) (*entities.User, error)
+	UpdateUser(ctx context.Context, user entities.User) error
+	ClaimUserDigest(ctx context.Context, userID int, claimTTL time.Duration) (bool, error)
 
 	// Temporary:
 
-	CreateTemporary(temp entities.Temporary) error
-	UpdateTemporary(temp entities.Temporary) error
-	GetTemporaryByUserID(userID int) (*entities.Temporary, error)
+	CreateTemporary(ctx context.Context, temp entities.Temporary) error
+	UpdateTemporary(ctx context.Context, temp entities.Temporary) error
+	GetTemporaryByUserID(ctx context.Context, userID int) (*entities.Temporary, error)
 
 	// Groups:
 
-	CreateGroup(group entities.Group) (int, error)
-	UpdateGroup(group entities.Group) error
-	GroupExists(group entities.Group) (bool, error)
-	DeleteGroup(id int) error
-	GetUserGroups(userID int) ([]entities.Group, error)
-	CountUserGroups(userID int) (int, error)
-	GetGroup(id int) (*entities.Group, error)
-	GetGroupsForNotify(limit, offset int) ([]entities.Group, error)
+	CreateGroup(ctx context.Context, group entities.Group) (int, error)
+	UpdateGroup(ctx context.Context, group entities.Group) error
+	GroupExists(ctx context.Context, group entities.Group) (bool, error)
+	DeleteGroup(ctx context.Context, id int) error
+	GetUserGroups(ctx context.Context, userID int) ([]entities.Group, error)
+	CountUserGroups(ctx context.Context, userID int) (int, error)
+	GetGroup(ctx context.Context, id int) (*entities.Group, error)
+	GetGroupsForNotify(ctx context.Context, limit, offset int) ([]entities.Group, error)
+	ClaimGroupsForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.Group, error)
 
 	// Plants:
 
-	CreatePlant(plant entities.Plant) (int, error)
-	UpdatePlant(plant entities.Plant) error
-	PlantExists(plant entities.Plant) (bool, error)
-	DeletePlant(id int) error
-	CountUserPlants(userID int) (int, error)
-	GetGroupPlants(groupID int) ([]entities.Plant, error)
-	CountGroupPlants(groupID int) (int, error)
-	GetPlant(id int) (*entities.Plant, error)
+	CreatePlant(ctx context.Context, plant entities.Plant) (int, error)
+	UpdatePlant(ctx context.Context, plant entities.Plant) error
+	PlantExists(ctx context.Context, plant entities.Plant) (bool, error)
+	DeletePlant(ctx context.Context, id int) error
+	CountUserPlants(ctx context.Context, userID int) (int, error)
+	GetGroupPlants(ctx context.Context, groupID int) ([]entities.Plant, error)
+	CountGroupPlants(ctx context.Context, groupID int) (int, error)
+	GetPlant(ctx context.Context, id int) (*entities.Plant, error)
 
 	// Notifications:
 
-	SaveNotification(notification entities.Notification) (int, error)
+	SaveNotification(ctx context.Context, notification entities.Notification) (int, error)
+	GetLastNotification(ctx context.Context, groupID int) (*entities.Notification, error)
+	GetLastCareTaskNotification(ctx context.Context, careTaskID int) (*entities.Notification, error)
+	GetGroupNotificationsSince(ctx context.Context, groupID int, since time.Time) ([]entities.Notification, error)
+	GetLastCareTaskNotifications(ctx context.Context, careTaskID int) ([]entities.Notification, error)
+	GetMessageNotifications(ctx context.Context, chatID int64, messageID int) ([]entities.Notification, error)
+	AcknowledgeGroupNotifications(ctx context.Context, groupID int, acknowledgedAt time.Time) error
+
+	// Waterings:
+
+	SaveWatering(ctx context.Context, watering entities.Watering) (int, error)
+	GetGroupWaterings(ctx context.Context, groupID, limit, offset int) ([]entities.Watering, error)
+	CountGroupWaterings(ctx context.Context, groupID int) (int, error)
+
+	// Care tasks:
+
+	CreateCareTask(ctx context.Context, careTask entities.CareTask) (int, error)
+	UpdateCareTask(ctx context.Context, careTask entities.CareTask) error
+	DeleteCareTask(ctx context.Context, id int) error
+	GetCareTask(ctx context.Context, id int) (*entities.CareTask, error)
+	GetGroupCareTasks(ctx context.Context, groupID int) ([]entities.CareTask, error)
+	GetPlantCareTasks(ctx context.Context, plantID int) ([]entities.CareTask, error)
+	ClaimCareTasksForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.CareTask, error)
+
+	// Plant photos:
+
+	SavePlantPhoto(ctx context.Context, photo entities.PlantPhoto) (int, error)
+	GetPlantPhoto(ctx context.Context, id int) (*entities.PlantPhoto, error)
+	GetPlantPhotos(ctx context.Context, plantID, limit, offset int) ([]entities.PlantPhoto, error)
+	CountPlantPhotos(ctx context.Context, plantID int) (int, error)
+
+	// Households:
+
+	CreateHousehold(ctx context.Context, ownerID int) (int, error)
+	GetUserHousehold(ctx context.Context, userID int) (*entities.Household, error)
+	AddHouseholdMember(ctx context.Context, householdID, userID int) error
+	DeleteHouseholdMember(ctx context.Context, userID int) error
+	GetHouseholdUsers(ctx context.Context, userID int) ([]entities.User, error)
+	CreateHouseholdInvite(ctx context.Context, invite entities.HouseholdInvite) (int, error)
+	GetHouseholdInvite(ctx context.Context, token string) (*entities.HouseholdInvite, error)
+	DeleteHouseholdInvite(ctx context.Context, id int) error
+
+	// Delegations:
+
+	CreateDelegation(ctx context.Context, delegation entities.Delegation) (int, error)
+	GetDelegation(ctx context.Context, id int) (*entities.Delegation, error)
+	GetDelegationByToken(ctx context.Context, token string) (*entities.Delegation, error)
+	GetGroupDelegation(ctx context.Context, groupID int, date time.Time) (*entities.Delegation, error)
+	GetOwnerDelegations(ctx context.Context, ownerID int, date time.Time) ([]entities.Delegation, error)
+	UpdateDelegationSitter(ctx context.Context, id, sitterID int) error
+	DeleteDelegation(ctx context.Context, id int) error
 }
@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"context"
 	"time"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
@@ -10,56 +11,151 @@ import (
 type UseCases interface {
 	// Users:
 
-	SaveUser(user entities.User) (int, error)
-	GetUserByID(id int) (*entities.User, error)
-	GetUserByTelegramID(telegramID int) (*entities.User, error)
+	SaveUser(ctx context.Context, user entities.User) (int, error)
+	GetUserByID(ctx context.Context, id int) (*entities.User, error)
+	GetUserByTelegramID(ctx context.Context, telegramID int) (*entities.User, error)
+	UpdateUserTimezone(ctx context.Context, id int, timezone string) (*entities.User, error)
+	UpdateUserNotifyHour(ctx context.Context, id, notifyHour int) (*entities.User, error)
+	UpdateUserNotificationsDigest(ctx context.Context, id int, digest bool) (*entities.User, error)
+	UpdateUserQuietHours(ctx context.Context, id, start, end int) (*entities.User, error)
+	UpdateUserQuietWeekdays(ctx context.Context, id, weekdays int) (*entities.User, error)
+	UpdateUserVacation(ctx context.Context, id int, start, end *time.Time) (*entities.User, error)
+	ClaimUserDigest(ctx context.Context, userID int, claimTTL time.Duration) (bool, error)
 
 	// Groups:
 
-	GetUserGroups(userID int) ([]entities.Group, error)
-	CountUserGroups(userID int) (int, error)
-	CreateGroup(group entities.Group) (*entities.Group, error)
-	GetGroup(id int) (*entities.Group, error)
-	GetGroupsForNotify(limit, offset int) ([]entities.Group, error)
-	DeleteGroup(id int) error
-	UpdateGroupTitle(id int, title string) (*entities.Group, error)
-	UpdateGroupDescription(id int, description string) (*entities.Group, error)
-	UpdateGroupLastWateringDate(id int, lastWateringDate time.Time) (*entities.Group, error)
-	UpdateGroupWateringInterval(id, wateringInterval int) (*entities.Group, error)
+	GetUserGroups(ctx context.Context, userID int) ([]entities.Group, error)
+	CountUserGroups(ctx context.Context, userID int) (int, error)
+	CreateGroup(ctx context.Context, group entities.Group) (*entities.Group, error)
+	GetGroup(ctx context.Context, id int) (*entities.Group, error)
+	GetGroupsForNotify(ctx context.Context, limit, offset int) ([]entities.Group, error)
+	ClaimGroupsForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.Group, error)
+	SnoozeGroup(ctx context.Context, id int, until time.Time) (*entities.Group, error)
+	SkipGroupWatering(ctx context.Context, id int) (*entities.Group, error)
+	DeleteGroup(ctx context.Context, id int) error
+	UpdateGroupTitle(ctx context.Context, id int, title string) (*entities.Group, error)
+	UpdateGroupDescription(ctx context.Context, id int, description string) (*entities.Group, error)
+	UpdateGroupLastWateringDate(
+		ctx context.Context,
+		id int,
+		lastWateringDate time.Time,
+		source string,
+		userID int,
+	) (*entities.Group, error)
+	UpdateGroupWateringInterval(ctx context.Context, id, wateringInterval int) (*entities.Group, error)
+	UpdateGroupSeasonSchedule(ctx context.Context, id int, seasonSchedule entities.SeasonSchedule) (*entities.Group, error)
 
 	// Plants:
 
-	CountUserPlants(userID int) (int, error)
-	GetGroupPlants(groupID int) ([]entities.Plant, error)
-	CountGroupPlants(groupID int) (int, error)
-	CreatePlant(plant entities.Plant) (*entities.Plant, error)
-	GetPlant(id int) (*entities.Plant, error)
-	UpdatePlantTitle(id int, title string) (*entities.Plant, error)
-	UpdatePlantDescription(id int, description string) (*entities.Plant, error)
-	UpdatePlantGroup(id, groupID int) (*entities.Plant, error)
-	UpdatePlantPhoto(id int, photo []byte) (*entities.Plant, error)
-	DeletePlant(id int) error
+	CountUserPlants(ctx context.Context, userID int) (int, error)
+	GetGroupPlants(ctx context.Context, groupID int) ([]entities.Plant, error)
+	CountGroupPlants(ctx context.Context, groupID int) (int, error)
+	CreatePlant(ctx context.Context, plant entities.Plant) (*entities.Plant, error)
+	GetPlant(ctx context.Context, id int) (*entities.Plant, error)
+	UpdatePlantTitle(ctx context.Context, id int, title string) (*entities.Plant, error)
+	UpdatePlantDescription(ctx context.Context, id int, description string) (*entities.Plant, error)
+	UpdatePlantGroup(ctx context.Context, id, groupID int) (*entities.Plant, error)
+	UpdatePlantPhoto(ctx context.Context, id int, fileID, fileUniqueID string) (*entities.Plant, error)
+	UpdatePlantWateringInterval(ctx context.Context, id int, wateringInterval *int) (*entities.Plant, error)
+	UpdatePlantLastWateringDate(
+		ctx context.Context,
+		id int,
+		lastWateringDate time.Time,
+		source string,
+		userID int,
+	) (*entities.Plant, error)
+	DeletePlant(ctx context.Context, id int) error
 
 	// Temporary:
 
-	GetUserTemporary(telegramID int) (*entities.Temporary, error)
-	SetTemporaryStep(telegramID, step int) error
-	SetTemporaryMessage(telegramID int, messageID *int) error
-	ResetTemporary(telegramID int) error
+	GetUserTemporary(ctx context.Context, telegramID int) (*entities.Temporary, error)
+	SetTemporaryStep(ctx context.Context, telegramID, step int) error
+	SetTemporaryMessage(ctx context.Context, telegramID int, messageID *int) error
+	SetTemporaryStepAndMessage(ctx context.Context, telegramID, step int, messageID *int) error
+	ResetTemporary(ctx context.Context, telegramID int) error
 
-	AddGroupTitle(telegramID int, title string) (*entities.Group, error)
-	AddGroupDescription(telegramID int, description string) (*entities.Group, error)
-	AddGroupLastWateringDate(telegramID int, lastWateringDate time.Time) (*entities.Group, error)
-	AddGroupWateringInterval(telegramID, wateringInterval int) (*entities.Group, error)
+	AddGroupTitle(ctx context.Context, telegramID int, title string) (*entities.Group, error)
+	AddGroupDescription(ctx context.Context, telegramID int, description string) (*entities.Group, error)
+	AddGroupLastWateringDate(ctx context.Context, telegramID int, lastWateringDate time.Time) (*entities.Group, error)
+	AddGroupWateringInterval(ctx context.Context, telegramID, wateringInterval int) (*entities.Group, error)
 
-	AddPlantTitle(telegramID int, title string) (*entities.Plant, error)
-	AddPlantDescription(telegramID int, description string) (*entities.Plant, error)
-	AddPlantGroup(telegramID, groupID int) (*entities.Plant, error)
-	AddPlantPhoto(telegramID int, photo []byte) (*entities.Plant, error)
-	ManagePlant(telegramID, plantID int) error
-	ManageGroup(telegramID, groupID int) error
+	AddPlantTitle(ctx context.Context, telegramID int, title string) (*entities.Plant, error)
+	AddPlantDescription(ctx context.Context, telegramID int, description string) (*entities.Plant, error)
+	AddPlantGroup(ctx context.Context, telegramID, groupID int) (*entities.Plant, error)
+	AddPlantPhoto(ctx context.Context, telegramID int, fileID, fileUniqueID string) (*entities.Plant, error)
+	ManagePlant(ctx context.Context, telegramID, plantID int) error
+	ManageGroup(ctx context.Context, telegramID, groupID int) error
+
+	ManageDelegation(ctx context.Context, telegramID int) error
+	ToggleDelegationGroup(ctx context.Context, telegramID, groupID int) (*entities.Delegation, error)
+
+	ManageCareTasks(ctx context.Context, telegramID int, owner entities.CareTask) error
+	ManageCareTask(ctx context.Context, telegramID, careTaskID int) error
+	AddCareTaskType(ctx context.Context, telegramID int, careTaskType string) (*entities.CareTask, error)
+	AddCareTaskLastDate(ctx context.Context, telegramID int, lastCareDate time.Time) (*entities.CareTask, error)
+	AddCareTaskInterval(ctx context.Context, telegramID, interval int) (*entities.CareTask, error)
 
 	// Notifications:
 
-	SaveNotification(notification entities.Notification) (*entities.Notification, error)
+	SaveNotification(ctx context.Context, notification entities.Notification) (*entities.Notification, error)
+	GetLastNotification(ctx context.Context, groupID int) (*entities.Notification, error)
+	GetLastCareTaskNotification(ctx context.Context, careTaskID int) (*entities.Notification, error)
+	GetGroupNotificationsSince(ctx context.Context, groupID int, since time.Time) ([]entities.Notification, error)
+	GetLastCareTaskNotifications(ctx context.Context, careTaskID int) ([]entities.Notification, error)
+	GetMessageNotifications(ctx context.Context, chatID int64, messageID int) ([]entities.Notification, error)
+	AcknowledgeGroupNotifications(ctx context.Context, groupID int) error
+
+	// Waterings:
+
+	GetGroupWaterings(ctx context.Context, groupID, limit, offset int) ([]entities.Watering, error)
+	CountGroupWaterings(ctx context.Context, groupID int) (int, error)
+
+	// Care tasks:
+
+	CreateCareTask(ctx context.Context, careTask entities.CareTask) (*entities.CareTask, error)
+	GetCareTask(ctx context.Context, id int) (*entities.CareTask, error)
+	GetGroupCareTasks(ctx context.Context, groupID int) ([]entities.CareTask, error)
+	GetPlantCareTasks(ctx context.Context, plantID int) ([]entities.CareTask, error)
+	ClaimCareTasksForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.CareTask, error)
+	UpdateCareTaskLastDate(ctx context.Context, id int, lastCareDate time.Time) (*entities.CareTask, error)
+	UpdateCareTaskInterval(ctx context.Context, id, interval int) (*entities.CareTask, error)
+	DeleteCareTask(ctx context.Context, id int) error
+
+	// Plant photos:
+
+	SavePlantPhoto(ctx context.Context, photo entities.PlantPhoto) (*entities.PlantPhoto, error)
+	GetPlantPhoto(ctx context.Context, id int) (*entities.PlantPhoto, error)
+	GetPlantPhotos(ctx context.Context, plantID, limit, offset int) ([]entities.PlantPhoto, error)
+	CountPlantPhotos(ctx context.Context, plantID int) (int, error)
+
+	// Households:
+
+	GetUserHousehold(ctx context.Context, userID int) (*entities.Household, error)
+	GetHouseholdUsers(ctx context.Context, userID int) ([]entities.User, error)
+	CreateHouseholdInvite(ctx context.Context, userID int) (*entities.HouseholdInvite, error)
+	JoinHousehold(ctx context.Context, userID int, token string) error
+	LeaveHousehold(ctx context.Context, userID int) error
+
+	// Delegations:
+
+	CreateDelegation(ctx context.Context, ownerID int, groupIDs []int, endDate time.Time) (*entities.Delegation, error)
+	AcceptDelegation(ctx context.Context, sitterID int, token string) (*entities.Delegation, error)
+	GetGroupDelegation(ctx context.Context, groupID int, date time.Time) (*entities.Delegation, error)
+	GetOwnerDelegations(ctx context.Context, ownerID int) ([]entities.Delegation, error)
+	CancelDelegation(ctx context.Context, ownerID, id int) error
+
+	// Export:
+
+	ExportUserData(ctx context.Context, userID int) (*entities.Export, error)
+
+	// Import:
+
+	ParseImport(data []byte) (*entities.Export, error)
+	PrepareImport(
+		ctx context.Context,
+		telegramID int,
+		data []byte,
+		photos map[string]entities.ImportPhoto,
+	) (*entities.ImportPreview, error)
+	ImportUserData(ctx context.Context, telegramID int, conflictResolution string) (*entities.ImportResult, error)
 }
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "plants_care_bot"
+
+// Статусы отправки уведомлений.
+const (
+	NotificationStatusSent   = "sent"
+	NotificationStatusFailed = "failed"
+)
+
+// DurationBuckets - границы корзин в секундах, подходящие для запросов к БД и обработки обновлений.
+var DurationBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// Metrics содержит все метрики приложения, которые отдаются по /metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	HandledUpdates  *prometheus.CounterVec   // Метка endpoint - Unique кнопки, команда или шаг пользователя
+	HandlerErrors   *prometheus.CounterVec   // Метки endpoint и type - тип возвращенной обработчиком ошибки
+	HandlerDuration *prometheus.HistogramVec // Метка endpoint
+	Notifications   *prometheus.CounterVec   // Метки cron - номер крона, status - результат отправки
+	DBQueryDuration *prometheus.HistogramVec // Метка operation - метод хранилища
+}
+
+// New создает метрики приложения в отдельном реестре вместе со стандартными метриками Go и процесса.
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	factory := promauto.With(registry)
+
+	return &Metrics{
+		registry: registry,
+		HandledUpdates: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "handled_updates_total",
+				Help:      "Number of updates received from Telegram.",
+			},
+			[]string{"endpoint"},
+		),
+		HandlerErrors: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "handler_errors_total",
+				Help:      "Number of updates whose handler returned an error.",
+			},
+			[]string{"endpoint", "type"},
+		),
+		HandlerDuration: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "handler_duration_seconds",
+				Help:      "Duration of update handling.",
+				Buckets:   DurationBuckets,
+			},
+			[]string{"endpoint"},
+		),
+		Notifications: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "notifications_total",
+				Help:      "Number of notifications sent by crons.",
+			},
+			[]string{"cron", "status"},
+		),
+		DBQueryDuration: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "db_query_duration_seconds",
+				Help:      "Duration of database queries.",
+				Buckets:   DurationBuckets,
+			},
+			[]string{"operation"},
+		),
+	}
+}
+
+// RegisterDB добавляет метрики пула соединений с БД: занятые и свободные соединения, ожидание соединения.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler отдает метрики в текстовом формате Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
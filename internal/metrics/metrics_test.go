@@ -0,0 +1,47 @@
+package metrics_test
+
+import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics_Handler(t *testing.T) {
+	appMetrics := metrics.New()
+	appMetrics.HandledUpdates.WithLabelValues("message").Inc()
+	appMetrics.DBQueryDuration.WithLabelValues("GetUser").Observe(0.003)
+
+	recorder := httptest.NewRecorder()
+	appMetrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body := recorder.Body.String()
+	for _, expected := range []string{
+		`plants_care_bot_handled_updates_total{endpoint="message"} 1`,
+		`plants_care_bot_db_query_duration_seconds_bucket{operation="GetUser",le="0.005"} 1`,
+		`plants_care_bot_db_query_duration_seconds_count{operation="GetUser"} 1`,
+		"go_goroutines",
+	} {
+		assert.True(t, strings.Contains(body, expected), "expected %q in body %q", expected, body)
+	}
+}
+
+func TestMetrics_Notifications(t *testing.T) {
+	appMetrics := metrics.New()
+	appMetrics.Notifications.WithLabelValues("1", metrics.NotificationStatusSent).Inc()
+	appMetrics.Notifications.WithLabelValues("1", metrics.NotificationStatusSent).Inc()
+
+	assert.InDelta(
+		t,
+		2,
+		testutil.ToFloat64(appMetrics.Notifications.WithLabelValues("1", metrics.NotificationStatusSent)),
+		0,
+	)
+	assert.Equal(t, 1, testutil.CollectAndCount(appMetrics.Notifications))
+}
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"context"
+
+	"gopkg.in/telebot.v4"
+)
+
+// contextKey - ключ, под которым в telebot.Context хранится контекст обработки обновления.
+const contextKey = "context"
+
+// Context передает обработчикам контекст, производный от ctx приложения, чтобы при остановке бота
+// отменялись и запросы к БД, выполняемые в рамках обработки обновления.
+func Context(ctx context.Context) func(next telebot.HandlerFunc) telebot.HandlerFunc {
+	return func(next telebot.HandlerFunc) telebot.HandlerFunc {
+		return func(c telebot.Context) error {
+			updateCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			c.Set(contextKey, updateCtx)
+
+			return next(c) // continue execution chain
+		}
+	}
+}
+
+// GetContext возвращает контекст обработки обновления. Если middleware Context не подключена,
+// возвращается context.Background(), чтобы обработчики работали и без нее.
+func GetContext(c telebot.Context) context.Context {
+	if ctx, ok := c.Get(contextKey).(context.Context); ok {
+		return ctx
+	}
+
+	return context.Background()
+}
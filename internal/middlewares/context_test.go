@@ -0,0 +1,58 @@
+package middlewares_test
+
+import (
+	"context"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/telebot.v4"
+	"testing"
+)
+
+func TestContext_Middleware(t *testing.T) {
+	t.Run("Handler context is derived from application context", func(t *testing.T) {
+		appCtx, cancel := context.WithCancel(context.Background())
+		c := telebot.NewContext(&telebot.Bot{}, telebot.Update{})
+
+		var handlerCtx context.Context
+
+		err := middlewares.Context(appCtx)(
+			func(c telebot.Context) error {
+				handlerCtx = middlewares.GetContext(c)
+
+				// Остановка приложения отменяет контекст обработчика:
+				cancel()
+				assert.ErrorIs(t, handlerCtx.Err(), context.Canceled)
+
+				return nil
+			},
+		)(c)
+
+		require.NoError(t, err)
+		require.NotNil(t, handlerCtx)
+	})
+
+	t.Run("Handler context is canceled after handling", func(t *testing.T) {
+		c := telebot.NewContext(&telebot.Bot{}, telebot.Update{})
+
+		var handlerCtx context.Context
+
+		err := middlewares.Context(context.Background())(
+			func(c telebot.Context) error {
+				handlerCtx = middlewares.GetContext(c)
+				assert.NoError(t, handlerCtx.Err())
+
+				return nil
+			},
+		)(c)
+
+		require.NoError(t, err)
+		assert.ErrorIs(t, handlerCtx.Err(), context.Canceled)
+	})
+}
+
+func TestGetContext_WithoutMiddleware(t *testing.T) {
+	c := telebot.NewContext(&telebot.Bot{}, telebot.Update{})
+
+	assert.Equal(t, context.Background(), middlewares.GetContext(c))
+}
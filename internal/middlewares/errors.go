@@ -0,0 +1,163 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/DKhorkov/libs/logging"
+	"gopkg.in/telebot.v4"
+
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+)
+
+const (
+	// correlationIDLength - количество случайных байт в коде ошибки, который видит пользователь.
+	correlationIDLength = 4
+
+	// callbackAnsweredKey - ключ, под которым в telebot.Context отмечается, что на нажатие кнопки уже ответили.
+	callbackAnsweredKey = "callbackAnswered"
+)
+
+// domainErrors сопоставляет ошибки бизнес-логики с понятными пользователю сообщениями.
+var domainErrors = []struct {
+	err  error
+	text string
+}{
+	{err: customerrors.ErrGroupAlreadyExists, text: texts.GroupAlreadyExists},
+	{err: customerrors.ErrPlantAlreadyExists, text: texts.PlantAlreadyExists},
+	{err: customerrors.ErrDelegationNotFound, text: texts.DelegationNotFound},
+	{err: customerrors.ErrDelegationExpired, text: texts.DelegationExpired},
+	{err: customerrors.ErrDelegationToSelf, text: texts.DelegationToSelf},
+	{err: customerrors.ErrDelegationWithoutGroups, text: texts.DelegationWithoutGroups},
+	{err: customerrors.ErrDelegationEndDateInPast, text: texts.HandoverEndDateInPast},
+	{err: customerrors.ErrHouseholdNotFound, text: texts.HouseholdNotFound},
+	{err: customerrors.ErrHouseholdInviteNotFound, text: texts.HouseholdInviteNotFound},
+	{err: customerrors.ErrHouseholdInviteExpired, text: texts.HouseholdInviteExpired},
+	{err: customerrors.ErrNotificationNotFound, text: texts.NotificationNotFound},
+	{err: customerrors.ErrInvalidCareTaskType, text: texts.InvalidCareTaskType},
+	{err: customerrors.ErrInvalidTimezone, text: texts.InvalidTimezone},
+	{err: customerrors.ErrInvalidNotifyHour, text: texts.InvalidNotifyHour},
+	{err: customerrors.ErrInvalidQuietHours, text: texts.InvalidQuietHours},
+	{err: customerrors.ErrInvalidQuietWeekdays, text: texts.InvalidQuietWeekdays},
+	{err: customerrors.ErrInvalidVacation, text: texts.InvalidVacation},
+	{err: customerrors.ErrInvalidImport, text: texts.InvalidImport},
+	{err: customerrors.ErrUnsupportedImportVersion, text: texts.UnsupportedImportVersion},
+}
+
+// Errors восстанавливает обработчик после паники и сообщает пользователю о доменных ошибках.
+// Остальные ошибки возвращаются дальше и попадают в OnError.
+func Errors(logger logging.Logger) func(next telebot.HandlerFunc) telebot.HandlerFunc {
+	return func(next telebot.HandlerFunc) telebot.HandlerFunc {
+		return func(c telebot.Context) (err error) {
+			// Как и в cron.Run, паника одного обработчика не должна останавливать бота:
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error("Recovered from panic", "Recovered", r, "Stack", string(debug.Stack()))
+
+					err = fmt.Errorf("%w: %v", customerrors.ErrPanic, r)
+				}
+			}()
+
+			err = next(&answerTrackingContext{Context: c}) // continue execution chain
+			if err == nil {
+				return nil
+			}
+
+			for _, domainError := range domainErrors {
+				if errors.Is(err, domainError.err) {
+					return notifyUser(c, domainError.text)
+				}
+			}
+
+			return err
+		}
+	}
+}
+
+// OnError логирует ошибки, которые не удалось обработать, и сообщает пользователю код ошибки,
+// по которому ее можно найти в логах. Контекст отсутствует для ошибок получения обновлений.
+func OnError(logger logging.Logger) func(error, telebot.Context) {
+	return func(err error, c telebot.Context) {
+		if c == nil {
+			logger.Error("Failed to get updates", "Error", err)
+
+			return
+		}
+
+		correlationID := newCorrelationID()
+
+		logger.Error(
+			"Failed to handle update",
+			"Error", err,
+			"CorrelationID", correlationID,
+			"UpdateID", c.Update().ID,
+			"Sender", c.Sender(),
+		)
+
+		if err = notifyUser(c, fmt.Sprintf(texts.UnexpectedError, correlationID)); err != nil {
+			logger.Error(
+				"Failed to notify user about error",
+				"Error", err,
+				"CorrelationID", correlationID,
+			)
+		}
+	}
+}
+
+// notifyUser показывает текст всплывающим окном при нажатии кнопки или отправляет его сообщением.
+// На нажатие кнопки Telegram принимает только один ответ, поэтому, если обработчик уже ответил, текст отправляется
+// сообщением.
+func notifyUser(c telebot.Context, text string) error {
+	if c.Callback() != nil && c.Get(callbackAnsweredKey) == nil {
+		return c.Respond(
+			&telebot.CallbackResponse{
+				CallbackID: c.Callback().ID,
+				Text:       text,
+				ShowAlert:  true,
+			},
+		)
+	}
+
+	if c.Chat() == nil {
+		return nil
+	}
+
+	return c.Send(text)
+}
+
+// answerTrackingContext отмечает в telebot.Context успешный ответ обработчика на нажатие кнопки.
+type answerTrackingContext struct {
+	telebot.Context
+}
+
+func (c *answerTrackingContext) Respond(resp ...*telebot.CallbackResponse) error {
+	if err := c.Context.Respond(resp...); err != nil {
+		return err
+	}
+
+	c.Set(callbackAnsweredKey, true)
+
+	return nil
+}
+
+// RespondText и RespondAlert переопределены, так как telebot.Context вызывает в них свой Respond:
+func (c *answerTrackingContext) RespondText(text string) error {
+	return c.Respond(&telebot.CallbackResponse{Text: text})
+}
+
+func (c *answerTrackingContext) RespondAlert(text string) error {
+	return c.Respond(&telebot.CallbackResponse{Text: text, ShowAlert: true})
+}
+
+func newCorrelationID() string {
+	b := make([]byte, correlationIDLength)
+
+	// crypto/rand не возвращает ошибок на поддерживаемых платформах:
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
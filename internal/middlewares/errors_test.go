@@ -0,0 +1,262 @@
+package middlewares_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DKhorkov/libs/logging/mocks"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"regexp"
+	"testing"
+)
+
+func TestErrors_Middleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       telebot.HandlerFunc
+		setupMocks    func(mockCtx *mockbot.MockContext, logger *mocks.MockLogger)
+		expectedError error
+	}{
+		{
+			name: "No error",
+			handler: func(telebot.Context) error {
+				return nil
+			},
+		},
+		{
+			name: "Domain error on callback - should show alert",
+			handler: func(telebot.Context) error {
+				return customerrors.ErrPlantAlreadyExists
+			},
+			setupMocks: func(mockCtx *mockbot.MockContext, _ *mocks.MockLogger) {
+				callback := &telebot.Callback{ID: "42"}
+
+				mockCtx.EXPECT().Callback().Return(callback).AnyTimes()
+				mockCtx.EXPECT().Get("callbackAnswered").Return(nil).AnyTimes()
+				mockCtx.EXPECT().Respond(
+					&telebot.CallbackResponse{
+						CallbackID: callback.ID,
+						Text:       texts.PlantAlreadyExists,
+						ShowAlert:  true,
+					},
+				).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Domain error on answered callback - should send text",
+			handler: func(c telebot.Context) error {
+				if err := c.RespondText("Готово"); err != nil {
+					return err
+				}
+
+				return customerrors.ErrPlantAlreadyExists
+			},
+			setupMocks: func(mockCtx *mockbot.MockContext, _ *mocks.MockLogger) {
+				answered := false
+
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{ID: "42"}).AnyTimes()
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{Text: "Готово"}).Return(nil).Times(1)
+				mockCtx.EXPECT().Set("callbackAnswered", true).Do(func(string, any) { answered = true }).Times(1)
+				mockCtx.
+					EXPECT().
+					Get("callbackAnswered").
+					DoAndReturn(func(string) any {
+						if answered {
+							return true
+						}
+
+						return nil
+					}).
+					AnyTimes()
+				mockCtx.EXPECT().Chat().Return(&telebot.Chat{ID: 1}).AnyTimes()
+				mockCtx.EXPECT().Send(texts.PlantAlreadyExists).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Failed callback answer - should answer with alert",
+			handler: func(c telebot.Context) error {
+				_ = c.RespondText("Готово")
+
+				return customerrors.ErrPlantAlreadyExists
+			},
+			setupMocks: func(mockCtx *mockbot.MockContext, _ *mocks.MockLogger) {
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{ID: "42"}).AnyTimes()
+				mockCtx.EXPECT().Respond(&telebot.CallbackResponse{Text: "Готово"}).Return(assert.AnError).Times(1)
+				mockCtx.EXPECT().Get("callbackAnswered").Return(nil).AnyTimes()
+				mockCtx.
+					EXPECT().
+					Respond(
+						&telebot.CallbackResponse{
+							CallbackID: "42",
+							Text:       texts.PlantAlreadyExists,
+							ShowAlert:  true,
+						},
+					).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Wrapped domain error on message - should send text",
+			handler: func(telebot.Context) error {
+				return fmt.Errorf("add group: %w", customerrors.ErrGroupAlreadyExists)
+			},
+			setupMocks: func(mockCtx *mockbot.MockContext, _ *mocks.MockLogger) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(&telebot.Chat{ID: 1}).AnyTimes()
+				mockCtx.EXPECT().Send(texts.GroupAlreadyExists).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Domain error and failed notification - should return notification error",
+			handler: func(telebot.Context) error {
+				return customerrors.ErrInvalidTimezone
+			},
+			setupMocks: func(mockCtx *mockbot.MockContext, _ *mocks.MockLogger) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Chat().Return(&telebot.Chat{ID: 1}).AnyTimes()
+				mockCtx.EXPECT().Send(texts.InvalidTimezone).Return(assert.AnError).Times(1)
+			},
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Unexpected error - should be passed to OnError",
+			handler: func(telebot.Context) error {
+				return assert.AnError
+			},
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Panic - should be recovered",
+			handler: func(telebot.Context) error {
+				panic("nil map")
+			},
+			setupMocks: func(_ *mockbot.MockContext, logger *mocks.MockLogger) {
+				logger.
+					EXPECT().
+					Error("Recovered from panic", "Recovered", "nil map", "Stack", gomock.Any()).
+					Times(1)
+			},
+			expectedError: customerrors.ErrPanic,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := mockbot.NewMockContext(ctrl)
+			mockLogger := mocks.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockCtx, mockLogger)
+			}
+
+			err := middlewares.Errors(mockLogger)(tt.handler)(mockCtx)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOnError(t *testing.T) {
+	correlationIDPattern := regexp.MustCompile(`код: ([0-9a-f]{8})$`)
+
+	t.Run("Without context - should only log", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockLogger := mocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().Error("Failed to get updates", "Error", assert.AnError).Times(1)
+
+		middlewares.OnError(mockLogger)(assert.AnError, nil)
+	})
+
+	t.Run("Callback - should show correlation ID that is logged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCtx := mockbot.NewMockContext(ctrl)
+		mockLogger := mocks.NewMockLogger(ctrl)
+
+		var loggedID, shownID string
+
+		mockCtx.EXPECT().Update().Return(telebot.Update{ID: 7}).AnyTimes()
+		mockCtx.EXPECT().Sender().Return(&telebot.User{ID: 12345}).AnyTimes()
+		mockCtx.EXPECT().Callback().Return(&telebot.Callback{ID: "42"}).AnyTimes()
+		mockCtx.EXPECT().Get("callbackAnswered").Return(nil).AnyTimes()
+		mockLogger.
+			EXPECT().
+			Error(
+				"Failed to handle update",
+				"Error", assert.AnError,
+				"CorrelationID", gomock.Any(),
+				"UpdateID", 7,
+				"Sender", &telebot.User{ID: 12345},
+			).
+			Do(func(_ string, args ...any) {
+				loggedID = args[3].(string)
+			}).
+			Times(1)
+		mockCtx.
+			EXPECT().
+			Respond(gomock.Any()).
+			DoAndReturn(func(responses ...*telebot.CallbackResponse) error {
+				require.Len(t, responses, 1)
+				assert.True(t, responses[0].ShowAlert)
+
+				matches := correlationIDPattern.FindStringSubmatch(responses[0].Text)
+				require.Len(t, matches, 2)
+				shownID = matches[1]
+
+				return nil
+			}).
+			Times(1)
+
+		middlewares.OnError(mockLogger)(assert.AnError, mockCtx)
+
+		assert.Equal(t, loggedID, shownID)
+	})
+
+	t.Run("Answered callback - should send correlation ID as message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCtx := mockbot.NewMockContext(ctrl)
+		mockLogger := mocks.NewMockLogger(ctrl)
+
+		mockCtx.EXPECT().Update().Return(telebot.Update{ID: 7}).AnyTimes()
+		mockCtx.EXPECT().Sender().Return(&telebot.User{ID: 12345}).AnyTimes()
+		mockCtx.EXPECT().Callback().Return(&telebot.Callback{ID: "42"}).AnyTimes()
+		mockCtx.EXPECT().Get("callbackAnswered").Return(true).AnyTimes()
+		mockCtx.EXPECT().Chat().Return(&telebot.Chat{ID: 12345}).AnyTimes()
+		mockCtx.EXPECT().Send(gomock.Any()).Return(nil).Times(1)
+		mockLogger.EXPECT().Error("Failed to handle update", gomock.Any()).Times(1)
+
+		middlewares.OnError(mockLogger)(assert.AnError, mockCtx)
+	})
+
+	t.Run("Message and failed notification - should log both errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCtx := mockbot.NewMockContext(ctrl)
+		mockLogger := mocks.NewMockLogger(ctrl)
+		sendErr := errors.New("bot was blocked")
+
+		mockCtx.EXPECT().Update().Return(telebot.Update{ID: 7}).AnyTimes()
+		mockCtx.EXPECT().Sender().Return(&telebot.User{ID: 12345}).AnyTimes()
+		mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+		mockCtx.EXPECT().Chat().Return(&telebot.Chat{ID: 12345}).AnyTimes()
+		mockCtx.EXPECT().Send(gomock.Any()).Return(sendErr).Times(1)
+		mockLogger.EXPECT().Error("Failed to handle update", gomock.Any()).Times(1)
+		mockLogger.
+			EXPECT().
+			Error("Failed to notify user about error", "Error", sendErr, "CorrelationID", gomock.Any()).
+			Times(1)
+
+		middlewares.OnError(mockLogger)(assert.AnError, mockCtx)
+	})
+}
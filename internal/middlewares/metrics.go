@@ -0,0 +1,152 @@
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/telebot.v4"
+
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/metrics"
+)
+
+const (
+	// messageEndpoint используется для сообщений, тип которых не выделяется в отдельный endpoint.
+	messageEndpoint        = "message"
+	textEndpoint           = "text"
+	photoEndpoint          = "photo"
+	documentEndpoint       = "document"
+	unknownCommandEndpoint = "unknown_command"
+
+	// otherErrorType используется для ошибок без sentinel и собственного типа.
+	otherErrorType = "other"
+
+	commandPrefix = "/"
+)
+
+// errorTypes содержит sentinel-ошибки, которые выделяются в отдельный тип в метриках.
+var errorTypes = []struct {
+	err  error
+	name string
+}{
+	{err: customerrors.ErrPanic, name: "ErrPanic"},
+	{err: customerrors.ErrGroupAlreadyExists, name: "ErrGroupAlreadyExists"},
+	{err: customerrors.ErrPlantAlreadyExists, name: "ErrPlantAlreadyExists"},
+	{err: customerrors.ErrDelegationNotFound, name: "ErrDelegationNotFound"},
+	{err: customerrors.ErrDelegationExpired, name: "ErrDelegationExpired"},
+	{err: customerrors.ErrDelegationToSelf, name: "ErrDelegationToSelf"},
+	{err: customerrors.ErrDelegationWithoutGroups, name: "ErrDelegationWithoutGroups"},
+	{err: customerrors.ErrDelegationEndDateInPast, name: "ErrDelegationEndDateInPast"},
+	{err: customerrors.ErrHouseholdNotFound, name: "ErrHouseholdNotFound"},
+	{err: customerrors.ErrHouseholdInviteNotFound, name: "ErrHouseholdInviteNotFound"},
+	{err: customerrors.ErrHouseholdInviteExpired, name: "ErrHouseholdInviteExpired"},
+	{err: customerrors.ErrNotificationNotFound, name: "ErrNotificationNotFound"},
+	{err: customerrors.ErrInvalidCareTaskType, name: "ErrInvalidCareTaskType"},
+	{err: customerrors.ErrInvalidTimezone, name: "ErrInvalidTimezone"},
+	{err: customerrors.ErrInvalidNotifyHour, name: "ErrInvalidNotifyHour"},
+	{err: customerrors.ErrInvalidQuietHours, name: "ErrInvalidQuietHours"},
+	{err: customerrors.ErrInvalidQuietWeekdays, name: "ErrInvalidQuietWeekdays"},
+	{err: customerrors.ErrInvalidVacation, name: "ErrInvalidVacation"},
+	{err: customerrors.ErrInvalidImport, name: "ErrInvalidImport"},
+	{err: customerrors.ErrUnsupportedImportVersion, name: "ErrUnsupportedImportVersion"},
+	{err: customerrors.ErrUnknownImportConflictResolution, name: "ErrUnknownImportConflictResolution"},
+	{err: sql.ErrNoRows, name: "sql.ErrNoRows"},
+	{err: context.DeadlineExceeded, name: "context.DeadlineExceeded"},
+	{err: context.Canceled, name: "context.Canceled"},
+}
+
+// Metrics считает обновления, ошибки обработчиков и длительность обработки. Обновления разбиваются по endpoint:
+// Unique для кнопок, команда для команд и тип сообщения для остальных сообщений. Метки берутся только из
+// ограниченных наборов значений, чтобы пользователи не могли создавать новые временные ряды.
+func Metrics(m *metrics.Metrics, commands []string) func(next telebot.HandlerFunc) telebot.HandlerFunc {
+	return func(next telebot.HandlerFunc) telebot.HandlerFunc {
+		return func(c telebot.Context) (err error) {
+			start := time.Now()
+			endpoint := getEndpoint(c, commands)
+
+			m.HandledUpdates.WithLabelValues(endpoint).Inc()
+
+			// Metrics подключается внутри Errors, поэтому паника учитывается здесь как ErrPanic
+			// и передается дальше, где Errors восстановит обработчик:
+			defer func() {
+				r := recover()
+				if r != nil {
+					err = customerrors.ErrPanic
+				}
+
+				m.HandlerDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+
+				if err != nil {
+					m.HandlerErrors.WithLabelValues(endpoint, getErrorType(err)).Inc()
+				}
+
+				if r != nil {
+					panic(r)
+				}
+			}()
+
+			return next(c) // continue execution chain
+		}
+	}
+}
+
+func getEndpoint(c telebot.Context, commands []string) string {
+	if c.Callback() != nil {
+		return c.Callback().Unique
+	}
+
+	message := c.Message()
+
+	switch {
+	case message == nil:
+		return messageEndpoint
+	case strings.HasPrefix(message.Text, commandPrefix):
+		// Команда может содержать параметры и имя бота, например "/start sitter-token" или "/help@bot":
+		command, _, _ := strings.Cut(strings.Fields(message.Text)[0], "@")
+		if !slices.Contains(commands, command) {
+			return unknownCommandEndpoint
+		}
+
+		return command
+	case message.Photo != nil:
+		return photoEndpoint
+	case message.Document != nil:
+		return documentEndpoint
+	case message.Text != "":
+		return textEndpoint
+	default:
+		return messageEndpoint
+	}
+}
+
+// getErrorType возвращает имя sentinel-ошибки или тип исходной ошибки. Текст ошибки не используется,
+// так как он может содержать идентификаторы и пользовательские данные.
+func getErrorType(err error) string {
+	for _, errorType := range errorTypes {
+		if errors.Is(err, errorType.err) {
+			return errorType.name
+		}
+	}
+
+	for {
+		unwrapped := errors.Unwrap(err)
+		if unwrapped == nil {
+			break
+		}
+
+		err = unwrapped
+	}
+
+	// Ошибки из errors.New и fmt.Errorf не имеют информативного типа:
+	switch errorType := fmt.Sprintf("%T", err); errorType {
+	case "*errors.errorString", "*fmt.wrapError", "*fmt.wrapErrors":
+		return otherErrorType
+	default:
+		return errorType
+	}
+}
@@ -0,0 +1,173 @@
+package middlewares_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/metrics"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/middlewares"
+	mockbot "github.com/DKhorkov/plantsCareTelegramBot/mocks/bot"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/telebot.v4"
+	"testing"
+)
+
+func TestMetrics_Middleware(t *testing.T) {
+	commands := []string{"/help", "/start"}
+
+	tests := []struct {
+		name              string
+		setupMocks        func(mockCtx *mockbot.MockContext)
+		handlerErr        error
+		expectedEndpoint  string
+		expectedErrorType string
+	}{
+		{
+			name: "Callback - endpoint is callback Unique",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{Unique: "menu:open"}).AnyTimes()
+			},
+			expectedEndpoint: "menu:open",
+		},
+		{
+			name: "Command with payload and bot name - endpoint is command",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Message().Return(&telebot.Message{Text: "/start@plants_bot sitter-token"}).AnyTimes()
+			},
+			expectedEndpoint: "/start",
+		},
+		{
+			name: "Unknown command - endpoint is fixed label",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Message().Return(&telebot.Message{Text: "/random_8f3a2c"}).AnyTimes()
+			},
+			expectedEndpoint: "unknown_command",
+		},
+		{
+			name: "Text message - endpoint is text",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Message().Return(&telebot.Message{Text: "Фикус"}).AnyTimes()
+			},
+			expectedEndpoint: "text",
+		},
+		{
+			name: "Photo message - endpoint is photo",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Message().Return(&telebot.Message{Photo: &telebot.Photo{}}).AnyTimes()
+			},
+			expectedEndpoint: "photo",
+		},
+		{
+			name: "Document message - endpoint is document",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Message().Return(&telebot.Message{Document: &telebot.Document{}}).AnyTimes()
+			},
+			expectedEndpoint: "document",
+		},
+		{
+			name: "Other update - endpoint is message",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(nil).AnyTimes()
+				mockCtx.EXPECT().Message().Return(nil).AnyTimes()
+			},
+			expectedEndpoint: "message",
+		},
+		{
+			name: "Domain error - error type is sentinel name",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{Unique: "add_group"}).AnyTimes()
+			},
+			handlerErr:        fmt.Errorf("save group: %w", customerrors.ErrGroupAlreadyExists),
+			expectedEndpoint:  "add_group",
+			expectedErrorType: "ErrGroupAlreadyExists",
+		},
+		{
+			name: "Storage error - error type is sentinel name",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{Unique: "add_group"}).AnyTimes()
+			},
+			handlerErr:        fmt.Errorf("get group 42: %w", sql.ErrNoRows),
+			expectedEndpoint:  "add_group",
+			expectedErrorType: "sql.ErrNoRows",
+		},
+		{
+			name: "Telegram API error - error type is its Go type",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{Unique: "add_group"}).AnyTimes()
+			},
+			handlerErr:        telebot.ErrMessageNotModified,
+			expectedEndpoint:  "add_group",
+			expectedErrorType: "*telebot.Error",
+		},
+		{
+			name: "Error without sentinel - error type is other",
+			setupMocks: func(mockCtx *mockbot.MockContext) {
+				mockCtx.EXPECT().Callback().Return(&telebot.Callback{Unique: "add_group"}).AnyTimes()
+			},
+			handlerErr:        errors.New("user 12345 sent invalid title \"Фикус\""),
+			expectedEndpoint:  "add_group",
+			expectedErrorType: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCtx := mockbot.NewMockContext(ctrl)
+			appMetrics := metrics.New()
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockCtx)
+			}
+
+			handler := middlewares.Metrics(appMetrics, commands)(
+				func(c telebot.Context) error {
+					return tt.handlerErr
+				},
+			)
+
+			err := handler(mockCtx)
+
+			assert.Equal(t, tt.handlerErr, err)
+			assert.Equal(t, float64(1), testutil.ToFloat64(appMetrics.HandledUpdates.WithLabelValues(tt.expectedEndpoint)))
+			assert.Equal(t, 1, testutil.CollectAndCount(appMetrics.HandlerDuration))
+
+			if tt.handlerErr != nil {
+				assert.Equal(
+					t,
+					float64(1),
+					testutil.ToFloat64(appMetrics.HandlerErrors.WithLabelValues(tt.expectedEndpoint, tt.expectedErrorType)),
+				)
+			}
+		})
+	}
+}
+
+func TestMetrics_Middleware_Panic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCtx := mockbot.NewMockContext(ctrl)
+	appMetrics := metrics.New()
+
+	mockCtx.EXPECT().Callback().Return(&telebot.Callback{Unique: "add_group"}).AnyTimes()
+
+	handler := middlewares.Metrics(appMetrics, nil)(
+		func(c telebot.Context) error {
+			panic("unexpected nil")
+		},
+	)
+
+	// Паника передается дальше, чтобы ее восстановил Errors:
+	assert.PanicsWithValue(t, "unexpected nil", func() { _ = handler(mockCtx) })
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(appMetrics.HandledUpdates.WithLabelValues("add_group")))
+	assert.Equal(t, 1, testutil.CollectAndCount(appMetrics.HandlerDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(appMetrics.HandlerErrors.WithLabelValues("add_group", "ErrPanic")))
+}
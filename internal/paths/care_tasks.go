@@ -0,0 +1,13 @@
+package paths
+
+const (
+	CareTasksImage              = "./static/images/media_message_picture.png"
+	AddCareTaskTypeImage        = "./static/images/media_message_picture.png"
+	AddCareTaskLastDateImage    = "./static/images/media_message_picture.png"
+	AddCareTaskIntervalImage    = "./static/images/media_message_picture.png"
+	ConfirmAddCareTaskImage     = "./static/images/media_message_picture.png"
+	ManageCareTaskImage         = "./static/images/media_message_picture.png"
+	ManageCareTaskRemovalImage  = "./static/images/media_message_picture.png"
+	ChangeCareTaskLastDateImage = "./static/images/media_message_picture.png"
+	ChangeCareTaskIntervalImage = "./static/images/media_message_picture.png"
+)
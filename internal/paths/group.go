@@ -15,4 +15,7 @@ const (
 	ChangeGroupLastWateringDateImage = "./static/images/media_message_picture.png"
 	ChangeGroupWateringIntervalImage = "./static/images/media_message_picture.png"
 	ManageGroupSeePlantsImage        = "./static/images/media_message_picture.png"
+	WateringHistoryImage             = "./static/images/media_message_picture.png"
+	ChangeGroupSeasonScheduleImage   = "./static/images/media_message_picture.png"
+	ChangeGroupSeasonIntervalImage   = "./static/images/media_message_picture.png"
 )
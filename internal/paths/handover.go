@@ -0,0 +1,9 @@
+package paths
+
+const (
+	HandoverImage          = "./static/images/media_message_picture.png"
+	HandoverGroupsImage    = "./static/images/media_message_picture.png"
+	HandoverEndDateImage   = "./static/images/media_message_picture.png"
+	HandoverInviteImage    = "./static/images/media_message_picture.png"
+	SitterGroupPlantsImage = "./static/images/media_message_picture.png"
+)
@@ -0,0 +1,6 @@
+package paths
+
+const (
+	HouseholdImage       = "./static/images/media_message_picture.png"
+	HouseholdInviteImage = "./static/images/media_message_picture.png"
+)
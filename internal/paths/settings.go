@@ -0,0 +1,11 @@
+package paths
+
+const (
+	SettingsImage         = "./static/images/media_message_picture.png"
+	ChangeTimezoneImage   = "./static/images/media_message_picture.png"
+	ChangeNotifyHourImage = "./static/images/media_message_picture.png"
+	QuietSettingsImage    = "./static/images/media_message_picture.png"
+	ChangeQuietHoursImage = "./static/images/media_message_picture.png"
+	ChangeQuietDaysImage  = "./static/images/media_message_picture.png"
+	ChangeVacationImage   = "./static/images/media_message_picture.png"
+)
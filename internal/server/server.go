@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+const (
+	// shutdownTimeout ограничивает ожидание запросов оркестратора и Prometheus в момент остановки:
+	shutdownTimeout   = 10 * time.Second
+	readHeaderTimeout = 10 * time.Second
+	pingTimeout       = 2 * time.Second
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusNotReady    = "not ready"
+)
+
+// Server - служебный HTTP-сервер, по которому оркестратор проверяет состояние бота, а Prometheus собирает метрики.
+type Server struct {
+	httpServer    *http.Server
+	database      interfaces.DatabasePinger
+	telegram      interfaces.TelegramChecker
+	metrics       http.Handler
+	logger        logging.Logger
+	getMeInterval time.Duration
+	maxGetMeAge   time.Duration
+	ready         atomic.Bool
+	stop          chan struct{}
+}
+
+func New(
+	cfg config.AdminConfig,
+	database interfaces.DatabasePinger,
+	telegram interfaces.TelegramChecker,
+	metrics http.Handler,
+	logger logging.Logger,
+) *Server {
+	server := &Server{
+		database:      database,
+		telegram:      telegram,
+		metrics:       metrics,
+		logger:        logger,
+		getMeInterval: cfg.GetMeInterval,
+		maxGetMeAge:   cfg.MaxGetMeAge,
+		stop:          make(chan struct{}),
+	}
+
+	server.httpServer = &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	return server
+}
+
+// Run запускает периодическую проверку Telegram Bot API и блокируется до остановки сервера.
+func (s *Server) Run() error {
+	go s.checkTelegram()
+
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) Stop() error {
+	close(s.stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return s.httpServer.Shutdown(ctx)
+}
+
+// SetReady сообщает, готов ли бот принимать обновления. Готовность снимается в начале остановки,
+// чтобы оркестратор перестал направлять трафик до того, как бот перестанет его обрабатывать.
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.healthz)
+	mux.HandleFunc("GET /readyz", s.readyz)
+	mux.Handle("GET /metrics", s.metrics)
+
+	return mux
+}
+
+type healthResponse struct {
+	Status    string    `json:"status"`
+	Database  string    `json:"database"`
+	Telegram  string    `json:"telegram"`
+	LastGetMe time.Time `json:"lastGetMe"`
+}
+
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	response := healthResponse{
+		Status:    statusOK,
+		Database:  statusOK,
+		Telegram:  statusOK,
+		LastGetMe: s.telegram.LastGetMe().UTC(),
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), pingTimeout)
+	defer cancel()
+
+	if err := s.database.PingContext(ctx); err != nil {
+		s.logger.Warn("Database health check failed", "Error", err)
+
+		response.Status = statusUnavailable
+		response.Database = statusUnavailable
+	}
+
+	if time.Since(response.LastGetMe) > s.maxGetMeAge {
+		response.Status = statusUnavailable
+		response.Telegram = statusUnavailable
+	}
+
+	statusCode := http.StatusOK
+	if response.Status != statusOK {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	s.writeJSON(w, statusCode, response)
+}
+
+type readyResponse struct {
+	Status string `json:"status"`
+}
+
+func (s *Server) readyz(w http.ResponseWriter, _ *http.Request) {
+	if !s.ready.Load() {
+		s.writeJSON(w, http.StatusServiceUnavailable, readyResponse{Status: statusNotReady})
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, readyResponse{Status: statusOK})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, statusCode int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.Error("Failed to write response", "Error", err)
+	}
+}
+
+// checkTelegram периодически вызывает getMe, чтобы /healthz отражал доступность Telegram Bot API
+// без обращения к нему на каждый запрос оркестратора.
+func (s *Server) checkTelegram() {
+	ticker := time.NewTicker(s.getMeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.telegram.GetMe(); err != nil {
+				s.logger.Warn("Telegram health check failed", "Error", err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package server_test
+
+import (
+	"errors"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/metrics"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/server"
+	mockserver "github.com/DKhorkov/plantsCareTelegramBot/mocks/server"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_Handler(t *testing.T) {
+	cfg := config.AdminConfig{
+		Listen:        "127.0.0.1:0",
+		GetMeInterval: time.Minute,
+		MaxGetMeAge:   time.Minute * 3,
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		method         string
+		ready          bool
+		setupMocks     func(database *mockserver.MockDatabasePinger, telegram *mockserver.MockTelegramChecker, logger *mocklogging.MockLogger)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name:   "Healthy",
+			path:   "/healthz",
+			method: http.MethodGet,
+			setupMocks: func(database *mockserver.MockDatabasePinger, telegram *mockserver.MockTelegramChecker, _ *mocklogging.MockLogger) {
+				database.EXPECT().PingContext(gomock.Any()).Return(nil).Times(1)
+				telegram.EXPECT().LastGetMe().Return(time.Now()).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"status":"ok"`, `"database":"ok"`, `"telegram":"ok"`, `"lastGetMe"`},
+		},
+		{
+			name:   "Database unavailable",
+			path:   "/healthz",
+			method: http.MethodGet,
+			setupMocks: func(database *mockserver.MockDatabasePinger, telegram *mockserver.MockTelegramChecker, logger *mocklogging.MockLogger) {
+				database.EXPECT().PingContext(gomock.Any()).Return(errors.New("connection refused")).Times(1)
+				telegram.EXPECT().LastGetMe().Return(time.Now()).Times(1)
+				logger.EXPECT().Warn("Database health check failed", "Error", gomock.Any()).Times(1)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   []string{`"status":"unavailable"`, `"database":"unavailable"`, `"telegram":"ok"`},
+		},
+		{
+			name:   "Telegram getMe is outdated",
+			path:   "/healthz",
+			method: http.MethodGet,
+			setupMocks: func(database *mockserver.MockDatabasePinger, telegram *mockserver.MockTelegramChecker, _ *mocklogging.MockLogger) {
+				database.EXPECT().PingContext(gomock.Any()).Return(nil).Times(1)
+				telegram.EXPECT().LastGetMe().Return(time.Now().Add(-time.Hour)).Times(1)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   []string{`"status":"unavailable"`, `"database":"ok"`, `"telegram":"unavailable"`},
+		},
+		{
+			name:           "Ready",
+			path:           "/readyz",
+			method:         http.MethodGet,
+			ready:          true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"status":"ok"`},
+		},
+		{
+			name:           "Not ready",
+			path:           "/readyz",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   []string{`"status":"not ready"`},
+		},
+		{
+			name:           "Metrics",
+			path:           "/metrics",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				"# TYPE plants_care_bot_handled_updates_total counter",
+				`plants_care_bot_handled_updates_total{endpoint="message"} 1`,
+				"# TYPE go_goroutines gauge",
+			},
+		},
+		{
+			name:           "Wrong method",
+			path:           "/metrics",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			database := mockserver.NewMockDatabasePinger(ctrl)
+			telegram := mockserver.NewMockTelegramChecker(ctrl)
+			logger := mocklogging.NewMockLogger(ctrl)
+
+			appMetrics := metrics.New()
+			appMetrics.HandledUpdates.WithLabelValues("message").Inc()
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(database, telegram, logger)
+			}
+
+			adminServer := server.New(cfg, database, telegram, appMetrics.Handler(), logger)
+			adminServer.SetReady(tt.ready)
+
+			recorder := httptest.NewRecorder()
+			adminServer.Handler().ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			for _, expected := range tt.expectedBody {
+				assert.True(
+					t,
+					strings.Contains(recorder.Body.String(), expected),
+					"expected %q in body %q", expected, recorder.Body.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestServer_RunAndStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telegram := mockserver.NewMockTelegramChecker(ctrl)
+	telegram.EXPECT().GetMe().Return(nil).AnyTimes()
+
+	adminServer := server.New(
+		config.AdminConfig{
+			Listen:        "127.0.0.1:0",
+			GetMeInterval: time.Millisecond,
+			MaxGetMeAge:   time.Minute,
+		},
+		mockserver.NewMockDatabasePinger(ctrl),
+		telegram,
+		metrics.New().Handler(),
+		mocklogging.NewMockLogger(ctrl),
+	)
+
+	done := make(chan error)
+	go func() {
+		done <- adminServer.Run()
+	}()
+
+	// Даем серверу начать прослушивание и выполнить несколько проверок Telegram:
+	time.Sleep(time.Millisecond * 50)
+
+	assert.NoError(t, adminServer.Stop())
+	assert.NoError(t, <-done)
+}
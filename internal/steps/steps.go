@@ -31,4 +31,35 @@ const (
 	ChangeGroupLastWateringDate
 	ChangeGroupWateringInterval
 	ManageGroupSeePlants
+	Settings
+	ChangeTimezone
+	ChangeNotifyHour
+	WateringHistory
+	CareTasks
+	AddCareTaskType
+	AddCareTaskLastDate
+	AddCareTaskInterval
+	ConfirmAddCareTask
+	ManageCareTask
+	ChangeCareTaskLastDate
+	ChangeCareTaskInterval
+	ChangeGroupSeasonSchedule
+	ChangeGroupSeasonInterval
+	ChangePlantWateringInterval
+	PlantGallery
+	AddPlantGalleryPhoto
+	Household
+	HouseholdInvite
+	QuietSettings
+	ChangeQuietHoursStart
+	ChangeQuietHoursEnd
+	ChangeQuietWeekdays
+	ChangeVacationStart
+	ChangeVacationEnd
+	Handover
+	HandoverGroups
+	HandoverEndDate
+	HandoverInvite
+	ImportData
+	ConfirmImport
 )
@@ -0,0 +1,317 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/logging"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+const (
+	careTasksTableName     = "care_tasks"
+	typeColumnName         = "type"
+	intervalDaysColumnName = "interval_days"
+	lastCareDateColumnName = "last_care_date"
+	nextCareDateColumnName = "next_care_date"
+	careTaskIDColumnName   = "care_task_id"
+)
+
+type careTasksStorage struct {
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
+}
+
+func (s *careTasksStorage) CreateCareTask(ctx context.Context, careTask entities.CareTask) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CreateCareTask")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Insert(careTasksTableName).
+		Columns(
+			userIDColumnName,
+			groupIDColumnName,
+			plantIDColumnName,
+			typeColumnName,
+			intervalDaysColumnName,
+			lastCareDateColumnName,
+			nextCareDateColumnName,
+		).
+		Values(
+			careTask.UserID,
+			careTask.GroupID,
+			careTask.PlantID,
+			careTask.Type,
+			careTask.Interval,
+			careTask.LastCareDate,
+			careTask.NextCareDate,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var careTaskID int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&careTaskID); err != nil {
+		return 0, err
+	}
+
+	return careTaskID, nil
+}
+
+func (s *careTasksStorage) UpdateCareTask(ctx context.Context, careTask entities.CareTask) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "UpdateCareTask")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Update(careTasksTableName).
+		Where(sq.Eq{idColumnName: careTask.ID}).
+		Set(intervalDaysColumnName, careTask.Interval).
+		Set(lastCareDateColumnName, careTask.LastCareDate).
+		Set(nextCareDateColumnName, careTask.NextCareDate).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (s *careTasksStorage) DeleteCareTask(ctx context.Context, id int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "DeleteCareTask")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Delete(careTasksTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (s *careTasksStorage) GetCareTask(ctx context.Context, id int) (*entities.CareTask, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetCareTask")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(careTasksTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	careTask := &entities.CareTask{}
+
+	columns := db.GetEntityColumns(careTask)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return careTask, nil
+}
+
+func (s *careTasksStorage) GetGroupCareTasks(ctx context.Context, groupID int) ([]entities.CareTask, error) {
+	return s.getCareTasks(ctx, sq.Eq{groupIDColumnName: groupID})
+}
+
+func (s *careTasksStorage) GetPlantCareTasks(ctx context.Context, plantID int) ([]entities.CareTask, error) {
+	return s.getCareTasks(ctx, sq.Eq{plantIDColumnName: plantID})
+}
+
+// ClaimCareTasksForNotify атомарно захватывает до limit задач ухода, требующих уведомления, на время claimTTL.
+// Работает аналогично ClaimGroupsForNotify, поэтому воркеры не пересекаются и при обработке задач ухода.
+func (s *careTasksStorage) ClaimCareTasksForNotify(
+	ctx context.Context,
+	limit int,
+	claimTTL time.Duration,
+) ([]entities.CareTask, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "ClaimCareTasksForNotify")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	claimable := sq.
+		Select(idColumnName).
+		From(careTasksTableName).
+		Where(
+			sq.Expr(
+				nextCareDateColumnName + " < CURRENT_TIMESTAMP",
+			),
+		).
+		Where(
+			sq.Or{
+				sq.Eq{notifyClaimedAtColumnName: nil},
+				sq.Expr(
+					notifyClaimedAtColumnName+" < CURRENT_TIMESTAMP - make_interval(secs => ?)",
+					claimTTL.Seconds(),
+				),
+			},
+		).
+		OrderBy( // В порядке добавления задач
+			fmt.Sprintf(
+				"%s.%s %s",
+				careTasksTableName,
+				idColumnName,
+				asc,
+			),
+		).
+		Limit(uint64(limit)).
+		Suffix(skipLockedSuffix)
+
+	stmt, params, err := sq.
+		Update(careTasksTableName).
+		Set(notifyClaimedAtColumnName, sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Expr(idColumnName+" IN (?)", claimable)).
+		Suffix(returningAllSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queryCareTasks(ctx, connection, stmt, params)
+}
+
+func (s *careTasksStorage) getCareTasks(ctx context.Context, where sq.Eq) ([]entities.CareTask, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "getCareTasks")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(careTasksTableName).
+		Where(where).
+		OrderBy( // В порядке добавления задач
+			fmt.Sprintf(
+				"%s.%s %s",
+				careTasksTableName,
+				idColumnName,
+				asc,
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queryCareTasks(ctx, connection, stmt, params)
+}
+
+func (s *careTasksStorage) queryCareTasks(
+	ctx context.Context,
+	connection executor,
+	stmt string,
+	params []any,
+) ([]entities.CareTask, error) {
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				s.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var careTasks []entities.CareTask
+
+	for rows.Next() {
+		careTask := entities.CareTask{}
+		columns := db.GetEntityColumns(&careTask) // Only pointer to use rows.Scan() successfully
+
+		if err = rows.Scan(columns...); err != nil {
+			return nil, err
+		}
+
+		careTasks = append(careTasks, careTask)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return careTasks, nil
+}
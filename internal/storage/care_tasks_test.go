@@ -0,0 +1,324 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/DKhorkov/libs/logging"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestCareTasksStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(CareTasksStorageTestSuite))
+}
+
+type CareTasksStorageTestSuite struct {
+	suite.Suite
+
+	cwd         string
+	ctx         context.Context
+	dbConnector db.Connector
+	connection  *sql.Conn
+	storage     *careTasksStorage
+	logger      *mocklogging.MockLogger
+}
+
+func (s *CareTasksStorageTestSuite) SetupSuite() {
+	// Инициализируем переменные окружения для дальнейшего считывания:
+	loadenv.Init("../../.env")
+
+	cwd, err := os.Getwd()
+	s.NoError(err)
+
+	cfg := config.New()
+	logger := logging.New(
+		cfg.Logging.Level,
+		cfg.Logging.LogFilePath,
+	)
+
+	dbConnector, err := db.New(
+		db.BuildDsn(cfg.Database),
+		cfg.Database.Driver,
+		logger,
+		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	)
+	s.NoError(err, "failed to connect to database")
+
+	s.NoError(goose.SetDialect(cfg.Database.Driver))
+
+	ctrl := gomock.NewController(s.T())
+	s.logger = mocklogging.NewMockLogger(ctrl)
+
+	s.cwd = cwd
+	s.dbConnector = dbConnector
+	s.ctx = context.Background()
+
+	s.storage = &careTasksStorage{
+		dbConnector: s.dbConnector,
+		logger:      s.logger,
+	}
+}
+
+func (s *CareTasksStorageTestSuite) SetupTest() {
+	s.NoError(
+		goose.Up(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+		),
+	)
+
+	connection, err := s.dbConnector.Connection(s.ctx)
+	s.NoError(err)
+
+	s.connection = connection
+}
+
+func (s *CareTasksStorageTestSuite) TearDownTest() {
+	s.NoError(
+		goose.DownTo(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+			gooseZeroVersion,
+		),
+	)
+
+	s.NoError(s.connection.Close())
+}
+
+func (s *CareTasksStorageTestSuite) TearDownSuite() {
+	s.NoError(s.dbConnector.Close())
+}
+
+func (s *CareTasksStorageTestSuite) createUser(now time.Time, offset int) int {
+	var userID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO users (
+				telegram_id, username, firstname, lastname, is_bot, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $6)
+			RETURNING id
+		`,
+		123456789+int64(offset),
+		fmt.Sprintf("user%d", offset),
+		fmt.Sprintf("First%d", offset),
+		fmt.Sprintf("Last%d", offset),
+		false,
+		now,
+	).Scan(&userID)
+	s.NoError(err)
+	return userID
+}
+
+func (s *CareTasksStorageTestSuite) createGroupForUser(userID int, now time.Time, offset int) int {
+	var groupID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO groups (
+				user_id, title, watering_interval, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $4)
+			RETURNING id
+		`,
+		userID,
+		fmt.Sprintf("Группа %d", offset),
+		7+offset,
+		now,
+	).Scan(&groupID)
+	s.NoError(err)
+	return groupID
+}
+
+func (s *CareTasksStorageTestSuite) createPlant(groupID, userID int, now time.Time) int {
+	var plantID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO plants (
+				group_id, user_id, title, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $4)
+			RETURNING id
+		`,
+		groupID,
+		userID,
+		"Фикус",
+		now,
+	).Scan(&plantID)
+	s.NoError(err)
+	return plantID
+}
+
+func (s *CareTasksStorageTestSuite) newCareTask(userID int, groupID, plantID *int, careTaskType string) entities.CareTask {
+	lastCareDate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	return entities.CareTask{
+		UserID:       userID,
+		GroupID:      groupID,
+		PlantID:      plantID,
+		Type:         careTaskType,
+		Interval:     30,
+		LastCareDate: lastCareDate,
+		NextCareDate: lastCareDate.AddDate(0, 0, 30),
+	}
+}
+
+func (s *CareTasksStorageTestSuite) TestCreateCareTask_Success() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	careTaskID, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing))
+	s.NoError(err)
+	s.Greater(careTaskID, 0)
+
+	careTask, err := s.storage.GetCareTask(s.ctx, careTaskID)
+	s.NoError(err)
+	s.Equal(userID, careTask.UserID)
+	s.Equal(groupID, *careTask.GroupID)
+	s.Nil(careTask.PlantID)
+	s.Equal(entities.CareTaskTypeFertilizing, careTask.Type)
+	s.Equal(30, careTask.Interval)
+	s.Nil(careTask.NotifyClaimedAt)
+}
+
+func (s *CareTasksStorageTestSuite) TestCreateCareTask_InvalidType() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	_, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, nil, "pruning"))
+	s.Error(err)
+	s.Contains(err.Error(), "violates check constraint")
+}
+
+func (s *CareTasksStorageTestSuite) TestCreateCareTask_BothOwners() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlant(groupID, userID, now)
+
+	_, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, &plantID, entities.CareTaskTypeMisting))
+	s.Error(err)
+	s.Contains(err.Error(), "violates check constraint")
+}
+
+func (s *CareTasksStorageTestSuite) TestCreateCareTask_DuplicateType() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	_, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeMisting))
+	s.NoError(err)
+
+	_, err = s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeMisting))
+	s.Error(err)
+	s.Contains(err.Error(), "duplicate key value")
+}
+
+func (s *CareTasksStorageTestSuite) TestGetOwnerCareTasks() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlant(groupID, userID, now)
+
+	_, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing))
+	s.NoError(err)
+
+	_, err = s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, nil, &plantID, entities.CareTaskTypeMisting))
+	s.NoError(err)
+
+	_, err = s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, nil, &plantID, entities.CareTaskTypeRepotting))
+	s.NoError(err)
+
+	groupCareTasks, err := s.storage.GetGroupCareTasks(s.ctx, groupID)
+	s.NoError(err)
+	s.Len(groupCareTasks, 1)
+
+	plantCareTasks, err := s.storage.GetPlantCareTasks(s.ctx, plantID)
+	s.NoError(err)
+	s.Len(plantCareTasks, 2)
+}
+
+func (s *CareTasksStorageTestSuite) TestUpdateCareTask() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	careTaskID, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeRepotting))
+	s.NoError(err)
+
+	careTask, err := s.storage.GetCareTask(s.ctx, careTaskID)
+	s.NoError(err)
+
+	careTask.Interval = 365
+	careTask.NextCareDate = careTask.LastCareDate.AddDate(0, 0, 365)
+	s.NoError(s.storage.UpdateCareTask(s.ctx, *careTask))
+
+	updated, err := s.storage.GetCareTask(s.ctx, careTaskID)
+	s.NoError(err)
+	s.Equal(365, updated.Interval)
+	s.True(careTask.NextCareDate.Equal(updated.NextCareDate))
+}
+
+func (s *CareTasksStorageTestSuite) TestDeleteCareTask_CascadesOnPlantRemoval() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlant(groupID, userID, now)
+
+	careTaskID, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, nil, &plantID, entities.CareTaskTypeMisting))
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(context.Background(), `DELETE FROM plants WHERE id = $1`, plantID)
+	s.NoError(err)
+
+	_, err = s.storage.GetCareTask(s.ctx, careTaskID)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *CareTasksStorageTestSuite) TestClaimCareTasksForNotify() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	// Просроченная задача ухода должна быть захвачена:
+	dueID, err := s.storage.CreateCareTask(s.ctx, s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing))
+	s.NoError(err)
+
+	// Задача ухода в будущем не должна быть захвачена:
+	future := s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeMisting)
+	future.NextCareDate = now.AddDate(0, 0, 10)
+	_, err = s.storage.CreateCareTask(s.ctx, future)
+	s.NoError(err)
+
+	claimed, err := s.storage.ClaimCareTasksForNotify(s.ctx, 10, time.Minute)
+	s.NoError(err)
+	s.Len(claimed, 1)
+	s.Equal(dueID, claimed[0].ID)
+	s.NotNil(claimed[0].NotifyClaimedAt)
+
+	// Повторный захват в течение claimTTL ничего не возвращает:
+	claimed, err = s.storage.ClaimCareTasksForNotify(s.ctx, 10, time.Minute)
+	s.NoError(err)
+	s.Empty(claimed)
+}
@@ -0,0 +1,90 @@
+//go:build integration
+
+package storage
+
+import (
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/DKhorkov/libs/logging"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/storage/storagetest"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestContractTestSuite(t *testing.T) {
+	suite.Run(t, new(ContractTestSuite))
+}
+
+// ContractTestSuite проверяет, что хранилище Postgres соответствует общему контракту хранилищ.
+type ContractTestSuite struct {
+	storagetest.Suite
+
+	cwd         string
+	dbConnector db.Connector
+}
+
+func (s *ContractTestSuite) SetupSuite() {
+	// Инициализируем переменные окружения для дальнейшего считывания:
+	loadenv.Init("../../.env")
+
+	cwd, err := os.Getwd()
+	s.NoError(err)
+
+	cfg := config.New()
+	logger := logging.New(
+		cfg.Logging.Level,
+		cfg.Logging.LogFilePath,
+	)
+
+	dbConnector, err := db.New(
+		db.BuildDsn(cfg.Database),
+		cfg.Database.Driver,
+		logger,
+		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	)
+	s.NoError(err, "failed to connect to database")
+
+	s.NoError(goose.SetDialect(cfg.Database.Driver))
+
+	ctrl := gomock.NewController(s.T())
+
+	s.cwd = cwd
+	s.dbConnector = dbConnector
+	s.Storage = New(s.dbConnector, cfg.Storage.QueryTimeout, nil, mocklogging.NewMockLogger(ctrl))
+}
+
+func (s *ContractTestSuite) SetupTest() {
+	s.NoError(
+		goose.Up(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+		),
+	)
+}
+
+func (s *ContractTestSuite) TearDownTest() {
+	s.NoError(
+		goose.DownTo(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+			gooseZeroVersion,
+		),
+	)
+}
+
+func (s *ContractTestSuite) TearDownSuite() {
+	s.NoError(s.dbConnector.Close())
+}
@@ -0,0 +1,263 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/logging"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+const (
+	delegationsTableName = "delegations"
+	sitterIDColumnName   = "sitter_id"
+	groupIDsColumnName   = "group_ids"
+	endDateColumnName    = "end_date"
+)
+
+type delegationsStorage struct {
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
+}
+
+func (s *delegationsStorage) CreateDelegation(ctx context.Context, delegation entities.Delegation) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CreateDelegation")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Insert(delegationsTableName).
+		Columns(
+			ownerIDColumnName,
+			groupIDsColumnName,
+			tokenColumnName,
+			endDateColumnName,
+		).
+		Values(
+			delegation.OwnerID,
+			delegation.GroupIDs,
+			delegation.Token,
+			delegation.EndDate,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var delegationID int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&delegationID); err != nil {
+		return 0, err
+	}
+
+	return delegationID, nil
+}
+
+func (s *delegationsStorage) GetDelegation(ctx context.Context, id int) (*entities.Delegation, error) {
+	return s.getDelegation(ctx,
+		sq.
+			Select(selectAllColumns).
+			From(delegationsTableName).
+			Where(sq.Eq{idColumnName: id}),
+	)
+}
+
+func (s *delegationsStorage) GetDelegationByToken(ctx context.Context, token string) (*entities.Delegation, error) {
+	return s.getDelegation(ctx,
+		sq.
+			Select(selectAllColumns).
+			From(delegationsTableName).
+			Where(sq.Eq{tokenColumnName: token}),
+	)
+}
+
+// GetGroupDelegation возвращает принятую передачу сценария, которая действует в указанную дату.
+// Если сценарий передавался несколько раз, возвращается последняя передача.
+func (s *delegationsStorage) GetGroupDelegation(
+	ctx context.Context,
+	groupID int,
+	date time.Time,
+) (*entities.Delegation, error) {
+	return s.getDelegation(ctx,
+		sq.
+			Select(selectAllColumns).
+			From(delegationsTableName).
+			Where(sq.NotEq{sitterIDColumnName: nil}).
+			Where(sq.GtOrEq{endDateColumnName: date}).
+			Where(sq.Expr(fmt.Sprintf("%s @> ?::jsonb", groupIDsColumnName), fmt.Sprintf("[%d]", groupID))).
+			OrderBy(idColumnName+" DESC").
+			Limit(1),
+	)
+}
+
+// GetOwnerDelegations возвращает передачи пользователя, которые действуют в указанную дату или еще не начались.
+func (s *delegationsStorage) GetOwnerDelegations(
+	ctx context.Context,
+	ownerID int,
+	date time.Time,
+) ([]entities.Delegation, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetOwnerDelegations")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(delegationsTableName).
+		Where(sq.Eq{ownerIDColumnName: ownerID}).
+		Where(sq.GtOrEq{endDateColumnName: date}).
+		OrderBy(idColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				s.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var delegations []entities.Delegation
+
+	for rows.Next() {
+		delegation := entities.Delegation{}
+		columns := db.GetEntityColumns(&delegation) // Only pointer to use rows.Scan() successfully
+
+		if err = rows.Scan(columns...); err != nil {
+			return nil, err
+		}
+
+		delegations = append(delegations, delegation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return delegations, nil
+}
+
+func (s *delegationsStorage) UpdateDelegationSitter(ctx context.Context, id, sitterID int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "UpdateDelegationSitter")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Update(delegationsTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Set(sitterIDColumnName, sitterID).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (s *delegationsStorage) DeleteDelegation(ctx context.Context, id int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "DeleteDelegation")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Delete(delegationsTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (s *delegationsStorage) getDelegation(ctx context.Context, query sq.SelectBuilder) (*entities.Delegation, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "getDelegation")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := query.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	delegation := &entities.Delegation{}
+
+	columns := db.GetEntityColumns(delegation)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return delegation, nil
+}
@@ -0,0 +1,204 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/DKhorkov/libs/logging"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestDelegationsStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(DelegationsStorageTestSuite))
+}
+
+type DelegationsStorageTestSuite struct {
+	suite.Suite
+
+	cwd         string
+	ctx         context.Context
+	dbConnector db.Connector
+	connection  *sql.Conn
+	storage     *delegationsStorage
+	logger      *mocklogging.MockLogger
+}
+
+func (s *DelegationsStorageTestSuite) SetupSuite() {
+	// Инициализируем переменные окружения для дальнейшего считывания:
+	loadenv.Init("../../.env")
+
+	cwd, err := os.Getwd()
+	s.NoError(err)
+
+	cfg := config.New()
+	logger := logging.New(
+		cfg.Logging.Level,
+		cfg.Logging.LogFilePath,
+	)
+
+	dbConnector, err := db.New(
+		db.BuildDsn(cfg.Database),
+		cfg.Database.Driver,
+		logger,
+		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	)
+	s.NoError(err, "failed to connect to database")
+
+	s.NoError(goose.SetDialect(cfg.Database.Driver))
+
+	ctrl := gomock.NewController(s.T())
+	s.logger = mocklogging.NewMockLogger(ctrl)
+
+	s.cwd = cwd
+	s.dbConnector = dbConnector
+	s.ctx = context.Background()
+
+	s.storage = &delegationsStorage{
+		dbConnector: s.dbConnector,
+		logger:      s.logger,
+	}
+}
+
+func (s *DelegationsStorageTestSuite) SetupTest() {
+	s.NoError(
+		goose.Up(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+		),
+	)
+
+	connection, err := s.dbConnector.Connection(s.ctx)
+	s.NoError(err)
+
+	s.connection = connection
+}
+
+func (s *DelegationsStorageTestSuite) TearDownTest() {
+	s.NoError(
+		goose.DownTo(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+			gooseZeroVersion,
+		),
+	)
+
+	s.NoError(s.connection.Close())
+}
+
+func (s *DelegationsStorageTestSuite) TearDownSuite() {
+	s.NoError(s.dbConnector.Close())
+}
+
+func (s *DelegationsStorageTestSuite) createUser(now time.Time, offset int) int {
+	var userID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO users (
+				telegram_id, username, firstname, lastname, is_bot, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $6)
+			RETURNING id
+		`,
+		123456789+int64(offset),
+		fmt.Sprintf("user%d", offset),
+		fmt.Sprintf("First%d", offset),
+		fmt.Sprintf("Last%d", offset),
+		false,
+		now,
+	).Scan(&userID)
+	s.NoError(err)
+	return userID
+}
+
+func (s *DelegationsStorageTestSuite) TestDelegation_Lifecycle() {
+	now := time.Now().UTC()
+	ownerID := s.createUser(now, 1)
+	sitterID := s.createUser(now, 2)
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7)
+
+	delegationID, err := s.storage.CreateDelegation(
+		s.ctx,
+		entities.Delegation{
+			OwnerID:  ownerID,
+			GroupIDs: entities.DelegationGroups{3, 4},
+			Token:    "sitter-token",
+			EndDate:  endDate,
+		},
+	)
+	s.NoError(err)
+
+	delegation, err := s.storage.GetDelegationByToken(s.ctx, "sitter-token")
+	s.NoError(err)
+	s.Equal(delegationID, delegation.ID)
+	s.Equal(ownerID, delegation.OwnerID)
+	s.Nil(delegation.SitterID)
+	s.Equal(entities.DelegationGroups{3, 4}, delegation.GroupIDs)
+	s.True(endDate.Equal(delegation.EndDate))
+
+	// Пока приглашение не принято, напоминания по сценарию не передаются:
+	_, err = s.storage.GetGroupDelegation(s.ctx, 3, endDate)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	s.NoError(s.storage.UpdateDelegationSitter(s.ctx, delegationID, sitterID))
+
+	delegation, err = s.storage.GetGroupDelegation(s.ctx, 3, endDate)
+	s.NoError(err)
+	s.Equal(delegationID, delegation.ID)
+	s.Equal(sitterID, *delegation.SitterID)
+
+	_, err = s.storage.GetGroupDelegation(s.ctx, 5, endDate)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.storage.GetGroupDelegation(s.ctx, 3, endDate.AddDate(0, 0, 1))
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	s.NoError(s.storage.DeleteDelegation(s.ctx, delegationID))
+
+	_, err = s.storage.GetDelegation(s.ctx, delegationID)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *DelegationsStorageTestSuite) TestGetOwnerDelegations_SkipsFinished() {
+	now := time.Now().UTC()
+	ownerID := s.createUser(now, 1)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	for i, endDate := range []time.Time{today.AddDate(0, 0, -1), today, today.AddDate(0, 0, 3)} {
+		_, err := s.storage.CreateDelegation(
+			s.ctx,
+			entities.Delegation{
+				OwnerID:  ownerID,
+				GroupIDs: entities.DelegationGroups{1},
+				Token:    fmt.Sprintf("sitter-%d", i),
+				EndDate:  endDate,
+			},
+		)
+		s.NoError(err)
+	}
+
+	delegations, err := s.storage.GetOwnerDelegations(s.ctx, ownerID, today)
+	s.NoError(err)
+	s.Len(delegations, 2)
+	s.Equal("sitter-1", delegations[0].Token)
+	s.Equal("sitter-2", delegations[1].Token)
+}
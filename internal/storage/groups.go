@@ -11,6 +11,7 @@ import (
 	"github.com/DKhorkov/libs/logging"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 )
@@ -27,25 +28,34 @@ const (
 	wateringIntervalColumnName = "watering_interval"
 	updatedAtColumnName        = "updated_at"
 	createdAtColumnName        = "created_at"
+	notifyClaimedAtColumnName  = "notify_claimed_at"
+	snoozedUntilColumnName     = "snoozed_until"
+	seasonScheduleColumnName   = "season_schedule"
+	returningAllSuffix         = "RETURNING *"
+	skipLockedSuffix           = "FOR UPDATE SKIP LOCKED"
 	selectExists               = "1"
 	asc                        = "ASC"
 	desc                       = "DESC"
 )
 
 type groupsStorage struct {
-	dbConnector db.Connector
-	logger      logging.Logger
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
 }
 
-func (s *groupsStorage) CreateGroup(group entities.Group) (int, error) {
-	ctx := context.Background()
+func (s *groupsStorage) CreateGroup(ctx context.Context, group entities.Group) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CreateGroup")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return 0, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Insert(groupsTableName).
@@ -56,6 +66,7 @@ func (s *groupsStorage) CreateGroup(group entities.Group) (int, error) {
 			lastWateringDateColumnName,
 			nextWateringDateColumnName,
 			wateringIntervalColumnName,
+			seasonScheduleColumnName,
 		).
 		Values(
 			group.UserID,
@@ -64,6 +75,7 @@ func (s *groupsStorage) CreateGroup(group entities.Group) (int, error) {
 			group.LastWateringDate,
 			group.NextWateringDate,
 			group.WateringInterval,
+			group.SeasonSchedule,
 		).
 		Suffix(returningIDSuffix).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
@@ -80,15 +92,16 @@ func (s *groupsStorage) CreateGroup(group entities.Group) (int, error) {
 	return groupID, nil
 }
 
-func (s *groupsStorage) UpdateGroup(group entities.Group) error {
-	ctx := context.Background()
+func (s *groupsStorage) UpdateGroup(ctx context.Context, group entities.Group) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "UpdateGroup")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Update(groupsTableName).
@@ -99,6 +112,8 @@ func (s *groupsStorage) UpdateGroup(group entities.Group) error {
 		Set(lastWateringDateColumnName, group.LastWateringDate).
 		Set(nextWateringDateColumnName, group.NextWateringDate).
 		Set(wateringIntervalColumnName, group.WateringInterval).
+		Set(snoozedUntilColumnName, group.SnoozedUntil).
+		Set(seasonScheduleColumnName, group.SeasonSchedule).
 		Set(updatedAtColumnName, time.Now()).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
 		ToSql()
@@ -111,15 +126,16 @@ func (s *groupsStorage) UpdateGroup(group entities.Group) error {
 	return err
 }
 
-func (s *groupsStorage) GroupExists(group entities.Group) (bool, error) {
-	ctx := context.Background()
+func (s *groupsStorage) GroupExists(ctx context.Context, group entities.Group) (bool, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GroupExists")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return false, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectExists).
@@ -148,15 +164,16 @@ func (s *groupsStorage) GroupExists(group entities.Group) (bool, error) {
 	return exists, nil
 }
 
-func (s *groupsStorage) DeleteGroup(id int) error {
-	ctx := context.Background()
+func (s *groupsStorage) DeleteGroup(ctx context.Context, id int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "DeleteGroup")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Delete(groupsTableName).
@@ -176,20 +193,22 @@ func (s *groupsStorage) DeleteGroup(id int) error {
 	return err
 }
 
-func (s *groupsStorage) GetUserGroups(userID int) ([]entities.Group, error) {
-	ctx := context.Background()
+func (s *groupsStorage) GetUserGroups(ctx context.Context, userID int) ([]entities.Group, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetUserGroups")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
+	// Сценарии общие для всех участников дома:
 	stmt, params, err := sq.
 		Select(selectAllColumns).
 		From(groupsTableName).
-		Where(sq.Eq{userIDColumnName: userID}).
+		Where(userOrHouseholdExpr(userIDColumnName, userID)).
 		OrderBy( // В порядке добавления сценариев
 			fmt.Sprintf(
 				"%s.%s %s",
@@ -244,20 +263,21 @@ func (s *groupsStorage) GetUserGroups(userID int) ([]entities.Group, error) {
 	return groups, nil
 }
 
-func (s *groupsStorage) CountUserGroups(userID int) (int, error) {
-	ctx := context.Background()
+func (s *groupsStorage) CountUserGroups(ctx context.Context, userID int) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CountUserGroups")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return 0, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectCount).
 		From(groupsTableName).
-		Where(sq.Eq{userIDColumnName: userID}).
+		Where(userOrHouseholdExpr(userIDColumnName, userID)). // Сценарии общие для всех участников дома
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -272,15 +292,16 @@ func (s *groupsStorage) CountUserGroups(userID int) (int, error) {
 	return count, nil
 }
 
-func (s *groupsStorage) GetGroup(id int) (*entities.Group, error) {
-	ctx := context.Background()
+func (s *groupsStorage) GetGroup(ctx context.Context, id int) (*entities.Group, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetGroup")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectAllColumns).
@@ -302,15 +323,16 @@ func (s *groupsStorage) GetGroup(id int) (*entities.Group, error) {
 	return group, nil
 }
 
-func (s *groupsStorage) GetGroupsForNotify(limit, offset int) ([]entities.Group, error) {
-	ctx := context.Background()
+func (s *groupsStorage) GetGroupsForNotify(ctx context.Context, limit, offset int) ([]entities.Group, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetGroupsForNotify")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectAllColumns).
@@ -375,3 +397,100 @@ func (s *groupsStorage) GetGroupsForNotify(limit, offset int) ([]entities.Group,
 
 	return groups, nil
 }
+
+// ClaimGroupsForNotify атомарно захватывает до limit сценариев, требующих уведомления, на время claimTTL.
+// Строки, заблокированные другими воркерами, пропускаются, поэтому несколько воркеров (в том числе
+// в разных репликах бота) обрабатывают сценарии без пересечений.
+func (s *groupsStorage) ClaimGroupsForNotify(
+	ctx context.Context,
+	limit int,
+	claimTTL time.Duration,
+) ([]entities.Group, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "ClaimGroupsForNotify")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	claimable := sq.
+		Select(idColumnName).
+		From(groupsTableName).
+		Where(
+			sq.Expr(
+				nextWateringDateColumnName + " < CURRENT_TIMESTAMP",
+			),
+		).
+		Where(
+			sq.Or{
+				sq.Eq{notifyClaimedAtColumnName: nil},
+				sq.Expr(
+					notifyClaimedAtColumnName+" < CURRENT_TIMESTAMP - make_interval(secs => ?)",
+					claimTTL.Seconds(),
+				),
+			},
+		).
+		OrderBy( // В порядке добавления сценариев
+			fmt.Sprintf(
+				"%s.%s %s",
+				groupsTableName,
+				idColumnName,
+				asc,
+			),
+		).
+		Limit(uint64(limit)).
+		Suffix(skipLockedSuffix)
+
+	stmt, params, err := sq.
+		Update(groupsTableName).
+		Set(notifyClaimedAtColumnName, sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Expr(idColumnName+" IN (?)", claimable)).
+		Suffix(returningAllSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				s.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var groups []entities.Group
+
+	for rows.Next() {
+		group := entities.Group{}
+		columns := db.GetEntityColumns(&group) // Only pointer to use rows.Scan() successfully
+
+		if err = rows.Scan(columns...); err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
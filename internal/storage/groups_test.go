@@ -17,6 +17,7 @@ import (
 	"go.uber.org/mock/gomock"
 	"os"
 	"path"
+	"sync"
 	"testing"
 	"time"
 )
@@ -168,7 +169,7 @@ func (s *GroupsStorageTestSuite) TestCreateGroup_Success() {
 		WateringInterval: 7,
 	}
 
-	groupID, err := s.storage.CreateGroup(group)
+	groupID, err := s.storage.CreateGroup(s.ctx, group)
 	s.NoError(err)
 	s.Greater(groupID, 0)
 
@@ -208,7 +209,7 @@ func (s *GroupsStorageTestSuite) TestUpdateGroup_Success() {
 		WateringInterval: 14,
 	}
 
-	err := s.storage.UpdateGroup(updatedGroup)
+	err := s.storage.UpdateGroup(s.ctx, updatedGroup)
 	s.NoError(err)
 
 	var storedGroup entities.Group
@@ -226,6 +227,68 @@ func (s *GroupsStorageTestSuite) TestUpdateGroup_Success() {
 	s.True(storedGroup.UpdatedAt.After(now))
 }
 
+func (s *GroupsStorageTestSuite) TestUpdateGroup_SnoozedUntil() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, "Отложенный", now, 1)
+
+	group, err := s.storage.GetGroup(s.ctx, groupID)
+	s.NoError(err)
+	s.Nil(group.SnoozedUntil)
+
+	snoozedUntil := now.Add(3 * time.Hour)
+	group.SnoozedUntil = &snoozedUntil
+	s.NoError(s.storage.UpdateGroup(s.ctx, *group))
+
+	group, err = s.storage.GetGroup(s.ctx, groupID)
+	s.NoError(err)
+	s.NotNil(group.SnoozedUntil)
+	s.WithinDuration(snoozedUntil, *group.SnoozedUntil, time.Second)
+
+	// Сброс отложенного напоминания:
+	group.SnoozedUntil = nil
+	s.NoError(s.storage.UpdateGroup(s.ctx, *group))
+
+	group, err = s.storage.GetGroup(s.ctx, groupID)
+	s.NoError(err)
+	s.Nil(group.SnoozedUntil)
+}
+
+func (s *GroupsStorageTestSuite) TestUpdateGroup_SeasonSchedule() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, "Суккуленты", now, 1)
+
+	group, err := s.storage.GetGroup(s.ctx, groupID)
+	s.NoError(err)
+	s.Nil(group.SeasonSchedule)
+
+	schedule := entities.SeasonSchedule{
+		{StartMonth: time.December, EndMonth: time.February, WateringInterval: 21},
+		{StartMonth: time.June, EndMonth: time.August, WateringInterval: 7},
+	}
+
+	group.SeasonSchedule = schedule
+	s.NoError(s.storage.UpdateGroup(s.ctx, *group))
+
+	group, err = s.storage.GetGroup(s.ctx, groupID)
+	s.NoError(err)
+	s.Equal(schedule, group.SeasonSchedule)
+
+	// Пустой график сохраняется как NULL:
+	group.SeasonSchedule = nil
+	s.NoError(s.storage.UpdateGroup(s.ctx, *group))
+
+	var isNull bool
+	err = s.connection.QueryRowContext(
+		context.Background(),
+		`SELECT season_schedule IS NULL FROM groups WHERE id = $1`,
+		groupID,
+	).Scan(&isNull)
+	s.NoError(err)
+	s.True(isNull)
+}
+
 func (s *GroupsStorageTestSuite) TestUpdateGroup_NonExistent() {
 	nonExistentGroup := entities.Group{
 		ID:               999999,
@@ -234,7 +297,7 @@ func (s *GroupsStorageTestSuite) TestUpdateGroup_NonExistent() {
 		WateringInterval: 7,
 	}
 
-	err := s.storage.UpdateGroup(nonExistentGroup)
+	err := s.storage.UpdateGroup(s.ctx, nonExistentGroup)
 	s.NoError(err) // Должен вернуть nil, даже если нет строки (UPDATE 0)
 }
 
@@ -243,7 +306,7 @@ func (s *GroupsStorageTestSuite) TestGroupExists_Exists() {
 	userID := s.createUser(now, 1)
 	s.createGroupForUser(userID, "Суккуленты", now, 1)
 
-	exists, err := s.storage.GroupExists(entities.Group{
+	exists, err := s.storage.GroupExists(s.ctx, entities.Group{
 		UserID: userID,
 		Title:  "Суккуленты",
 	})
@@ -252,7 +315,7 @@ func (s *GroupsStorageTestSuite) TestGroupExists_Exists() {
 }
 
 func (s *GroupsStorageTestSuite) TestGroupExists_NotExists() {
-	exists, err := s.storage.GroupExists(entities.Group{
+	exists, err := s.storage.GroupExists(s.ctx, entities.Group{
 		UserID: 999,
 		Title:  "Не существующая",
 	})
@@ -266,7 +329,7 @@ func (s *GroupsStorageTestSuite) TestGroupExists_DifferentUserSameTitle() {
 	userID2 := s.createUser(now, 2)
 	s.createGroupForUser(userID1, "Одинаковое имя", now, 1)
 
-	exists, err := s.storage.GroupExists(entities.Group{
+	exists, err := s.storage.GroupExists(s.ctx, entities.Group{
 		UserID: userID2,
 		Title:  "Одинаковое имя",
 	})
@@ -279,7 +342,7 @@ func (s *GroupsStorageTestSuite) TestDeleteGroup_Success() {
 	userID := s.createUser(now, 1)
 	groupID := s.createGroupForUser(userID, "Для удаления", now, 1)
 
-	err := s.storage.DeleteGroup(groupID)
+	err := s.storage.DeleteGroup(s.ctx, groupID)
 	s.NoError(err)
 
 	var count int
@@ -293,7 +356,7 @@ func (s *GroupsStorageTestSuite) TestDeleteGroup_Success() {
 }
 
 func (s *GroupsStorageTestSuite) TestDeleteGroup_NonExistent() {
-	err := s.storage.DeleteGroup(999999)
+	err := s.storage.DeleteGroup(s.ctx, 999999)
 	s.NoError(err) // Удаление несуществующей строки — не ошибка
 }
 
@@ -303,7 +366,7 @@ func (s *GroupsStorageTestSuite) TestGetUserGroups_HasGroups() {
 	s.createGroupForUser(userID, "Группа 1", now, 1)
 	s.createGroupForUser(userID, "Группа 2", now, 2)
 
-	groups, err := s.storage.GetUserGroups(userID)
+	groups, err := s.storage.GetUserGroups(s.ctx, userID)
 	s.NoError(err)
 	s.Len(groups, 2)
 	s.Equal("Группа 1", groups[0].Title)
@@ -314,7 +377,7 @@ func (s *GroupsStorageTestSuite) TestGetUserGroups_NoGroups() {
 	now := time.Now().UTC()
 	userID := s.createUser(now, 1)
 
-	groups, err := s.storage.GetUserGroups(userID)
+	groups, err := s.storage.GetUserGroups(s.ctx, userID)
 	s.NoError(err)
 	s.Empty(groups)
 }
@@ -326,7 +389,7 @@ func (s *GroupsStorageTestSuite) TestCountUserGroups_HasGroups() {
 	s.createGroupForUser(userID, "Группа B", now, 2)
 	s.createGroupForUser(userID, "Группа C", now, 3)
 
-	count, err := s.storage.CountUserGroups(userID)
+	count, err := s.storage.CountUserGroups(s.ctx, userID)
 	s.NoError(err)
 	s.Equal(3, count)
 }
@@ -335,7 +398,7 @@ func (s *GroupsStorageTestSuite) TestCountUserGroups_NoGroups() {
 	now := time.Now().UTC()
 	userID := s.createUser(now, 1)
 
-	count, err := s.storage.CountUserGroups(userID)
+	count, err := s.storage.CountUserGroups(s.ctx, userID)
 	s.NoError(err)
 	s.Equal(0, count)
 }
@@ -345,7 +408,7 @@ func (s *GroupsStorageTestSuite) TestGetGroup_Exists() {
 	userID := s.createUser(now, 1)
 	groupID := s.createGroupForUser(userID, "Тестовая", now, 1)
 
-	group, err := s.storage.GetGroup(groupID)
+	group, err := s.storage.GetGroup(s.ctx, groupID)
 	s.NoError(err)
 	s.NotNil(group)
 	s.Equal(groupID, group.ID)
@@ -353,7 +416,7 @@ func (s *GroupsStorageTestSuite) TestGetGroup_Exists() {
 }
 
 func (s *GroupsStorageTestSuite) TestGetGroup_NotFound() {
-	group, err := s.storage.GetGroup(999999)
+	group, err := s.storage.GetGroup(s.ctx, 999999)
 	s.Error(err)
 	s.Nil(group)
 }
@@ -370,7 +433,7 @@ func (s *GroupsStorageTestSuite) TestGetGroupsForNotify_HasDueGroups() {
 	// Группа с будущим next_watering_date — не должна попасть
 	s.createGroupForUser(s.createUser(now, 3), "Ещё не время", now.AddDate(0, 0, 1), 3)
 
-	groups, err := s.storage.GetGroupsForNotify(10, 0)
+	groups, err := s.storage.GetGroupsForNotify(s.ctx, 10, 0)
 	s.NoError(err)
 	s.Len(groups, 2)
 
@@ -383,7 +446,7 @@ func (s *GroupsStorageTestSuite) TestGetGroupsForNotify_NoDueGroups() {
 	now := time.Now().UTC()
 	s.createGroupForUser(s.createUser(now, 1), "Будущее", now.AddDate(0, 0, 1), 1)
 
-	groups, err := s.storage.GetGroupsForNotify(10, 0)
+	groups, err := s.storage.GetGroupsForNotify(s.ctx, 10, 0)
 	s.NoError(err)
 	s.Empty(groups)
 }
@@ -397,7 +460,7 @@ func (s *GroupsStorageTestSuite) TestGetGroupsForNotify_WithLimitAndOffset() {
 	}
 
 	// Лимит 2, оффсет 1 → ожидаем группы 2 и 3 (по ID)
-	groups, err := s.storage.GetGroupsForNotify(2, 1)
+	groups, err := s.storage.GetGroupsForNotify(s.ctx, 2, 1)
 	s.NoError(err)
 	s.Len(groups, 2)
 
@@ -405,3 +468,96 @@ func (s *GroupsStorageTestSuite) TestGetGroupsForNotify_WithLimitAndOffset() {
 	s.Equal(2, groups[0].ID)
 	s.Equal(3, groups[1].ID)
 }
+
+func (s *GroupsStorageTestSuite) TestClaimGroupsForNotify_ClaimsOnlyDueGroups() {
+	now := time.Now().UTC()
+
+	s.createGroupForUser(s.createUser(now, 1), "Просрочено", now.AddDate(0, 0, -2), 1)
+	s.createGroupForUser(s.createUser(now, 2), "Ещё не время", now.AddDate(0, 0, 1), 2)
+
+	groups, err := s.storage.ClaimGroupsForNotify(s.ctx, 10, time.Minute)
+	s.NoError(err)
+	s.Len(groups, 1)
+	s.Equal("Просрочено", groups[0].Title)
+	s.NotNil(groups[0].NotifyClaimedAt)
+}
+
+func (s *GroupsStorageTestSuite) TestClaimGroupsForNotify_ClaimedGroupsSkippedUntilTTLExpires() {
+	now := time.Now().UTC()
+	s.createGroupForUser(s.createUser(now, 1), "Просрочено", now.AddDate(0, 0, -2), 1)
+
+	groups, err := s.storage.ClaimGroupsForNotify(s.ctx, 10, time.Hour)
+	s.NoError(err)
+	s.Len(groups, 1)
+
+	// Пока аренда действует, сценарий повторно не выдается:
+	groups, err = s.storage.ClaimGroupsForNotify(s.ctx, 10, time.Hour)
+	s.NoError(err)
+	s.Empty(groups)
+
+	// После истечения аренды сценарий снова доступен:
+	time.Sleep(1100 * time.Millisecond)
+
+	groups, err = s.storage.ClaimGroupsForNotify(s.ctx, 10, time.Second)
+	s.NoError(err)
+	s.Len(groups, 1)
+}
+
+func (s *GroupsStorageTestSuite) TestClaimGroupsForNotify_ConcurrentWorkersClaimEachGroupOnce() {
+	const (
+		groupsCount  = 30
+		workersCount = 5
+		limit        = 4
+	)
+
+	now := time.Now().UTC()
+	for i := 1; i <= groupsCount; i++ {
+		s.createGroupForUser(s.createUser(now, i), fmt.Sprintf("Группа %d", i), now.AddDate(0, 0, -i), i)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		claimed = make(map[int]int)
+		errs    = make(chan error, workersCount)
+	)
+
+	for range workersCount {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				groups, err := s.storage.ClaimGroupsForNotify(s.ctx, limit, time.Hour)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				if len(groups) == 0 {
+					return
+				}
+
+				mu.Lock()
+				for _, group := range groups {
+					claimed[group.ID]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		s.NoError(err)
+	}
+
+	s.Len(claimed, groupsCount)
+
+	for groupID, count := range claimed {
+		s.Equal(1, count, "group %d claimed more than once", groupID)
+	}
+}
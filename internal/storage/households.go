@@ -0,0 +1,369 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/logging"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+const (
+	householdsTableName       = "households"
+	householdMembersTableName = "household_members"
+	householdInvitesTableName = "household_invites"
+	ownerIDColumnName         = "owner_id"
+	householdIDColumnName     = "household_id"
+	joinedAtColumnName        = "joined_at"
+	tokenColumnName           = "token"
+	createdByColumnName       = "created_by"
+	expiresAtColumnName       = "expires_at"
+)
+
+type householdsStorage struct {
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
+}
+
+// householdUsersExpr отбирает записи, принадлежащие участникам дома пользователя с userID.
+// Если пользователь не состоит в доме, условие ложно, поэтому его нужно объединять с проверкой самого пользователя.
+func householdUsersExpr(column string, userID int) sq.Sqlizer {
+	return sq.Expr(
+		fmt.Sprintf(
+			"%[1]s IN (SELECT members.%[2]s FROM %[3]s AS members "+
+				"JOIN %[3]s AS self ON self.%[4]s = members.%[4]s WHERE self.%[2]s = ?)",
+			column,
+			userIDColumnName,
+			householdMembersTableName,
+			householdIDColumnName,
+		),
+		userID,
+	)
+}
+
+// userOrHouseholdExpr отбирает записи пользователя и всех участников его дома.
+func userOrHouseholdExpr(column string, userID int) sq.Sqlizer {
+	return sq.Or{
+		sq.Eq{column: userID},
+		householdUsersExpr(column, userID),
+	}
+}
+
+func (s *householdsStorage) CreateHousehold(ctx context.Context, ownerID int) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CreateHousehold")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Insert(householdsTableName).
+		Columns(ownerIDColumnName).
+		Values(ownerID).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var householdID int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&householdID); err != nil {
+		return 0, err
+	}
+
+	return householdID, nil
+}
+
+func (s *householdsStorage) GetUserHousehold(ctx context.Context, userID int) (*entities.Household, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetUserHousehold")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(householdsTableName).
+		Where(
+			sq.Expr(
+				fmt.Sprintf(
+					"%s = (SELECT %s FROM %s WHERE %s = ?)",
+					idColumnName,
+					householdIDColumnName,
+					householdMembersTableName,
+					userIDColumnName,
+				),
+				userID,
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	household := &entities.Household{}
+
+	columns := db.GetEntityColumns(household)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+// AddHouseholdMember добавляет пользователя в дом. Участник другого дома переходит в новый дом.
+func (s *householdsStorage) AddHouseholdMember(ctx context.Context, householdID, userID int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "AddHouseholdMember")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Insert(householdMembersTableName).
+		Columns(
+			householdIDColumnName,
+			userIDColumnName,
+		).
+		Values(
+			householdID,
+			userID,
+		).
+		Suffix(
+			fmt.Sprintf(
+				"ON CONFLICT (%[1]s) DO UPDATE SET %[2]s = EXCLUDED.%[2]s, %[3]s = CURRENT_TIMESTAMP",
+				userIDColumnName,
+				householdIDColumnName,
+				joinedAtColumnName,
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (s *householdsStorage) DeleteHouseholdMember(ctx context.Context, userID int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "DeleteHouseholdMember")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Delete(householdMembersTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// GetHouseholdUsers возвращает пользователя и всех участников его дома.
+func (s *householdsStorage) GetHouseholdUsers(ctx context.Context, userID int) ([]entities.User, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetHouseholdUsers")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(usersTableName).
+		Where(userOrHouseholdExpr(idColumnName, userID)).
+		OrderBy(idColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				s.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var users []entities.User
+
+	for rows.Next() {
+		user := entities.User{}
+		columns := db.GetEntityColumns(&user) // Only pointer to use rows.Scan() successfully
+
+		if err = rows.Scan(columns...); err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (s *householdsStorage) CreateHouseholdInvite(ctx context.Context, invite entities.HouseholdInvite) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CreateHouseholdInvite")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Insert(householdInvitesTableName).
+		Columns(
+			householdIDColumnName,
+			tokenColumnName,
+			createdByColumnName,
+			expiresAtColumnName,
+		).
+		Values(
+			invite.HouseholdID,
+			invite.Token,
+			invite.CreatedBy,
+			invite.ExpiresAt,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var inviteID int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&inviteID); err != nil {
+		return 0, err
+	}
+
+	return inviteID, nil
+}
+
+func (s *householdsStorage) GetHouseholdInvite(ctx context.Context, token string) (*entities.HouseholdInvite, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetHouseholdInvite")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(householdInvitesTableName).
+		Where(sq.Eq{tokenColumnName: token}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &entities.HouseholdInvite{}
+
+	columns := db.GetEntityColumns(invite)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (s *householdsStorage) DeleteHouseholdInvite(ctx context.Context, id int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "DeleteHouseholdInvite")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Delete(householdInvitesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
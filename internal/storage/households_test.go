@@ -0,0 +1,236 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/DKhorkov/libs/logging"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestHouseholdsStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(HouseholdsStorageTestSuite))
+}
+
+type HouseholdsStorageTestSuite struct {
+	suite.Suite
+
+	cwd         string
+	ctx         context.Context
+	dbConnector db.Connector
+	connection  *sql.Conn
+	storage     *householdsStorage
+	logger      *mocklogging.MockLogger
+}
+
+func (s *HouseholdsStorageTestSuite) SetupSuite() {
+	// Инициализируем переменные окружения для дальнейшего считывания:
+	loadenv.Init("../../.env")
+
+	cwd, err := os.Getwd()
+	s.NoError(err)
+
+	cfg := config.New()
+	logger := logging.New(
+		cfg.Logging.Level,
+		cfg.Logging.LogFilePath,
+	)
+
+	dbConnector, err := db.New(
+		db.BuildDsn(cfg.Database),
+		cfg.Database.Driver,
+		logger,
+		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	)
+	s.NoError(err, "failed to connect to database")
+
+	s.NoError(goose.SetDialect(cfg.Database.Driver))
+
+	ctrl := gomock.NewController(s.T())
+	s.logger = mocklogging.NewMockLogger(ctrl)
+
+	s.cwd = cwd
+	s.dbConnector = dbConnector
+	s.ctx = context.Background()
+
+	s.storage = &householdsStorage{
+		dbConnector: s.dbConnector,
+		logger:      s.logger,
+	}
+}
+
+func (s *HouseholdsStorageTestSuite) SetupTest() {
+	s.NoError(
+		goose.Up(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+		),
+	)
+
+	connection, err := s.dbConnector.Connection(s.ctx)
+	s.NoError(err)
+
+	s.connection = connection
+}
+
+func (s *HouseholdsStorageTestSuite) TearDownTest() {
+	s.NoError(
+		goose.DownTo(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+			gooseZeroVersion,
+		),
+	)
+
+	s.NoError(s.connection.Close())
+}
+
+func (s *HouseholdsStorageTestSuite) TearDownSuite() {
+	s.NoError(s.dbConnector.Close())
+}
+
+func (s *HouseholdsStorageTestSuite) createUser(now time.Time, offset int) int {
+	var userID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO users (
+				telegram_id, username, firstname, lastname, is_bot, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $6)
+			RETURNING id
+		`,
+		123456789+int64(offset),
+		fmt.Sprintf("user%d", offset),
+		fmt.Sprintf("First%d", offset),
+		fmt.Sprintf("Last%d", offset),
+		false,
+		now,
+	).Scan(&userID)
+	s.NoError(err)
+	return userID
+}
+
+func (s *HouseholdsStorageTestSuite) TestGetHouseholdUsers_WithoutHousehold() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	s.createUser(now, 2)
+
+	users, err := s.storage.GetHouseholdUsers(s.ctx, userID)
+	s.NoError(err)
+	s.Len(users, 1)
+	s.Equal(userID, users[0].ID)
+
+	_, err = s.storage.GetUserHousehold(s.ctx, userID)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *HouseholdsStorageTestSuite) TestAddHouseholdMember_Success() {
+	now := time.Now().UTC()
+	ownerID := s.createUser(now, 1)
+	memberID := s.createUser(now, 2)
+	s.createUser(now, 3)
+
+	householdID, err := s.storage.CreateHousehold(s.ctx, ownerID)
+	s.NoError(err)
+	s.NoError(s.storage.AddHouseholdMember(s.ctx, householdID, ownerID))
+	s.NoError(s.storage.AddHouseholdMember(s.ctx, householdID, memberID))
+
+	household, err := s.storage.GetUserHousehold(s.ctx, memberID)
+	s.NoError(err)
+	s.Equal(householdID, household.ID)
+	s.Equal(ownerID, household.OwnerID)
+
+	users, err := s.storage.GetHouseholdUsers(s.ctx, memberID)
+	s.NoError(err)
+	s.Len(users, 2)
+	s.Equal(ownerID, users[0].ID)
+	s.Equal(memberID, users[1].ID)
+}
+
+func (s *HouseholdsStorageTestSuite) TestAddHouseholdMember_MovesToAnotherHousehold() {
+	now := time.Now().UTC()
+	firstOwnerID := s.createUser(now, 1)
+	secondOwnerID := s.createUser(now, 2)
+	memberID := s.createUser(now, 3)
+
+	firstHouseholdID, err := s.storage.CreateHousehold(s.ctx, firstOwnerID)
+	s.NoError(err)
+	s.NoError(s.storage.AddHouseholdMember(s.ctx, firstHouseholdID, memberID))
+
+	secondHouseholdID, err := s.storage.CreateHousehold(s.ctx, secondOwnerID)
+	s.NoError(err)
+	s.NoError(s.storage.AddHouseholdMember(s.ctx, secondHouseholdID, memberID))
+
+	household, err := s.storage.GetUserHousehold(s.ctx, memberID)
+	s.NoError(err)
+	s.Equal(secondHouseholdID, household.ID)
+}
+
+func (s *HouseholdsStorageTestSuite) TestDeleteHouseholdMember_Success() {
+	now := time.Now().UTC()
+	ownerID := s.createUser(now, 1)
+	memberID := s.createUser(now, 2)
+
+	householdID, err := s.storage.CreateHousehold(s.ctx, ownerID)
+	s.NoError(err)
+	s.NoError(s.storage.AddHouseholdMember(s.ctx, householdID, ownerID))
+	s.NoError(s.storage.AddHouseholdMember(s.ctx, householdID, memberID))
+
+	s.NoError(s.storage.DeleteHouseholdMember(s.ctx, memberID))
+
+	users, err := s.storage.GetHouseholdUsers(s.ctx, ownerID)
+	s.NoError(err)
+	s.Len(users, 1)
+	s.Equal(ownerID, users[0].ID)
+}
+
+func (s *HouseholdsStorageTestSuite) TestHouseholdInvite_Lifecycle() {
+	now := time.Now().UTC()
+	ownerID := s.createUser(now, 1)
+
+	householdID, err := s.storage.CreateHousehold(s.ctx, ownerID)
+	s.NoError(err)
+
+	inviteID, err := s.storage.CreateHouseholdInvite(
+		s.ctx,
+		entities.HouseholdInvite{
+			HouseholdID: householdID,
+			Token:       "token",
+			CreatedBy:   ownerID,
+			ExpiresAt:   now.Add(time.Hour),
+		},
+	)
+	s.NoError(err)
+
+	invite, err := s.storage.GetHouseholdInvite(s.ctx, "token")
+	s.NoError(err)
+	s.Equal(inviteID, invite.ID)
+	s.Equal(householdID, invite.HouseholdID)
+	s.Equal(ownerID, invite.CreatedBy)
+	s.WithinDuration(now.Add(time.Hour), invite.ExpiresAt, time.Second)
+
+	s.NoError(s.storage.DeleteHouseholdInvite(s.ctx, inviteID))
+
+	_, err = s.storage.GetHouseholdInvite(s.ctx, "token")
+	s.ErrorIs(err, sql.ErrNoRows)
+}
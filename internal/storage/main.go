@@ -1,42 +1,158 @@
 package storage
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
 	"github.com/DKhorkov/libs/db"
 	"github.com/DKhorkov/libs/logging"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+var ErrUnknownStorageType = errors.New("unknown storage type")
+
 type Storage struct {
 	usersStorage
 	temporaryStorage
 	groupsStorage
 	plantsStorage
 	notificationsStorage
+	wateringsStorage
+	careTasksStorage
+	plantPhotosStorage
+	householdsStorage
+	delegationsStorage
+
+	dbConnector   db.Connector
+	logger        logging.Logger
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
 }
 
+// New создает хранилище, каждый запрос которого ограничен queryTimeout. Нулевой таймаут не ограничивает запросы.
+// Длительность запросов замеряется в queryDuration с меткой - именем метода хранилища, если она передана.
 func New(
 	dbConnector db.Connector,
+	queryTimeout time.Duration,
+	queryDuration prometheus.ObserverVec,
+	logger logging.Logger,
+) *Storage {
+	return newStorage(dbConnector, nil, queryTimeout, queryDuration, logger)
+}
+
+// newStorage создает хранилище, все запросы которого выполняются в транзакции tx, если она передана.
+func newStorage(
+	dbConnector db.Connector,
+	tx *sql.Tx,
+	queryTimeout time.Duration,
+	queryDuration prometheus.ObserverVec,
 	logger logging.Logger,
 ) *Storage {
 	return &Storage{
 		usersStorage: usersStorage{
-			dbConnector: dbConnector,
-			logger:      logger,
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
 		},
 		temporaryStorage: temporaryStorage{
-			dbConnector: dbConnector,
-			logger:      logger,
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
 		},
 		groupsStorage: groupsStorage{
-			dbConnector: dbConnector,
-			logger:      logger,
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
 		},
 		plantsStorage: plantsStorage{
-			dbConnector: dbConnector,
-			logger:      logger,
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
 		},
 		notificationsStorage: notificationsStorage{
-			dbConnector: dbConnector,
-			logger:      logger,
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
+		},
+		wateringsStorage: wateringsStorage{
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
+		},
+		careTasksStorage: careTasksStorage{
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
+		},
+		plantPhotosStorage: plantPhotosStorage{
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
 		},
+		householdsStorage: householdsStorage{
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
+		},
+		delegationsStorage: delegationsStorage{
+			dbConnector:   dbConnector,
+			tx:            tx,
+			queryTimeout:  queryTimeout,
+			queryDuration: queryDuration,
+			logger:        logger,
+		},
+		dbConnector:   dbConnector,
+		logger:        logger,
+		tx:            tx,
+		queryTimeout:  queryTimeout,
+		queryDuration: queryDuration,
+	}
+}
+
+// startQuery ограничивает время выполнения запроса operation, если таймаут задан. Возвращаемая функция
+// завершает запрос и замеряет его длительность вместе с ожиданием соединения, если queryDuration передана.
+func startQuery(
+	ctx context.Context,
+	timeout time.Duration,
+	queryDuration prometheus.ObserverVec,
+	operation string,
+) (context.Context, context.CancelFunc) {
+	var cancel context.CancelFunc
+	if timeout <= 0 {
+		ctx, cancel = context.WithCancel(ctx)
+	} else {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	if queryDuration == nil {
+		return ctx, cancel
+	}
+
+	start := time.Now()
+
+	return ctx, func() {
+		cancel()
+		queryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
 	}
 }
@@ -1,16 +1,22 @@
 package storage
 
 import (
+	"context"
 	"github.com/DKhorkov/libs/db"
 	mockdb "github.com/DKhorkov/libs/db/mocks"
 	"github.com/DKhorkov/libs/logging"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestNewStorage_Initialization(t *testing.T) {
+	const queryTimeout = time.Second * 5
+
 	ctrl := gomock.NewController(t)
 	tests := []struct {
 		name        string
@@ -30,6 +36,8 @@ func TestNewStorage_Initialization(t *testing.T) {
 				"groupsStorage",
 				"plantsStorage",
 				"notificationsStorage",
+				"wateringsStorage",
+				"careTasksStorage",
 			},
 		},
 		{
@@ -43,6 +51,8 @@ func TestNewStorage_Initialization(t *testing.T) {
 				"groupsStorage",
 				"plantsStorage",
 				"notificationsStorage",
+				"wateringsStorage",
+				"careTasksStorage",
 			},
 		},
 		{
@@ -58,13 +68,13 @@ func TestNewStorage_Initialization(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.shouldPanic {
 				assert.Panics(t, func() {
-					New(tt.dbConnector, tt.logger)
+					New(tt.dbConnector, queryTimeout, nil, tt.logger)
 				}, "Expected panic when dbConnector is nil")
 				return
 			}
 
 			// Выполняем тестируемый код
-			s := New(tt.dbConnector, tt.logger)
+			s := New(tt.dbConnector, queryTimeout, nil, tt.logger)
 
 			// Проверяем, что storage не nil
 			assert.NotNil(t, s, "Storage should not be nil")
@@ -75,6 +85,7 @@ func TestNewStorage_Initialization(t *testing.T) {
 			assert.NotNil(t, &s.usersStorage, "usersStorage should not be nil")
 			assert.Equal(t, tt.dbConnector, s.usersStorage.dbConnector, "usersStorage should have correct dbConnector")
 			assert.Equal(t, tt.logger, s.usersStorage.logger, "usersStorage should have correct logger")
+			assert.Equal(t, queryTimeout, s.usersStorage.queryTimeout, "usersStorage should have correct queryTimeout")
 
 			assert.NotNil(t, &s.temporaryStorage, "temporaryStorage should not be nil")
 			assert.Equal(t, tt.dbConnector, s.temporaryStorage.dbConnector, "temporaryStorage should have correct dbConnector")
@@ -91,6 +102,34 @@ func TestNewStorage_Initialization(t *testing.T) {
 			assert.NotNil(t, &s.notificationsStorage, "notificationsStorage should not be nil")
 			assert.Equal(t, tt.dbConnector, s.notificationsStorage.dbConnector, "notificationsStorage should have correct dbConnector")
 			assert.Equal(t, tt.logger, s.notificationsStorage.logger, "notificationsStorage should have correct logger")
+
+			assert.NotNil(t, &s.wateringsStorage, "wateringsStorage should not be nil")
+			assert.Equal(t, tt.dbConnector, s.wateringsStorage.dbConnector, "wateringsStorage should have correct dbConnector")
+			assert.Equal(t, tt.logger, s.wateringsStorage.logger, "wateringsStorage should have correct logger")
+
+			assert.NotNil(t, &s.careTasksStorage, "careTasksStorage should not be nil")
+			assert.Equal(t, tt.dbConnector, s.careTasksStorage.dbConnector, "careTasksStorage should have correct dbConnector")
+			assert.Equal(t, tt.logger, s.careTasksStorage.logger, "careTasksStorage should have correct logger")
 		})
 	}
 }
+
+func TestStartQuery(t *testing.T) {
+	queryDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "query_duration_seconds"}, []string{"operation"})
+
+	ctx, done := startQuery(context.Background(), time.Second, queryDuration, "GetUser")
+	_, hasDeadline := ctx.Deadline()
+	assert.True(t, hasDeadline)
+
+	done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.Equal(t, 1, testutil.CollectAndCount(queryDuration))
+
+	// Без таймаута и гистограммы запрос только отменяется по завершении:
+	ctx, done = startQuery(context.Background(), 0, nil, "GetUser")
+	_, hasDeadline = ctx.Deadline()
+	assert.False(t, hasDeadline)
+
+	done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
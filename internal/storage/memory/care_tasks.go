@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) CreateCareTask(ctx context.Context, careTask entities.CareTask) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	if err = s.checkCareTask(careTask); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	careTask.ID = s.tables.careTasks.nextID()
+	careTask.CreatedAt = now
+	careTask.UpdatedAt = now
+	careTask.NotifyClaimedAt = nil
+
+	s.tables.careTasks.rows = append(s.tables.careTasks.rows, careTask)
+
+	return careTask.ID, nil
+}
+
+func (s *Storage) UpdateCareTask(ctx context.Context, careTask entities.CareTask) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if careTask.Interval <= 0 {
+		return fmt.Errorf("%w: care_tasks interval_days", ErrCheckViolation)
+	}
+
+	s.tables.careTasks.update(
+		func(c entities.CareTask) bool { return c.ID == careTask.ID },
+		func(c *entities.CareTask) {
+			c.Interval = careTask.Interval
+			c.LastCareDate = careTask.LastCareDate
+			c.NextCareDate = careTask.NextCareDate
+			c.UpdatedAt = time.Now()
+		},
+	)
+
+	return nil
+}
+
+func (s *Storage) DeleteCareTask(ctx context.Context, id int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.deleteCareTasks(func(c entities.CareTask) bool { return c.ID == id })
+
+	return nil
+}
+
+func (s *Storage) GetCareTask(ctx context.Context, id int) (*entities.CareTask, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.careTasks.find(func(c entities.CareTask) bool { return c.ID == id })
+}
+
+func (s *Storage) GetGroupCareTasks(ctx context.Context, groupID int) ([]entities.CareTask, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	careTasks := s.tables.careTasks.filter(
+		func(c entities.CareTask) bool { return c.GroupID != nil && *c.GroupID == groupID },
+	)
+
+	return careTasks, nil
+}
+
+func (s *Storage) GetPlantCareTasks(ctx context.Context, plantID int) ([]entities.CareTask, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	careTasks := s.tables.careTasks.filter(
+		func(c entities.CareTask) bool { return c.PlantID != nil && *c.PlantID == plantID },
+	)
+
+	return careTasks, nil
+}
+
+// ClaimCareTasksForNotify захватывает до limit задач ухода, требующих уведомления, на время claimTTL.
+// Работает аналогично ClaimGroupsForNotify.
+func (s *Storage) ClaimCareTasksForNotify(
+	ctx context.Context,
+	limit int,
+	claimTTL time.Duration,
+) ([]entities.CareTask, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	now := time.Now()
+	claimable := paginate(
+		s.tables.careTasks.filter(
+			func(c entities.CareTask) bool {
+				return c.NextCareDate.Before(now) && isClaimable(c.NotifyClaimedAt, now, claimTTL)
+			},
+		),
+		limit,
+		0,
+	)
+
+	careTasks := make([]entities.CareTask, 0, len(claimable))
+	for _, careTask := range claimable {
+		careTask.NotifyClaimedAt = &now
+		careTasks = append(careTasks, careTask)
+
+		s.tables.careTasks.update(
+			func(c entities.CareTask) bool { return c.ID == careTask.ID },
+			func(c *entities.CareTask) { c.NotifyClaimedAt = &now },
+		)
+	}
+
+	if len(careTasks) == 0 {
+		return nil, nil
+	}
+
+	return careTasks, nil
+}
+
+// checkCareTask повторяет внешние ключи, CHECK-ограничения и уникальные индексы care_tasks.
+func (s *Storage) checkCareTask(careTask entities.CareTask) error {
+	switch {
+	case !s.userExists(careTask.UserID):
+		return fmt.Errorf("%w: care_tasks user_id", ErrForeignKeyViolation)
+	case careTask.GroupID != nil && !s.groupExists(*careTask.GroupID):
+		return fmt.Errorf("%w: care_tasks group_id", ErrForeignKeyViolation)
+	case careTask.PlantID != nil && !s.plantExists(*careTask.PlantID):
+		return fmt.Errorf("%w: care_tasks plant_id", ErrForeignKeyViolation)
+	case (careTask.GroupID == nil) == (careTask.PlantID == nil): // Задача привязывается либо к сценарию, либо к растению
+		return fmt.Errorf("%w: care_tasks group_id or plant_id", ErrCheckViolation)
+	case !slices.Contains(entities.CareTaskTypes, careTask.Type):
+		return fmt.Errorf("%w: care_tasks type", ErrCheckViolation)
+	case careTask.Interval <= 0:
+		return fmt.Errorf("%w: care_tasks interval_days", ErrCheckViolation)
+	}
+
+	if s.tables.careTasks.exists(
+		func(c entities.CareTask) bool {
+			return c.Type == careTask.Type && (sameID(c.GroupID, careTask.GroupID) || sameID(c.PlantID, careTask.PlantID))
+		},
+	) {
+		return fmt.Errorf("%w: care_tasks type", ErrUniqueViolation)
+	}
+
+	return nil
+}
+
+// deleteCareTasks удаляет задачи ухода вместе с уведомлениями о них.
+func (s *Storage) deleteCareTasks(match func(entities.CareTask) bool) {
+	careTaskIDs := make(map[int]bool)
+	for _, careTask := range s.tables.careTasks.filter(match) {
+		careTaskIDs[careTask.ID] = true
+	}
+
+	s.tables.notifications.delete(
+		func(n entities.Notification) bool { return n.CareTaskID != nil && careTaskIDs[*n.CareTaskID] },
+	)
+	s.tables.careTasks.delete(func(c entities.CareTask) bool { return careTaskIDs[c.ID] })
+}
+
+func (s *Storage) careTaskExists(id int) bool {
+	return s.tables.careTasks.exists(func(c entities.CareTask) bool { return c.ID == id })
+}
+
+// sameID сравнивает необязательные ссылки так же, как частичный уникальный индекс: пустые ссылки не совпадают.
+func sameID(a, b *int) bool {
+	return a != nil && b != nil && *a == *b
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) CreateDelegation(ctx context.Context, delegation entities.Delegation) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	switch {
+	case !s.userExists(delegation.OwnerID):
+		return 0, fmt.Errorf("%w: delegations owner_id", ErrForeignKeyViolation)
+	case s.tables.delegations.exists(func(d entities.Delegation) bool { return d.Token == delegation.Token }):
+		return 0, fmt.Errorf("%w: delegations token", ErrUniqueViolation)
+	}
+
+	// Присматривающий появляется, когда примет приглашение:
+	delegation.ID = s.tables.delegations.nextID()
+	delegation.SitterID = nil
+	delegation.CreatedAt = time.Now()
+
+	s.tables.delegations.rows = append(s.tables.delegations.rows, delegation)
+
+	return delegation.ID, nil
+}
+
+func (s *Storage) GetDelegation(ctx context.Context, id int) (*entities.Delegation, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.delegations.find(func(d entities.Delegation) bool { return d.ID == id })
+}
+
+func (s *Storage) GetDelegationByToken(ctx context.Context, token string) (*entities.Delegation, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.delegations.find(func(d entities.Delegation) bool { return d.Token == token })
+}
+
+// GetGroupDelegation возвращает принятую передачу сценария, которая действует в указанную дату.
+// Если сценарий передавался несколько раз, возвращается последняя передача.
+func (s *Storage) GetGroupDelegation(
+	ctx context.Context,
+	groupID int,
+	date time.Time,
+) (*entities.Delegation, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	delegations := s.tables.delegations.filter(
+		func(d entities.Delegation) bool {
+			return d.SitterID != nil && !d.EndDate.Before(date) && slices.Contains(d.GroupIDs, groupID)
+		},
+	)
+	if len(delegations) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &delegations[len(delegations)-1], nil
+}
+
+// GetOwnerDelegations возвращает передачи пользователя, которые действуют в указанную дату или еще не начались.
+func (s *Storage) GetOwnerDelegations(
+	ctx context.Context,
+	ownerID int,
+	date time.Time,
+) ([]entities.Delegation, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	delegations := s.tables.delegations.filter(
+		func(d entities.Delegation) bool { return d.OwnerID == ownerID && !d.EndDate.Before(date) },
+	)
+
+	return delegations, nil
+}
+
+func (s *Storage) UpdateDelegationSitter(ctx context.Context, id, sitterID int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if !s.userExists(sitterID) {
+		return fmt.Errorf("%w: delegations sitter_id", ErrForeignKeyViolation)
+	}
+
+	s.tables.delegations.update(
+		func(d entities.Delegation) bool { return d.ID == id },
+		func(d *entities.Delegation) { d.SitterID = &sitterID },
+	)
+
+	return nil
+}
+
+func (s *Storage) DeleteDelegation(ctx context.Context, id int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.tables.delegations.delete(func(d entities.Delegation) bool { return d.ID == id })
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) CreateGroup(ctx context.Context, group entities.Group) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	if !s.userExists(group.UserID) {
+		return 0, fmt.Errorf("%w: groups user_id", ErrForeignKeyViolation)
+	}
+
+	now := time.Now()
+
+	// Захват и откладывание не задаются при создании сценария:
+	group.ID = s.tables.groups.nextID()
+	group.CreatedAt = now
+	group.UpdatedAt = now
+	group.NotifyClaimedAt = nil
+	group.SnoozedUntil = nil
+	group.SeasonSchedule = storedSchedule(group.SeasonSchedule)
+
+	s.tables.groups.rows = append(s.tables.groups.rows, group)
+
+	return group.ID, nil
+}
+
+func (s *Storage) UpdateGroup(ctx context.Context, group entities.Group) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if !s.userExists(group.UserID) {
+		return fmt.Errorf("%w: groups user_id", ErrForeignKeyViolation)
+	}
+
+	s.tables.groups.update(
+		func(g entities.Group) bool { return g.ID == group.ID },
+		func(g *entities.Group) {
+			g.UserID = group.UserID
+			g.Title = group.Title
+			g.Description = group.Description
+			g.LastWateringDate = group.LastWateringDate
+			g.NextWateringDate = group.NextWateringDate
+			g.WateringInterval = group.WateringInterval
+			g.SnoozedUntil = group.SnoozedUntil
+			g.SeasonSchedule = storedSchedule(group.SeasonSchedule)
+			g.UpdatedAt = time.Now()
+		},
+	)
+
+	return nil
+}
+
+func (s *Storage) GroupExists(ctx context.Context, group entities.Group) (bool, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer unlock()
+
+	exists := s.tables.groups.exists(
+		func(g entities.Group) bool { return g.UserID == group.UserID && g.Title == group.Title },
+	)
+
+	return exists, nil
+}
+
+func (s *Storage) DeleteGroup(ctx context.Context, id int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.deleteGroups(func(g entities.Group) bool { return g.ID == id })
+
+	return nil
+}
+
+func (s *Storage) GetUserGroups(ctx context.Context, userID int) ([]entities.Group, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	// Сценарии общие для всех участников дома:
+	isHouseholdUser := s.tables.userOrHousehold(userID)
+
+	return s.tables.groups.filter(func(g entities.Group) bool { return isHouseholdUser(g.UserID) }), nil
+}
+
+func (s *Storage) CountUserGroups(ctx context.Context, userID int) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	isHouseholdUser := s.tables.userOrHousehold(userID)
+
+	return s.tables.groups.count(func(g entities.Group) bool { return isHouseholdUser(g.UserID) }), nil
+}
+
+func (s *Storage) GetGroup(ctx context.Context, id int) (*entities.Group, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.groups.find(func(g entities.Group) bool { return g.ID == id })
+}
+
+func (s *Storage) GetGroupsForNotify(ctx context.Context, limit, offset int) ([]entities.Group, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	now := time.Now()
+	groups := s.tables.groups.filter(func(g entities.Group) bool { return g.NextWateringDate.Before(now) })
+
+	return paginate(groups, limit, offset), nil
+}
+
+// ClaimGroupsForNotify захватывает до limit сценариев, требующих уведомления, на время claimTTL.
+// Запросы к хранилищу выполняются по очереди, поэтому воркеры не получат один и тот же сценарий.
+func (s *Storage) ClaimGroupsForNotify(
+	ctx context.Context,
+	limit int,
+	claimTTL time.Duration,
+) ([]entities.Group, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	now := time.Now()
+	claimable := paginate(
+		s.tables.groups.filter(
+			func(g entities.Group) bool {
+				return g.NextWateringDate.Before(now) && isClaimable(g.NotifyClaimedAt, now, claimTTL)
+			},
+		),
+		limit,
+		0,
+	)
+
+	groups := make([]entities.Group, 0, len(claimable))
+	for _, group := range claimable {
+		group.NotifyClaimedAt = &now
+		groups = append(groups, group)
+
+		s.tables.groups.update(
+			func(g entities.Group) bool { return g.ID == group.ID },
+			func(g *entities.Group) { g.NotifyClaimedAt = &now },
+		)
+	}
+
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	return groups, nil
+}
+
+// deleteGroups удаляет сценарии вместе с зависимыми записями, как ON DELETE CASCADE в Postgres.
+func (s *Storage) deleteGroups(match func(entities.Group) bool) {
+	groupIDs := make(map[int]bool)
+	for _, group := range s.tables.groups.filter(match) {
+		groupIDs[group.ID] = true
+	}
+
+	s.deletePlants(func(p entities.Plant) bool { return groupIDs[p.GroupID] })
+	s.deleteCareTasks(func(c entities.CareTask) bool { return c.GroupID != nil && groupIDs[*c.GroupID] })
+	s.tables.notifications.delete(func(n entities.Notification) bool { return groupIDs[n.GroupID] })
+	s.tables.waterings.delete(func(w entities.Watering) bool { return groupIDs[w.GroupID] })
+	s.tables.groups.delete(func(g entities.Group) bool { return groupIDs[g.ID] })
+}
+
+func (s *Storage) groupExists(id int) bool {
+	return s.tables.groups.exists(func(g entities.Group) bool { return g.ID == id })
+}
+
+// isClaimable проверяет, что запись не захвачена или ее захват истек.
+func isClaimable(claimedAt *time.Time, now time.Time, claimTTL time.Duration) bool {
+	return claimedAt == nil || claimedAt.Before(now.Add(-claimTTL))
+}
+
+// storedSchedule возвращает график в том виде, в котором его вернет Postgres: пустой график хранится как NULL.
+func storedSchedule(schedule entities.SeasonSchedule) entities.SeasonSchedule {
+	if len(schedule) == 0 {
+		return nil
+	}
+
+	return schedule
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) CreateHousehold(ctx context.Context, ownerID int) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	if !s.userExists(ownerID) {
+		return 0, fmt.Errorf("%w: households owner_id", ErrForeignKeyViolation)
+	}
+
+	household := entities.Household{
+		ID:        s.tables.households.nextID(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	}
+
+	s.tables.households.rows = append(s.tables.households.rows, household)
+
+	return household.ID, nil
+}
+
+func (s *Storage) GetUserHousehold(ctx context.Context, userID int) (*entities.Household, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	member, err := s.tables.householdMembers.find(func(m householdMember) bool { return m.UserID == userID })
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tables.households.find(func(h entities.Household) bool { return h.ID == member.HouseholdID })
+}
+
+// AddHouseholdMember добавляет пользователя в дом. Участник другого дома переходит в новый дом.
+func (s *Storage) AddHouseholdMember(ctx context.Context, householdID, userID int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	switch {
+	case !s.tables.households.exists(func(h entities.Household) bool { return h.ID == householdID }):
+		return fmt.Errorf("%w: household_members household_id", ErrForeignKeyViolation)
+	case !s.userExists(userID):
+		return fmt.Errorf("%w: household_members user_id", ErrForeignKeyViolation)
+	}
+
+	isMember := func(m householdMember) bool { return m.UserID == userID }
+	joinedAt := time.Now()
+
+	if s.tables.householdMembers.exists(isMember) {
+		s.tables.householdMembers.update(
+			isMember,
+			func(m *householdMember) {
+				m.HouseholdID = householdID
+				m.JoinedAt = joinedAt
+			},
+		)
+
+		return nil
+	}
+
+	s.tables.householdMembers.rows = append(
+		s.tables.householdMembers.rows,
+		householdMember{
+			HouseholdID: householdID,
+			UserID:      userID,
+			JoinedAt:    joinedAt,
+		},
+	)
+
+	return nil
+}
+
+func (s *Storage) DeleteHouseholdMember(ctx context.Context, userID int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.tables.householdMembers.delete(func(m householdMember) bool { return m.UserID == userID })
+
+	return nil
+}
+
+// GetHouseholdUsers возвращает пользователя и всех участников его дома.
+func (s *Storage) GetHouseholdUsers(ctx context.Context, userID int) ([]entities.User, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	isHouseholdUser := s.tables.userOrHousehold(userID)
+
+	return s.tables.users.filter(func(u entities.User) bool { return isHouseholdUser(u.ID) }), nil
+}
+
+func (s *Storage) CreateHouseholdInvite(ctx context.Context, invite entities.HouseholdInvite) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	switch {
+	case !s.tables.households.exists(func(h entities.Household) bool { return h.ID == invite.HouseholdID }):
+		return 0, fmt.Errorf("%w: household_invites household_id", ErrForeignKeyViolation)
+	case !s.userExists(invite.CreatedBy):
+		return 0, fmt.Errorf("%w: household_invites created_by", ErrForeignKeyViolation)
+	case s.tables.householdInvites.exists(func(i entities.HouseholdInvite) bool { return i.Token == invite.Token }):
+		return 0, fmt.Errorf("%w: household_invites token", ErrUniqueViolation)
+	}
+
+	invite.ID = s.tables.householdInvites.nextID()
+	invite.CreatedAt = time.Now()
+
+	s.tables.householdInvites.rows = append(s.tables.householdInvites.rows, invite)
+
+	return invite.ID, nil
+}
+
+func (s *Storage) GetHouseholdInvite(ctx context.Context, token string) (*entities.HouseholdInvite, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.householdInvites.find(func(i entities.HouseholdInvite) bool { return i.Token == token })
+}
+
+func (s *Storage) DeleteHouseholdInvite(ctx context.Context, id int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.tables.householdInvites.delete(func(i entities.HouseholdInvite) bool { return i.ID == id })
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+// Аналоги ошибок ограничений Postgres, которые возвращаются при нарушении схемы из migrations:
+var (
+	ErrUniqueViolation     = errors.New("unique constraint violation")
+	ErrForeignKeyViolation = errors.New("foreign key constraint violation")
+	ErrCheckViolation      = errors.New("check constraint violation")
+)
+
+// Значения по умолчанию колонок users из migrations:
+const (
+	defaultTimezone   = "Europe/Moscow"
+	defaultNotifyHour = 12
+)
+
+// Storage хранит данные в памяти процесса с той же семантикой, что и хранилище Postgres: ограничения уникальности,
+// каскадные удаления и порядок выборок совпадают со схемой из migrations. Подходит для локального запуска без БД
+// и тестов, но данные теряются при остановке бота.
+type Storage struct {
+	mu     sync.Mutex
+	tables *tables
+	inTx   bool
+}
+
+func New() *Storage {
+	return &Storage{
+		tables: &tables{},
+	}
+}
+
+// WithTx выполняет fn над копией данных и применяет ее, только если fn не вернула ошибку и не запаниковала.
+// На время транзакции остальные запросы к хранилищу ожидают ее завершения, поэтому внутри fn нужно
+// использовать только tx. Вложенный вызов WithTx выполняется в уже открытой транзакции.
+func (s *Storage) WithTx(ctx context.Context, fn func(tx interfaces.Storage) error) error {
+	if s.inTx {
+		return fn(s)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &Storage{
+		tables: s.tables.clone(),
+		inTx:   true,
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	// Контекст мог быть отменен во время fn, как и при фиксации транзакции в Postgres:
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.tables = tx.tables
+
+	return nil
+}
+
+// PingContext позволяет использовать хранилище в проверке здоровья вместо пула соединений с БД.
+func (s *Storage) PingContext(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// lock захватывает хранилище для одного запроса, если контекст запроса еще не отменен.
+func (s *Storage) lock(ctx context.Context) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+
+	return s.mu.Unlock, nil
+}
+
+// table - строки одной таблицы в порядке вставки, то есть по возрастанию id, и ее последовательность id.
+type table[T any] struct {
+	rows     []T
+	sequence int
+}
+
+func (t *table[T]) nextID() int {
+	t.sequence++
+
+	return t.sequence
+}
+
+func (t *table[T]) clone() table[T] {
+	return table[T]{
+		rows:     slices.Clone(t.rows),
+		sequence: t.sequence,
+	}
+}
+
+// find возвращает копию первой строки, удовлетворяющей условию, или sql.ErrNoRows, как QueryRow в Postgres.
+func (t *table[T]) find(match func(T) bool) (*T, error) {
+	index := slices.IndexFunc(t.rows, match)
+	if index < 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	row := t.rows[index]
+
+	return &row, nil
+}
+
+func (t *table[T]) exists(match func(T) bool) bool {
+	return slices.ContainsFunc(t.rows, match)
+}
+
+// filter возвращает строки, удовлетворяющие условию, в порядке id. Пустая выборка возвращается как nil.
+func (t *table[T]) filter(match func(T) bool) []T {
+	var rows []T
+
+	for _, row := range t.rows {
+		if match(row) {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+func (t *table[T]) count(match func(T) bool) int {
+	return len(t.filter(match))
+}
+
+// update заменяет строки, удовлетворяющие условию. Как и UPDATE в Postgres, не считает ошибкой отсутствие строк.
+func (t *table[T]) update(match func(T) bool, change func(*T)) {
+	for i := range t.rows {
+		if match(t.rows[i]) {
+			change(&t.rows[i])
+		}
+	}
+}
+
+func (t *table[T]) delete(match func(T) bool) {
+	t.rows = slices.DeleteFunc(t.rows, match)
+}
+
+type householdMember struct {
+	HouseholdID int
+	UserID      int
+	JoinedAt    time.Time
+}
+
+type tables struct {
+	users            table[entities.User]
+	temporary        table[entities.Temporary]
+	groups           table[entities.Group]
+	plants           table[entities.Plant]
+	notifications    table[entities.Notification]
+	waterings        table[entities.Watering]
+	careTasks        table[entities.CareTask]
+	plantPhotos      table[entities.PlantPhoto]
+	households       table[entities.Household]
+	householdMembers table[householdMember]
+	householdInvites table[entities.HouseholdInvite]
+	delegations      table[entities.Delegation]
+}
+
+// clone копирует таблицы для транзакции. Строки изменяются только целиком, поэтому копировать
+// вложенные указатели и срезы не требуется.
+func (t *tables) clone() *tables {
+	return &tables{
+		users:            t.users.clone(),
+		temporary:        t.temporary.clone(),
+		groups:           t.groups.clone(),
+		plants:           t.plants.clone(),
+		notifications:    t.notifications.clone(),
+		waterings:        t.waterings.clone(),
+		careTasks:        t.careTasks.clone(),
+		plantPhotos:      t.plantPhotos.clone(),
+		households:       t.households.clone(),
+		householdMembers: t.householdMembers.clone(),
+		householdInvites: t.householdInvites.clone(),
+		delegations:      t.delegations.clone(),
+	}
+}
+
+// userOrHousehold отбирает записи пользователя и всех участников его дома, как userOrHouseholdExpr в Postgres.
+func (t *tables) userOrHousehold(userID int) func(ownerID int) bool {
+	var householdID *int
+
+	if member, err := t.householdMembers.find(
+		func(m householdMember) bool { return m.UserID == userID },
+	); err == nil {
+		householdID = &member.HouseholdID
+	}
+
+	return func(ownerID int) bool {
+		if ownerID == userID {
+			return true
+		}
+
+		return householdID != nil && t.householdMembers.exists(
+			func(m householdMember) bool { return m.UserID == ownerID && m.HouseholdID == *householdID },
+		)
+	}
+}
+
+func paginate[T any](rows []T, limit, offset int) []T {
+	if offset >= len(rows) {
+		return nil
+	}
+
+	rows = rows[offset:]
+	if limit < len(rows) {
+		rows = rows[:limit]
+	}
+
+	return rows
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/storage/storagetest"
+)
+
+func TestContractTestSuite(t *testing.T) {
+	suite.Run(t, new(ContractTestSuite))
+}
+
+type ContractTestSuite struct {
+	storagetest.Suite
+}
+
+func (s *ContractTestSuite) SetupTest() {
+	s.Storage = New()
+}
+
+func (s *ContractTestSuite) TestCanceledContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.Storage.SaveUser(ctx, entities.User{TelegramID: 1})
+	s.ErrorIs(err, context.Canceled)
+
+	err = s.Storage.WithTx(ctx, func(interfaces.Storage) error { return nil })
+	s.ErrorIs(err, context.Canceled)
+
+	s.ErrorIs(New().PingContext(ctx), context.Canceled)
+	s.NoError(New().PingContext(context.Background()))
+}
+
+func (s *ContractTestSuite) TestConstraintErrors() {
+	ctx := context.Background()
+
+	_, err := s.Storage.CreateGroup(ctx, entities.Group{UserID: 1, Title: "Кухня", WateringInterval: 7})
+	s.ErrorIs(err, ErrForeignKeyViolation)
+
+	userID, err := s.Storage.SaveUser(ctx, entities.User{TelegramID: 1, Username: "user"})
+	s.Require().NoError(err)
+
+	_, err = s.Storage.SaveUser(ctx, entities.User{TelegramID: 2, Username: "user"})
+	s.ErrorIs(err, ErrUniqueViolation)
+
+	err = s.Storage.UpdateUser(ctx, entities.User{ID: userID, TelegramID: 1, Username: "user", NotifyHour: 24})
+	s.ErrorIs(err, ErrCheckViolation)
+}
@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) SaveNotification(ctx context.Context, notification entities.Notification) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	if !s.groupExists(notification.GroupID) {
+		return 0, fmt.Errorf("%w: notifications group_id", ErrForeignKeyViolation)
+	}
+
+	if notification.CareTaskID != nil && !s.careTaskExists(*notification.CareTaskID) {
+		return 0, fmt.Errorf("%w: notifications care_task_id", ErrForeignKeyViolation)
+	}
+
+	// Подтверждение сохраняется только через AcknowledgeGroupNotifications:
+	notification.ID = s.tables.notifications.nextID()
+	notification.AcknowledgedAt = nil
+
+	s.tables.notifications.rows = append(s.tables.notifications.rows, notification)
+
+	return notification.ID, nil
+}
+
+func (s *Storage) GetLastNotification(ctx context.Context, groupID int) (*entities.Notification, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	// Только уведомления о поливе:
+	return lastSent(
+		s.tables.notifications.filter(
+			func(n entities.Notification) bool { return n.GroupID == groupID && n.CareTaskID == nil },
+		),
+	)
+}
+
+func (s *Storage) GetLastCareTaskNotification(
+	ctx context.Context,
+	careTaskID int,
+) (*entities.Notification, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return lastSent(s.tables.notifications.filter(isCareTaskNotification(careTaskID)))
+}
+
+// GetGroupNotificationsSince возвращает все уведомления о поливе сценария во всех чатах,
+// отправленные начиная с указанного момента.
+func (s *Storage) GetGroupNotificationsSince(
+	ctx context.Context,
+	groupID int,
+	since time.Time,
+) ([]entities.Notification, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	notifications := s.tables.notifications.filter(
+		func(n entities.Notification) bool {
+			return n.GroupID == groupID && n.CareTaskID == nil && !n.SentAt.Before(since)
+		},
+	)
+
+	return notifications, nil
+}
+
+// GetLastCareTaskNotifications возвращает последнее уведомление о задаче ухода в каждом чате,
+// куда отправлялись напоминания. Уведомления упорядочены по чату, как DISTINCT ON в Postgres.
+func (s *Storage) GetLastCareTaskNotifications(
+	ctx context.Context,
+	careTaskID int,
+) ([]entities.Notification, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	lastByChat := make(map[int64]entities.Notification)
+	for _, notification := range s.tables.notifications.filter(isCareTaskNotification(careTaskID)) {
+		if last, ok := lastByChat[notification.ChatID]; !ok || !notification.SentAt.Before(last.SentAt) {
+			lastByChat[notification.ChatID] = notification
+		}
+	}
+
+	var notifications []entities.Notification
+
+	for _, chatID := range slices.Sorted(maps.Keys(lastByChat)) {
+		notifications = append(notifications, lastByChat[chatID])
+	}
+
+	return notifications, nil
+}
+
+// GetMessageNotifications возвращает все уведомления, сохраненные для одного сообщения.
+// Для сводки это уведомления по каждому вошедшему в нее сценарию.
+func (s *Storage) GetMessageNotifications(
+	ctx context.Context,
+	chatID int64,
+	messageID int,
+) ([]entities.Notification, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	notifications := s.tables.notifications.filter(
+		func(n entities.Notification) bool { return n.ChatID == chatID && n.MessageID == messageID },
+	)
+
+	return notifications, nil
+}
+
+// AcknowledgeGroupNotifications отмечает все неподтвержденные уведомления о поливе сценария
+// как подтвержденные, чтобы по ним больше не отправлялись повторные напоминания.
+func (s *Storage) AcknowledgeGroupNotifications(
+	ctx context.Context,
+	groupID int,
+	acknowledgedAt time.Time,
+) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.tables.notifications.update(
+		func(n entities.Notification) bool {
+			return n.GroupID == groupID && n.CareTaskID == nil && n.AcknowledgedAt == nil
+		},
+		func(n *entities.Notification) { n.AcknowledgedAt = &acknowledgedAt },
+	)
+
+	return nil
+}
+
+func isCareTaskNotification(careTaskID int) func(entities.Notification) bool {
+	return func(n entities.Notification) bool {
+		return n.CareTaskID != nil && *n.CareTaskID == careTaskID
+	}
+}
+
+// lastSent возвращает уведомление с наибольшим sent_at, а из отправленных одновременно - последнее сохраненное.
+func lastSent(notifications []entities.Notification) (*entities.Notification, error) {
+	if len(notifications) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	last := notifications[0]
+	for _, notification := range notifications[1:] {
+		if !notification.SentAt.Before(last.SentAt) {
+			last = notification
+		}
+	}
+
+	return &last, nil
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) SavePlantPhoto(ctx context.Context, photo entities.PlantPhoto) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	if !s.plantExists(photo.PlantID) {
+		return 0, fmt.Errorf("%w: plant_photos plant_id", ErrForeignKeyViolation)
+	}
+
+	photo.ID = s.tables.plantPhotos.nextID()
+	photo.CreatedAt = time.Now()
+
+	s.tables.plantPhotos.rows = append(s.tables.plantPhotos.rows, photo)
+
+	return photo.ID, nil
+}
+
+func (s *Storage) GetPlantPhoto(ctx context.Context, id int) (*entities.PlantPhoto, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.plantPhotos.find(func(p entities.PlantPhoto) bool { return p.ID == id })
+}
+
+func (s *Storage) GetPlantPhotos(
+	ctx context.Context,
+	plantID, limit, offset int,
+) ([]entities.PlantPhoto, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	photos := s.tables.plantPhotos.filter(func(p entities.PlantPhoto) bool { return p.PlantID == plantID })
+
+	// Хронологический порядок, чтобы листать историю роста растения. Строки уже упорядочены по id:
+	slices.SortStableFunc(
+		photos,
+		func(a, b entities.PlantPhoto) int { return a.TakenAt.Compare(b.TakenAt) },
+	)
+
+	return paginate(photos, limit, offset), nil
+}
+
+func (s *Storage) CountPlantPhotos(ctx context.Context, plantID int) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	return s.tables.plantPhotos.count(func(p entities.PlantPhoto) bool { return p.PlantID == plantID }), nil
+}
@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) CreatePlant(ctx context.Context, plant entities.Plant) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	if err = s.checkPlant(plant); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	plant.ID = s.tables.plants.nextID()
+	plant.CreatedAt = now
+	plant.UpdatedAt = now
+
+	s.tables.plants.rows = append(s.tables.plants.rows, plant)
+
+	return plant.ID, nil
+}
+
+func (s *Storage) UpdatePlant(ctx context.Context, plant entities.Plant) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if err = s.checkPlant(plant); err != nil {
+		return err
+	}
+
+	s.tables.plants.update(
+		func(p entities.Plant) bool { return p.ID == plant.ID },
+		func(p *entities.Plant) {
+			p.GroupID = plant.GroupID
+			p.UserID = plant.UserID
+			p.Title = plant.Title
+			p.Description = plant.Description
+			p.Photo = plant.Photo
+			p.WateringInterval = plant.WateringInterval
+			p.LastWateringDate = plant.LastWateringDate
+			p.PhotoFileID = plant.PhotoFileID
+			p.PhotoFileUniqueID = plant.PhotoFileUniqueID
+			p.UpdatedAt = time.Now()
+		},
+	)
+
+	return nil
+}
+
+func (s *Storage) PlantExists(ctx context.Context, plant entities.Plant) (bool, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer unlock()
+
+	exists := s.tables.plants.exists(
+		func(p entities.Plant) bool { return p.GroupID == plant.GroupID && p.Title == plant.Title },
+	)
+
+	return exists, nil
+}
+
+func (s *Storage) DeletePlant(ctx context.Context, id int) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.deletePlants(func(p entities.Plant) bool { return p.ID == id })
+
+	return nil
+}
+
+func (s *Storage) CountUserPlants(ctx context.Context, userID int) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	// Растения общие для всех участников дома:
+	isHouseholdUser := s.tables.userOrHousehold(userID)
+
+	return s.tables.plants.count(func(p entities.Plant) bool { return isHouseholdUser(p.UserID) }), nil
+}
+
+func (s *Storage) GetGroupPlants(ctx context.Context, groupID int) ([]entities.Plant, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.plants.filter(func(p entities.Plant) bool { return p.GroupID == groupID }), nil
+}
+
+func (s *Storage) CountGroupPlants(ctx context.Context, groupID int) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	return s.tables.plants.count(func(p entities.Plant) bool { return p.GroupID == groupID }), nil
+}
+
+func (s *Storage) GetPlant(ctx context.Context, id int) (*entities.Plant, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.plants.find(func(p entities.Plant) bool { return p.ID == id })
+}
+
+// checkPlant повторяет внешние ключи и CHECK-ограничения plants.
+func (s *Storage) checkPlant(plant entities.Plant) error {
+	switch {
+	case !s.groupExists(plant.GroupID):
+		return fmt.Errorf("%w: plants group_id", ErrForeignKeyViolation)
+	case !s.userExists(plant.UserID):
+		return fmt.Errorf("%w: plants user_id", ErrForeignKeyViolation)
+	case plant.WateringInterval != nil && *plant.WateringInterval <= 0:
+		return fmt.Errorf("%w: plants watering_interval", ErrCheckViolation)
+	default:
+		return nil
+	}
+}
+
+// deletePlants удаляет растения вместе с зависимыми записями. Поливы растений остаются в истории сценария,
+// как при ON DELETE SET NULL в Postgres.
+func (s *Storage) deletePlants(match func(entities.Plant) bool) {
+	plantIDs := make(map[int]bool)
+	for _, plant := range s.tables.plants.filter(match) {
+		plantIDs[plant.ID] = true
+	}
+
+	s.deleteCareTasks(func(c entities.CareTask) bool { return c.PlantID != nil && plantIDs[*c.PlantID] })
+	s.tables.plantPhotos.delete(func(p entities.PlantPhoto) bool { return plantIDs[p.PlantID] })
+	s.tables.waterings.update(
+		func(w entities.Watering) bool { return w.PlantID != nil && plantIDs[*w.PlantID] },
+		func(w *entities.Watering) { w.PlantID = nil },
+	)
+	s.tables.plants.delete(func(p entities.Plant) bool { return plantIDs[p.ID] })
+}
+
+func (s *Storage) plantExists(id int) bool {
+	return s.tables.plants.exists(func(p entities.Plant) bool { return p.ID == id })
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) CreateTemporary(ctx context.Context, temp entities.Temporary) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if !s.userExists(temp.UserID) {
+		return fmt.Errorf("%w: temporary user_id", ErrForeignKeyViolation)
+	}
+
+	if s.tables.temporary.exists(func(t entities.Temporary) bool { return t.UserID == temp.UserID }) {
+		return fmt.Errorf("%w: temporary user_id", ErrUniqueViolation)
+	}
+
+	temp.ID = s.tables.temporary.nextID()
+	s.tables.temporary.rows = append(s.tables.temporary.rows, temp)
+
+	return nil
+}
+
+func (s *Storage) UpdateTemporary(ctx context.Context, temp entities.Temporary) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	s.tables.temporary.update(
+		func(t entities.Temporary) bool { return t.ID == temp.ID },
+		func(t *entities.Temporary) {
+			t.Step = temp.Step
+			t.MessageID = temp.MessageID
+			t.Data = temp.Data
+		},
+	)
+
+	return nil
+}
+
+func (s *Storage) GetTemporaryByUserID(ctx context.Context, userID int) (*entities.Temporary, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.temporary.find(func(t entities.Temporary) bool { return t.UserID == userID })
+}
@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+func (s *Storage) SaveUser(ctx context.Context, user entities.User) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	if s.tables.users.exists(
+		func(u entities.User) bool { return u.TelegramID == user.TelegramID || u.Username == user.Username },
+	) {
+		return 0, fmt.Errorf("%w: users telegram_id or username", ErrUniqueViolation)
+	}
+
+	now := time.Now()
+
+	// Как и INSERT в Postgres, сохраняются только регистрационные данные, остальное берется из значений по умолчанию:
+	saved := entities.User{
+		ID:         s.tables.users.nextID(),
+		TelegramID: user.TelegramID,
+		Username:   user.Username,
+		Firstname:  user.Firstname,
+		Lastname:   user.Lastname,
+		IsBot:      user.IsBot,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Timezone:   defaultTimezone,
+		NotifyHour: defaultNotifyHour,
+	}
+
+	s.tables.users.rows = append(s.tables.users.rows, saved)
+
+	return saved.ID, nil
+}
+
+func (s *Storage) GetUserByTelegramID(ctx context.Context, telegramID int) (*entities.User, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.users.find(func(u entities.User) bool { return u.TelegramID == telegramID })
+}
+
+func (s *Storage) GetUserByID(ctx context.Context, id int) (*entities.User, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	return s.tables.users.find(func(u entities.User) bool { return u.ID == id })
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, user entities.User) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if s.tables.users.exists(func(u entities.User) bool { return u.ID != user.ID && u.Username == user.Username }) {
+		return fmt.Errorf("%w: users username", ErrUniqueViolation)
+	}
+
+	if err = checkUserSettings(user); err != nil {
+		return err
+	}
+
+	s.tables.users.update(
+		func(u entities.User) bool { return u.ID == user.ID },
+		func(u *entities.User) {
+			u.Username = user.Username
+			u.Firstname = user.Firstname
+			u.Lastname = user.Lastname
+			u.Timezone = user.Timezone
+			u.NotifyHour = user.NotifyHour
+			u.NotificationsDigest = user.NotificationsDigest
+			u.QuietHoursStart = user.QuietHoursStart
+			u.QuietHoursEnd = user.QuietHoursEnd
+			u.QuietWeekdays = user.QuietWeekdays
+			u.VacationStart = user.VacationStart
+			u.VacationEnd = user.VacationEnd
+			u.UpdatedAt = time.Now()
+		},
+	)
+
+	return nil
+}
+
+func (s *Storage) ClaimUserDigest(ctx context.Context, userID int, claimTTL time.Duration) (bool, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer unlock()
+
+	now := time.Now()
+	claimed := false
+
+	s.tables.users.update(
+		func(u entities.User) bool { return u.ID == userID && isClaimable(u.DigestClaimedAt, now, claimTTL) },
+		func(u *entities.User) {
+			u.DigestClaimedAt = &now
+			claimed = true
+		},
+	)
+
+	return claimed, nil
+}
+
+func (s *Storage) userExists(id int) bool {
+	return s.tables.users.exists(func(u entities.User) bool { return u.ID == id })
+}
+
+// checkUserSettings повторяет CHECK-ограничения колонок настроек users.
+func checkUserSettings(user entities.User) error {
+	const (
+		lastHour        = 23
+		allWeekdaysMask = 127
+	)
+
+	switch {
+	case user.NotifyHour < 0 || user.NotifyHour > lastHour:
+		return fmt.Errorf("%w: users notify_hour", ErrCheckViolation)
+	case user.QuietHoursStart < 0 || user.QuietHoursStart > lastHour:
+		return fmt.Errorf("%w: users quiet_hours_start", ErrCheckViolation)
+	case user.QuietHoursEnd < 0 || user.QuietHoursEnd > lastHour:
+		return fmt.Errorf("%w: users quiet_hours_end", ErrCheckViolation)
+	case user.QuietWeekdays < 0 || user.QuietWeekdays > allWeekdaysMask:
+		return fmt.Errorf("%w: users quiet_weekdays", ErrCheckViolation)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+// wateringSources - допустимые значения колонки source таблицы waterings.
+var wateringSources = []string{
+	entities.WateringSourceNotification,
+	entities.WateringSourceManual,
+	entities.WateringSourceBackfill,
+}
+
+func (s *Storage) SaveWatering(ctx context.Context, watering entities.Watering) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	switch {
+	case !s.groupExists(watering.GroupID):
+		return 0, fmt.Errorf("%w: waterings group_id", ErrForeignKeyViolation)
+	case watering.PlantID != nil && !s.plantExists(*watering.PlantID):
+		return 0, fmt.Errorf("%w: waterings plant_id", ErrForeignKeyViolation)
+	case watering.UserID != nil && !s.userExists(*watering.UserID):
+		return 0, fmt.Errorf("%w: waterings user_id", ErrForeignKeyViolation)
+	case !slices.Contains(wateringSources, watering.Source):
+		return 0, fmt.Errorf("%w: waterings source", ErrCheckViolation)
+	}
+
+	watering.ID = s.tables.waterings.nextID()
+	watering.CreatedAt = time.Now()
+
+	s.tables.waterings.rows = append(s.tables.waterings.rows, watering)
+
+	return watering.ID, nil
+}
+
+func (s *Storage) GetGroupWaterings(
+	ctx context.Context,
+	groupID, limit, offset int,
+) ([]entities.Watering, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer unlock()
+
+	waterings := s.tables.waterings.filter(func(w entities.Watering) bool { return w.GroupID == groupID })
+
+	// Сначала последние поливы:
+	slices.SortStableFunc(
+		waterings,
+		func(a, b entities.Watering) int {
+			if order := b.WateredAt.Compare(a.WateredAt); order != 0 {
+				return order
+			}
+
+			return b.ID - a.ID
+		},
+	)
+
+	return paginate(waterings, limit, offset), nil
+}
+
+func (s *Storage) CountGroupWaterings(ctx context.Context, groupID int) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer unlock()
+
+	return s.tables.waterings.count(func(w entities.Watering) bool { return w.GroupID == groupID }), nil
+}
@@ -2,35 +2,46 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/DKhorkov/libs/db"
 	"github.com/DKhorkov/libs/logging"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 )
 
 const (
-	notificationsTableName = "notifications"
-	textColumnName         = "text"
-	sentAtColumnName       = "sent_at"
+	notificationsTableName   = "notifications"
+	textColumnName           = "text"
+	sentAtColumnName         = "sent_at"
+	chatIDColumnName         = "chat_id"
+	acknowledgedAtColumnName = "acknowledged_at"
+	digestColumnName         = "digest"
 )
 
 type notificationsStorage struct {
-	dbConnector db.Connector
-	logger      logging.Logger
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
 }
 
-func (s *notificationsStorage) SaveNotification(notification entities.Notification) (int, error) {
-	ctx := context.Background()
+func (s *notificationsStorage) SaveNotification(ctx context.Context, notification entities.Notification) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "SaveNotification")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return 0, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Insert(notificationsTableName).
@@ -39,12 +50,18 @@ func (s *notificationsStorage) SaveNotification(notification entities.Notificati
 			messageIDColumnName,
 			textColumnName,
 			sentAtColumnName,
+			careTaskIDColumnName,
+			chatIDColumnName,
+			digestColumnName,
 		).
 		Values(
 			notification.GroupID,
 			notification.MessageID,
 			notification.Text,
 			notification.SentAt,
+			notification.CareTaskID,
+			notification.ChatID,
+			notification.Digest,
 		).
 		Suffix(returningIDSuffix).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
@@ -60,3 +77,238 @@ func (s *notificationsStorage) SaveNotification(notification entities.Notificati
 
 	return notificationID, nil
 }
+
+func (s *notificationsStorage) GetLastNotification(ctx context.Context, groupID int) (*entities.Notification, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetLastNotification")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(notificationsTableName).
+		Where(
+			sq.Eq{
+				groupIDColumnName:    groupID,
+				careTaskIDColumnName: nil, // Только уведомления о поливе
+			},
+		).
+		OrderBy(sentAtColumnName + " DESC").
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &entities.Notification{}
+
+	columns := db.GetEntityColumns(notification)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (s *notificationsStorage) GetLastCareTaskNotification(
+	ctx context.Context,
+	careTaskID int,
+) (*entities.Notification, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetLastCareTaskNotification")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(notificationsTableName).
+		Where(sq.Eq{careTaskIDColumnName: careTaskID}).
+		OrderBy(sentAtColumnName + " DESC").
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &entities.Notification{}
+
+	columns := db.GetEntityColumns(notification)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// GetGroupNotificationsSince возвращает все уведомления о поливе сценария во всех чатах,
+// отправленные начиная с указанного момента.
+func (s *notificationsStorage) GetGroupNotificationsSince(
+	ctx context.Context,
+	groupID int,
+	since time.Time,
+) ([]entities.Notification, error) {
+	return s.getNotifications(ctx,
+		sq.
+			Select(selectAllColumns).
+			From(notificationsTableName).
+			Where(
+				sq.Eq{
+					groupIDColumnName:    groupID,
+					careTaskIDColumnName: nil, // Только уведомления о поливе
+				},
+			).
+			Where(sq.GtOrEq{sentAtColumnName: since}).
+			OrderBy(idColumnName),
+	)
+}
+
+// GetLastCareTaskNotifications возвращает последнее уведомление о задаче ухода в каждом чате,
+// куда отправлялись напоминания.
+func (s *notificationsStorage) GetLastCareTaskNotifications(
+	ctx context.Context,
+	careTaskID int,
+) ([]entities.Notification, error) {
+	return s.getNotifications(ctx,
+		sq.
+			Select(selectAllColumns).
+			Options(fmt.Sprintf("DISTINCT ON (%s)", chatIDColumnName)).
+			From(notificationsTableName).
+			Where(sq.Eq{careTaskIDColumnName: careTaskID}).
+			OrderBy(
+				chatIDColumnName,
+				fmt.Sprintf("%s %s", sentAtColumnName, desc),
+			),
+	)
+}
+
+// GetMessageNotifications возвращает все уведомления, сохраненные для одного сообщения.
+// Для сводки это уведомления по каждому вошедшему в нее сценарию.
+func (s *notificationsStorage) GetMessageNotifications(
+	ctx context.Context,
+	chatID int64,
+	messageID int,
+) ([]entities.Notification, error) {
+	return s.getNotifications(ctx,
+		sq.
+			Select(selectAllColumns).
+			From(notificationsTableName).
+			Where(
+				sq.Eq{
+					chatIDColumnName:    chatID,
+					messageIDColumnName: messageID,
+				},
+			).
+			OrderBy(idColumnName),
+	)
+}
+
+// AcknowledgeGroupNotifications отмечает все неподтвержденные уведомления о поливе сценария
+// как подтвержденные, чтобы по ним больше не отправлялись повторные напоминания.
+func (s *notificationsStorage) AcknowledgeGroupNotifications(
+	ctx context.Context,
+	groupID int,
+	acknowledgedAt time.Time,
+) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "AcknowledgeGroupNotifications")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Update(notificationsTableName).
+		Where(
+			sq.Eq{
+				groupIDColumnName:        groupID,
+				careTaskIDColumnName:     nil, // Только уведомления о поливе
+				acknowledgedAtColumnName: nil,
+			},
+		).
+		Set(acknowledgedAtColumnName, acknowledgedAt).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(ctx, stmt, params...)
+
+	return err
+}
+
+func (s *notificationsStorage) getNotifications(
+	ctx context.Context,
+	query sq.SelectBuilder,
+) ([]entities.Notification, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "getNotifications")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := query.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				s.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var notifications []entities.Notification
+
+	for rows.Next() {
+		notification := entities.Notification{}
+		columns := db.GetEntityColumns(&notification) // Only pointer to use rows.Scan() successfully
+
+		if err = rows.Scan(columns...); err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
@@ -162,7 +162,7 @@ func (s *NotificationsStorageTestSuite) TestSaveNotification_Success() {
 		SentAt:    now,
 	}
 
-	notificationID, err := s.storage.SaveNotification(notification)
+	notificationID, err := s.storage.SaveNotification(s.ctx, notification)
 	s.NoError(err)
 	s.Greater(notificationID, 0)
 
@@ -192,7 +192,7 @@ func (s *NotificationsStorageTestSuite) TestSaveNotification_GroupDoesNotExist()
 		SentAt:    now,
 	}
 
-	_, err := s.storage.SaveNotification(notification)
+	_, err := s.storage.SaveNotification(s.ctx, notification)
 	s.Error(err)
 	// Ожидаем ошибку от PostgreSQL: "insert or update on table "notifications" violates foreign key constraint"
 	s.Contains(err.Error(), "violates foreign key constraint")
@@ -210,7 +210,7 @@ func (s *NotificationsStorageTestSuite) TestSaveNotification_EmptyText() {
 		SentAt:    now,
 	}
 
-	_, err := s.storage.SaveNotification(notification)
+	_, err := s.storage.SaveNotification(s.ctx, notification)
 	s.NoError(err)
 }
 
@@ -226,7 +226,7 @@ func (s *NotificationsStorageTestSuite) TestSaveNotification_MessageID_Zero() {
 		SentAt:    now,
 	}
 
-	notificationID, err := s.storage.SaveNotification(notification)
+	notificationID, err := s.storage.SaveNotification(s.ctx, notification)
 	s.NoError(err)
 	s.Greater(notificationID, 0)
 
@@ -253,7 +253,7 @@ func (s *NotificationsStorageTestSuite) TestSaveNotification_SentAt_InFuture() {
 		SentAt:    future,
 	}
 
-	notificationID, err := s.storage.SaveNotification(notification)
+	notificationID, err := s.storage.SaveNotification(s.ctx, notification)
 	s.NoError(err)
 
 	var stored entities.Notification
@@ -284,7 +284,7 @@ func (s *NotificationsStorageTestSuite) TestSaveNotification_ConcurrentInserts()
 				Text:      fmt.Sprintf("Уведомление #%d", i),
 				SentAt:    now,
 			}
-			_, err := s.storage.SaveNotification(notification)
+			_, err := s.storage.SaveNotification(s.ctx, notification)
 			errs <- err
 		}(i)
 	}
@@ -328,7 +328,164 @@ func (s *NotificationsStorageTestSuite) TestSaveNotification_AfterGroupDeleted()
 		SentAt:    now,
 	}
 
-	_, err = s.storage.SaveNotification(notification)
+	_, err = s.storage.SaveNotification(s.ctx, notification)
 	s.Error(err)
 	s.Contains(err.Error(), "violates foreign key constraint")
 }
+
+func (s *NotificationsStorageTestSuite) TestGetLastNotification_Success() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	for i, sentAt := range []time.Time{now.Add(-48 * time.Hour), now, now.Add(-24 * time.Hour)} {
+		_, err := s.storage.SaveNotification(
+			s.ctx,
+			entities.Notification{
+				GroupID:   groupID,
+				MessageID: 1000 + i,
+				Text:      fmt.Sprintf("Уведомление #%d", i),
+				SentAt:    sentAt,
+			},
+		)
+		s.NoError(err)
+	}
+
+	notification, err := s.storage.GetLastNotification(s.ctx, groupID)
+	s.NoError(err)
+	s.NotNil(notification)
+	s.Equal(groupID, notification.GroupID)
+	s.Equal(1001, notification.MessageID)
+	s.WithinDuration(now, notification.SentAt, time.Second)
+}
+
+func (s *NotificationsStorageTestSuite) TestGetLastNotification_NotFound() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	notification, err := s.storage.GetLastNotification(s.ctx, groupID)
+	s.Error(err)
+	s.ErrorIs(err, sql.ErrNoRows)
+	s.Nil(notification)
+}
+
+func (s *NotificationsStorageTestSuite) TestGetGroupNotificationsSince_Success() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	for i, sentAt := range []time.Time{now.Add(-48 * time.Hour), now.Add(-time.Hour), now} {
+		_, err := s.storage.SaveNotification(
+			s.ctx,
+			entities.Notification{
+				GroupID:   groupID,
+				MessageID: 1000 + i,
+				Text:      fmt.Sprintf("Уведомление #%d", i),
+				SentAt:    sentAt,
+				ChatID:    int64(500 + i),
+			},
+		)
+		s.NoError(err)
+	}
+
+	// Уведомления о задачах ухода не относятся к циклу полива:
+	var careTaskID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO care_tasks (
+				user_id, group_id, type, interval_days, last_care_date, next_care_date
+			) VALUES ($1, $2, 'fertilizing', 30, $3, $3)
+			RETURNING id
+		`,
+		userID,
+		groupID,
+		now,
+	).Scan(&careTaskID)
+	s.NoError(err)
+
+	_, err = s.storage.SaveNotification(
+		s.ctx,
+		entities.Notification{
+			GroupID:    groupID,
+			MessageID:  2000,
+			Text:       "Уход",
+			SentAt:     now,
+			CareTaskID: &careTaskID,
+		},
+	)
+	s.NoError(err)
+
+	notifications, err := s.storage.GetGroupNotificationsSince(s.ctx, groupID, now.Add(-24*time.Hour))
+	s.NoError(err)
+	s.Len(notifications, 2)
+	s.Equal(1001, notifications[0].MessageID)
+	s.Equal(int64(501), notifications[0].ChatID)
+	s.Equal(1002, notifications[1].MessageID)
+}
+
+func (s *NotificationsStorageTestSuite) TestAcknowledgeGroupNotifications_Success() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	otherGroupID := s.createGroupForUser(userID, now, 2)
+
+	for i, id := range []int{groupID, groupID, otherGroupID} {
+		_, err := s.storage.SaveNotification(
+			s.ctx,
+			entities.Notification{
+				GroupID:   id,
+				MessageID: 1000 + i,
+				Text:      fmt.Sprintf("Уведомление #%d", i),
+				SentAt:    now,
+			},
+		)
+		s.NoError(err)
+	}
+
+	s.NoError(s.storage.AcknowledgeGroupNotifications(s.ctx, groupID, now))
+
+	notifications, err := s.storage.GetGroupNotificationsSince(s.ctx, groupID, now.Add(-time.Hour))
+	s.NoError(err)
+	s.Len(notifications, 2)
+
+	for _, notification := range notifications {
+		s.NotNil(notification.AcknowledgedAt)
+		s.WithinDuration(now, *notification.AcknowledgedAt, time.Second)
+	}
+
+	// Напоминания других сценариев остаются неподтвержденными:
+	notifications, err = s.storage.GetGroupNotificationsSince(s.ctx, otherGroupID, now.Add(-time.Hour))
+	s.NoError(err)
+	s.Len(notifications, 1)
+	s.Nil(notifications[0].AcknowledgedAt)
+}
+
+func (s *NotificationsStorageTestSuite) TestGetMessageNotifications_Success() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	firstGroupID := s.createGroupForUser(userID, now, 1)
+	secondGroupID := s.createGroupForUser(userID, now, 2)
+
+	// Сводка сохраняется уведомлением для каждого сценария в каждом чате:
+	for _, notification := range []entities.Notification{
+		{GroupID: firstGroupID, MessageID: 1000, ChatID: 500, Digest: true},
+		{GroupID: secondGroupID, MessageID: 1000, ChatID: 500, Digest: true},
+		{GroupID: firstGroupID, MessageID: 1000, ChatID: 501, Digest: true},
+		{GroupID: firstGroupID, MessageID: 1001, ChatID: 500},
+	} {
+		notification.Text = "Сводка"
+		notification.SentAt = now
+
+		_, err := s.storage.SaveNotification(s.ctx, notification)
+		s.NoError(err)
+	}
+
+	notifications, err := s.storage.GetMessageNotifications(s.ctx, 500, 1000)
+	s.NoError(err)
+	s.Len(notifications, 2)
+	s.Equal(firstGroupID, notifications[0].GroupID)
+	s.Equal(secondGroupID, notifications[1].GroupID)
+	s.True(notifications[0].Digest)
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/logging"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+const (
+	plantPhotosTableName   = "plant_photos"
+	fileIDColumnName       = "file_id"
+	fileUniqueIDColumnName = "file_unique_id"
+	captionColumnName      = "caption"
+	takenAtColumnName      = "taken_at"
+)
+
+type plantPhotosStorage struct {
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
+}
+
+func (s *plantPhotosStorage) SavePlantPhoto(ctx context.Context, photo entities.PlantPhoto) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "SavePlantPhoto")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Insert(plantPhotosTableName).
+		Columns(
+			plantIDColumnName,
+			fileIDColumnName,
+			fileUniqueIDColumnName,
+			captionColumnName,
+			takenAtColumnName,
+		).
+		Values(
+			photo.PlantID,
+			photo.FileID,
+			photo.FileUniqueID,
+			photo.Caption,
+			photo.TakenAt,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var photoID int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&photoID); err != nil {
+		return 0, err
+	}
+
+	return photoID, nil
+}
+
+func (s *plantPhotosStorage) GetPlantPhoto(ctx context.Context, id int) (*entities.PlantPhoto, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetPlantPhoto")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(plantPhotosTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	photo := &entities.PlantPhoto{}
+
+	columns := db.GetEntityColumns(photo)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return photo, nil
+}
+
+func (s *plantPhotosStorage) GetPlantPhotos(
+	ctx context.Context,
+	plantID, limit, offset int,
+) ([]entities.PlantPhoto, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetPlantPhotos")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(plantPhotosTableName).
+		Where(sq.Eq{plantIDColumnName: plantID}).
+		OrderBy( // Хронологический порядок, чтобы листать историю роста растения
+			fmt.Sprintf("%s %s", takenAtColumnName, asc),
+			fmt.Sprintf("%s %s", idColumnName, asc),
+		).
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				s.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var photos []entities.PlantPhoto
+
+	for rows.Next() {
+		photo := entities.PlantPhoto{}
+		columns := db.GetEntityColumns(&photo) // Only pointer to use rows.Scan() successfully
+
+		if err = rows.Scan(columns...); err != nil {
+			return nil, err
+		}
+
+		photos = append(photos, photo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+func (s *plantPhotosStorage) CountPlantPhotos(ctx context.Context, plantID int) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CountPlantPhotos")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectCount).
+		From(plantPhotosTableName).
+		Where(sq.Eq{plantIDColumnName: plantID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,296 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/DKhorkov/libs/logging"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestPlantPhotosStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(PlantPhotosStorageTestSuite))
+}
+
+type PlantPhotosStorageTestSuite struct {
+	suite.Suite
+
+	cwd         string
+	ctx         context.Context
+	dbConnector db.Connector
+	connection  *sql.Conn
+	storage     *plantPhotosStorage
+	logger      *mocklogging.MockLogger
+}
+
+func (s *PlantPhotosStorageTestSuite) SetupSuite() {
+	// Инициализируем переменные окружения для дальнейшего считывания:
+	loadenv.Init("../../.env")
+
+	cwd, err := os.Getwd()
+	s.NoError(err)
+
+	cfg := config.New()
+	logger := logging.New(
+		cfg.Logging.Level,
+		cfg.Logging.LogFilePath,
+	)
+
+	dbConnector, err := db.New(
+		db.BuildDsn(cfg.Database),
+		cfg.Database.Driver,
+		logger,
+		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	)
+	s.NoError(err, "failed to connect to database")
+
+	s.NoError(goose.SetDialect(cfg.Database.Driver))
+
+	ctrl := gomock.NewController(s.T())
+	s.logger = mocklogging.NewMockLogger(ctrl)
+
+	s.cwd = cwd
+	s.dbConnector = dbConnector
+	s.ctx = context.Background()
+
+	s.storage = &plantPhotosStorage{
+		dbConnector: s.dbConnector,
+		logger:      s.logger,
+	}
+}
+
+func (s *PlantPhotosStorageTestSuite) SetupTest() {
+	s.NoError(
+		goose.Up(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+		),
+	)
+
+	connection, err := s.dbConnector.Connection(s.ctx)
+	s.NoError(err)
+
+	s.connection = connection
+}
+
+func (s *PlantPhotosStorageTestSuite) TearDownTest() {
+	s.NoError(
+		goose.DownTo(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+			gooseZeroVersion,
+		),
+	)
+
+	s.NoError(s.connection.Close())
+}
+
+func (s *PlantPhotosStorageTestSuite) TearDownSuite() {
+	s.NoError(s.dbConnector.Close())
+}
+
+func (s *PlantPhotosStorageTestSuite) createUser(now time.Time, offset int) int {
+	var userID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO users (
+				telegram_id, username, firstname, lastname, is_bot, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $6)
+			RETURNING id
+		`,
+		123456789+int64(offset),
+		fmt.Sprintf("user%d", offset),
+		fmt.Sprintf("First%d", offset),
+		fmt.Sprintf("Last%d", offset),
+		false,
+		now,
+	).Scan(&userID)
+	s.NoError(err)
+	return userID
+}
+
+func (s *PlantPhotosStorageTestSuite) createGroupForUser(userID int, now time.Time, offset int) int {
+	var groupID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO groups (
+				user_id, title, watering_interval, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $4)
+			RETURNING id
+		`,
+		userID,
+		fmt.Sprintf("Группа %d", offset),
+		7+offset,
+		now,
+	).Scan(&groupID)
+	s.NoError(err)
+	return groupID
+}
+
+func (s *PlantPhotosStorageTestSuite) createPlant(groupID, userID int, now time.Time) int {
+	var plantID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO plants (
+				group_id, user_id, title, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $4)
+			RETURNING id
+		`,
+		groupID,
+		userID,
+		"Фикус",
+		now,
+	).Scan(&plantID)
+	s.NoError(err)
+	return plantID
+}
+
+func (s *PlantPhotosStorageTestSuite) TestSavePlantPhoto_Success() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlant(groupID, userID, now)
+	takenAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	photoID, err := s.storage.SavePlantPhoto(
+		s.ctx,
+		entities.PlantPhoto{
+			PlantID:      plantID,
+			FileID:       "file",
+			FileUniqueID: "file_unique",
+			Caption:      "Новый лист",
+			TakenAt:      takenAt,
+		},
+	)
+	s.NoError(err)
+	s.Greater(photoID, 0)
+
+	photo, err := s.storage.GetPlantPhoto(s.ctx, photoID)
+	s.NoError(err)
+	s.Equal(plantID, photo.PlantID)
+	s.Equal("file", photo.FileID)
+	s.Equal("file_unique", photo.FileUniqueID)
+	s.Equal("Новый лист", photo.Caption)
+	s.True(takenAt.Equal(photo.TakenAt))
+}
+
+func (s *PlantPhotosStorageTestSuite) TestSavePlantPhoto_PlantDoesNotExist() {
+	_, err := s.storage.SavePlantPhoto(
+		s.ctx,
+		entities.PlantPhoto{
+			PlantID:      999999, // Такого растения нет
+			FileID:       "file",
+			FileUniqueID: "file_unique",
+			TakenAt:      time.Now().UTC(),
+		},
+	)
+	s.Error(err)
+	s.Contains(err.Error(), "violates foreign key constraint")
+}
+
+func (s *PlantPhotosStorageTestSuite) TestGetPlantPhoto_NotFound() {
+	photo, err := s.storage.GetPlantPhoto(s.ctx, 999999)
+	s.Error(err)
+	s.ErrorIs(err, sql.ErrNoRows)
+	s.Nil(photo)
+}
+
+func (s *PlantPhotosStorageTestSuite) TestGetPlantPhotos_OrderAndPagination() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlant(groupID, userID, now)
+	otherPlantID := s.createPlant(groupID, userID, now)
+
+	for _, days := range []int{1, 3, 2} {
+		_, err := s.storage.SavePlantPhoto(
+			s.ctx,
+			entities.PlantPhoto{
+				PlantID:      plantID,
+				FileID:       fmt.Sprintf("file%d", days),
+				FileUniqueID: fmt.Sprintf("file_unique%d", days),
+				TakenAt:      now.AddDate(0, 0, -days),
+			},
+		)
+		s.NoError(err)
+	}
+
+	_, err := s.storage.SavePlantPhoto(
+		s.ctx,
+		entities.PlantPhoto{
+			PlantID:      otherPlantID,
+			FileID:       "other",
+			FileUniqueID: "other_unique",
+			TakenAt:      now,
+		},
+	)
+	s.NoError(err)
+
+	// Фотографии идут от самой старой к самой новой:
+	photos, err := s.storage.GetPlantPhotos(s.ctx, plantID, 2, 0)
+	s.NoError(err)
+	s.Len(photos, 2)
+	s.Equal("file3", photos[0].FileID)
+	s.Equal("file2", photos[1].FileID)
+
+	photos, err = s.storage.GetPlantPhotos(s.ctx, plantID, 2, 2)
+	s.NoError(err)
+	s.Len(photos, 1)
+	s.Equal("file1", photos[0].FileID)
+
+	count, err := s.storage.CountPlantPhotos(s.ctx, plantID)
+	s.NoError(err)
+	s.Equal(3, count)
+}
+
+func (s *PlantPhotosStorageTestSuite) TestGetPlantPhotos_DeletedWithPlant() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlant(groupID, userID, now)
+
+	_, err := s.storage.SavePlantPhoto(
+		s.ctx,
+		entities.PlantPhoto{
+			PlantID:      plantID,
+			FileID:       "file",
+			FileUniqueID: "file_unique",
+			TakenAt:      now,
+		},
+	)
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(context.Background(), `DELETE FROM plants WHERE id = $1`, plantID)
+	s.NoError(err)
+
+	photos, err := s.storage.GetPlantPhotos(s.ctx, plantID, 10, 0)
+	s.NoError(err)
+	s.Empty(photos)
+
+	count, err := s.storage.CountPlantPhotos(s.ctx, plantID)
+	s.NoError(err)
+	s.Zero(count)
+}
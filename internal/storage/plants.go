@@ -11,30 +11,37 @@ import (
 	"github.com/DKhorkov/libs/logging"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 )
 
 const (
-	plantsTableName   = "plants"
-	groupIDColumnName = "group_id"
-	photoColumnName   = "photo"
+	plantsTableName             = "plants"
+	groupIDColumnName           = "group_id"
+	photoColumnName             = "photo"
+	photoFileIDColumnName       = "photo_file_id"
+	photoFileUniqueIDColumnName = "photo_file_unique_id"
 )
 
 type plantsStorage struct {
-	dbConnector db.Connector
-	logger      logging.Logger
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
 }
 
-func (s *plantsStorage) CreatePlant(plant entities.Plant) (int, error) {
-	ctx := context.Background()
+func (s *plantsStorage) CreatePlant(ctx context.Context, plant entities.Plant) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CreatePlant")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return 0, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Insert(plantsTableName).
@@ -44,6 +51,10 @@ func (s *plantsStorage) CreatePlant(plant entities.Plant) (int, error) {
 			titleColumnName,
 			descriptionColumnName,
 			photoColumnName,
+			wateringIntervalColumnName,
+			lastWateringDateColumnName,
+			photoFileIDColumnName,
+			photoFileUniqueIDColumnName,
 		).
 		Values(
 			plant.GroupID,
@@ -51,6 +62,10 @@ func (s *plantsStorage) CreatePlant(plant entities.Plant) (int, error) {
 			plant.Title,
 			plant.Description,
 			plant.Photo,
+			plant.WateringInterval,
+			plant.LastWateringDate,
+			plant.PhotoFileID,
+			plant.PhotoFileUniqueID,
 		).
 		Suffix(returningIDSuffix).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
@@ -67,15 +82,16 @@ func (s *plantsStorage) CreatePlant(plant entities.Plant) (int, error) {
 	return plantID, nil
 }
 
-func (s *plantsStorage) UpdatePlant(plant entities.Plant) error {
-	ctx := context.Background()
+func (s *plantsStorage) UpdatePlant(ctx context.Context, plant entities.Plant) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "UpdatePlant")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Update(plantsTableName).
@@ -85,6 +101,10 @@ func (s *plantsStorage) UpdatePlant(plant entities.Plant) error {
 		Set(titleColumnName, plant.Title).
 		Set(descriptionColumnName, plant.Description).
 		Set(photoColumnName, plant.Photo).
+		Set(wateringIntervalColumnName, plant.WateringInterval).
+		Set(lastWateringDateColumnName, plant.LastWateringDate).
+		Set(photoFileIDColumnName, plant.PhotoFileID).
+		Set(photoFileUniqueIDColumnName, plant.PhotoFileUniqueID).
 		Set(updatedAtColumnName, time.Now()).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
 		ToSql()
@@ -97,15 +117,16 @@ func (s *plantsStorage) UpdatePlant(plant entities.Plant) error {
 	return err
 }
 
-func (s *plantsStorage) PlantExists(plant entities.Plant) (bool, error) {
-	ctx := context.Background()
+func (s *plantsStorage) PlantExists(ctx context.Context, plant entities.Plant) (bool, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "PlantExists")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return false, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectExists).
@@ -134,15 +155,16 @@ func (s *plantsStorage) PlantExists(plant entities.Plant) (bool, error) {
 	return exists, nil
 }
 
-func (s *plantsStorage) DeletePlant(id int) error {
-	ctx := context.Background()
+func (s *plantsStorage) DeletePlant(ctx context.Context, id int) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "DeletePlant")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Delete(plantsTableName).
@@ -162,20 +184,21 @@ func (s *plantsStorage) DeletePlant(id int) error {
 	return err
 }
 
-func (s *plantsStorage) CountUserPlants(userID int) (int, error) {
-	ctx := context.Background()
+func (s *plantsStorage) CountUserPlants(ctx context.Context, userID int) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CountUserPlants")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return 0, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectCount).
 		From(plantsTableName).
-		Where(sq.Eq{userIDColumnName: userID}).
+		Where(userOrHouseholdExpr(userIDColumnName, userID)). // Растения общие для всех участников дома
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -190,15 +213,16 @@ func (s *plantsStorage) CountUserPlants(userID int) (int, error) {
 	return count, nil
 }
 
-func (s *plantsStorage) GetGroupPlants(groupID int) ([]entities.Plant, error) {
-	ctx := context.Background()
+func (s *plantsStorage) GetGroupPlants(ctx context.Context, groupID int) ([]entities.Plant, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetGroupPlants")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectAllColumns).
@@ -258,15 +282,16 @@ func (s *plantsStorage) GetGroupPlants(groupID int) ([]entities.Plant, error) {
 	return plants, nil
 }
 
-func (s *plantsStorage) CountGroupPlants(groupID int) (int, error) {
-	ctx := context.Background()
+func (s *plantsStorage) CountGroupPlants(ctx context.Context, groupID int) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CountGroupPlants")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return 0, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectCount).
@@ -286,15 +311,16 @@ func (s *plantsStorage) CountGroupPlants(groupID int) (int, error) {
 	return count, nil
 }
 
-func (s *plantsStorage) GetPlant(id int) (*entities.Plant, error) {
-	ctx := context.Background()
+func (s *plantsStorage) GetPlant(ctx context.Context, id int) (*entities.Plant, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetPlant")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectAllColumns).
@@ -189,7 +189,7 @@ func (s *PlantsStorageTestSuite) TestCreatePlant_Success() {
 		Photo:       []byte{0xFF, 0xD8, 0xFF}, // JPEG-заголовок
 	}
 
-	plantID, err := s.storage.CreatePlant(plant)
+	plantID, err := s.storage.CreatePlant(s.ctx, plant)
 	s.NoError(err)
 	s.Greater(plantID, 0)
 
@@ -212,6 +212,29 @@ func (s *PlantsStorageTestSuite) TestCreatePlant_Success() {
 	s.WithinDuration(now, stored.UpdatedAt, 2*time.Second)
 }
 
+func (s *PlantsStorageTestSuite) TestCreatePlant_PhotoFileID() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	plant := entities.Plant{
+		GroupID:           groupID,
+		UserID:            userID,
+		Title:             "Фикус",
+		PhotoFileID:       "AgACAgIAAxkBAAI",
+		PhotoFileUniqueID: "AQADzL0xG",
+	}
+
+	plantID, err := s.storage.CreatePlant(s.ctx, plant)
+	s.NoError(err)
+
+	stored, err := s.storage.GetPlant(s.ctx, plantID)
+	s.NoError(err)
+	s.Equal("AgACAgIAAxkBAAI", stored.PhotoFileID)
+	s.Equal("AQADzL0xG", stored.PhotoFileUniqueID)
+	s.Empty(stored.Photo)
+}
+
 func (s *PlantsStorageTestSuite) TestUpdatePlant_Success() {
 	now := time.Now().UTC()
 	userID := s.createUser(now, 1)
@@ -227,7 +250,7 @@ func (s *PlantsStorageTestSuite) TestUpdatePlant_Success() {
 		Photo:       []byte{0x00, 0x01, 0x02},
 	}
 
-	err := s.storage.UpdatePlant(updatedPlant)
+	err := s.storage.UpdatePlant(s.ctx, updatedPlant)
 	s.NoError(err)
 
 	var stored entities.Plant
@@ -253,17 +276,55 @@ func (s *PlantsStorageTestSuite) TestUpdatePlant_NonExistent() {
 		Title:   "Не существует",
 	}
 
-	err := s.storage.UpdatePlant(nonExistent)
+	err := s.storage.UpdatePlant(s.ctx, nonExistent)
 	s.NoError(err) // UPDATE 0 строк — не ошибка
 }
 
+func (s *PlantsStorageTestSuite) TestUpdatePlant_WateringOverride() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlantForGroup(groupID, userID, "Папоротник", now, 1)
+
+	wateringInterval := 3
+	lastWateringDate := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	plant := entities.Plant{
+		ID:               plantID,
+		GroupID:          groupID,
+		UserID:           userID,
+		Title:            "Папоротник",
+		WateringInterval: &wateringInterval,
+		LastWateringDate: &lastWateringDate,
+	}
+
+	s.NoError(s.storage.UpdatePlant(s.ctx, plant))
+
+	stored, err := s.storage.GetPlant(s.ctx, plantID)
+	s.NoError(err)
+	s.Require().NotNil(stored.WateringInterval)
+	s.Require().NotNil(stored.LastWateringDate)
+	s.Equal(3, *stored.WateringInterval)
+	s.True(stored.LastWateringDate.Equal(lastWateringDate))
+
+	// Сброс собственного графика возвращает растение к графику сценария:
+	plant.WateringInterval = nil
+	plant.LastWateringDate = nil
+	s.NoError(s.storage.UpdatePlant(s.ctx, plant))
+
+	stored, err = s.storage.GetPlant(s.ctx, plantID)
+	s.NoError(err)
+	s.Nil(stored.WateringInterval)
+	s.Nil(stored.LastWateringDate)
+}
+
 func (s *PlantsStorageTestSuite) TestPlantExists_Exists() {
 	now := time.Now().UTC()
 	userID := s.createUser(now, 1)
 	groupID := s.createGroupForUser(userID, now, 1)
 	s.createPlantForGroup(groupID, userID, "Кактус", now, 1)
 
-	exists, err := s.storage.PlantExists(entities.Plant{
+	exists, err := s.storage.PlantExists(s.ctx, entities.Plant{
 		GroupID: groupID,
 		Title:   "Кактус",
 	})
@@ -272,7 +333,7 @@ func (s *PlantsStorageTestSuite) TestPlantExists_Exists() {
 }
 
 func (s *PlantsStorageTestSuite) TestPlantExists_NotExists() {
-	exists, err := s.storage.PlantExists(entities.Plant{
+	exists, err := s.storage.PlantExists(s.ctx, entities.Plant{
 		GroupID: 999,
 		Title:   "Не существующее растение",
 	})
@@ -287,7 +348,7 @@ func (s *PlantsStorageTestSuite) TestPlantExists_DifferentGroupSameTitle() {
 	groupID2 := s.createGroupForUser(userID, now, 2)
 	s.createPlantForGroup(groupID1, userID, "Одинаковое имя", now, 1)
 
-	exists, err := s.storage.PlantExists(entities.Plant{
+	exists, err := s.storage.PlantExists(s.ctx, entities.Plant{
 		GroupID: groupID2,
 		Title:   "Одинаковое имя",
 	})
@@ -301,7 +362,7 @@ func (s *PlantsStorageTestSuite) TestDeletePlant_Success() {
 	groupID := s.createGroupForUser(userID, now, 1)
 	plantID := s.createPlantForGroup(groupID, userID, "Для удаления", now, 1)
 
-	err := s.storage.DeletePlant(plantID)
+	err := s.storage.DeletePlant(s.ctx, plantID)
 	s.NoError(err)
 
 	var count int
@@ -315,7 +376,7 @@ func (s *PlantsStorageTestSuite) TestDeletePlant_Success() {
 }
 
 func (s *PlantsStorageTestSuite) TestDeletePlant_NonExistent() {
-	err := s.storage.DeletePlant(999999)
+	err := s.storage.DeletePlant(s.ctx, 999999)
 	s.NoError(err) // Удаление несуществующей — не ошибка
 }
 
@@ -329,7 +390,7 @@ func (s *PlantsStorageTestSuite) TestCountUserPlants_HasPlants() {
 	s.createPlantForGroup(groupID1, userID, "Растение 2", now, 2)
 	s.createPlantForGroup(groupID2, userID, "Растение 3", now, 3)
 
-	count, err := s.storage.CountUserPlants(userID)
+	count, err := s.storage.CountUserPlants(s.ctx, userID)
 	s.NoError(err)
 	s.Equal(3, count)
 }
@@ -338,7 +399,7 @@ func (s *PlantsStorageTestSuite) TestCountUserPlants_NoPlants() {
 	now := time.Now().UTC()
 	userID := s.createUser(now, 1)
 
-	count, err := s.storage.CountUserPlants(userID)
+	count, err := s.storage.CountUserPlants(s.ctx, userID)
 	s.NoError(err)
 	s.Equal(0, count)
 }
@@ -351,7 +412,7 @@ func (s *PlantsStorageTestSuite) TestGetGroupPlants_HasPlants() {
 	s.createPlantForGroup(groupID, userID, "Спатифиллум", now, 1)
 	s.createPlantForGroup(groupID, userID, "Замиокулькас", now, 2)
 
-	plants, err := s.storage.GetGroupPlants(groupID)
+	plants, err := s.storage.GetGroupPlants(s.ctx, groupID)
 	s.NoError(err)
 	s.Len(plants, 2)
 	s.Equal("Спатифиллум", plants[0].Title)
@@ -363,7 +424,7 @@ func (s *PlantsStorageTestSuite) TestGetGroupPlants_NoPlants() {
 	userID := s.createUser(now, 1)
 	groupID := s.createGroupForUser(userID, now, 1)
 
-	plants, err := s.storage.GetGroupPlants(groupID)
+	plants, err := s.storage.GetGroupPlants(s.ctx, groupID)
 	s.NoError(err)
 	s.Empty(plants)
 }
@@ -377,7 +438,7 @@ func (s *PlantsStorageTestSuite) TestCountGroupPlants_HasPlants() {
 	s.createPlantForGroup(groupID, userID, "B", now, 2)
 	s.createPlantForGroup(groupID, userID, "C", now, 3)
 
-	count, err := s.storage.CountGroupPlants(groupID)
+	count, err := s.storage.CountGroupPlants(s.ctx, groupID)
 	s.NoError(err)
 	s.Equal(3, count)
 }
@@ -387,7 +448,7 @@ func (s *PlantsStorageTestSuite) TestCountGroupPlants_NoPlants() {
 	userID := s.createUser(now, 1)
 	groupID := s.createGroupForUser(userID, now, 1)
 
-	count, err := s.storage.CountGroupPlants(groupID)
+	count, err := s.storage.CountGroupPlants(s.ctx, groupID)
 	s.NoError(err)
 	s.Equal(0, count)
 }
@@ -398,7 +459,7 @@ func (s *PlantsStorageTestSuite) TestGetPlant_Exists() {
 	groupID := s.createGroupForUser(userID, now, 1)
 	plantID := s.createPlantForGroup(groupID, userID, "Монстера", now, 1)
 
-	plant, err := s.storage.GetPlant(plantID)
+	plant, err := s.storage.GetPlant(s.ctx, plantID)
 	s.NoError(err)
 	s.NotNil(plant)
 	s.Equal(plantID, plant.ID)
@@ -406,7 +467,7 @@ func (s *PlantsStorageTestSuite) TestGetPlant_Exists() {
 }
 
 func (s *PlantsStorageTestSuite) TestGetPlant_NotFound() {
-	plant, err := s.storage.GetPlant(999999)
+	plant, err := s.storage.GetPlant(s.ctx, 999999)
 	s.Error(err)
 	s.Nil(plant)
 }
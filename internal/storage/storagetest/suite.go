@@ -0,0 +1,909 @@
+package storagetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+// farFromNow - сдвиг дат относительно текущего момента, при котором результат не зависит от часового пояса БД.
+const farFromNow = time.Hour * 48
+
+// Suite - контрактные тесты interfaces.Storage, которые должна проходить каждая реализация хранилища.
+// Реализация подключается встраиванием Suite и заполнением Storage пустым хранилищем перед каждым тестом.
+type Suite struct {
+	suite.Suite
+
+	Storage interfaces.Storage
+}
+
+func (s *Suite) TestUsers() {
+	ctx := context.Background()
+
+	userID := s.createUser(1)
+
+	user, err := s.Storage.GetUserByID(ctx, userID)
+	s.Require().NoError(err)
+	s.Equal(1001, user.TelegramID)
+	s.Equal("user1", user.Username)
+
+	// Настройки берутся из значений по умолчанию:
+	s.Equal("Europe/Moscow", user.Timezone)
+	s.Equal(12, user.NotifyHour)
+	s.False(user.NotificationsDigest)
+
+	user.Firstname = "Renamed"
+	user.NotifyHour = 9
+	user.QuietWeekdays = 1 << time.Sunday
+	s.Require().NoError(s.Storage.UpdateUser(ctx, *user))
+
+	updated, err := s.Storage.GetUserByTelegramID(ctx, 1001)
+	s.Require().NoError(err)
+	s.Equal(userID, updated.ID)
+	s.Equal("Renamed", updated.Firstname)
+	s.Equal(9, updated.NotifyHour)
+	s.Equal(1<<time.Sunday, updated.QuietWeekdays)
+
+	_, err = s.Storage.GetUserByTelegramID(ctx, 404)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetUserByID(ctx, userID+1)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestUsers_UniqueTelegramID() {
+	s.createUser(1)
+
+	_, err := s.Storage.SaveUser(
+		context.Background(),
+		entities.User{TelegramID: 1001, Username: "other"},
+	)
+	s.Error(err)
+}
+
+func (s *Suite) TestClaimUserDigest() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	otherUserID := s.createUser(2)
+
+	claimed, err := s.Storage.ClaimUserDigest(ctx, userID, time.Hour)
+	s.Require().NoError(err)
+	s.True(claimed)
+
+	user, err := s.Storage.GetUserByID(ctx, userID)
+	s.Require().NoError(err)
+	s.NotNil(user.DigestClaimedAt)
+
+	// Захваченная сводка недоступна другим воркерам до истечения claimTTL:
+	claimed, err = s.Storage.ClaimUserDigest(ctx, userID, time.Hour)
+	s.Require().NoError(err)
+	s.False(claimed)
+
+	// Сводки пользователей захватываются независимо:
+	claimed, err = s.Storage.ClaimUserDigest(ctx, otherUserID, time.Hour)
+	s.Require().NoError(err)
+	s.True(claimed)
+
+	claimed, err = s.Storage.ClaimUserDigest(ctx, otherUserID+1, time.Hour)
+	s.Require().NoError(err)
+	s.False(claimed)
+}
+
+func (s *Suite) TestTemporary() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+
+	s.Require().NoError(s.Storage.CreateTemporary(ctx, entities.Temporary{UserID: userID, Step: 1}))
+
+	// У пользователя только одни временные данные:
+	s.Error(s.Storage.CreateTemporary(ctx, entities.Temporary{UserID: userID, Step: 2}))
+
+	temp, err := s.Storage.GetTemporaryByUserID(ctx, userID)
+	s.Require().NoError(err)
+	s.Equal(1, temp.Step)
+	s.Nil(temp.MessageID)
+
+	messageID := 42
+	temp.Step = 3
+	temp.MessageID = &messageID
+	temp.Data = []byte(`{"title":"Фикус"}`)
+	s.Require().NoError(s.Storage.UpdateTemporary(ctx, *temp))
+
+	updated, err := s.Storage.GetTemporaryByUserID(ctx, userID)
+	s.Require().NoError(err)
+	s.Equal(3, updated.Step)
+	s.Equal(&messageID, updated.MessageID)
+	s.JSONEq(`{"title":"Фикус"}`, string(updated.Data))
+
+	_, err = s.Storage.GetTemporaryByUserID(ctx, userID+1)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestGroups() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now().Add(farFromNow))
+
+	group, err := s.Storage.GetGroup(ctx, groupID)
+	s.Require().NoError(err)
+	s.Equal("Кухня", group.Title)
+	s.Nil(group.NotifyClaimedAt)
+	s.Nil(group.SnoozedUntil)
+	s.Nil(group.SeasonSchedule)
+
+	group.Description = "На подоконнике"
+	group.SeasonSchedule = entities.SeasonSchedule{
+		{StartMonth: time.December, EndMonth: time.February, WateringInterval: 14},
+	}
+	s.Require().NoError(s.Storage.UpdateGroup(ctx, *group))
+
+	updated, err := s.Storage.GetGroup(ctx, groupID)
+	s.Require().NoError(err)
+	s.Equal("На подоконнике", updated.Description)
+	s.Equal(group.SeasonSchedule, updated.SeasonSchedule)
+
+	_, err = s.Storage.GetGroup(ctx, groupID+1)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestGroupExists() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	otherUserID := s.createUser(2)
+	s.createGroup(userID, "Кухня", time.Now())
+
+	exists, err := s.Storage.GroupExists(ctx, entities.Group{UserID: userID, Title: "Кухня"})
+	s.Require().NoError(err)
+	s.True(exists)
+
+	// Название уникально только в пределах пользователя:
+	exists, err = s.Storage.GroupExists(ctx, entities.Group{UserID: otherUserID, Title: "Кухня"})
+	s.Require().NoError(err)
+	s.False(exists)
+
+	exists, err = s.Storage.GroupExists(ctx, entities.Group{UserID: userID, Title: "Спальня"})
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *Suite) TestUserGroups_SharedWithHousehold() {
+	ctx := context.Background()
+	ownerID := s.createUser(1)
+	memberID := s.createUser(2)
+	strangerID := s.createUser(3)
+
+	firstGroupID := s.createGroup(ownerID, "Кухня", time.Now())
+	secondGroupID := s.createGroup(memberID, "Спальня", time.Now())
+	s.createGroup(strangerID, "Офис", time.Now())
+
+	groups, err := s.Storage.GetUserGroups(ctx, ownerID)
+	s.Require().NoError(err)
+	s.Equal([]int{firstGroupID}, groupIDs(groups))
+
+	householdID, err := s.Storage.CreateHousehold(ctx, ownerID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.Storage.AddHouseholdMember(ctx, householdID, ownerID))
+	s.Require().NoError(s.Storage.AddHouseholdMember(ctx, householdID, memberID))
+
+	// Сценарии участников дома возвращаются в порядке добавления:
+	for _, userID := range []int{ownerID, memberID} {
+		groups, err = s.Storage.GetUserGroups(ctx, userID)
+		s.Require().NoError(err)
+		s.Equal([]int{firstGroupID, secondGroupID}, groupIDs(groups))
+
+		count, err := s.Storage.CountUserGroups(ctx, userID)
+		s.Require().NoError(err)
+		s.Equal(2, count)
+	}
+
+	groups, err = s.Storage.GetUserGroups(ctx, strangerID)
+	s.Require().NoError(err)
+	s.Len(groups, 1)
+}
+
+func (s *Suite) TestGroupsForNotify() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+
+	firstGroupID := s.createGroup(userID, "Первый", time.Now().Add(-farFromNow))
+	s.createGroup(userID, "Будущий", time.Now().Add(farFromNow))
+	secondGroupID := s.createGroup(userID, "Второй", time.Now().Add(-farFromNow*2))
+	thirdGroupID := s.createGroup(userID, "Третий", time.Now().Add(-farFromNow))
+
+	// Сценарии возвращаются в порядке добавления, а не по дате полива:
+	groups, err := s.Storage.GetGroupsForNotify(ctx, 10, 0)
+	s.Require().NoError(err)
+	s.Equal([]int{firstGroupID, secondGroupID, thirdGroupID}, groupIDs(groups))
+
+	groups, err = s.Storage.GetGroupsForNotify(ctx, 1, 1)
+	s.Require().NoError(err)
+	s.Equal([]int{secondGroupID}, groupIDs(groups))
+
+	groups, err = s.Storage.GetGroupsForNotify(ctx, 10, 3)
+	s.Require().NoError(err)
+	s.Empty(groups)
+}
+
+func (s *Suite) TestClaimGroupsForNotify() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+
+	firstGroupID := s.createGroup(userID, "Первый", time.Now().Add(-farFromNow))
+	s.createGroup(userID, "Будущий", time.Now().Add(farFromNow))
+	secondGroupID := s.createGroup(userID, "Второй", time.Now().Add(-farFromNow))
+
+	groups, err := s.Storage.ClaimGroupsForNotify(ctx, 1, time.Hour)
+	s.Require().NoError(err)
+	s.Require().Len(groups, 1)
+	s.Equal(firstGroupID, groups[0].ID)
+	s.NotNil(groups[0].NotifyClaimedAt)
+
+	// Захваченный сценарий недоступен другим воркерам до истечения claimTTL:
+	groups, err = s.Storage.ClaimGroupsForNotify(ctx, 10, time.Hour)
+	s.Require().NoError(err)
+	s.Equal([]int{secondGroupID}, groupIDs(groups))
+
+	groups, err = s.Storage.ClaimGroupsForNotify(ctx, 10, time.Hour)
+	s.Require().NoError(err)
+	s.Empty(groups)
+}
+
+func (s *Suite) TestDeleteGroup_Cascade() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	otherGroupID := s.createGroup(userID, "Спальня", time.Now())
+	plantID := s.createPlant(userID, groupID, "Фикус")
+	otherPlantID := s.createPlant(userID, otherGroupID, "Кактус")
+	careTaskID := s.createCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing)
+	photoID := s.createPlantPhoto(plantID, time.Now())
+	s.createWatering(groupID, nil, time.Now())
+	s.createNotification(groupID, nil, 1, time.Now())
+	s.createNotification(groupID, &careTaskID, 2, time.Now())
+
+	s.Require().NoError(s.Storage.DeleteGroup(ctx, groupID))
+
+	_, err := s.Storage.GetGroup(ctx, groupID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetPlant(ctx, plantID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetCareTask(ctx, careTaskID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetPlantPhoto(ctx, photoID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetLastNotification(ctx, groupID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetLastCareTaskNotification(ctx, careTaskID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	count, err := s.Storage.CountGroupWaterings(ctx, groupID)
+	s.Require().NoError(err)
+	s.Zero(count)
+
+	// Записи других сценариев не затрагиваются:
+	_, err = s.Storage.GetPlant(ctx, otherPlantID)
+	s.NoError(err)
+}
+
+func (s *Suite) TestPlants() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	otherGroupID := s.createGroup(userID, "Спальня", time.Now())
+
+	firstPlantID := s.createPlant(userID, groupID, "Фикус")
+	secondPlantID := s.createPlant(userID, groupID, "Кактус")
+	s.createPlant(userID, otherGroupID, "Фикус")
+
+	exists, err := s.Storage.PlantExists(ctx, entities.Plant{GroupID: groupID, Title: "Фикус"})
+	s.Require().NoError(err)
+	s.True(exists)
+
+	// Название уникально только в пределах сценария:
+	exists, err = s.Storage.PlantExists(ctx, entities.Plant{GroupID: groupID, Title: "Монстера"})
+	s.Require().NoError(err)
+	s.False(exists)
+
+	plants, err := s.Storage.GetGroupPlants(ctx, groupID)
+	s.Require().NoError(err)
+	s.Equal([]int{firstPlantID, secondPlantID}, plantIDs(plants))
+
+	count, err := s.Storage.CountGroupPlants(ctx, groupID)
+	s.Require().NoError(err)
+	s.Equal(2, count)
+
+	count, err = s.Storage.CountUserPlants(ctx, userID)
+	s.Require().NoError(err)
+	s.Equal(3, count)
+
+	interval := 3
+	plant := plants[0]
+	plant.GroupID = otherGroupID
+	plant.WateringInterval = &interval
+	plant.PhotoFileID = "file-id"
+	s.Require().NoError(s.Storage.UpdatePlant(ctx, plant))
+
+	updated, err := s.Storage.GetPlant(ctx, firstPlantID)
+	s.Require().NoError(err)
+	s.Equal(otherGroupID, updated.GroupID)
+	s.Equal(&interval, updated.WateringInterval)
+	s.Equal("file-id", updated.PhotoFileID)
+}
+
+func (s *Suite) TestDeletePlant_Cascade() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	plantID := s.createPlant(userID, groupID, "Фикус")
+	careTaskID := s.createCareTask(userID, nil, &plantID, entities.CareTaskTypeMisting)
+	photoID := s.createPlantPhoto(plantID, time.Now())
+	s.createWatering(groupID, &plantID, time.Now())
+
+	s.Require().NoError(s.Storage.DeletePlant(ctx, plantID))
+
+	_, err := s.Storage.GetCareTask(ctx, careTaskID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetPlantPhoto(ctx, photoID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	// Полив растения остается в истории сценария:
+	waterings, err := s.Storage.GetGroupWaterings(ctx, groupID, 10, 0)
+	s.Require().NoError(err)
+	s.Require().Len(waterings, 1)
+	s.Nil(waterings[0].PlantID)
+}
+
+func (s *Suite) TestNotifications() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	careTaskID := s.createCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	firstID := s.createNotification(groupID, nil, 1, now.Add(-time.Hour*3))
+	lastID := s.createNotification(groupID, nil, 1, now.Add(-time.Hour))
+	s.createNotification(groupID, &careTaskID, 1, now) // Уведомления о задачах ухода не относятся к поливу
+
+	last, err := s.Storage.GetLastNotification(ctx, groupID)
+	s.Require().NoError(err)
+	s.Equal(lastID, last.ID)
+
+	notifications, err := s.Storage.GetGroupNotificationsSince(ctx, groupID, now.Add(-time.Hour*2))
+	s.Require().NoError(err)
+	s.Equal([]int{lastID}, notificationIDs(notifications))
+
+	s.Require().NoError(s.Storage.AcknowledgeGroupNotifications(ctx, groupID, now))
+
+	notifications, err = s.Storage.GetGroupNotificationsSince(ctx, groupID, now.Add(-time.Hour*4))
+	s.Require().NoError(err)
+	s.Equal([]int{firstID, lastID}, notificationIDs(notifications))
+
+	for _, notification := range notifications {
+		s.Require().NotNil(notification.AcknowledgedAt)
+		s.WithinDuration(now, *notification.AcknowledgedAt, time.Second)
+	}
+
+	careTaskNotification, err := s.Storage.GetLastCareTaskNotification(ctx, careTaskID)
+	s.Require().NoError(err)
+	s.Nil(careTaskNotification.AcknowledgedAt)
+}
+
+func (s *Suite) TestLastCareTaskNotifications() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	careTaskID := s.createCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	s.createNotificationInChat(groupID, &careTaskID, 20, 1, now.Add(-time.Hour*2))
+	secondChatLastID := s.createNotificationInChat(groupID, &careTaskID, 20, 2, now.Add(-time.Hour))
+	firstChatLastID := s.createNotificationInChat(groupID, &careTaskID, 10, 3, now.Add(-time.Hour*3))
+
+	// По одному последнему уведомлению на чат в порядке чатов:
+	notifications, err := s.Storage.GetLastCareTaskNotifications(ctx, careTaskID)
+	s.Require().NoError(err)
+	s.Equal([]int{firstChatLastID, secondChatLastID}, notificationIDs(notifications))
+
+	notifications, err = s.Storage.GetMessageNotifications(ctx, 20, 2)
+	s.Require().NoError(err)
+	s.Equal([]int{secondChatLastID}, notificationIDs(notifications))
+}
+
+func (s *Suite) TestWaterings() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	now := time.Now().UTC().Truncate(time.Second)
+
+	oldestID := s.createWatering(groupID, nil, now.Add(-time.Hour*48))
+	latestID := s.createWatering(groupID, nil, now)
+	sameTimeID := s.createWatering(groupID, nil, now.Add(-time.Hour*24))
+	lastAddedID := s.createWatering(groupID, nil, now.Add(-time.Hour*24))
+
+	// Сначала последние поливы, а из одновременных - последние добавленные:
+	waterings, err := s.Storage.GetGroupWaterings(ctx, groupID, 10, 0)
+	s.Require().NoError(err)
+	s.Equal([]int{latestID, lastAddedID, sameTimeID, oldestID}, wateringIDs(waterings))
+
+	waterings, err = s.Storage.GetGroupWaterings(ctx, groupID, 2, 2)
+	s.Require().NoError(err)
+	s.Equal([]int{sameTimeID, oldestID}, wateringIDs(waterings))
+
+	count, err := s.Storage.CountGroupWaterings(ctx, groupID)
+	s.Require().NoError(err)
+	s.Equal(4, count)
+}
+
+func (s *Suite) TestCareTasks() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	plantID := s.createPlant(userID, groupID, "Фикус")
+
+	groupTaskID := s.createCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing)
+	plantTaskID := s.createCareTask(userID, nil, &plantID, entities.CareTaskTypeFertilizing)
+
+	// Одна задача каждого типа на сценарий или растение:
+	_, err := s.Storage.CreateCareTask(ctx, s.newCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing))
+	s.Error(err)
+
+	// Задача привязывается либо к сценарию, либо к растению:
+	_, err = s.Storage.CreateCareTask(ctx, s.newCareTask(userID, &groupID, &plantID, entities.CareTaskTypeMisting))
+	s.Error(err)
+
+	careTasks, err := s.Storage.GetGroupCareTasks(ctx, groupID)
+	s.Require().NoError(err)
+	s.Require().Len(careTasks, 1)
+	s.Equal(groupTaskID, careTasks[0].ID)
+
+	careTasks, err = s.Storage.GetPlantCareTasks(ctx, plantID)
+	s.Require().NoError(err)
+	s.Require().Len(careTasks, 1)
+	s.Equal(plantTaskID, careTasks[0].ID)
+
+	careTask := careTasks[0]
+	careTask.Interval = 30
+	s.Require().NoError(s.Storage.UpdateCareTask(ctx, careTask))
+
+	updated, err := s.Storage.GetCareTask(ctx, plantTaskID)
+	s.Require().NoError(err)
+	s.Equal(30, updated.Interval)
+
+	s.Require().NoError(s.Storage.DeleteCareTask(ctx, groupTaskID))
+
+	_, err = s.Storage.GetCareTask(ctx, groupTaskID)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestClaimCareTasksForNotify() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	otherGroupID := s.createGroup(userID, "Спальня", time.Now())
+
+	dueTaskID := s.createCareTask(userID, &groupID, nil, entities.CareTaskTypeFertilizing)
+
+	futureTask := s.newCareTask(userID, &otherGroupID, nil, entities.CareTaskTypeMisting)
+	futureTask.NextCareDate = time.Now().Add(farFromNow)
+	_, err := s.Storage.CreateCareTask(ctx, futureTask)
+	s.Require().NoError(err)
+
+	careTasks, err := s.Storage.ClaimCareTasksForNotify(ctx, 10, time.Hour)
+	s.Require().NoError(err)
+	s.Require().Len(careTasks, 1)
+	s.Equal(dueTaskID, careTasks[0].ID)
+	s.NotNil(careTasks[0].NotifyClaimedAt)
+
+	careTasks, err = s.Storage.ClaimCareTasksForNotify(ctx, 10, time.Hour)
+	s.Require().NoError(err)
+	s.Empty(careTasks)
+}
+
+func (s *Suite) TestPlantPhotos() {
+	ctx := context.Background()
+	userID := s.createUser(1)
+	groupID := s.createGroup(userID, "Кухня", time.Now())
+	plantID := s.createPlant(userID, groupID, "Фикус")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	latestID := s.createPlantPhoto(plantID, now)
+	earliestID := s.createPlantPhoto(plantID, now.Add(-time.Hour*48))
+	middleID := s.createPlantPhoto(plantID, now.Add(-time.Hour*24))
+
+	// В хронологическом порядке съемки:
+	photos, err := s.Storage.GetPlantPhotos(ctx, plantID, 10, 0)
+	s.Require().NoError(err)
+	s.Equal([]int{earliestID, middleID, latestID}, photoIDs(photos))
+
+	photos, err = s.Storage.GetPlantPhotos(ctx, plantID, 1, 2)
+	s.Require().NoError(err)
+	s.Equal([]int{latestID}, photoIDs(photos))
+
+	count, err := s.Storage.CountPlantPhotos(ctx, plantID)
+	s.Require().NoError(err)
+	s.Equal(3, count)
+}
+
+func (s *Suite) TestHouseholds() {
+	ctx := context.Background()
+	ownerID := s.createUser(1)
+	memberID := s.createUser(2)
+	otherOwnerID := s.createUser(3)
+
+	_, err := s.Storage.GetUserHousehold(ctx, ownerID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	householdID, err := s.Storage.CreateHousehold(ctx, ownerID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.Storage.AddHouseholdMember(ctx, householdID, ownerID))
+	s.Require().NoError(s.Storage.AddHouseholdMember(ctx, householdID, memberID))
+
+	household, err := s.Storage.GetUserHousehold(ctx, memberID)
+	s.Require().NoError(err)
+	s.Equal(householdID, household.ID)
+	s.Equal(ownerID, household.OwnerID)
+
+	users, err := s.Storage.GetHouseholdUsers(ctx, memberID)
+	s.Require().NoError(err)
+	s.Equal([]int{ownerID, memberID}, userIDs(users))
+
+	// Участник переходит в новый дом, а не состоит в двух:
+	otherHouseholdID, err := s.Storage.CreateHousehold(ctx, otherOwnerID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.Storage.AddHouseholdMember(ctx, otherHouseholdID, memberID))
+
+	users, err = s.Storage.GetHouseholdUsers(ctx, ownerID)
+	s.Require().NoError(err)
+	s.Equal([]int{ownerID}, userIDs(users))
+
+	s.Require().NoError(s.Storage.DeleteHouseholdMember(ctx, memberID))
+
+	_, err = s.Storage.GetUserHousehold(ctx, memberID)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestHouseholdInvites() {
+	ctx := context.Background()
+	ownerID := s.createUser(1)
+
+	householdID, err := s.Storage.CreateHousehold(ctx, ownerID)
+	s.Require().NoError(err)
+
+	invite := entities.HouseholdInvite{
+		HouseholdID: householdID,
+		Token:       "invite-token",
+		CreatedBy:   ownerID,
+		ExpiresAt:   time.Now().Add(farFromNow),
+	}
+
+	inviteID, err := s.Storage.CreateHouseholdInvite(ctx, invite)
+	s.Require().NoError(err)
+
+	_, err = s.Storage.CreateHouseholdInvite(ctx, invite)
+	s.Error(err)
+
+	saved, err := s.Storage.GetHouseholdInvite(ctx, "invite-token")
+	s.Require().NoError(err)
+	s.Equal(inviteID, saved.ID)
+	s.Equal(householdID, saved.HouseholdID)
+
+	s.Require().NoError(s.Storage.DeleteHouseholdInvite(ctx, inviteID))
+
+	_, err = s.Storage.GetHouseholdInvite(ctx, "invite-token")
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestDelegations() {
+	ctx := context.Background()
+	ownerID := s.createUser(1)
+	sitterID := s.createUser(2)
+	groupID := s.createGroup(ownerID, "Кухня", time.Now())
+	otherGroupID := s.createGroup(ownerID, "Спальня", time.Now())
+	today := time.Now().UTC().Truncate(time.Hour * 24)
+
+	firstID := s.createDelegation(ownerID, "sitter-first", entities.DelegationGroups{groupID}, today.Add(farFromNow))
+	secondID := s.createDelegation(
+		ownerID,
+		"sitter-second",
+		entities.DelegationGroups{groupID, otherGroupID},
+		today.Add(farFromNow),
+	)
+	s.createDelegation(ownerID, "sitter-expired", entities.DelegationGroups{groupID}, today.Add(-farFromNow))
+
+	// Пока приглашение не принято, передача не действует:
+	_, err := s.Storage.GetGroupDelegation(ctx, groupID, today)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	s.Require().NoError(s.Storage.UpdateDelegationSitter(ctx, firstID, sitterID))
+	s.Require().NoError(s.Storage.UpdateDelegationSitter(ctx, secondID, sitterID))
+
+	// Из нескольких передач сценария возвращается последняя:
+	delegation, err := s.Storage.GetGroupDelegation(ctx, groupID, today)
+	s.Require().NoError(err)
+	s.Equal(secondID, delegation.ID)
+	s.Equal(&sitterID, delegation.SitterID)
+
+	delegation, err = s.Storage.GetDelegationByToken(ctx, "sitter-first")
+	s.Require().NoError(err)
+	s.Equal(firstID, delegation.ID)
+	s.Equal(entities.DelegationGroups{groupID}, delegation.GroupIDs)
+
+	// Закончившиеся передачи не возвращаются:
+	delegations, err := s.Storage.GetOwnerDelegations(ctx, ownerID, today)
+	s.Require().NoError(err)
+	s.Equal([]int{firstID, secondID}, delegationIDs(delegations))
+
+	s.Require().NoError(s.Storage.DeleteDelegation(ctx, secondID))
+
+	_, err = s.Storage.GetDelegation(ctx, secondID)
+	s.ErrorIs(err, sql.ErrNoRows)
+
+	_, err = s.Storage.GetGroupDelegation(ctx, otherGroupID, today)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestWithTx_Commit() {
+	ctx := context.Background()
+
+	var userID int
+
+	err := s.Storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			var err error
+			if userID, err = tx.SaveUser(ctx, newUser(1)); err != nil {
+				return err
+			}
+
+			return tx.CreateTemporary(ctx, entities.Temporary{UserID: userID, Step: 1})
+		},
+	)
+	s.Require().NoError(err)
+
+	_, err = s.Storage.GetTemporaryByUserID(ctx, userID)
+	s.NoError(err)
+}
+
+func (s *Suite) TestWithTx_Rollback() {
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+
+	err := s.Storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			userID, err := tx.SaveUser(ctx, newUser(1))
+			if err != nil {
+				return err
+			}
+
+			// Вложенная транзакция выполняется в рамках внешней и откатывается вместе с ней:
+			err = tx.WithTx(
+				ctx,
+				func(nested interfaces.Storage) error {
+					return nested.CreateTemporary(ctx, entities.Temporary{UserID: userID, Step: 1})
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return errAbort
+		},
+	)
+	s.ErrorIs(err, errAbort)
+
+	_, err = s.Storage.GetUserByTelegramID(ctx, 1001)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) TestWithTx_RollbackOnPanic() {
+	ctx := context.Background()
+
+	s.Panics(
+		func() {
+			_ = s.Storage.WithTx(
+				ctx,
+				func(tx interfaces.Storage) error {
+					if _, err := tx.SaveUser(ctx, newUser(1)); err != nil {
+						return err
+					}
+
+					panic("nil map")
+				},
+			)
+		},
+	)
+
+	_, err := s.Storage.GetUserByTelegramID(ctx, 1001)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *Suite) createUser(n int) int {
+	userID, err := s.Storage.SaveUser(context.Background(), newUser(n))
+	s.Require().NoError(err)
+
+	return userID
+}
+
+func (s *Suite) createGroup(userID int, title string, nextWateringDate time.Time) int {
+	groupID, err := s.Storage.CreateGroup(
+		context.Background(),
+		entities.Group{
+			UserID:           userID,
+			Title:            title,
+			LastWateringDate: nextWateringDate.Add(-time.Hour * 24 * 7),
+			NextWateringDate: nextWateringDate,
+			WateringInterval: 7,
+		},
+	)
+	s.Require().NoError(err)
+
+	return groupID
+}
+
+func (s *Suite) createPlant(userID, groupID int, title string) int {
+	plantID, err := s.Storage.CreatePlant(
+		context.Background(),
+		entities.Plant{
+			GroupID: groupID,
+			UserID:  userID,
+			Title:   title,
+		},
+	)
+	s.Require().NoError(err)
+
+	return plantID
+}
+
+func (s *Suite) newCareTask(userID int, groupID, plantID *int, careTaskType string) entities.CareTask {
+	return entities.CareTask{
+		UserID:       userID,
+		GroupID:      groupID,
+		PlantID:      plantID,
+		Type:         careTaskType,
+		Interval:     14,
+		LastCareDate: time.Now().Add(-farFromNow * 7),
+		NextCareDate: time.Now().Add(-farFromNow),
+	}
+}
+
+func (s *Suite) createCareTask(userID int, groupID, plantID *int, careTaskType string) int {
+	careTaskID, err := s.Storage.CreateCareTask(
+		context.Background(),
+		s.newCareTask(userID, groupID, plantID, careTaskType),
+	)
+	s.Require().NoError(err)
+
+	return careTaskID
+}
+
+func (s *Suite) createPlantPhoto(plantID int, takenAt time.Time) int {
+	photoID, err := s.Storage.SavePlantPhoto(
+		context.Background(),
+		entities.PlantPhoto{
+			PlantID:      plantID,
+			FileID:       fmt.Sprintf("file-%d", takenAt.Unix()),
+			FileUniqueID: fmt.Sprintf("unique-%d", takenAt.Unix()),
+			TakenAt:      takenAt,
+		},
+	)
+	s.Require().NoError(err)
+
+	return photoID
+}
+
+func (s *Suite) createWatering(groupID int, plantID *int, wateredAt time.Time) int {
+	wateringID, err := s.Storage.SaveWatering(
+		context.Background(),
+		entities.Watering{
+			GroupID:   groupID,
+			PlantID:   plantID,
+			WateredAt: wateredAt,
+			Source:    entities.WateringSourceManual,
+		},
+	)
+	s.Require().NoError(err)
+
+	return wateringID
+}
+
+func (s *Suite) createNotification(groupID int, careTaskID *int, messageID int, sentAt time.Time) int {
+	return s.createNotificationInChat(groupID, careTaskID, 1001, messageID, sentAt)
+}
+
+func (s *Suite) createNotificationInChat(
+	groupID int,
+	careTaskID *int,
+	chatID int64,
+	messageID int,
+	sentAt time.Time,
+) int {
+	notificationID, err := s.Storage.SaveNotification(
+		context.Background(),
+		entities.Notification{
+			GroupID:    groupID,
+			MessageID:  messageID,
+			Text:       "Пора полить растения",
+			SentAt:     sentAt,
+			CareTaskID: careTaskID,
+			ChatID:     chatID,
+		},
+	)
+	s.Require().NoError(err)
+
+	return notificationID
+}
+
+func (s *Suite) createDelegation(ownerID int, token string, groupIDs entities.DelegationGroups, endDate time.Time) int {
+	delegationID, err := s.Storage.CreateDelegation(
+		context.Background(),
+		entities.Delegation{
+			OwnerID:  ownerID,
+			GroupIDs: groupIDs,
+			Token:    token,
+			EndDate:  endDate,
+		},
+	)
+	s.Require().NoError(err)
+
+	return delegationID
+}
+
+func newUser(n int) entities.User {
+	return entities.User{
+		TelegramID: 1000 + n,
+		Username:   fmt.Sprintf("user%d", n),
+		Firstname:  fmt.Sprintf("First%d", n),
+		Lastname:   fmt.Sprintf("Last%d", n),
+	}
+}
+
+func groupIDs(groups []entities.Group) []int {
+	return collectIDs(groups, func(g entities.Group) int { return g.ID })
+}
+
+func plantIDs(plants []entities.Plant) []int {
+	return collectIDs(plants, func(p entities.Plant) int { return p.ID })
+}
+
+func notificationIDs(notifications []entities.Notification) []int {
+	return collectIDs(notifications, func(n entities.Notification) int { return n.ID })
+}
+
+func wateringIDs(waterings []entities.Watering) []int {
+	return collectIDs(waterings, func(w entities.Watering) int { return w.ID })
+}
+
+func photoIDs(photos []entities.PlantPhoto) []int {
+	return collectIDs(photos, func(p entities.PlantPhoto) int { return p.ID })
+}
+
+func userIDs(users []entities.User) []int {
+	return collectIDs(users, func(u entities.User) int { return u.ID })
+}
+
+func delegationIDs(delegations []entities.Delegation) []int {
+	return collectIDs(delegations, func(d entities.Delegation) int { return d.ID })
+}
+
+func collectIDs[T any](rows []T, getID func(T) int) []int {
+	ids := make([]int, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, getID(row))
+	}
+
+	return ids
+}
@@ -2,11 +2,14 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/DKhorkov/libs/db"
 	"github.com/DKhorkov/libs/logging"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 )
@@ -19,19 +22,23 @@ const (
 )
 
 type temporaryStorage struct {
-	dbConnector db.Connector
-	logger      logging.Logger
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
 }
 
-func (s *temporaryStorage) CreateTemporary(temp entities.Temporary) error {
-	ctx := context.Background()
+func (s *temporaryStorage) CreateTemporary(ctx context.Context, temp entities.Temporary) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CreateTemporary")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Insert(temporaryTableName).
@@ -58,15 +65,16 @@ func (s *temporaryStorage) CreateTemporary(temp entities.Temporary) error {
 	return err
 }
 
-func (s *temporaryStorage) UpdateTemporary(temp entities.Temporary) error {
-	ctx := context.Background()
+func (s *temporaryStorage) UpdateTemporary(ctx context.Context, temp entities.Temporary) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "UpdateTemporary")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Update(temporaryTableName).
@@ -85,15 +93,16 @@ func (s *temporaryStorage) UpdateTemporary(temp entities.Temporary) error {
 	return err
 }
 
-func (s *temporaryStorage) GetTemporaryByUserID(userID int) (*entities.Temporary, error) {
-	ctx := context.Background()
+func (s *temporaryStorage) GetTemporaryByUserID(ctx context.Context, userID int) (*entities.Temporary, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetTemporaryByUserID")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectAllColumns).
@@ -141,7 +141,7 @@ func (s *TemporaryStorageTestSuite) TestCreateTemporary_Success_WithMessageID()
 		Data:      []byte(`{"state": "in_progress", "plant": "Фикус"}`),
 	}
 
-	err := s.storage.CreateTemporary(temp)
+	err := s.storage.CreateTemporary(s.ctx, temp)
 	s.NoError(err)
 
 	// Проверим, что запись появилась
@@ -172,7 +172,7 @@ func (s *TemporaryStorageTestSuite) TestCreateTemporary_Success_WithoutMessageID
 		Data:      []byte(`{"action": "rename"}`),
 	}
 
-	err := s.storage.CreateTemporary(temp)
+	err := s.storage.CreateTemporary(s.ctx, temp)
 	s.NoError(err)
 
 	var stored entities.Temporary
@@ -220,7 +220,7 @@ func (s *TemporaryStorageTestSuite) TestUpdateTemporary_Success() {
 		Data:      []byte(`{"updated": true, "step": 7}`),
 	}
 
-	err = s.storage.UpdateTemporary(updatedTemp)
+	err = s.storage.UpdateTemporary(s.ctx, updatedTemp)
 	s.NoError(err)
 
 	// Проверим
@@ -247,7 +247,7 @@ func (s *TemporaryStorageTestSuite) TestUpdateTemporary_NonExistent() {
 		Data:   []byte("test"),
 	}
 
-	err := s.storage.UpdateTemporary(nonExistent)
+	err := s.storage.UpdateTemporary(s.ctx, nonExistent)
 	s.NoError(err) // UPDATE 0 строк — не ошибка
 }
 
@@ -270,7 +270,7 @@ func (s *TemporaryStorageTestSuite) TestGetTemporaryByUserID_Exists() {
 	).Scan(&tempID)
 	s.NoError(err)
 
-	temp, err := s.storage.GetTemporaryByUserID(userID)
+	temp, err := s.storage.GetTemporaryByUserID(s.ctx, userID)
 	s.NoError(err)
 	s.NotNil(temp)
 	s.Equal(tempID, temp.ID)
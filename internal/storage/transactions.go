@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+// WithTx выполняет fn в транзакции: все запросы хранилища tx фиксируются вместе, если fn не вернула ошибку,
+// и откатываются в противном случае. Вложенный вызов WithTx выполняется в уже открытой транзакции.
+func (s *Storage) WithTx(ctx context.Context, fn func(tx interfaces.Storage) error) (err error) {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	tx, err := s.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Откатываем транзакцию и при панике, чтобы не оставить соединение занятым:
+		if r := recover(); r != nil {
+			s.rollback(ctx, tx)
+			panic(r)
+		}
+
+		if err != nil {
+			s.rollback(ctx, tx)
+		}
+	}()
+
+	if err = fn(newStorage(s.dbConnector, tx, s.queryTimeout, s.queryDuration, s.logger)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Storage) rollback(ctx context.Context, tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		logging.LogErrorContext(ctx, s.logger, "error during transaction rollback", err)
+	}
+}
+
+// executor - соединение из пула или транзакция, на которых выполняются запросы хранилищ.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// getConnection возвращает транзакцию, если хранилище работает внутри WithTx, и соединение из пула в остальных случаях.
+func getConnection(ctx context.Context, dbConnector db.Connector, tx *sql.Tx) (executor, error) {
+	if tx != nil {
+		return tx, nil
+	}
+
+	connection, err := dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+// closeConnection возвращает соединение в пул. Транзакция завершается в WithTx, поэтому здесь не закрывается.
+func closeConnection(ctx context.Context, connection executor, logger logging.Logger) {
+	if conn, ok := connection.(*sql.Conn); ok {
+		db.CloseConnectionContext(ctx, conn, logger)
+	}
+}
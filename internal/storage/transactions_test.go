@@ -0,0 +1,206 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/DKhorkov/libs/logging"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestTransactionsTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionsTestSuite))
+}
+
+type TransactionsTestSuite struct {
+	suite.Suite
+
+	cwd         string
+	ctx         context.Context
+	dbConnector db.Connector
+	connection  *sql.Conn
+	storage     *Storage
+	logger      *mocklogging.MockLogger
+}
+
+func (s *TransactionsTestSuite) SetupSuite() {
+	// Инициализируем переменные окружения для дальнейшего считывания:
+	loadenv.Init("../../.env")
+
+	cwd, err := os.Getwd()
+	s.NoError(err)
+
+	cfg := config.New()
+	logger := logging.New(
+		cfg.Logging.Level,
+		cfg.Logging.LogFilePath,
+	)
+
+	dbConnector, err := db.New(
+		db.BuildDsn(cfg.Database),
+		cfg.Database.Driver,
+		logger,
+		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	)
+	s.NoError(err, "failed to connect to database")
+
+	s.NoError(goose.SetDialect(cfg.Database.Driver))
+
+	ctrl := gomock.NewController(s.T())
+	s.logger = mocklogging.NewMockLogger(ctrl)
+
+	s.cwd = cwd
+	s.dbConnector = dbConnector
+	s.ctx = context.Background()
+	s.storage = New(s.dbConnector, cfg.Storage.QueryTimeout, nil, s.logger)
+}
+
+func (s *TransactionsTestSuite) SetupTest() {
+	s.NoError(
+		goose.Up(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+		),
+	)
+	connection, err := s.dbConnector.Connection(s.ctx)
+	s.NoError(err)
+
+	s.connection = connection
+}
+
+func (s *TransactionsTestSuite) TearDownTest() {
+	s.NoError(
+		goose.DownTo(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+			gooseZeroVersion,
+		),
+	)
+
+	s.NoError(s.connection.Close())
+}
+
+func (s *TransactionsTestSuite) TearDownSuite() {
+	s.NoError(s.dbConnector.Close())
+}
+
+func (s *TransactionsTestSuite) countRows(table string) int {
+	var count int
+	err := s.connection.QueryRowContext(s.ctx, `SELECT COUNT(*) FROM `+table).Scan(&count)
+	s.NoError(err)
+
+	return count
+}
+
+func (s *TransactionsTestSuite) saveUserWithTemporary(tx interfaces.Storage) (int, error) {
+	userID, err := tx.SaveUser(s.ctx, entities.User{TelegramID: 123456789, Username: "user", Firstname: "First"})
+	if err != nil {
+		return 0, err
+	}
+
+	return userID, tx.CreateTemporary(s.ctx, entities.Temporary{UserID: userID})
+}
+
+func (s *TransactionsTestSuite) TestWithTx_Commit() {
+	var userID int
+
+	err := s.storage.WithTx(
+		s.ctx,
+		func(tx interfaces.Storage) error {
+			var err error
+			userID, err = s.saveUserWithTemporary(tx)
+
+			return err
+		},
+	)
+	s.NoError(err)
+
+	s.Equal(1, s.countRows("users"))
+	s.Equal(1, s.countRows("temporary"))
+
+	temp, err := s.storage.GetTemporaryByUserID(s.ctx, userID)
+	s.NoError(err)
+	s.Equal(userID, temp.UserID)
+}
+
+func (s *TransactionsTestSuite) TestWithTx_RollbackOnError() {
+	err := s.storage.WithTx(
+		s.ctx,
+		func(tx interfaces.Storage) error {
+			if _, err := tx.SaveUser(s.ctx, entities.User{TelegramID: 123456789}); err != nil {
+				return err
+			}
+
+			return assert.AnError
+		},
+	)
+	s.ErrorIs(err, assert.AnError)
+
+	s.Equal(0, s.countRows("users"))
+	s.Equal(0, s.countRows("temporary"))
+}
+
+func (s *TransactionsTestSuite) TestWithTx_RollbackOnPanic() {
+	s.Panics(
+		func() {
+			_ = s.storage.WithTx(
+				s.ctx,
+				func(tx interfaces.Storage) error {
+					if _, err := tx.SaveUser(s.ctx, entities.User{TelegramID: 123456789}); err != nil {
+						return err
+					}
+
+					panic("unexpected")
+				},
+			)
+		},
+	)
+
+	s.Equal(0, s.countRows("users"))
+}
+
+func (s *TransactionsTestSuite) TestWithTx_Nested() {
+	err := s.storage.WithTx(
+		s.ctx,
+		func(tx interfaces.Storage) error {
+			err := tx.WithTx(
+				s.ctx,
+				func(nested interfaces.Storage) error {
+					_, err := s.saveUserWithTemporary(nested)
+
+					return err
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			// Ошибка внешней транзакции откатывает и изменения вложенной:
+			return assert.AnError
+		},
+	)
+	s.ErrorIs(err, assert.AnError)
+
+	s.Equal(0, s.countRows("users"))
+	s.Equal(0, s.countRows("temporary"))
+}
@@ -2,40 +2,56 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/DKhorkov/libs/db"
 	"github.com/DKhorkov/libs/logging"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 )
 
 const (
-	selectAllColumns     = "*"
-	usersTableName       = "users"
-	telegramIDColumnName = "telegram_id"
-	usernameColumnName   = "username"
-	firstnameColumnName  = "firstname"
-	lastnameColumnName   = "lastname"
-	isBotColumnName      = "is_bot"
-	returningIDSuffix    = "RETURNING id"
+	selectAllColumns              = "*"
+	usersTableName                = "users"
+	telegramIDColumnName          = "telegram_id"
+	usernameColumnName            = "username"
+	firstnameColumnName           = "firstname"
+	lastnameColumnName            = "lastname"
+	isBotColumnName               = "is_bot"
+	timezoneColumnName            = "timezone"
+	notifyHourColumnName          = "notify_hour"
+	notificationsDigestColumnName = "notifications_digest"
+	quietHoursStartColumnName     = "quiet_hours_start"
+	quietHoursEndColumnName       = "quiet_hours_end"
+	quietWeekdaysColumnName       = "quiet_weekdays"
+	vacationStartColumnName       = "vacation_start"
+	vacationEndColumnName         = "vacation_end"
+	digestClaimedAtColumnName     = "digest_claimed_at"
+	returningIDSuffix             = "RETURNING id"
 )
 
 type usersStorage struct {
-	dbConnector db.Connector
-	logger      logging.Logger
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
 }
 
-func (s *usersStorage) SaveUser(user entities.User) (int, error) {
-	ctx := context.Background()
+func (s *usersStorage) SaveUser(ctx context.Context, user entities.User) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "SaveUser")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return 0, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Insert(usersTableName).
@@ -68,15 +84,16 @@ func (s *usersStorage) SaveUser(user entities.User) (int, error) {
 	return userID, nil
 }
 
-func (s *usersStorage) GetUserByTelegramID(telegramID int) (*entities.User, error) {
-	ctx := context.Background()
+func (s *usersStorage) GetUserByTelegramID(ctx context.Context, telegramID int) (*entities.User, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetUserByTelegramID")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectAllColumns).
@@ -98,15 +115,16 @@ func (s *usersStorage) GetUserByTelegramID(telegramID int) (*entities.User, erro
 	return user, nil
 }
 
-func (s *usersStorage) GetUserByID(id int) (*entities.User, error) {
-	ctx := context.Background()
+func (s *usersStorage) GetUserByID(ctx context.Context, id int) (*entities.User, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetUserByID")
+	defer cancel()
 
-	connection, err := s.dbConnector.Connection(ctx)
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer db.CloseConnectionContext(ctx, connection, s.logger)
+	defer closeConnection(ctx, connection, s.logger)
 
 	stmt, params, err := sq.
 		Select(selectAllColumns).
@@ -127,3 +145,86 @@ func (s *usersStorage) GetUserByID(id int) (*entities.User, error) {
 
 	return user, nil
 }
+
+func (s *usersStorage) UpdateUser(ctx context.Context, user entities.User) error {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "UpdateUser")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Update(usersTableName).
+		Where(sq.Eq{idColumnName: user.ID}).
+		Set(usernameColumnName, user.Username).
+		Set(firstnameColumnName, user.Firstname).
+		Set(lastnameColumnName, user.Lastname).
+		Set(timezoneColumnName, user.Timezone).
+		Set(notifyHourColumnName, user.NotifyHour).
+		Set(notificationsDigestColumnName, user.NotificationsDigest).
+		Set(quietHoursStartColumnName, user.QuietHoursStart).
+		Set(quietHoursEndColumnName, user.QuietHoursEnd).
+		Set(quietWeekdaysColumnName, user.QuietWeekdays).
+		Set(vacationStartColumnName, user.VacationStart).
+		Set(vacationEndColumnName, user.VacationEnd).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(ctx, stmt, params...)
+
+	return err
+}
+
+// ClaimUserDigest атомарно захватывает сводку пользователя на время claimTTL и сообщает, удалось ли это.
+// Условие на время захвата проверяется в том же UPDATE, поэтому из воркеров, захвативших разные сценарии
+// одного пользователя, сводку собирает только один.
+func (s *usersStorage) ClaimUserDigest(ctx context.Context, userID int, claimTTL time.Duration) (bool, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "ClaimUserDigest")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return false, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Update(usersTableName).
+		Set(digestClaimedAtColumnName, sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{idColumnName: userID}).
+		Where(
+			sq.Or{
+				sq.Eq{digestClaimedAtColumnName: nil},
+				sq.Expr(
+					digestClaimedAtColumnName+" < CURRENT_TIMESTAMP - make_interval(secs => ?)",
+					claimTTL.Seconds(),
+				),
+			},
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
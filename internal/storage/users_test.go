@@ -16,7 +16,9 @@ import (
 	"go.uber.org/mock/gomock"
 	"os"
 	"path"
+	"sync"
 	"testing"
+	"time"
 )
 
 const (
@@ -121,7 +123,7 @@ func (s *UsersStorageTestSuite) TestSaveUser_Success() {
 		IsBot:      false,
 	}
 
-	userID, err := s.storage.SaveUser(user)
+	userID, err := s.storage.SaveUser(s.ctx, user)
 	s.NoError(err)
 	s.Greater(userID, 0)
 
@@ -173,7 +175,7 @@ func (s *UsersStorageTestSuite) TestGetUserByTelegramID_UserExists() {
 	)
 	s.NoError(err)
 
-	user, err := s.storage.GetUserByTelegramID(telegramID)
+	user, err := s.storage.GetUserByTelegramID(s.ctx, telegramID)
 	s.NoError(err)
 	s.NotNil(user)
 	s.Equal(telegramID, user.TelegramID)
@@ -186,7 +188,7 @@ func (s *UsersStorageTestSuite) TestGetUserByTelegramID_UserExists() {
 func (s *UsersStorageTestSuite) TestGetUserByTelegramID_UserNotFound() {
 	telegramID := 999999 // не существует
 
-	user, err := s.storage.GetUserByTelegramID(telegramID)
+	user, err := s.storage.GetUserByTelegramID(s.ctx, telegramID)
 	s.Error(err)
 	s.Nil(user)
 	// В вашем стиле — достаточно проверить, что ошибка и nil
@@ -211,7 +213,7 @@ func (s *UsersStorageTestSuite) TestGetUserByID_UserExists() {
 	).Scan(&userID)
 	s.NoError(err)
 
-	user, err := s.storage.GetUserByID(userID)
+	user, err := s.storage.GetUserByID(s.ctx, userID)
 	s.NoError(err)
 	s.NotNil(user)
 	s.Equal(userID, user.ID)
@@ -225,7 +227,138 @@ func (s *UsersStorageTestSuite) TestGetUserByID_UserExists() {
 func (s *UsersStorageTestSuite) TestGetUserByID_UserNotFound() {
 	nonExistentID := 999999
 
-	user, err := s.storage.GetUserByID(nonExistentID)
+	user, err := s.storage.GetUserByID(s.ctx, nonExistentID)
 	s.Error(err)
 	s.Nil(user)
 }
+
+func (s *UsersStorageTestSuite) TestSaveUser_DefaultNotificationSettings() {
+	userID, err := s.storage.SaveUser(
+		s.ctx,
+		entities.User{
+			TelegramID: 123456,
+			Username:   "testuser",
+			Firstname:  "John",
+			Lastname:   "Doe",
+		},
+	)
+	s.NoError(err)
+
+	user, err := s.storage.GetUserByID(s.ctx, userID)
+	s.NoError(err)
+	s.Equal("Europe/Moscow", user.Timezone)
+	s.Equal(12, user.NotifyHour)
+	s.False(user.NotificationsDigest)
+	s.Zero(user.QuietHoursStart)
+	s.Zero(user.QuietHoursEnd)
+	s.Zero(user.QuietWeekdays)
+	s.Nil(user.VacationStart)
+	s.Nil(user.VacationEnd)
+}
+
+func (s *UsersStorageTestSuite) TestUpdateUser_Success() {
+	userID, err := s.storage.SaveUser(
+		s.ctx,
+		entities.User{
+			TelegramID: 123456,
+			Username:   "testuser",
+			Firstname:  "John",
+			Lastname:   "Doe",
+		},
+	)
+	s.NoError(err)
+
+	user, err := s.storage.GetUserByID(s.ctx, userID)
+	s.NoError(err)
+
+	user.Timezone = "Asia/Vladivostok"
+	user.NotifyHour = 9
+	user.NotificationsDigest = true
+	user.QuietHoursStart = 22
+	user.QuietHoursEnd = 8
+	user.QuietWeekdays = 1<<time.Saturday | 1<<time.Sunday
+	vacationStart := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	vacationEnd := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+	user.VacationStart = &vacationStart
+	user.VacationEnd = &vacationEnd
+	s.NoError(s.storage.UpdateUser(s.ctx, *user))
+
+	updated, err := s.storage.GetUserByID(s.ctx, userID)
+	s.NoError(err)
+	s.Equal("Asia/Vladivostok", updated.Timezone)
+	s.Equal(9, updated.NotifyHour)
+	s.True(updated.NotificationsDigest)
+	s.Equal(22, updated.QuietHoursStart)
+	s.Equal(8, updated.QuietHoursEnd)
+	s.Equal(user.QuietWeekdays, updated.QuietWeekdays)
+	s.Require().NotNil(updated.VacationStart)
+	s.Require().NotNil(updated.VacationEnd)
+	s.True(vacationStart.Equal(*updated.VacationStart))
+	s.True(vacationEnd.Equal(*updated.VacationEnd))
+	s.Equal(user.Username, updated.Username)
+}
+
+func (s *UsersStorageTestSuite) TestUpdateUser_InvalidNotifyHour() {
+	userID, err := s.storage.SaveUser(
+		s.ctx,
+		entities.User{
+			TelegramID: 123456,
+			Username:   "testuser",
+			Firstname:  "John",
+			Lastname:   "Doe",
+		},
+	)
+	s.NoError(err)
+
+	user, err := s.storage.GetUserByID(s.ctx, userID)
+	s.NoError(err)
+
+	user.NotifyHour = 24
+	s.Error(s.storage.UpdateUser(s.ctx, *user))
+}
+
+func (s *UsersStorageTestSuite) TestClaimUserDigest_ConcurrentWorkersClaimOnce() {
+	const workersCount = 5
+
+	userID, err := s.storage.SaveUser(s.ctx, entities.User{TelegramID: 123456, Username: "testuser"})
+	s.Require().NoError(err)
+
+	var (
+		wg      sync.WaitGroup
+		claimed = make(chan bool, workersCount)
+		errs    = make(chan error, workersCount)
+	)
+
+	for range workersCount {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ok, err := s.storage.ClaimUserDigest(s.ctx, userID, time.Hour)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			claimed <- ok
+		}()
+	}
+
+	wg.Wait()
+	close(claimed)
+	close(errs)
+
+	for err := range errs {
+		s.NoError(err)
+	}
+
+	var claimedCount int
+	for ok := range claimed {
+		if ok {
+			claimedCount++
+		}
+	}
+
+	s.Equal(1, claimedCount)
+}
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/logging"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+)
+
+const (
+	wateringsTableName  = "waterings"
+	plantIDColumnName   = "plant_id"
+	wateredAtColumnName = "watered_at"
+	sourceColumnName    = "source"
+)
+
+type wateringsStorage struct {
+	dbConnector   db.Connector
+	tx            *sql.Tx
+	queryTimeout  time.Duration
+	queryDuration prometheus.ObserverVec
+	logger        logging.Logger
+}
+
+func (s *wateringsStorage) SaveWatering(ctx context.Context, watering entities.Watering) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "SaveWatering")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Insert(wateringsTableName).
+		Columns(
+			groupIDColumnName,
+			plantIDColumnName,
+			wateredAtColumnName,
+			sourceColumnName,
+			userIDColumnName,
+		).
+		Values(
+			watering.GroupID,
+			watering.PlantID,
+			watering.WateredAt,
+			watering.Source,
+			watering.UserID,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var wateringID int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&wateringID); err != nil {
+		return 0, err
+	}
+
+	return wateringID, nil
+}
+
+func (s *wateringsStorage) GetGroupWaterings(
+	ctx context.Context,
+	groupID, limit, offset int,
+) ([]entities.Watering, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "GetGroupWaterings")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(wateringsTableName).
+		Where(sq.Eq{groupIDColumnName: groupID}).
+		OrderBy( // Сначала последние поливы
+			fmt.Sprintf("%s %s", wateredAtColumnName, desc),
+			fmt.Sprintf("%s %s", idColumnName, desc),
+		).
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				s.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var waterings []entities.Watering
+
+	for rows.Next() {
+		watering := entities.Watering{}
+		columns := db.GetEntityColumns(&watering) // Only pointer to use rows.Scan() successfully
+
+		if err = rows.Scan(columns...); err != nil {
+			return nil, err
+		}
+
+		waterings = append(waterings, watering)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return waterings, nil
+}
+
+func (s *wateringsStorage) CountGroupWaterings(ctx context.Context, groupID int) (int, error) {
+	ctx, cancel := startQuery(ctx, s.queryTimeout, s.queryDuration, "CountGroupWaterings")
+	defer cancel()
+
+	connection, err := getConnection(ctx, s.dbConnector, s.tx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeConnection(ctx, connection, s.logger)
+
+	stmt, params, err := sq.
+		Select(selectCount).
+		From(wateringsTableName).
+		Where(sq.Eq{groupIDColumnName: groupID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
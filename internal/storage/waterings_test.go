@@ -0,0 +1,319 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DKhorkov/libs/db"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/DKhorkov/libs/logging"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/config"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestWateringsStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(WateringsStorageTestSuite))
+}
+
+type WateringsStorageTestSuite struct {
+	suite.Suite
+
+	cwd         string
+	ctx         context.Context
+	dbConnector db.Connector
+	connection  *sql.Conn
+	storage     *wateringsStorage
+	logger      *mocklogging.MockLogger
+}
+
+func (s *WateringsStorageTestSuite) SetupSuite() {
+	// Инициализируем переменные окружения для дальнейшего считывания:
+	loadenv.Init("../../.env")
+
+	cwd, err := os.Getwd()
+	s.NoError(err)
+
+	cfg := config.New()
+	logger := logging.New(
+		cfg.Logging.Level,
+		cfg.Logging.LogFilePath,
+	)
+
+	dbConnector, err := db.New(
+		db.BuildDsn(cfg.Database),
+		cfg.Database.Driver,
+		logger,
+		db.WithMaxOpenConnections(cfg.Database.Pool.MaxOpenConnections),
+		db.WithMaxIdleConnections(cfg.Database.Pool.MaxIdleConnections),
+		db.WithMaxConnectionLifetime(cfg.Database.Pool.MaxConnectionLifetime),
+		db.WithMaxConnectionIdleTime(cfg.Database.Pool.MaxConnectionIdleTime),
+	)
+	s.NoError(err, "failed to connect to database")
+
+	s.NoError(goose.SetDialect(cfg.Database.Driver))
+
+	ctrl := gomock.NewController(s.T())
+	s.logger = mocklogging.NewMockLogger(ctrl)
+
+	s.cwd = cwd
+	s.dbConnector = dbConnector
+	s.ctx = context.Background()
+
+	s.storage = &wateringsStorage{
+		dbConnector: s.dbConnector,
+		logger:      s.logger,
+	}
+}
+
+func (s *WateringsStorageTestSuite) SetupTest() {
+	s.NoError(
+		goose.Up(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+		),
+	)
+
+	connection, err := s.dbConnector.Connection(s.ctx)
+	s.NoError(err)
+
+	s.connection = connection
+}
+
+func (s *WateringsStorageTestSuite) TearDownTest() {
+	s.NoError(
+		goose.DownTo(
+			s.dbConnector.Pool(),
+			path.Dir(
+				path.Dir(s.cwd),
+			)+migrationsDir,
+			gooseZeroVersion,
+		),
+	)
+
+	s.NoError(s.connection.Close())
+}
+
+func (s *WateringsStorageTestSuite) TearDownSuite() {
+	s.NoError(s.dbConnector.Close())
+}
+
+func (s *WateringsStorageTestSuite) createUser(now time.Time, offset int) int {
+	var userID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO users (
+				telegram_id, username, firstname, lastname, is_bot, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $6)
+			RETURNING id
+		`,
+		123456789+int64(offset),
+		fmt.Sprintf("user%d", offset),
+		fmt.Sprintf("First%d", offset),
+		fmt.Sprintf("Last%d", offset),
+		false,
+		now,
+	).Scan(&userID)
+	s.NoError(err)
+	return userID
+}
+
+func (s *WateringsStorageTestSuite) createGroupForUser(userID int, now time.Time, offset int) int {
+	var groupID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO groups (
+				user_id, title, watering_interval, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $4)
+			RETURNING id
+		`,
+		userID,
+		fmt.Sprintf("Группа %d", offset),
+		7+offset,
+		now,
+	).Scan(&groupID)
+	s.NoError(err)
+	return groupID
+}
+
+func (s *WateringsStorageTestSuite) createPlant(groupID, userID int, now time.Time) int {
+	var plantID int
+	err := s.connection.QueryRowContext(
+		context.Background(),
+		`
+			INSERT INTO plants (
+				group_id, user_id, title, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $4)
+			RETURNING id
+		`,
+		groupID,
+		userID,
+		"Фикус",
+		now,
+	).Scan(&plantID)
+	s.NoError(err)
+	return plantID
+}
+
+func (s *WateringsStorageTestSuite) TestSaveWatering_Success() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	wateredAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	wateringID, err := s.storage.SaveWatering(
+		s.ctx,
+		entities.Watering{
+			GroupID:   groupID,
+			WateredAt: wateredAt,
+			Source:    entities.WateringSourceNotification,
+		},
+	)
+	s.NoError(err)
+	s.Greater(wateringID, 0)
+
+	var stored entities.Watering
+	err = s.connection.QueryRowContext(
+		context.Background(),
+		`SELECT id, group_id, plant_id, watered_at, source FROM waterings WHERE id = $1`,
+		wateringID,
+	).Scan(&stored.ID, &stored.GroupID, &stored.PlantID, &stored.WateredAt, &stored.Source)
+	s.NoError(err)
+
+	s.Equal(groupID, stored.GroupID)
+	s.Nil(stored.PlantID)
+	s.True(wateredAt.Equal(stored.WateredAt))
+	s.Equal(entities.WateringSourceNotification, stored.Source)
+}
+
+func (s *WateringsStorageTestSuite) TestSaveWatering_InvalidSource() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	_, err := s.storage.SaveWatering(
+		s.ctx,
+		entities.Watering{
+			GroupID:   groupID,
+			WateredAt: now,
+			Source:    "unknown",
+		},
+	)
+	s.Error(err)
+	s.Contains(err.Error(), "violates check constraint")
+}
+
+func (s *WateringsStorageTestSuite) TestSaveWatering_GroupDoesNotExist() {
+	_, err := s.storage.SaveWatering(
+		s.ctx,
+		entities.Watering{
+			GroupID:   999999, // Такой группы нет
+			WateredAt: time.Now().UTC(),
+			Source:    entities.WateringSourceManual,
+		},
+	)
+	s.Error(err)
+	s.Contains(err.Error(), "violates foreign key constraint")
+}
+
+func (s *WateringsStorageTestSuite) TestSaveWatering_PlantDeleted() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	plantID := s.createPlant(groupID, userID, now)
+
+	wateringID, err := s.storage.SaveWatering(
+		s.ctx,
+		entities.Watering{
+			GroupID:   groupID,
+			PlantID:   &plantID,
+			WateredAt: now,
+			Source:    entities.WateringSourceManual,
+		},
+	)
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(context.Background(), `DELETE FROM plants WHERE id = $1`, plantID)
+	s.NoError(err)
+
+	// История полива сохраняется, но без привязки к удаленному растению:
+	var storedPlantID *int
+	err = s.connection.QueryRowContext(
+		context.Background(),
+		`SELECT plant_id FROM waterings WHERE id = $1`,
+		wateringID,
+	).Scan(&storedPlantID)
+	s.NoError(err)
+	s.Nil(storedPlantID)
+}
+
+func (s *WateringsStorageTestSuite) TestGetGroupWaterings_OrderAndPagination() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+	otherGroupID := s.createGroupForUser(userID, now, 2)
+
+	for _, days := range []int{3, 1, 2} {
+		_, err := s.storage.SaveWatering(
+			s.ctx,
+			entities.Watering{
+				GroupID:   groupID,
+				WateredAt: now.AddDate(0, 0, -days),
+				Source:    entities.WateringSourceManual,
+			},
+		)
+		s.NoError(err)
+	}
+
+	_, err := s.storage.SaveWatering(
+		s.ctx,
+		entities.Watering{
+			GroupID:   otherGroupID,
+			WateredAt: now,
+			Source:    entities.WateringSourceManual,
+		},
+	)
+	s.NoError(err)
+
+	waterings, err := s.storage.GetGroupWaterings(s.ctx, groupID, 2, 0)
+	s.NoError(err)
+	s.Len(waterings, 2)
+	s.WithinDuration(now.AddDate(0, 0, -1), waterings[0].WateredAt, time.Second)
+	s.WithinDuration(now.AddDate(0, 0, -2), waterings[1].WateredAt, time.Second)
+
+	waterings, err = s.storage.GetGroupWaterings(s.ctx, groupID, 2, 2)
+	s.NoError(err)
+	s.Len(waterings, 1)
+	s.WithinDuration(now.AddDate(0, 0, -3), waterings[0].WateredAt, time.Second)
+
+	count, err := s.storage.CountGroupWaterings(s.ctx, groupID)
+	s.NoError(err)
+	s.Equal(3, count)
+}
+
+func (s *WateringsStorageTestSuite) TestGetGroupWaterings_Empty() {
+	now := time.Now().UTC()
+	userID := s.createUser(now, 1)
+	groupID := s.createGroupForUser(userID, now, 1)
+
+	waterings, err := s.storage.GetGroupWaterings(s.ctx, groupID, 10, 0)
+	s.NoError(err)
+	s.Empty(waterings)
+
+	count, err := s.storage.CountGroupWaterings(s.ctx, groupID)
+	s.NoError(err)
+	s.Zero(count)
+}
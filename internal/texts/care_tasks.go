@@ -0,0 +1,70 @@
+package texts
+
+const (
+	CareTaskTypeFertilizing = "Подкормка удобрением 🧪"
+	CareTaskTypeMisting     = "Опрыскивание 💦"
+	CareTaskTypeRepotting   = "Пересадка 🪴"
+
+	CareTasksOwnerGroup = "<b>Cценарий полива:</b> %s"
+	CareTasksOwnerPlant = "<b>Растение:</b> %s"
+
+	CareTasks = "%s\n\n" +
+		"<b>Задачи ухода:</b>\n%s\n" +
+		"Помимо полива я могу напоминать о подкормке, опрыскивании и пересадке🌱\n\n" +
+		"Пожалуйста, выберите задачу ухода для управления или добавьте новую:"
+
+	CareTasksEmpty = "Задачи ухода пока не добавлены.\n"
+
+	CareTaskEntry = "• %s: каждые %s, следующий раз %s\n"
+
+	AddCareTaskType = "%s\n\n" +
+		"Пожалуйста, выберите вид ухода:"
+
+	AddCareTaskLastDate = "%s\n" +
+		"<b>Вид ухода:</b> %s\n\n" +
+		"Пожалуйста, выберите дату, когда данный уход выполнялся в последний раз:"
+
+	CareTaskDateInFuture = "Дата последнего ухода не может быть позже сегодняшнего дня!"
+
+	AddCareTaskInterval = "%s\n" +
+		"<b>Вид ухода:</b> %s\n" +
+		"<b>Дата последнего ухода:</b> %s\n\n" +
+		"Пожалуйста, выберите, как часто следует выполнять данный уход:"
+
+	ConfirmAddCareTask = "%s\n" +
+		"<b>Вид ухода:</b> %s\n" +
+		"<b>Дата последнего ухода:</b> %s\n" +
+		"<b>Интервал ухода:</b> %s\n" +
+		"<b>Дата следующего ухода:</b> %s\n\n" +
+		"Пожалуйста, проверьте введенные данные:"
+
+	ManageCareTask = "%s\n" +
+		"<b>Вид ухода:</b> %s\n" +
+		"<b>Дата последнего ухода:</b> %s\n" +
+		"<b>Интервал ухода:</b> %s\n" +
+		"<b>Дата следующего ухода:</b> %s\n\n" +
+		"Пожалуйста, выберите действие для данной задачи ухода:"
+
+	ManageCareTaskRemoval = "%s\n" +
+		"<b>Вид ухода:</b> %s\n" +
+		"<b>Дата последнего ухода:</b> %s\n" +
+		"<b>Интервал ухода:</b> %s\n" +
+		"<b>Дата следующего ухода:</b> %s\n\n" +
+		"Пожалуйста, подтвердите удаление данной задачи ухода:"
+
+	ChangeCareTaskLastDate = "%s\n" +
+		"<b>Вид ухода:</b> %s\n" +
+		"<b>Дата последнего ухода:</b> %s\n" +
+		"<b>Интервал ухода:</b> %s\n" +
+		"<b>Дата следующего ухода:</b> %s\n\n" +
+		"Пожалуйста, выберите обновленную дату последнего ухода:"
+
+	ChangeCareTaskInterval = "%s\n" +
+		"<b>Вид ухода:</b> %s\n" +
+		"<b>Дата последнего ухода:</b> %s\n" +
+		"<b>Интервал ухода:</b> %s\n" +
+		"<b>Дата следующего ухода:</b> %s\n\n" +
+		"Пожалуйста, выберите новый интервал ухода:"
+
+	CareTaskDone = "Отлично! Следующий раз напомню %s"
+)
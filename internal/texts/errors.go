@@ -0,0 +1,28 @@
+package texts
+
+const (
+	DelegationWithoutGroups = "Выбери хотя бы один сценарий, напоминания по которому нужно передать!"
+
+	HouseholdNotFound = "Ты пока не состоишь в доме 🏠"
+
+	NotificationNotFound = "Это напоминание уже неактуально 🙏"
+
+	InvalidCareTaskType = "Такой вид ухода не поддерживается!"
+
+	InvalidTimezone = "Такой часовой пояс не поддерживается!"
+
+	InvalidNotifyHour = "Время уведомлений должно быть от 00:00 до 23:00!"
+
+	InvalidQuietHours = "Тихие часы должны быть в пределах от 00:00 до 23:00!"
+
+	InvalidQuietWeekdays = "Некорректные дни без уведомлений!"
+
+	InvalidVacation = "Некорректные даты отпуска!"
+
+	InvalidImport = "Не получилось прочитать файл 😔 Пришли файл, который я отправил по команде /export."
+
+	UnsupportedImportVersion = "Этот файл сделан другой версией бота, я не могу его загрузить 😔"
+
+	UnexpectedError = "Что-то пошло не так 😔 Попробуй еще раз чуть позже.\n\n" +
+		"Если ошибка повторится, сообщи разработчику код: %s"
+)
@@ -0,0 +1,42 @@
+package texts
+
+const (
+	Export = "Твои сценарии и растения 📦\n\n" +
+		"В файле настройки полива, задачи ухода и история поливов и напоминаний. " +
+		"По этому файлу данные можно будет восстановить."
+
+	ExportArchive = "Выгрузка вместе с фотографиями растений 📸\n\n" +
+		"По этому архиву данные можно будет восстановить вместе с фотографиями."
+)
+
+const (
+	ImportData = "Пришли файл или ZIP-архив, который я отправил по команде /export, и я восстановлю из него " +
+		"сценарии и растения 📥\n\n" +
+		"Фотографии растений загружаются только из архива."
+
+	ImportPreview = "Из файла будут загружены:\n\n" +
+		"<b>Сценарии:</b> %d\n" +
+		"<b>Растения:</b> %d\n" +
+		"<b>Задачи ухода:</b> %d\n" +
+		"<b>Записи о поливе:</b> %d\n" +
+		"<b>Фотографии:</b> %d\n\n"
+
+	ImportConflicts = "Эти сценарии у тебя уже есть: %s\n\n"
+
+	ImportPlantConflicts = "В них уже есть эти растения: %s\n\n"
+
+	ImportConflictsQuestion = "Пропустить совпадающие сценарии, загрузить их под новыми названиями или заменить " +
+		"совпадающие сценарии и растения данными из файла?"
+
+	ConfirmImport = "Загрузить?"
+
+	ImportDone = "Готово! Загружено сценариев: %d, растений: %d 🌿"
+
+	ImportSkipped = "\nПропущено сценариев: %d"
+
+	ImportRenamed = "\nЗагружено под новыми названиями: %d"
+
+	ImportOverwritten = "\nЗаменены настройки сценариев: %d"
+
+	ImportOverwrittenPlants = "\nЗаменено растений: %d"
+)
@@ -50,6 +50,18 @@ const (
 
 	GroupWatered = "Вы молодец! Растения вам благодарны ❤️"
 
+	AllGroupsWatered = "Все сценарии отмечены политыми! Растения вам благодарны ❤️"
+
+	DigestGroupWateredButton = "%s полит ✅"
+
+	DigestGroupWatered = "\n✅ %s полит"
+
+	GroupRemindLater = "Хорошо, напомню через %d часа ⏰"
+
+	GroupRemindTomorrow = "Хорошо, напомню завтра в %02d:00 📅"
+
+	GroupWateringSkipped = "Полив пропущен. Следующий полив: %s ⏭"
+
 	ManageGroup = "Пожалуйста, выберите сценарий полива растений для дальнейших действий:"
 
 	ManageGroupAction = "<b>Cценарий полива:</b> %s\n" +
@@ -109,4 +121,66 @@ const (
 		"<b>Интервал между поливами:</b> %s\n" +
 		"<b>Дата следующего полива:</b> %s\n\n" +
 		"Пожалуйста, выберите растение для просмотра:"
+
+	WateringHistory = "<b>Cценарий полива:</b> %s\n" +
+		"<b>История поливов</b> (страница %d из %d):\n\n" +
+		"%s"
+
+	WateringHistoryEntry = "%s — %s\n"
+
+	WateringHistoryEmpty = "Записей о поливах пока нет."
+
+	WateringSourceNotification = "по напоминанию"
+	WateringSourceManual       = "указано вручную"
+	WateringSourceBackfill     = "перенесено из прежних данных"
+
+	WateringHistoryPreviousPage = "⬅️"
+	WateringHistoryNextPage     = "➡️"
+
+	ChangeGroupSeasonSchedule = "<b>Cценарий полива:</b> %s\n" +
+		"<b>Описание сценария полива:</b> %s\n" +
+		"<b>Дата последнего полива:</b> %s\n" +
+		"<b>Интервал между поливами:</b> %s\n" +
+		"<b>Дата следующего полива:</b> %s\n\n" +
+		"<b>Сезонный график полива:</b>\n%s\n" +
+		"Для каждого времени года можно задать собственный интервал полива. " +
+		"В остальное время действует основной интервал сценария.\n\n" +
+		"Пожалуйста, выберите время года:"
+
+	ChangeGroupSeasonInterval = "<b>Cценарий полива:</b> %s\n" +
+		"<b>Основной интервал между поливами:</b> %s\n" +
+		"<b>Время года:</b> %s\n\n" +
+		"Пожалуйста, выберите интервал полива для данного времени года:"
+
+	SeasonScheduleEntry = "• %s: %s\n"
+
+	SeasonScheduleDefaultInterval = "основной интервал"
+
+	SeasonScheduleResetInterval = "Использовать основной интервал"
+
+	SeasonalWateringInterval = "%s, сезон: %s"
+
+	DefaultSeasonWateringInterval = "%s, основной интервал"
+
+	SeasonTitle = "%s (%s — %s)"
+
+	SeasonMonthsRange = "%s — %s"
+
+	SeasonWinter = "зима"
+	SeasonSpring = "весна"
+	SeasonSummer = "лето"
+	SeasonAutumn = "осень"
+
+	MonthJanuary   = "январь"
+	MonthFebruary  = "февраль"
+	MonthMarch     = "март"
+	MonthApril     = "апрель"
+	MonthMay       = "май"
+	MonthJune      = "июнь"
+	MonthJuly      = "июль"
+	MonthAugust    = "август"
+	MonthSeptember = "сентябрь"
+	MonthOctober   = "октябрь"
+	MonthNovember  = "ноябрь"
+	MonthDecember  = "декабрь"
 )
@@ -0,0 +1,50 @@
+package texts
+
+const (
+	Handover = "<b>Передачи растений:</b>\n%s\n" +
+		"Уезжаешь? Передай напоминания о поливе выбранных сценариев другому человеку до нужной даты 🧳\n" +
+		"Присматривающему не нужно вступать в дом: он получит напоминания с кнопкой полива и сможет " +
+		"посмотреть растения и их фотографии.\n\n"
+
+	HandoverEmpty = "Пока нет передач\n"
+
+	HandoverEntry = "• %s — до %s, %s\n"
+
+	HandoverGroupsSeparator = ", "
+
+	HandoverPending = "ожидает принятия приглашения"
+
+	HandoverAccepted = "присматривает %s"
+
+	CancelHandover = "Отменить передачу до %s ❌"
+
+	HandoverGroups = "Выбери сценарии, напоминания по которым получит присматривающий за растениями🧳\n\n"
+
+	HandoverGroupSelected = "✅ %s"
+
+	HandoverEndDate = "Выбери последний день, когда напоминания будет получать присматривающий🗓\n\n"
+
+	HandoverEndDateInPast = "Дата окончания передачи не может быть в прошлом!"
+
+	HandoverInvite = "Отправь эту ссылку человеку, который присмотрит за растениями:\n\n" +
+		"%s\n\n" +
+		"До %s включительно он будет получать напоминания о поливе выбранных сценариев вместо тебя 🧳\n\n"
+
+	DelegationAccepted = "Ты присматриваешь за растениями пользователя %s до %s включительно! " +
+		"Я буду присылать тебе напоминания о поливе 🪴\n\n"
+
+	DelegationNotFound = "Приглашение недействительно или уже использовано. Попроси у владельца растений новую ссылку 🙏\n\n"
+
+	DelegationExpired = "Срок передачи растений уже закончился. Попроси у владельца растений новую ссылку 🙏\n\n"
+
+	DelegationToSelf = "Нельзя передать растения самому себе 🙃\n\n"
+
+	SitterGroupPlants = "<b>Растения сценария «%s»:</b>\n\n" +
+		"Выбери растение, чтобы посмотреть его фотографию🪴\n\n"
+
+	SitterGroupPlantsEmpty = "<b>В сценарии «%s» пока нет растений.</b>\n\n"
+
+	SitterPlant = "<b>%s</b>\n\n%s"
+
+	SitterAccessDenied = "Передача растений уже закончилась 🙏"
+)
@@ -0,0 +1,23 @@
+package texts
+
+const (
+	Household = "<b>Участники дома:</b>\n%s\n" +
+		"Пригласи близких, чтобы вместе ухаживать за растениями 🏡\n" +
+		"Все участники дома видят общие сценарии и растения, получают напоминания и могут отмечать полив.\n\n"
+
+	HouseholdMember = "• %s\n"
+
+	HouseholdInvite = "Отправь эту ссылку человеку, с которым хочешь вместе ухаживать за растениями:\n\n" +
+		"%s\n\n" +
+		"Ссылка действует до %s (UTC) и подходит только для одного приглашения ✉️\n\n"
+
+	HouseholdJoined = "Ты присоединился к дому! Теперь вам доступны общие сценарии и растения 🏡\n\n"
+
+	HouseholdInviteNotFound = "Приглашение недействительно или уже использовано. Попроси у участника дома новую ссылку 🙏\n\n"
+
+	HouseholdInviteExpired = "Срок действия приглашения истек. Попроси у участника дома новую ссылку 🙏\n\n"
+
+	GroupWateredByMember = "\n\n✅ Полил(а) %s в %s"
+
+	CareTaskDoneByMember = "\n\n✅ Выполнил(а) %s в %s"
+)
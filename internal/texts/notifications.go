@@ -8,4 +8,30 @@ const (
 		"<b>Интервал полива сценария:</b> %s\n\n" +
 		"Растения в данном сценарии:\n%s\n\n" +
 		"Растения в данном сценарии были политы сегодня?"
+
+	NotifyDigest = "Сегодня необходимо полить растения из следующих сценариев полива:\n\n" +
+		"%s" +
+		"Отметь сценарии, которые уже политы:"
+
+	NotifyDigestGroup = "<b>%d. %s</b>\n" +
+		"%s" +
+		"<b>Дата последнего полива:</b> %s\n" +
+		"<b>Интервал полива:</b> %s\n" +
+		"Растения:\n%s\n"
+
+	NotifyDigestOverdue = "⚠️ Полив просрочен на %s\n"
+
+	// Заголовки повторных напоминаний, которые добавляются перед Notify:
+	NotifyOverdue = "⚠️ <b>Полив просрочен на %s!</b>\n\n"
+	NotifyCatchUp = "🏖 <b>С возвращением!</b> Пока тебя не было, растения успели соскучиться.\n\n"
+
+	NotifyFollowUp = "⏰ <b>Повторное напоминание:</b> растения все еще ждут полива.\n\n"
+
+	NotifySitter = "🧳 <b>Ты присматриваешь за растениями пользователя %s.</b>\n\n"
+
+	NotifyCareTask = "Пора выполнить уход: <b>%s</b>\n\n" +
+		"%s\n" +
+		"<b>Дата последнего ухода:</b> %s\n" +
+		"<b>Интервал ухода:</b> %s\n\n" +
+		"Уход был выполнен сегодня?"
 )
@@ -88,4 +88,42 @@ const (
 		"<b>Заметки по растению:</b> %s\n" +
 		"<b>Сценарий полива:</b> %s\n\n" +
 		"Пожалуйста, отправьте боту новую фотографию для данного растения:"
+
+	ChangePlantWateringInterval = "<b>Название растения:</b> %s\n" +
+		"<b>Заметки по растению:</b> %s\n" +
+		"<b>Сценарий полива:</b> %s\n" +
+		"<b>Интервал полива растения:</b> %s\n\n" +
+		"Если растению нужен свой график полива, выберите для него интервал. " +
+		"Я напомню о нем вместе со сценарием, как только растение нужно будет полить:"
+
+	PlantWateringIntervalInherited = "как в сценарии полива"
+
+	ChangePlantWateringIntervalReset = "Как в сценарии полива"
+
+	PlantWateredButton = "%s полито ✅"
+
+	PlantWatered = "Растение %s полито, я запомнил 💧"
+
+	PlantGallery = "<b>Название растения:</b> %s\n" +
+		"<b>Фотография %d из %d</b> от %s\n" +
+		"%s\n" +
+		"Листайте фотографии, чтобы увидеть, как растение росло 🌱"
+
+	PlantGalleryCaption = "<b>Подпись:</b> %s\n"
+
+	PlantGalleryEmpty = "<b>Название растения:</b> %s\n\n" +
+		"У растения пока нет фотографий в галерее.\n" +
+		"Добавляйте их время от времени, и я сохраню историю того, как растение растет 🌱"
+
+	PlantGalleryPreviousPhoto = "◀️ Раньше"
+
+	PlantGalleryNextPhoto = "Позже ▶️"
+
+	SetPlantCoverPhoto = "Сделать обложкой растения 🖼"
+
+	PlantCoverPhotoUpdated = "Фотография стала обложкой растения ✅"
+
+	AddPlantGalleryPhoto = "<b>Название растения:</b> %s\n\n" +
+		"Пожалуйста, отправьте боту новую фотографию растения. Подпись к фотографии я сохраню вместе с ней.\n" +
+		"Если не получилось, проверьте, не отправляете ли фотографию как файл - я распознаю только изображения."
 )
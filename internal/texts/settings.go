@@ -0,0 +1,65 @@
+package texts
+
+const (
+	Settings = "<b>Часовой пояс:</b> %s\n" +
+		"<b>Время уведомлений:</b> %02d:00\n" +
+		"<b>Режим уведомлений:</b> %s\n\n" +
+		"Здесь можно настроить, когда я буду напоминать о поливе⏰\n\n" +
+		"Выбери, что хочешь изменить:\n\n"
+
+	ChangeTimezone = "<b>Текущий часовой пояс:</b> %s\n\n" +
+		"Выбери свой часовой пояс, чтобы я присылал напоминания в удобное для тебя время🌍\n\n"
+
+	ChangeNotifyHour = "<b>Текущее время уведомлений:</b> %02d:00\n\n" +
+		"Выбери час, начиная с которого я буду присылать напоминания о поливе⏰\n\n"
+
+	NotificationsModeSeparate = "отдельно по каждому сценарию"
+
+	NotificationsModeDigest = "одна сводка по всем сценариям"
+
+	QuietSettings = "<b>Тихие часы:</b> %s\n" +
+		"<b>Дни без уведомлений:</b> %s\n" +
+		"<b>Отпуск:</b> %s\n\n" +
+		"В это время я не буду присылать напоминания🌙\n\n" +
+		"После отпуска пришлю одну сводку по всем сценариям, которые нужно полить🏖\n\n"
+
+	QuietHoursRange = "%02d:00 — %02d:00"
+
+	QuietHoursDisabled = "не заданы"
+
+	QuietWeekdaysEmpty = "нет"
+
+	QuietWeekdaysSeparator = ", "
+
+	VacationRange = "%s — %s"
+
+	VacationNotPlanned = "не запланирован"
+
+	ChangeQuietHoursStart = "<b>Текущие тихие часы:</b> %s\n\n" +
+		"Выбери час, начиная с которого я не буду присылать напоминания🌙\n\n"
+
+	ChangeQuietHoursEnd = "<b>Начало тихих часов:</b> %02d:00\n\n" +
+		"Выбери час, начиная с которого напоминания снова можно присылать⏰\n\n"
+
+	DisableQuietHours = "Отключить тихие часы ❌"
+
+	ChangeQuietWeekdays = "<b>Дни без уведомлений:</b> %s\n\n" +
+		"Выбери дни недели, в которые я не буду присылать напоминания📵\n\n"
+
+	QuietWeekdaySelected = "✅ %s"
+
+	ChangeVacationStart = "Выбери дату начала отпуска🏖\n\n"
+
+	ChangeVacationEnd = "<b>Начало отпуска:</b> %s\n\n" +
+		"Выбери дату окончания отпуска🏖\n\n"
+
+	VacationEndBeforeStart = "Дата окончания отпуска не может быть раньше даты начала!"
+
+	WeekdayMonday    = "понедельник"
+	WeekdayTuesday   = "вторник"
+	WeekdayWednesday = "среда"
+	WeekdayThursday  = "четверг"
+	WeekdayFriday    = "пятница"
+	WeekdaySaturday  = "суббота"
+	WeekdaySunday    = "воскресенье"
+)
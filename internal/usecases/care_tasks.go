@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+type careTasksUseCases struct {
+	storage interfaces.Storage
+	logger  logging.Logger
+}
+
+func (u *careTasksUseCases) CreateCareTask(
+	ctx context.Context,
+	careTask entities.CareTask,
+) (*entities.CareTask, error) {
+	careTaskID, err := u.storage.CreateCareTask(ctx, careTask)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to create CareTask for User with ID=%d", careTask.UserID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	careTask.ID = careTaskID
+
+	return &careTask, err
+}
+
+func (u *careTasksUseCases) GetCareTask(ctx context.Context, id int) (*entities.CareTask, error) {
+	careTask, err := u.storage.GetCareTask(ctx, id)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get CareTask with ID=%d", id),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return careTask, err
+}
+
+func (u *careTasksUseCases) GetGroupCareTasks(ctx context.Context, groupID int) ([]entities.CareTask, error) {
+	careTasks, err := u.storage.GetGroupCareTasks(ctx, groupID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get CareTasks for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return careTasks, err
+}
+
+func (u *careTasksUseCases) GetPlantCareTasks(ctx context.Context, plantID int) ([]entities.CareTask, error) {
+	careTasks, err := u.storage.GetPlantCareTasks(ctx, plantID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get CareTasks for Plant with ID=%d", plantID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return careTasks, err
+}
+
+func (u *careTasksUseCases) ClaimCareTasksForNotify(
+	ctx context.Context,
+	limit int,
+	claimTTL time.Duration,
+) ([]entities.CareTask, error) {
+	careTasks, err := u.storage.ClaimCareTasksForNotify(ctx, limit, claimTTL)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to claim CareTasks for Notify with limit=%d", limit),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return careTasks, err
+}
+
+func (u *careTasksUseCases) UpdateCareTaskLastDate(
+	ctx context.Context,
+	id int,
+	lastCareDate time.Time,
+) (*entities.CareTask, error) {
+	careTask, err := u.GetCareTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask.LastCareDate = lastCareDate
+
+	return u.updateCareTaskSchedule(ctx, careTask)
+}
+
+func (u *careTasksUseCases) UpdateCareTaskInterval(ctx context.Context, id, interval int) (*entities.CareTask, error) {
+	careTask, err := u.GetCareTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask.Interval = interval
+
+	return u.updateCareTaskSchedule(ctx, careTask)
+}
+
+func (u *careTasksUseCases) DeleteCareTask(ctx context.Context, id int) error {
+	err := u.storage.DeleteCareTask(ctx, id)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to delete CareTask with ID=%d", id),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return err
+}
+
+// updateCareTaskSchedule пересчитывает дату следующего ухода так же, как это делается для полива сценария.
+func (u *careTasksUseCases) updateCareTaskSchedule(
+	ctx context.Context,
+	careTask *entities.CareTask,
+) (*entities.CareTask, error) {
+	nextCareDate := careTask.LastCareDate.AddDate(0, 0, careTask.Interval)
+
+	today, err := getUserToday(ctx, u.storage, u.logger, careTask.UserID, nextCareDate.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	if nextCareDate.Before(today) {
+		nextCareDate = today
+	}
+
+	careTask.NextCareDate = nextCareDate
+
+	if err = u.storage.UpdateCareTask(ctx, *careTask); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update CareTask with ID=%d", careTask.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return careTask, nil
+}
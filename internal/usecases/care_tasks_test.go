@@ -0,0 +1,402 @@
+package usecases
+
+import (
+	"context"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestCareTasksUseCases_CreateCareTask(t *testing.T) {
+	groupID := 1
+	careTask := entities.CareTask{
+		UserID:       123,
+		GroupID:      &groupID,
+		Type:         entities.CareTaskTypeFertilizing,
+		Interval:     30,
+		LastCareDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		NextCareDate: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name       string
+		careTask   entities.CareTask
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantID     int
+		wantErr    bool
+	}{
+		{
+			name:     "Success - care task created",
+			careTask: careTask,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CreateCareTask(gomock.Any(), careTask).
+					Return(5, nil).
+					Times(1)
+			},
+			wantID:  5,
+			wantErr: false,
+		},
+		{
+			name:     "Failure - storage error",
+			careTask: careTask,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CreateCareTask(gomock.Any(), careTask).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to create CareTask for User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &careTasksUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.CreateCareTask(context.Background(), tt.careTask)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantID, got.ID)
+			}
+		})
+	}
+}
+
+func TestCareTasksUseCases_GetGroupCareTasks(t *testing.T) {
+	groupID := 1
+	careTasks := []entities.CareTask{
+		{ID: 1, GroupID: &groupID, Type: entities.CareTaskTypeFertilizing},
+		{ID: 2, GroupID: &groupID, Type: entities.CareTaskTypeMisting},
+	}
+
+	tests := []struct {
+		name       string
+		groupID    int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       []entities.CareTask
+		wantErr    bool
+	}{
+		{
+			name:    "Success - care tasks found",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupCareTasks(gomock.Any(), 1).
+					Return(careTasks, nil).
+					Times(1)
+			},
+			want:    careTasks,
+			wantErr: false,
+		},
+		{
+			name:    "Failure - storage error",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupCareTasks(gomock.Any(), 1).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get CareTasks for Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &careTasksUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetGroupCareTasks(context.Background(), tt.groupID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCareTasksUseCases_UpdateCareTaskLastDate(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+
+	newCareTask := func() *entities.CareTask {
+		plantID := 2
+
+		return &entities.CareTask{
+			ID:           1,
+			UserID:       123,
+			PlantID:      &plantID,
+			Type:         entities.CareTaskTypeRepotting,
+			Interval:     365,
+			LastCareDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+			NextCareDate: time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	tests := []struct {
+		name             string
+		lastCareDate     time.Time
+		setupMocks       func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantNextCareDate time.Time
+		wantErr          bool
+	}{
+		{
+			name:         "Success - next care date after interval",
+			lastCareDate: today,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetCareTask(gomock.Any(), 1).
+					Return(newCareTask(), nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateCareTask(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			wantNextCareDate: today.AddDate(0, 0, 365),
+			wantErr:          false,
+		},
+		{
+			name:         "Success - overdue next care date is set to today",
+			lastCareDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetCareTask(gomock.Any(), 1).
+					Return(newCareTask(), nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateCareTask(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			wantNextCareDate: today,
+			wantErr:          false,
+		},
+		{
+			name:         "Failure - care task not found",
+			lastCareDate: today,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetCareTask(gomock.Any(), 1).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get CareTask with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:         "Failure - storage update error",
+			lastCareDate: today,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetCareTask(gomock.Any(), 1).
+					Return(newCareTask(), nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateCareTask(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update CareTask with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &careTasksUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateCareTaskLastDate(context.Background(), 1, tt.lastCareDate)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.lastCareDate, got.LastCareDate)
+				assert.Equal(t, tt.wantNextCareDate, got.NextCareDate)
+			}
+		})
+	}
+}
+
+func TestCareTasksUseCases_DeleteCareTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    bool
+	}{
+		{
+			name: "Success - care task deleted",
+			id:   1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					DeleteCareTask(gomock.Any(), 1).
+					Return(nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failure - storage error",
+			id:   1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					DeleteCareTask(gomock.Any(), 1).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to delete CareTask with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &careTasksUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			err := useCases.DeleteCareTask(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+type delegationsUseCases struct {
+	storage interfaces.Storage
+	logger  logging.Logger
+}
+
+// CreateDelegation создает приглашение присматривающего за сценариями пользователя до даты окончания включительно.
+func (u *delegationsUseCases) CreateDelegation(
+	ctx context.Context,
+	ownerID int,
+	groupIDs []int,
+	endDate time.Time,
+) (*entities.Delegation, error) {
+	if len(groupIDs) == 0 {
+		return nil, customerrors.ErrDelegationWithoutGroups
+	}
+
+	// Дата окончания выбирается в календаре без часового пояса, поэтому сравниваем ее с датой пользователя в UTC:
+	today, err := getUserToday(ctx, u.storage, u.logger, ownerID, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+
+	if endDate.Before(today) {
+		return nil, customerrors.ErrDelegationEndDateInPast
+	}
+
+	token, err := generateInviteToken()
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to generate Delegation token for User with ID=%d", ownerID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	delegation := entities.Delegation{
+		OwnerID:  ownerID,
+		GroupIDs: groupIDs,
+		Token:    entities.DelegationTokenPrefix + token,
+		EndDate:  endDate.UTC(),
+	}
+
+	delegation.ID, err = u.storage.CreateDelegation(ctx, delegation)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to create Delegation for User with ID=%d", ownerID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return &delegation, nil
+}
+
+// AcceptDelegation назначает пользователя присматривающим по токену приглашения. Приглашение одноразовое,
+// но повторный переход по ссылке тем же присматривающим не считается ошибкой.
+func (u *delegationsUseCases) AcceptDelegation(
+	ctx context.Context,
+	sitterID int,
+	token string,
+) (*entities.Delegation, error) {
+	delegation, err := u.storage.GetDelegationByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customerrors.ErrDelegationNotFound
+		}
+
+		u.logger.Error(
+			"Failed to get Delegation by token",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	if delegation.OwnerID == sitterID {
+		return nil, customerrors.ErrDelegationToSelf
+	}
+
+	if delegation.IsAccepted() && *delegation.SitterID != sitterID {
+		return nil, customerrors.ErrDelegationNotFound
+	}
+
+	today, err := getUserToday(ctx, u.storage, u.logger, sitterID, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+
+	if delegation.IsExpired(today) {
+		return nil, customerrors.ErrDelegationExpired
+	}
+
+	if err = u.storage.UpdateDelegationSitter(ctx, delegation.ID, sitterID); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to set sitter with ID=%d for Delegation with ID=%d", sitterID, delegation.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	delegation.SitterID = &sitterID
+
+	return delegation, nil
+}
+
+// GetGroupDelegation возвращает принятую передачу сценария, которая действует в указанный день.
+func (u *delegationsUseCases) GetGroupDelegation(
+	ctx context.Context,
+	groupID int,
+	date time.Time,
+) (*entities.Delegation, error) {
+	// Дата окончания хранится без часового пояса, поэтому передаем только календарную дату:
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	delegation, err := u.storage.GetGroupDelegation(ctx, groupID, day)
+	if err != nil {
+		// Сценарий без передачи - штатная ситуация, которую не нужно логировать:
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customerrors.ErrDelegationNotFound
+		}
+
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Delegation for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+// GetOwnerDelegations возвращает действующие и ожидающие принятия передачи пользователя.
+func (u *delegationsUseCases) GetOwnerDelegations(ctx context.Context, ownerID int) ([]entities.Delegation, error) {
+	today, err := getUserToday(ctx, u.storage, u.logger, ownerID, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, err := u.storage.GetOwnerDelegations(ctx, ownerID, today)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Delegations for User with ID=%d", ownerID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return delegations, err
+}
+
+// CancelDelegation досрочно завершает передачу. Отменить передачу может только ее владелец.
+func (u *delegationsUseCases) CancelDelegation(ctx context.Context, ownerID, id int) error {
+	delegation, err := u.storage.GetDelegation(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return customerrors.ErrDelegationNotFound
+		}
+
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Delegation with ID=%d", id),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if delegation.OwnerID != ownerID {
+		return customerrors.ErrDelegationNotFound
+	}
+
+	if err = u.storage.DeleteDelegation(ctx, id); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to delete Delegation with ID=%d", id),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
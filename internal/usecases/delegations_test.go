@@ -0,0 +1,458 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDelegationsUseCases_CreateDelegation(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	owner := &entities.User{ID: 1, Timezone: "UTC"}
+
+	tests := []struct {
+		name       string
+		groupIDs   []int
+		endDate    time.Time
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name:     "Success - delegation with sitter token",
+			groupIDs: []int{3, 4},
+			endDate:  today,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 1).
+					Return(owner, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					CreateDelegation(
+						gomock.Any(),
+						gomock.Cond(func(delegation entities.Delegation) bool {
+							return delegation.OwnerID == 1 &&
+								delegation.SitterID == nil &&
+								len(delegation.GroupIDs) == 2 &&
+								strings.HasPrefix(delegation.Token, entities.DelegationTokenPrefix) &&
+								len(delegation.Token) == len(entities.DelegationTokenPrefix)+inviteTokenBytes*2 &&
+								delegation.EndDate.Equal(today)
+						}),
+					).
+					Return(5, nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "Failure - without groups",
+			groupIDs: nil,
+			endDate:  today,
+			wantErr:  customerrors.ErrDelegationWithoutGroups,
+		},
+		{
+			name:     "Failure - end date in past",
+			groupIDs: []int{3},
+			endDate:  today.AddDate(0, 0, -1),
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 1).
+					Return(owner, nil).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationEndDateInPast,
+		},
+		{
+			name:     "Failure - create delegation error",
+			groupIDs: []int{3},
+			endDate:  today.AddDate(0, 0, 7),
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 1).
+					Return(owner, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					CreateDelegation(gomock.Any(), gomock.Any()).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to create Delegation for User with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &delegationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.CreateDelegation(context.Background(), 1, tt.groupIDs, tt.endDate)
+
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 5, got.ID)
+			}
+		})
+	}
+}
+
+func TestDelegationsUseCases_AcceptDelegation(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sitter := &entities.User{ID: 2, Timezone: "UTC"}
+	otherSitterID := 3
+	token := entities.DelegationTokenPrefix + "abc"
+
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name: "Success - sitter assigned",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegationByToken(gomock.Any(), token).
+					Return(&entities.Delegation{ID: 5, OwnerID: 1, EndDate: today}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 2).
+					Return(sitter, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateDelegationSitter(gomock.Any(), 5, 2).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Failure - delegation not found",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegationByToken(gomock.Any(), token).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationNotFound,
+		},
+		{
+			name: "Failure - delegation to self",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegationByToken(gomock.Any(), token).
+					Return(&entities.Delegation{ID: 5, OwnerID: 2, EndDate: today}, nil).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationToSelf,
+		},
+		{
+			name: "Failure - accepted by another sitter",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegationByToken(gomock.Any(), token).
+					Return(&entities.Delegation{ID: 5, OwnerID: 1, SitterID: &otherSitterID, EndDate: today}, nil).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationNotFound,
+		},
+		{
+			name: "Failure - delegation expired",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegationByToken(gomock.Any(), token).
+					Return(&entities.Delegation{ID: 5, OwnerID: 1, EndDate: today.AddDate(0, 0, -1)}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 2).
+					Return(sitter, nil).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationExpired,
+		},
+		{
+			name: "Failure - update sitter error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegationByToken(gomock.Any(), token).
+					Return(&entities.Delegation{ID: 5, OwnerID: 1, EndDate: today}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 2).
+					Return(sitter, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateDelegationSitter(gomock.Any(), 5, 2).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to set sitter with ID=2 for Delegation with ID=5",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &delegationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.AcceptDelegation(context.Background(), 2, token)
+
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, *got.SitterID)
+			}
+		})
+	}
+}
+
+func TestDelegationsUseCases_GetGroupDelegation(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	date := time.Date(2026, 7, 10, 1, 30, 0, 0, moscow)
+	day := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	sitterID := 2
+
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name: "Success - delegation found by local calendar date",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupDelegation(gomock.Any(), 3, day).
+					Return(&entities.Delegation{ID: 5, SitterID: &sitterID}, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Failure - no delegation is not logged",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupDelegation(gomock.Any(), 3, day).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationNotFound,
+		},
+		{
+			name: "Failure - storage error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupDelegation(gomock.Any(), 3, day).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Delegation for Group with ID=3",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &delegationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetGroupDelegation(context.Background(), 3, date)
+
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 5, got.ID)
+			}
+		})
+	}
+}
+
+func TestDelegationsUseCases_CancelDelegation(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name: "Success - delegation deleted by owner",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegation(gomock.Any(), 5).
+					Return(&entities.Delegation{ID: 5, OwnerID: 1}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					DeleteDelegation(gomock.Any(), 5).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Failure - delegation of another user",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegation(gomock.Any(), 5).
+					Return(&entities.Delegation{ID: 5, OwnerID: 9}, nil).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationNotFound,
+		},
+		{
+			name: "Failure - delegation not found",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegation(gomock.Any(), 5).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+			},
+			wantErr: customerrors.ErrDelegationNotFound,
+		},
+		{
+			name: "Failure - delete error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetDelegation(gomock.Any(), 5).
+					Return(&entities.Delegation{ID: 5, OwnerID: 1}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					DeleteDelegation(gomock.Any(), 5).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to delete Delegation with ID=5",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &delegationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			err := useCases.CancelDelegation(context.Background(), 1, 5)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
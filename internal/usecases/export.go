@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+const (
+	// Сколько поливов и фотографий запрашивать за раз при выгрузке всей истории:
+	exportPageSize = 100
+
+	exportPhotoPathFormat       = entities.ExportPhotosPath + "%s.jpg"
+	exportLegacyPhotoPathFormat = entities.ExportPhotosPath + "plant-%d.jpg"
+)
+
+type exportUseCases struct {
+	storage interfaces.Storage
+	logger  logging.Logger
+}
+
+// ExportUserData выгружает все сценарии пользователя, включая общие сценарии его дома, вместе с растениями,
+// задачами ухода, историей поливов и уведомлений о поливе.
+func (u *exportUseCases) ExportUserData(ctx context.Context, userID int) (*entities.Export, error) {
+	export, err := u.exportUserData(ctx, userID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to export data for User with ID=%d", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return export, err
+}
+
+func (u *exportUseCases) exportUserData(ctx context.Context, userID int) (*entities.Export, error) {
+	groups, err := u.storage.GetUserGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Растения запрашиваются заранее, чтобы подписать поливы растений, перенесенных в другой сценарий:
+	groupsPlants := make([][]entities.Plant, 0, len(groups))
+	plantTitles := make(map[int]string)
+
+	for _, group := range groups {
+		plants, err := u.storage.GetGroupPlants(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plant := range plants {
+			plantTitles[plant.ID] = plant.Title
+		}
+
+		groupsPlants = append(groupsPlants, plants)
+	}
+
+	export := &entities.Export{
+		Version:    entities.ExportVersion,
+		ExportedAt: time.Now(),
+		Groups:     make([]entities.ExportGroup, 0, len(groups)),
+	}
+
+	for i, group := range groups {
+		exportGroup, err := u.exportGroup(ctx, export, group, groupsPlants[i], plantTitles)
+		if err != nil {
+			return nil, err
+		}
+
+		export.Groups = append(export.Groups, *exportGroup)
+	}
+
+	return export, nil
+}
+
+func (u *exportUseCases) exportGroup(
+	ctx context.Context,
+	export *entities.Export,
+	group entities.Group,
+	plants []entities.Plant,
+	plantTitles map[int]string,
+) (*entities.ExportGroup, error) {
+	careTasks, err := u.storage.GetGroupCareTasks(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	waterings, err := getAllPages(
+		func(limit, offset int) ([]entities.Watering, error) {
+			return u.storage.GetGroupWaterings(ctx, group.ID, limit, offset)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Выгружаем всю историю уведомлений о поливе:
+	notifications, err := u.storage.GetGroupNotificationsSince(ctx, group.ID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	exportGroup := &entities.ExportGroup{
+		Title:            group.Title,
+		Description:      group.Description,
+		LastWateringDate: group.LastWateringDate,
+		NextWateringDate: group.NextWateringDate,
+		WateringInterval: group.WateringInterval,
+		SeasonSchedule:   group.SeasonSchedule,
+		Plants:           make([]entities.ExportPlant, 0, len(plants)),
+		CareTasks:        exportCareTasks(careTasks),
+		Waterings:        make([]entities.ExportWatering, 0, len(waterings)),
+		Notifications:    make([]entities.ExportNotification, 0, len(notifications)),
+	}
+
+	for _, plant := range plants {
+		exportPlant, err := u.exportPlant(ctx, export, plant)
+		if err != nil {
+			return nil, err
+		}
+
+		exportGroup.Plants = append(exportGroup.Plants, *exportPlant)
+	}
+
+	for _, watering := range waterings {
+		exportWatering := entities.ExportWatering{
+			WateredAt: watering.WateredAt,
+			Source:    watering.Source,
+		}
+
+		if watering.PlantID != nil {
+			exportWatering.Plant = plantTitles[*watering.PlantID]
+		}
+
+		exportGroup.Waterings = append(exportGroup.Waterings, exportWatering)
+	}
+
+	for _, notification := range notifications {
+		exportGroup.Notifications = append(
+			exportGroup.Notifications,
+			entities.ExportNotification{
+				Text:           notification.Text,
+				SentAt:         notification.SentAt,
+				AcknowledgedAt: notification.AcknowledgedAt,
+				Digest:         notification.Digest,
+			},
+		)
+	}
+
+	return exportGroup, nil
+}
+
+func (u *exportUseCases) exportPlant(
+	ctx context.Context,
+	export *entities.Export,
+	plant entities.Plant,
+) (*entities.ExportPlant, error) {
+	careTasks, err := u.storage.GetPlantCareTasks(ctx, plant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	photos, err := getAllPages(
+		func(limit, offset int) ([]entities.PlantPhoto, error) {
+			return u.storage.GetPlantPhotos(ctx, plant.ID, limit, offset)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	exportPlant := &entities.ExportPlant{
+		Title:            plant.Title,
+		Description:      plant.Description,
+		WateringInterval: plant.WateringInterval,
+		LastWateringDate: plant.LastWateringDate,
+		Photos:           make([]entities.ExportPhoto, 0, len(photos)),
+		CareTasks:        exportCareTasks(careTasks),
+	}
+
+	switch {
+	case plant.PhotoFileID != "":
+		exportPlant.Photo = addExportFile(
+			export,
+			entities.ExportFile{
+				Path:   fmt.Sprintf(exportPhotoPathFormat, plant.PhotoFileUniqueID),
+				FileID: plant.PhotoFileID,
+			},
+		)
+	case len(plant.Photo) > 0:
+		exportPlant.Photo = addExportFile(
+			export,
+			entities.ExportFile{
+				Path: fmt.Sprintf(exportLegacyPhotoPathFormat, plant.ID),
+				Data: plant.Photo,
+			},
+		)
+	}
+
+	for _, photo := range photos {
+		exportPlant.Photos = append(
+			exportPlant.Photos,
+			entities.ExportPhoto{
+				File: addExportFile(
+					export,
+					entities.ExportFile{
+						Path:   fmt.Sprintf(exportPhotoPathFormat, photo.FileUniqueID),
+						FileID: photo.FileID,
+					},
+				),
+				Caption: photo.Caption,
+				TakenAt: photo.TakenAt,
+			},
+		)
+	}
+
+	return exportPlant, nil
+}
+
+// addExportFile добавляет фотографию в архив выгрузки и возвращает путь к ней. Обложка обычно есть и в галерее,
+// поэтому одна и та же фотография кладется в архив один раз.
+func addExportFile(export *entities.Export, file entities.ExportFile) string {
+	for _, added := range export.Files {
+		if added.Path == file.Path {
+			return file.Path
+		}
+	}
+
+	export.Files = append(export.Files, file)
+
+	return file.Path
+}
+
+func exportCareTasks(careTasks []entities.CareTask) []entities.ExportCareTask {
+	exportCareTasks := make([]entities.ExportCareTask, 0, len(careTasks))
+	for _, careTask := range careTasks {
+		exportCareTasks = append(
+			exportCareTasks,
+			entities.ExportCareTask{
+				Type:         careTask.Type,
+				Interval:     careTask.Interval,
+				LastCareDate: careTask.LastCareDate,
+				NextCareDate: careTask.NextCareDate,
+			},
+		)
+	}
+
+	return exportCareTasks
+}
+
+// getAllPages запрашивает страницы по exportPageSize записей, пока не получит неполную страницу.
+func getAllPages[T any](getPage func(limit, offset int) ([]T, error)) ([]T, error) {
+	var rows []T
+
+	for {
+		page, err := getPage(exportPageSize, len(rows))
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, page...)
+		if len(page) < exportPageSize {
+			return rows, nil
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package usecases
+
+import (
+	"context"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestExportUseCases_ExportUserData(t *testing.T) {
+	lastWateringDate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	nextWateringDate := lastWateringDate.AddDate(0, 0, 7)
+	acknowledgedAt := lastWateringDate.Add(time.Hour)
+	plantInterval := 3
+	plantID := 10
+	movedPlantID := 11
+
+	group := entities.Group{
+		ID:               1,
+		UserID:           1,
+		Title:            "Кухня",
+		Description:      "На подоконнике",
+		LastWateringDate: lastWateringDate,
+		NextWateringDate: nextWateringDate,
+		WateringInterval: 7,
+	}
+	otherGroup := entities.Group{ID: 2, UserID: 2, Title: "Спальня", WateringInterval: 5}
+	plant := entities.Plant{
+		ID:                plantID,
+		GroupID:           1,
+		Title:             "Фикус",
+		WateringInterval:  &plantInterval,
+		PhotoFileID:       "cover-file-id",
+		PhotoFileUniqueID: "cover",
+	}
+	legacyPlant := entities.Plant{ID: 12, GroupID: 1, Title: "Кактус", Photo: []byte("legacy")}
+	movedPlant := entities.Plant{ID: movedPlantID, GroupID: 2, Title: "Монстера"}
+	careTask := entities.CareTask{
+		ID:           1,
+		PlantID:      &plantID,
+		Type:         entities.CareTaskTypeMisting,
+		Interval:     2,
+		LastCareDate: lastWateringDate,
+		NextCareDate: lastWateringDate.AddDate(0, 0, 2),
+	}
+
+	setupSuccessMocks := func(storage *mockstorage.MockStorage) {
+		storage.EXPECT().GetUserGroups(gomock.Any(), 1).Return([]entities.Group{group, otherGroup}, nil)
+		storage.EXPECT().GetGroupPlants(gomock.Any(), 1).Return([]entities.Plant{plant, legacyPlant}, nil)
+		storage.EXPECT().GetGroupPlants(gomock.Any(), 2).Return([]entities.Plant{movedPlant}, nil)
+
+		storage.EXPECT().GetGroupCareTasks(gomock.Any(), 1).Return(nil, nil)
+		storage.EXPECT().GetGroupCareTasks(gomock.Any(), 2).Return(nil, nil)
+		storage.EXPECT().GetPlantCareTasks(gomock.Any(), plantID).Return([]entities.CareTask{careTask}, nil)
+		storage.EXPECT().GetPlantCareTasks(gomock.Any(), 12).Return(nil, nil)
+		storage.EXPECT().GetPlantCareTasks(gomock.Any(), movedPlantID).Return(nil, nil)
+
+		// Полная страница поливов требует запроса следующей:
+		fullPage := make([]entities.Watering, exportPageSize)
+		for i := range fullPage {
+			fullPage[i] = entities.Watering{GroupID: 1, WateredAt: lastWateringDate, Source: entities.WateringSourceManual}
+		}
+
+		storage.EXPECT().GetGroupWaterings(gomock.Any(), 1, exportPageSize, 0).Return(fullPage, nil)
+		storage.EXPECT().GetGroupWaterings(gomock.Any(), 1, exportPageSize, exportPageSize).Return(
+			[]entities.Watering{
+				{GroupID: 1, PlantID: &movedPlantID, WateredAt: lastWateringDate, Source: entities.WateringSourceManual},
+			},
+			nil,
+		)
+		storage.EXPECT().GetGroupWaterings(gomock.Any(), 2, exportPageSize, 0).Return(nil, nil)
+
+		storage.EXPECT().GetGroupNotificationsSince(gomock.Any(), 1, time.Time{}).Return(
+			[]entities.Notification{
+				{ID: 1, GroupID: 1, Text: "Пора полить", SentAt: lastWateringDate, AcknowledgedAt: &acknowledgedAt},
+			},
+			nil,
+		)
+		storage.EXPECT().GetGroupNotificationsSince(gomock.Any(), 2, time.Time{}).Return(nil, nil)
+
+		storage.EXPECT().GetPlantPhotos(gomock.Any(), plantID, exportPageSize, 0).Return(
+			[]entities.PlantPhoto{
+				{ID: 1, PlantID: plantID, FileID: "first-file-id", FileUniqueID: "first", TakenAt: lastWateringDate},
+				{ID: 2, PlantID: plantID, FileID: "cover-file-id", FileUniqueID: "cover", Caption: "Подрос"},
+			},
+			nil,
+		)
+		storage.EXPECT().GetPlantPhotos(gomock.Any(), 12, exportPageSize, 0).Return(nil, nil)
+		storage.EXPECT().GetPlantPhotos(gomock.Any(), movedPlantID, exportPageSize, 0).Return(nil, nil)
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		validate   func(t *testing.T, export *entities.Export)
+		wantErr    bool
+	}{
+		{
+			name: "Success - groups exported with plants, history and photos",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				setupSuccessMocks(storage)
+			},
+			validate: func(t *testing.T, export *entities.Export) {
+				assert.Equal(t, entities.ExportVersion, export.Version)
+				require.Len(t, export.Groups, 2)
+
+				kitchen := export.Groups[0]
+				assert.Equal(t, "Кухня", kitchen.Title)
+				assert.Equal(t, "На подоконнике", kitchen.Description)
+				assert.Equal(t, nextWateringDate, kitchen.NextWateringDate)
+				assert.Equal(t, 7, kitchen.WateringInterval)
+				assert.Empty(t, kitchen.CareTasks)
+
+				require.Len(t, kitchen.Plants, 2)
+				assert.Equal(t, &plantInterval, kitchen.Plants[0].WateringInterval)
+				assert.Equal(t, "photos/cover.jpg", kitchen.Plants[0].Photo)
+				assert.Equal(
+					t,
+					[]entities.ExportPhoto{
+						{File: "photos/first.jpg", TakenAt: lastWateringDate},
+						{File: "photos/cover.jpg", Caption: "Подрос"},
+					},
+					kitchen.Plants[0].Photos,
+				)
+				assert.Equal(
+					t,
+					[]entities.ExportCareTask{
+						{
+							Type:         entities.CareTaskTypeMisting,
+							Interval:     2,
+							LastCareDate: careTask.LastCareDate,
+							NextCareDate: careTask.NextCareDate,
+						},
+					},
+					kitchen.Plants[0].CareTasks,
+				)
+				assert.Equal(t, "photos/plant-12.jpg", kitchen.Plants[1].Photo)
+
+				require.Len(t, kitchen.Waterings, exportPageSize+1)
+				assert.Empty(t, kitchen.Waterings[0].Plant)
+				assert.Equal(t, "Монстера", kitchen.Waterings[exportPageSize].Plant)
+
+				assert.Equal(
+					t,
+					[]entities.ExportNotification{
+						{Text: "Пора полить", SentAt: lastWateringDate, AcknowledgedAt: &acknowledgedAt},
+					},
+					kitchen.Notifications,
+				)
+
+				// Обложка совпадает с фотографией из галереи и кладется в архив один раз:
+				assert.Equal(
+					t,
+					[]entities.ExportFile{
+						{Path: "photos/cover.jpg", FileID: "cover-file-id"},
+						{Path: "photos/first.jpg", FileID: "first-file-id"},
+						{Path: "photos/plant-12.jpg", Data: []byte("legacy")},
+					},
+					export.Files,
+				)
+
+				assert.Equal(t, "Спальня", export.Groups[1].Title)
+				assert.Empty(t, export.Groups[1].Waterings)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failure - storage error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetUserGroups(gomock.Any(), 1).Return([]entities.Group{group}, nil)
+				storage.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(nil, assert.AnError)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to export data for User with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mockstorage.NewMockStorage(ctrl)
+			logger := mocklogging.NewMockLogger(ctrl)
+
+			useCases := &exportUseCases{
+				storage: storage,
+				logger:  logger,
+			}
+
+			tt.setupMocks(storage, logger)
+
+			export, err := useCases.ExportUserData(context.Background(), 1)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, export)
+
+				return
+			}
+
+			require.NoError(t, err)
+			tt.validate(t, export)
+		})
+	}
+}
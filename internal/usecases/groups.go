@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -16,8 +17,8 @@ type groupsUseCases struct {
 	logger  logging.Logger
 }
 
-func (u *groupsUseCases) GetUserGroups(userID int) ([]entities.Group, error) {
-	groups, err := u.storage.GetUserGroups(userID)
+func (u *groupsUseCases) GetUserGroups(ctx context.Context, userID int) ([]entities.Group, error) {
+	groups, err := u.storage.GetUserGroups(ctx, userID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get Groups for User with ID=%d", userID),
@@ -31,8 +32,8 @@ func (u *groupsUseCases) GetUserGroups(userID int) ([]entities.Group, error) {
 	return groups, err
 }
 
-func (u *groupsUseCases) CountUserGroups(userID int) (int, error) {
-	count, err := u.storage.CountUserGroups(userID)
+func (u *groupsUseCases) CountUserGroups(ctx context.Context, userID int) (int, error) {
+	count, err := u.storage.CountUserGroups(ctx, userID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to count Groups for User with ID=%d", userID),
@@ -46,25 +47,43 @@ func (u *groupsUseCases) CountUserGroups(userID int) (int, error) {
 	return count, err
 }
 
-func (u *groupsUseCases) CreateGroup(group entities.Group) (*entities.Group, error) {
-	groupID, err := u.storage.CreateGroup(group)
+func (u *groupsUseCases) CreateGroup(ctx context.Context, group entities.Group) (*entities.Group, error) {
+	err := u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			groupID, err := tx.CreateGroup(ctx, group)
+			if err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to create Group for User with ID=%d", group.UserID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			group.ID = groupID
+
+			// Дата последнего полива, указанная при создании, становится первой записью в истории поливов:
+			return u.saveWatering(
+				ctx,
+				tx,
+				group.ID,
+				group.LastWateringDate,
+				entities.WateringSourceManual,
+				group.UserID,
+			)
+		},
+	)
 	if err != nil {
-		u.logger.Error(
-			fmt.Sprintf("Failed to create Group for User with ID=%d", group.UserID),
-			"Error", err,
-			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
-		)
-
 		return nil, err
 	}
 
-	group.ID = groupID
-
-	return &group, err
+	return &group, nil
 }
 
-func (u *groupsUseCases) UpdateGroup(group entities.Group) error {
-	err := u.storage.UpdateGroup(group)
+func (u *groupsUseCases) UpdateGroup(ctx context.Context, group entities.Group) error {
+	err := u.storage.UpdateGroup(ctx, group)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Group for with ID=%d", group.ID),
@@ -76,8 +95,8 @@ func (u *groupsUseCases) UpdateGroup(group entities.Group) error {
 	return err
 }
 
-func (u *groupsUseCases) GetGroup(id int) (*entities.Group, error) {
-	group, err := u.storage.GetGroup(id)
+func (u *groupsUseCases) GetGroup(ctx context.Context, id int) (*entities.Group, error) {
+	group, err := u.storage.GetGroup(ctx, id)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get Group with ID=%d", id),
@@ -91,8 +110,8 @@ func (u *groupsUseCases) GetGroup(id int) (*entities.Group, error) {
 	return group, err
 }
 
-func (u *groupsUseCases) GetGroupsForNotify(limit, offset int) ([]entities.Group, error) {
-	groups, err := u.storage.GetGroupsForNotify(limit, offset)
+func (u *groupsUseCases) GetGroupsForNotify(ctx context.Context, limit, offset int) ([]entities.Group, error) {
+	groups, err := u.storage.GetGroupsForNotify(ctx, limit, offset)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get Groups for Notify with limit=%d and offset=%d", limit, offset),
@@ -106,8 +125,27 @@ func (u *groupsUseCases) GetGroupsForNotify(limit, offset int) ([]entities.Group
 	return groups, err
 }
 
-func (u *groupsUseCases) DeleteGroup(id int) error {
-	err := u.storage.DeleteGroup(id)
+func (u *groupsUseCases) ClaimGroupsForNotify(
+	ctx context.Context,
+	limit int,
+	claimTTL time.Duration,
+) ([]entities.Group, error) {
+	groups, err := u.storage.ClaimGroupsForNotify(ctx, limit, claimTTL)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to claim Groups for Notify with limit=%d", limit),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return groups, err
+}
+
+func (u *groupsUseCases) DeleteGroup(ctx context.Context, id int) error {
+	err := u.storage.DeleteGroup(ctx, id)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to delete Group with ID=%d", id),
@@ -119,15 +157,15 @@ func (u *groupsUseCases) DeleteGroup(id int) error {
 	return err
 }
 
-func (u *groupsUseCases) UpdateGroupTitle(id int, title string) (*entities.Group, error) {
-	group, err := u.GetGroup(id)
+func (u *groupsUseCases) UpdateGroupTitle(ctx context.Context, id int, title string) (*entities.Group, error) {
+	group, err := u.GetGroup(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	group.Title = title
 
-	exists, err := u.storage.GroupExists(*group)
+	exists, err := u.storage.GroupExists(ctx, *group)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to check existence for Group with ID=%d", group.ID),
@@ -142,7 +180,7 @@ func (u *groupsUseCases) UpdateGroupTitle(id int, title string) (*entities.Group
 		return nil, customerrors.ErrGroupAlreadyExists
 	}
 
-	if err = u.storage.UpdateGroup(*group); err != nil {
+	if err = u.storage.UpdateGroup(ctx, *group); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
 			"Error", err,
@@ -155,14 +193,18 @@ func (u *groupsUseCases) UpdateGroupTitle(id int, title string) (*entities.Group
 	return group, err
 }
 
-func (u *groupsUseCases) UpdateGroupDescription(id int, description string) (*entities.Group, error) {
-	group, err := u.GetGroup(id)
+func (u *groupsUseCases) UpdateGroupDescription(
+	ctx context.Context,
+	id int,
+	description string,
+) (*entities.Group, error) {
+	group, err := u.GetGroup(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	group.Description = description
-	if err = u.storage.UpdateGroup(*group); err != nil {
+	if err = u.storage.UpdateGroup(ctx, *group); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
 			"Error", err,
@@ -175,26 +217,102 @@ func (u *groupsUseCases) UpdateGroupDescription(id int, description string) (*en
 	return group, err
 }
 
-func (u *groupsUseCases) UpdateGroupLastWateringDate(id int, lastWateringDate time.Time) (*entities.Group, error) {
-	group, err := u.GetGroup(id)
+// UpdateGroupLastWateringDate отмечает полив сценария участником дома с userID.
+func (u *groupsUseCases) UpdateGroupLastWateringDate(
+	ctx context.Context,
+	id int,
+	lastWateringDate time.Time,
+	source string,
+	userID int,
+) (*entities.Group, error) {
+	group, err := u.GetGroup(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	nextWateringDate := lastWateringDate.AddDate(0, 0, group.WateringInterval)
+	group.LastWateringDate = lastWateringDate
 
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, nextWateringDate.Location())
+	// Интервал определяется сезоном, в который был выполнен полив, и растениями с собственным графиком:
+	group.NextWateringDate, err = u.getNextWateringDate(ctx, *group)
+	if err != nil {
+		return nil, err
+	}
 
-	if nextWateringDate.Before(today) {
-		nextWateringDate = today
+	// Полив отменяет отложенное напоминание:
+	group.SnoozedUntil = nil
+
+	err = u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			if err := tx.UpdateGroup(ctx, *group); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return u.saveWatering(ctx, tx, group.ID, lastWateringDate, source, userID)
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	group.LastWateringDate = lastWateringDate
+	return group, nil
+}
+
+func (u *groupsUseCases) UpdateGroupWateringInterval(
+	ctx context.Context,
+	id, wateringInterval int,
+) (*entities.Group, error) {
+	group, err := u.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	group.WateringInterval = wateringInterval
+
+	// Основной интервал может быть перекрыт сезоном, в который был выполнен последний полив:
+	group.NextWateringDate, err = u.getNextWateringDate(ctx, *group)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.storage.UpdateGroup(ctx, *group); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return group, err
+}
+
+// UpdateGroupSeasonSchedule сохраняет сезонный график и пересчитывает дату следующего полива.
+func (u *groupsUseCases) UpdateGroupSeasonSchedule(
+	ctx context.Context,
+	id int,
+	seasonSchedule entities.SeasonSchedule,
+) (*entities.Group, error) {
+	group, err := u.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	group.SeasonSchedule = seasonSchedule
 
-	group.NextWateringDate = nextWateringDate
+	group.NextWateringDate, err = u.getNextWateringDate(ctx, *group)
+	if err != nil {
+		return nil, err
+	}
 
-	if err = u.storage.UpdateGroup(*group); err != nil {
+	if err = u.storage.UpdateGroup(ctx, *group); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
 			"Error", err,
@@ -207,26 +325,51 @@ func (u *groupsUseCases) UpdateGroupLastWateringDate(id int, lastWateringDate ti
 	return group, err
 }
 
-func (u *groupsUseCases) UpdateGroupWateringInterval(id, wateringInterval int) (*entities.Group, error) {
-	group, err := u.GetGroup(id)
+func (u *groupsUseCases) SnoozeGroup(ctx context.Context, id int, until time.Time) (*entities.Group, error) {
+	group, err := u.GetGroup(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	nextWateringDate := group.LastWateringDate.AddDate(0, 0, wateringInterval)
+	snoozedUntil := until.UTC()
+	group.SnoozedUntil = &snoozedUntil
+
+	if err = u.storage.UpdateGroup(ctx, *group); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return group, err
+}
+
+// SkipGroupWatering переносит дату следующего полива на один интервал вперед, не отмечая полив.
+func (u *groupsUseCases) SkipGroupWatering(ctx context.Context, id int) (*entities.Group, error) {
+	group, err := u.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, nextWateringDate.Location())
+	today, err := u.getUserToday(ctx, group.UserID, group.NextWateringDate.Location())
+	if err != nil {
+		return nil, err
+	}
 
+	// Отсчитываем интервал от сегодняшнего дня, если полив уже просрочен:
+	nextWateringDate := group.NextWateringDate
 	if nextWateringDate.Before(today) {
 		nextWateringDate = today
 	}
 
-	group.WateringInterval = wateringInterval
+	group.NextWateringDate = nextWateringDate.AddDate(0, 0, group.GetWateringInterval(nextWateringDate))
 
-	group.NextWateringDate = nextWateringDate
+	group.SnoozedUntil = nil
 
-	if err = u.storage.UpdateGroup(*group); err != nil {
+	if err = u.storage.UpdateGroup(ctx, *group); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
 			"Error", err,
@@ -238,3 +381,74 @@ func (u *groupsUseCases) UpdateGroupWateringInterval(id, wateringInterval int) (
 
 	return group, err
 }
+
+// getUserToday возвращает начало текущего дня в часовом поясе владельца сценария.
+func (u *groupsUseCases) getUserToday(ctx context.Context, userID int, location *time.Location) (time.Time, error) {
+	return getUserToday(ctx, u.storage, u.logger, userID, location)
+}
+
+// getNextWateringDate возвращает дату следующего полива сценария с учетом его растений.
+func (u *groupsUseCases) getNextWateringDate(ctx context.Context, group entities.Group) (time.Time, error) {
+	return getGroupNextWateringDate(ctx, u.storage, u.logger, group)
+}
+
+func (u *groupsUseCases) saveWatering(
+	ctx context.Context,
+	storage interfaces.Storage,
+	groupID int,
+	wateredAt time.Time,
+	source string,
+	userID int,
+) error {
+	watering := entities.Watering{
+		GroupID:   groupID,
+		WateredAt: wateredAt,
+		Source:    source,
+		UserID:    &userID,
+	}
+
+	if _, err := storage.SaveWatering(ctx, watering); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to save Watering for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// getGroupNextWateringDate рассчитывает дату следующего полива сценария с учетом растений с собственным графиком
+// полива. Просроченная дата переносится на сегодняшний день владельца сценария.
+func getGroupNextWateringDate(
+	ctx context.Context,
+	storage interfaces.Storage,
+	logger logging.Logger,
+	group entities.Group,
+) (time.Time, error) {
+	plants, err := storage.GetGroupPlants(ctx, group.ID)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("Failed to get Plants for Group with ID=%d", group.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return time.Time{}, err
+	}
+
+	nextWateringDate := group.GetNextWateringDate(plants)
+
+	today, err := getUserToday(ctx, storage, logger, group.UserID, nextWateringDate.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if nextWateringDate.Before(today) {
+		nextWateringDate = today
+	}
+
+	return nextWateringDate, nil
+}
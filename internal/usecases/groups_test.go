@@ -1,9 +1,11 @@
 package usecases
 
 import (
+	"context"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
 	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -13,7 +15,18 @@ import (
 
 func TestGroupsUseCases_UpdateGroupLastWateringDate(t *testing.T) {
 	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	utcNow := now.UTC()
+	today := time.Date(utcNow.Year(), utcNow.Month(), utcNow.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kiritimatiNow := now.In(kiritimati)
+	kiritimatiToday := time.Date(kiritimatiNow.Year(), kiritimatiNow.Month(), kiritimatiNow.Day(), 0, 0, 0, 0, time.UTC)
+	snoozedUntil := utcNow.Add(time.Hour)
 
 	baseGroup := entities.Group{
 		ID:               1,
@@ -24,6 +37,7 @@ func TestGroupsUseCases_UpdateGroupLastWateringDate(t *testing.T) {
 		NextWateringDate: time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
 		CreatedAt:        now,
 		UpdatedAt:        now,
+		SnoozedUntil:     &snoozedUntil, // Полив должен сбрасывать отложенное напоминание
 	}
 
 	tests := []struct {
@@ -41,17 +55,41 @@ func TestGroupsUseCases_UpdateGroupLastWateringDate(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
+
+				storage.
+					EXPECT().
+					SaveWatering(
+						gomock.Any(),
+						gomock.Cond(func(w entities.Watering) bool {
+							return w.GroupID == 1 && w.PlantID == nil && w.Source == entities.WateringSourceManual &&
+								w.UserID != nil && *w.UserID == 2
+						}),
+					).
+					Return(1, nil).
+					Times(1)
 			},
-			wantNextWateringDate: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+			wantNextWateringDate: today,
 			wantErr:              false,
 		},
 		{
@@ -61,15 +99,39 @@ func TestGroupsUseCases_UpdateGroupLastWateringDate(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
+
+				storage.
+					EXPECT().
+					SaveWatering(
+						gomock.Any(),
+						gomock.Cond(func(w entities.Watering) bool {
+							return w.GroupID == 1 && w.PlantID == nil && w.Source == entities.WateringSourceManual &&
+								w.UserID != nil && *w.UserID == 2
+						}),
+					).
+					Return(1, nil).
+					Times(1)
 			},
 			wantNextWateringDate: today,
 			wantErr:              false,
@@ -81,7 +143,7 @@ func TestGroupsUseCases_UpdateGroupLastWateringDate(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -104,13 +166,25 @@ func TestGroupsUseCases_UpdateGroupLastWateringDate(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -126,144 +200,124 @@ func TestGroupsUseCases_UpdateGroupLastWateringDate(t *testing.T) {
 			wantNextWateringDate: time.Time{},
 			wantErr:              true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			mockStorage := mockstorage.NewMockStorage(ctrl)
-			mockLogger := mocklogging.NewMockLogger(ctrl)
-
-			if tt.setupMocks != nil {
-				tt.setupMocks(mockStorage, mockLogger)
-			}
-
-			useCases := &groupsUseCases{
-				storage: mockStorage,
-				logger:  mockLogger,
-			}
-
-			got, err := useCases.UpdateGroupLastWateringDate(tt.id, tt.lastWateringDate)
-
-			if tt.wantErr {
-				assert.Nil(t, got)
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, got)
-				assert.Equal(t, tt.wantNextWateringDate, got.NextWateringDate)
-			}
-		})
-	}
-}
-
-func TestGroupsUseCases_UpdateGroupWateringInterval(t *testing.T) {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-	baseGroup := entities.Group{
-		ID:               1,
-		UserID:           123,
-		Title:            "Цветы",
-		WateringInterval: 7,
-		LastWateringDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
-		NextWateringDate: time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
-		CreatedAt:        now,
-		UpdatedAt:        now,
-	}
-
-	tests := []struct {
-		name                 string
-		id                   int
-		wateringInterval     int
-		setupMocks           func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
-		wantNextWateringDate time.Time
-		wantErr              bool
-	}{
 		{
-			name:             "Success - next watering date in future",
+			name:             "Failure - owner not found",
 			id:               1,
-			wateringInterval: 10,
+			lastWateringDate: time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC),
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
-					Return(nil).
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
 					Times(1)
 			},
-			wantNextWateringDate: today,
-			wantErr:              false,
+			wantNextWateringDate: time.Time{},
+			wantErr:              true,
 		},
 		{
-			name:             "Success - new interval causes past date → set to today",
+			name:             "Success - today is calculated in owner's timezone",
 			id:               1,
-			wateringInterval: 1,
+			lastWateringDate: time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC),
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
-					Return(nil).
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
 					Times(1)
-			},
-			wantNextWateringDate: today,
-			wantErr:              false,
-		},
-		{
-			name:             "Failure - group not found",
-			id:               1,
-			wateringInterval: 5,
-			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+
 				storage.
 					EXPECT().
-					GetGroup(1).
-					Return(nil, assert.AnError).
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, Timezone: "Pacific/Kiritimati"}, nil).
 					Times(1)
 
-				logger.
+				storage.
 					EXPECT().
-					Error(
-						"Failed to get Group with ID=1",
-						"Error", assert.AnError,
-						"Tracing", gomock.Any(),
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					SaveWatering(
+						gomock.Any(),
+						gomock.Cond(func(w entities.Watering) bool {
+							return w.GroupID == 1 && w.PlantID == nil && w.Source == entities.WateringSourceManual &&
+								w.UserID != nil && *w.UserID == 2
+						}),
 					).
+					Return(1, nil).
 					Times(1)
 			},
-			wantNextWateringDate: time.Time{},
-			wantErr:              true,
+			wantNextWateringDate: kiritimatiToday,
+			wantErr:              false,
 		},
 		{
-			name:             "Failure - storage update error",
+			name:             "Failure - save watering error",
 			id:               1,
-			wateringInterval: 5,
+			lastWateringDate: time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC),
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
-					Return(assert.AnError).
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					SaveWatering(gomock.Any(), gomock.Any()).
+					Return(0, assert.AnError).
 					Times(1)
 
 				logger.
 					EXPECT().
 					Error(
-						"Failed to update Group with ID=1",
+						"Failed to save Watering for Group with ID=1",
 						"Error", assert.AnError,
 						"Tracing", gomock.Any(),
 					).
@@ -280,6 +334,17 @@ func TestGroupsUseCases_UpdateGroupWateringInterval(t *testing.T) {
 			mockStorage := mockstorage.NewMockStorage(ctrl)
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
 			if tt.setupMocks != nil {
 				tt.setupMocks(mockStorage, mockLogger)
 			}
@@ -289,7 +354,7 @@ func TestGroupsUseCases_UpdateGroupWateringInterval(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.UpdateGroupWateringInterval(tt.id, tt.wateringInterval)
+			got, err := useCases.UpdateGroupLastWateringDate(context.Background(), tt.id, tt.lastWateringDate, entities.WateringSourceManual, 2)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -298,60 +363,896 @@ func TestGroupsUseCases_UpdateGroupWateringInterval(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, got)
 				assert.Equal(t, tt.wantNextWateringDate, got.NextWateringDate)
+				assert.Nil(t, got.SnoozedUntil)
 			}
 		})
 	}
 }
 
-func TestGroupsUseCases_GetUserGroups(t *testing.T) {
-	now := time.Now()
+func TestGroupsUseCases_UpdateGroupLastWateringDate_SeasonSchedule(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+
+	// Сезон, в который входит текущий месяц, и сезон, в который он не входит:
+	currentSeason := entities.Season{StartMonth: today.Month(), EndMonth: today.Month(), WateringInterval: 21}
+	otherMonth := today.AddDate(0, 6, 0).Month()
+	otherSeason := entities.Season{StartMonth: otherMonth, EndMonth: otherMonth, WateringInterval: 3}
 
 	tests := []struct {
-		name       string
-		userID     int
-		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
-		want       []entities.Group
-		wantErr    bool
+		name                 string
+		seasonSchedule       entities.SeasonSchedule
+		wantNextWateringDate time.Time
 	}{
 		{
-			name:   "Success - returns groups",
-			userID: 123,
+			name:                 "Success - interval of active season is used",
+			seasonSchedule:       entities.SeasonSchedule{currentSeason, otherSeason},
+			wantNextWateringDate: today.AddDate(0, 0, 21),
+		},
+		{
+			name:                 "Success - base interval is used outside of seasons",
+			seasonSchedule:       entities.SeasonSchedule{otherSeason},
+			wantNextWateringDate: today.AddDate(0, 0, 7),
+		},
+		{
+			name:                 "Success - base interval is used without season schedule",
+			seasonSchedule:       nil,
+			wantNextWateringDate: today.AddDate(0, 0, 7),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
+			group := &entities.Group{
+				ID:               1,
+				UserID:           123,
+				WateringInterval: 7,
+				LastWateringDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+				NextWateringDate: time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+				SeasonSchedule:   tt.seasonSchedule,
+			}
+
+			mockStorage.EXPECT().GetGroup(gomock.Any(), 1).Return(group, nil).Times(1)
+			mockStorage.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(nil, nil).Times(1)
+			mockStorage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+			mockStorage.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			mockStorage.EXPECT().SaveWatering(gomock.Any(), gomock.Any()).Return(1, nil).Times(1)
+
+			useCases := &groupsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateGroupLastWateringDate(context.Background(), 1, today, entities.WateringSourceNotification, 2)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNextWateringDate, got.NextWateringDate)
+		})
+	}
+}
+
+func TestGroupsUseCases_UpdateGroupLastWateringDate_PlantOverrides(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+	shortInterval := 3
+	longInterval := 14
+
+	tests := []struct {
+		name                 string
+		plants               []entities.Plant
+		wantNextWateringDate time.Time
+	}{
+		{
+			name:                 "Success - thirsty plant brings next watering date closer",
+			plants:               []entities.Plant{{ID: 1}, {ID: 2, WateringInterval: &shortInterval}},
+			wantNextWateringDate: today.AddDate(0, 0, 3),
+		},
+		{
+			name:                 "Success - group interval is used while plants inherit it",
+			plants:               []entities.Plant{{ID: 1}, {ID: 2, WateringInterval: &longInterval}},
+			wantNextWateringDate: today.AddDate(0, 0, 7),
+		},
+		{
+			name:                 "Success - only plants with own intervals",
+			plants:               []entities.Plant{{ID: 2, WateringInterval: &longInterval}},
+			wantNextWateringDate: today.AddDate(0, 0, 14),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
+			group := &entities.Group{
+				ID:               1,
+				UserID:           123,
+				WateringInterval: 7,
+				LastWateringDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+				NextWateringDate: time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+			}
+
+			mockStorage.EXPECT().GetGroup(gomock.Any(), 1).Return(group, nil).Times(1)
+			mockStorage.EXPECT().GetGroupPlants(gomock.Any(), 1).Return(tt.plants, nil).Times(1)
+			mockStorage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+			mockStorage.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			mockStorage.EXPECT().SaveWatering(gomock.Any(), gomock.Any()).Return(1, nil).Times(1)
+
+			useCases := &groupsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateGroupLastWateringDate(context.Background(), 1, today, entities.WateringSourceNotification, 2)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNextWateringDate, got.NextWateringDate)
+		})
+	}
+}
+
+func TestGroupsUseCases_UpdateGroupSeasonSchedule(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+	seasonSchedule := entities.SeasonSchedule{
+		{StartMonth: today.Month(), EndMonth: today.Month(), WateringInterval: 14},
+	}
+
+	newGroup := func() *entities.Group {
+		return &entities.Group{
+			ID:               1,
+			UserID:           123,
+			WateringInterval: 7,
+			LastWateringDate: today,
+			NextWateringDate: today.AddDate(0, 0, 7),
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		setupMocks           func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantNextWateringDate time.Time
+		wantErr              bool
+	}{
+		{
+			name: "Success - next watering date recalculated with season interval",
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserGroups(123).
-					Return([]entities.Group{
+					GetGroup(gomock.Any(), 1).
+					Return(newGroup(), nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(
+						gomock.Any(),
+						gomock.Cond(func(group entities.Group) bool {
+							return len(group.SeasonSchedule) == 1 &&
+								group.NextWateringDate.Equal(today.AddDate(0, 0, 14))
+						}),
+					).
+					Return(nil).
+					Times(1)
+			},
+			wantNextWateringDate: today.AddDate(0, 0, 14),
+			wantErr:              false,
+		},
+		{
+			name: "Failure - group not found",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failure - storage update error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(newGroup(), nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &groupsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateGroupSeasonSchedule(context.Background(), 1, seasonSchedule)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantNextWateringDate, got.NextWateringDate)
+				assert.Equal(t, seasonSchedule, got.SeasonSchedule)
+			}
+		})
+	}
+}
+
+func TestGroupsUseCases_UpdateGroupWateringInterval(t *testing.T) {
+	now := time.Now()
+	utcNow := now.UTC()
+	today := time.Date(utcNow.Year(), utcNow.Month(), utcNow.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kiritimatiNow := now.In(kiritimati)
+	kiritimatiToday := time.Date(kiritimatiNow.Year(), kiritimatiNow.Month(), kiritimatiNow.Day(), 0, 0, 0, 0, time.UTC)
+
+	baseGroup := entities.Group{
+		ID:               1,
+		UserID:           123,
+		Title:            "Цветы",
+		WateringInterval: 7,
+		LastWateringDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+		NextWateringDate: time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	tests := []struct {
+		name                 string
+		id                   int
+		wateringInterval     int
+		setupMocks           func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantNextWateringDate time.Time
+		wantErr              bool
+	}{
+		{
+			name:             "Success - next watering date in future",
+			id:               1,
+			wateringInterval: 10,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(&baseGroup, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			wantNextWateringDate: today,
+			wantErr:              false,
+		},
+		{
+			name:             "Success - new interval causes past date → set to today",
+			id:               1,
+			wateringInterval: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(&baseGroup, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			wantNextWateringDate: today,
+			wantErr:              false,
+		},
+		{
+			name:             "Failure - group not found",
+			id:               1,
+			wateringInterval: 5,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantNextWateringDate: time.Time{},
+			wantErr:              true,
+		},
+		{
+			name:             "Failure - storage update error",
+			id:               1,
+			wateringInterval: 5,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(&baseGroup, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantNextWateringDate: time.Time{},
+			wantErr:              true,
+		},
+		{
+			name:             "Failure - owner not found",
+			id:               1,
+			wateringInterval: 5,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(&baseGroup, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantNextWateringDate: time.Time{},
+			wantErr:              true,
+		},
+		{
+			name:             "Success - today is calculated in owner's timezone",
+			id:               1,
+			wateringInterval: 5,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(&baseGroup, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 1).
+					Return(nil, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, Timezone: "Pacific/Kiritimati"}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			wantNextWateringDate: kiritimatiToday,
+			wantErr:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &groupsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateGroupWateringInterval(context.Background(), tt.id, tt.wateringInterval)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+				assert.Equal(t, tt.wantNextWateringDate, got.NextWateringDate)
+			}
+		})
+	}
+}
+
+func TestGroupsUseCases_GetUserGroups(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		userID     int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       []entities.Group
+		wantErr    bool
+	}{
+		{
+			name:   "Success - returns groups",
+			userID: 123,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserGroups(gomock.Any(), 123).
+					Return([]entities.Group{
 						{ID: 1, UserID: 123, Title: "Цветы", CreatedAt: now},
 						{ID: 2, UserID: 123, Title: "Суккуленты", CreatedAt: now},
 					}, nil).
 					Times(1)
 			},
-			want: []entities.Group{
-				{ID: 1, UserID: 123, Title: "Цветы", CreatedAt: now},
-				{ID: 2, UserID: 123, Title: "Суккуленты", CreatedAt: now},
-			},
+			want: []entities.Group{
+				{ID: 1, UserID: 123, Title: "Цветы", CreatedAt: now},
+				{ID: 2, UserID: 123, Title: "Суккуленты", CreatedAt: now},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Failure - storage error",
+			userID: 123,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserGroups(gomock.Any(), 123).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Groups for User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &groupsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetUserGroups(context.Background(), tt.userID)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestGroupsUseCases_CountUserGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       int
+		wantErr    bool
+	}{
+		{
+			name:   "Success - returns count",
+			userID: 123,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CountUserGroups(gomock.Any(), 123).
+					Return(5, nil).
+					Times(1)
+			},
+			want:    5,
+			wantErr: false,
+		},
+		{
+			name:   "Failure - storage error",
+			userID: 123,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CountUserGroups(gomock.Any(), 123).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to count Groups for User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &groupsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.CountUserGroups(context.Background(), tt.userID)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestGroupsUseCases_CreateGroup(t *testing.T) {
+	ownerID := 123
+	inputGroup := entities.Group{
+		UserID:           123,
+		Title:            "Цветы",
+		Description:      "Комнатные растения",
+		WateringInterval: 7,
+	}
+
+	tests := []struct {
+		name       string
+		group      entities.Group
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       *entities.Group
+		wantErr    bool
+	}{
+		{
+			name:  "Success - group created",
+			group: inputGroup,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CreateGroup(gomock.Any(), inputGroup).
+					Return(1, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					SaveWatering(gomock.Any(), entities.Watering{GroupID: 1, Source: entities.WateringSourceManual, UserID: &ownerID}).
+					Return(1, nil).
+					Times(1)
+			},
+			want: &entities.Group{
+				ID:               1,
+				UserID:           123,
+				Title:            "Цветы",
+				Description:      "Комнатные растения",
+				WateringInterval: 7,
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Failure - storage error",
+			group: inputGroup,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CreateGroup(gomock.Any(), inputGroup).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to create Group for User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:  "Failure - save watering error",
+			group: inputGroup,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CreateGroup(gomock.Any(), inputGroup).
+					Return(1, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					SaveWatering(gomock.Any(), gomock.Any()).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to save Watering for Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &groupsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.CreateGroup(context.Background(), tt.group)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want.ID, got.ID)
+				assert.Equal(t, tt.want.UserID, got.UserID)
+				assert.Equal(t, tt.want.Title, got.Title)
+			}
+		})
+	}
+}
+
+func TestGroupsUseCases_UpdateGroup(t *testing.T) {
+	updatedGroup := entities.Group{
+		ID:               1,
+		UserID:           123,
+		Title:            "Обновлённые цветы",
+		Description:      "Обновлённое описание",
+		WateringInterval: 10,
+		LastWateringDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+		NextWateringDate: time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name       string
+		group      entities.Group
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    bool
+	}{
+		{
+			name:  "Success - group updated",
+			group: updatedGroup,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), updatedGroup).
+					Return(nil).
+					Times(1)
+			},
 			wantErr: false,
 		},
 		{
-			name:   "Failure - storage error",
-			userID: 123,
+			name:  "Failure - storage error",
+			group: updatedGroup,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserGroups(123).
-					Return(nil, assert.AnError).
+					UpdateGroup(gomock.Any(), updatedGroup).
+					Return(assert.AnError).
 					Times(1)
 
 				logger.
 					EXPECT().
 					Error(
-						"Failed to get Groups for User with ID=123",
+						"Failed to update Group for with ID=1",
 						"Error", assert.AnError,
 						"Tracing", gomock.Any(),
 					).
 					Times(1)
 			},
-			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -371,55 +1272,65 @@ func TestGroupsUseCases_GetUserGroups(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetUserGroups(tt.userID)
+			err := useCases.UpdateGroup(context.Background(), tt.group)
 
-			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
 }
 
-func TestGroupsUseCases_CountUserGroups(t *testing.T) {
+func TestGroupsUseCases_GetGroup(t *testing.T) {
+	now := time.Now()
+	group := entities.Group{
+		ID:               1,
+		UserID:           123,
+		Title:            "Цветы",
+		Description:      "Комнатные растения",
+		WateringInterval: 7,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
 	tests := []struct {
 		name       string
-		userID     int
+		id         int
 		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
-		want       int
+		want       *entities.Group
 		wantErr    bool
 	}{
 		{
-			name:   "Success - returns count",
-			userID: 123,
+			name: "Success - group found",
+			id:   1,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CountUserGroups(123).
-					Return(5, nil).
+					GetGroup(gomock.Any(), 1).
+					Return(&group, nil).
 					Times(1)
 			},
-			want:    5,
+			want:    &group,
 			wantErr: false,
 		},
 		{
-			name:   "Failure - storage error",
-			userID: 123,
+			name: "Failure - storage error",
+			id:   1,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CountUserGroups(123).
-					Return(0, assert.AnError).
+					GetGroup(gomock.Any(), 1).
+					Return(nil, assert.AnError).
 					Times(1)
 
 				logger.
 					EXPECT().
 					Error(
-						"Failed to count Groups for User with ID=123",
+						"Failed to get Group with ID=1",
 						"Error", assert.AnError,
 						"Tracing", gomock.Any(),
 					).
 					Times(1)
 			},
-			want:    0,
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -439,7 +1350,7 @@ func TestGroupsUseCases_CountUserGroups(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.CountUserGroups(tt.userID)
+			got, err := useCases.GetGroup(context.Background(), tt.id)
 
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, err != nil)
@@ -447,54 +1358,49 @@ func TestGroupsUseCases_CountUserGroups(t *testing.T) {
 	}
 }
 
-func TestGroupsUseCases_CreateGroup(t *testing.T) {
-	inputGroup := entities.Group{
-		UserID:           123,
-		Title:            "Цветы",
-		Description:      "Комнатные растения",
-		WateringInterval: 7,
+func TestGroupsUseCases_GetGroupsForNotify(t *testing.T) {
+	now := time.Now()
+	groups := []entities.Group{
+		{ID: 1, UserID: 123, Title: "Цветы", NextWateringDate: now},
 	}
 
 	tests := []struct {
 		name       string
-		group      entities.Group
+		limit      int
+		offset     int
 		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
-		want       *entities.Group
+		want       []entities.Group
 		wantErr    bool
 	}{
 		{
-			name:  "Success - group created",
-			group: inputGroup,
+			name:   "Success - returns groups for notify",
+			limit:  10,
+			offset: 0,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CreateGroup(inputGroup).
-					Return(1, nil).
+					GetGroupsForNotify(gomock.Any(), 10, 0).
+					Return(groups, nil).
 					Times(1)
 			},
-			want: &entities.Group{
-				ID:               1,
-				UserID:           123,
-				Title:            "Цветы",
-				Description:      "Комнатные растения",
-				WateringInterval: 7,
-			},
+			want:    groups,
 			wantErr: false,
 		},
 		{
-			name:  "Failure - storage error",
-			group: inputGroup,
+			name:   "Failure - storage error",
+			limit:  10,
+			offset: 0,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CreateGroup(inputGroup).
-					Return(0, assert.AnError).
+					GetGroupsForNotify(gomock.Any(), 10, 0).
+					Return(nil, assert.AnError).
 					Times(1)
 
 				logger.
 					EXPECT().
 					Error(
-						"Failed to create Group for User with ID=123",
+						"Failed to get Groups for Notify with limit=10 and offset=0",
 						"Error", assert.AnError,
 						"Tracing", gomock.Any(),
 					).
@@ -520,69 +1426,63 @@ func TestGroupsUseCases_CreateGroup(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.CreateGroup(tt.group)
+			got, err := useCases.GetGroupsForNotify(context.Background(), tt.limit, tt.offset)
 
-			if tt.wantErr {
-				assert.Nil(t, got)
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want.ID, got.ID)
-				assert.Equal(t, tt.want.UserID, got.UserID)
-				assert.Equal(t, tt.want.Title, got.Title)
-			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
 }
 
-func TestGroupsUseCases_UpdateGroup(t *testing.T) {
-	updatedGroup := entities.Group{
-		ID:               1,
-		UserID:           123,
-		Title:            "Обновлённые цветы",
-		Description:      "Обновлённое описание",
-		WateringInterval: 10,
-		LastWateringDate: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
-		NextWateringDate: time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC),
+func TestGroupsUseCases_ClaimGroupsForNotify(t *testing.T) {
+	now := time.Now()
+	groups := []entities.Group{
+		{ID: 1, UserID: 123, Title: "Цветы", NextWateringDate: now, NotifyClaimedAt: &now},
 	}
 
 	tests := []struct {
 		name       string
-		group      entities.Group
+		limit      int
+		claimTTL   time.Duration
 		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       []entities.Group
 		wantErr    bool
 	}{
 		{
-			name:  "Success - group updated",
-			group: updatedGroup,
+			name:     "Success - returns claimed groups",
+			limit:    10,
+			claimTTL: time.Minute,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					UpdateGroup(updatedGroup).
-					Return(nil).
+					ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).
+					Return(groups, nil).
 					Times(1)
 			},
+			want:    groups,
 			wantErr: false,
 		},
 		{
-			name:  "Failure - storage error",
-			group: updatedGroup,
+			name:     "Failure - storage error",
+			limit:    10,
+			claimTTL: time.Minute,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					UpdateGroup(updatedGroup).
-					Return(assert.AnError).
+					ClaimGroupsForNotify(gomock.Any(), 10, time.Minute).
+					Return(nil, assert.AnError).
 					Times(1)
 
 				logger.
 					EXPECT().
 					Error(
-						"Failed to update Group for with ID=1",
+						"Failed to claim Groups for Notify with limit=10",
 						"Error", assert.AnError,
 						"Tracing", gomock.Any(),
 					).
 					Times(1)
 			},
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -602,52 +1502,60 @@ func TestGroupsUseCases_UpdateGroup(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.UpdateGroup(tt.group)
+			got, err := useCases.ClaimGroupsForNotify(context.Background(), tt.limit, tt.claimTTL)
 
+			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
 }
 
-func TestGroupsUseCases_GetGroup(t *testing.T) {
-	now := time.Now()
-	group := entities.Group{
-		ID:               1,
-		UserID:           123,
-		Title:            "Цветы",
-		Description:      "Комнатные растения",
-		WateringInterval: 7,
-		CreatedAt:        now,
-		UpdatedAt:        now,
+func TestGroupsUseCases_SnoozeGroup(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
 	}
 
+	until := time.Date(2025, 9, 2, 15, 0, 0, 0, moscow)
+
 	tests := []struct {
 		name       string
 		id         int
 		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
-		want       *entities.Group
 		wantErr    bool
 	}{
 		{
-			name: "Success - group found",
+			name: "Success - snoozed until is saved in UTC",
 			id:   1,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
-					Return(&group, nil).
+					GetGroup(gomock.Any(), 1).
+					Return(&entities.Group{ID: 1, UserID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(
+						gomock.Any(),
+						gomock.Cond(func(group entities.Group) bool {
+							return group.SnoozedUntil != nil &&
+								group.SnoozedUntil.Equal(until) &&
+								group.SnoozedUntil.Location() == time.UTC
+						}),
+					).
+					Return(nil).
 					Times(1)
 			},
-			want:    &group,
 			wantErr: false,
 		},
 		{
-			name: "Failure - storage error",
+			name: "Failure - group not found",
 			id:   1,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -660,7 +1568,33 @@ func TestGroupsUseCases_GetGroup(t *testing.T) {
 					).
 					Times(1)
 			},
-			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failure - storage update error",
+			id:   1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroup(gomock.Any(), 1).
+					Return(&entities.Group{ID: 1, UserID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
 			wantErr: true,
 		},
 	}
@@ -680,63 +1614,114 @@ func TestGroupsUseCases_GetGroup(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetGroup(tt.id)
+			got, err := useCases.SnoozeGroup(context.Background(), tt.id, until)
 
-			assert.Equal(t, tt.want, got)
-			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+				assert.True(t, until.Equal(*got.SnoozedUntil))
+			}
 		})
 	}
 }
 
-func TestGroupsUseCases_GetGroupsForNotify(t *testing.T) {
-	now := time.Now()
-	groups := []entities.Group{
-		{ID: 1, UserID: 123, Title: "Цветы", NextWateringDate: now},
-	}
+func TestGroupsUseCases_SkipGroupWatering(t *testing.T) {
+	utcNow := time.Now().UTC()
+	today := time.Date(utcNow.Year(), utcNow.Month(), utcNow.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+	snoozedUntil := utcNow.Add(time.Hour)
+	lastWateringDate := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		name       string
-		limit      int
-		offset     int
-		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
-		want       []entities.Group
-		wantErr    bool
+		name                 string
+		group                entities.Group
+		setupMocks           func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger, group entities.Group)
+		wantNextWateringDate time.Time
+		wantErr              bool
 	}{
 		{
-			name:   "Success - returns groups for notify",
-			limit:  10,
-			offset: 0,
-			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
-				storage.
-					EXPECT().
-					GetGroupsForNotify(10, 0).
-					Return(groups, nil).
-					Times(1)
+			name: "Success - overdue group is moved one interval from today",
+			group: entities.Group{
+				ID:               1,
+				UserID:           123,
+				WateringInterval: 7,
+				LastWateringDate: lastWateringDate,
+				NextWateringDate: today.AddDate(0, 0, -3),
+				SnoozedUntil:     &snoozedUntil,
 			},
-			want:    groups,
-			wantErr: false,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger, group entities.Group) {
+				storage.EXPECT().GetGroup(gomock.Any(), 1).Return(&group, nil).Times(1)
+				storage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+				storage.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			wantNextWateringDate: today.AddDate(0, 0, 7),
+			wantErr:              false,
 		},
 		{
-			name:   "Failure - storage error",
-			limit:  10,
-			offset: 0,
-			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
-				storage.
+			name: "Success - group due today is moved one interval",
+			group: entities.Group{
+				ID:               1,
+				UserID:           123,
+				WateringInterval: 3,
+				LastWateringDate: lastWateringDate,
+				NextWateringDate: today,
+			},
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger, group entities.Group) {
+				storage.EXPECT().GetGroup(gomock.Any(), 1).Return(&group, nil).Times(1)
+				storage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+				storage.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			wantNextWateringDate: today.AddDate(0, 0, 3),
+			wantErr:              false,
+		},
+		{
+			name: "Failure - owner not found",
+			group: entities.Group{
+				ID:               1,
+				UserID:           123,
+				WateringInterval: 7,
+				NextWateringDate: today,
+			},
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger, group entities.Group) {
+				storage.EXPECT().GetGroup(gomock.Any(), 1).Return(&group, nil).Times(1)
+				storage.EXPECT().GetUserByID(gomock.Any(), 123).Return(nil, assert.AnError).Times(1)
+
+				logger.
 					EXPECT().
-					GetGroupsForNotify(10, 0).
-					Return(nil, assert.AnError).
+					Error(
+						"Failed to get User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
 					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failure - storage update error",
+			group: entities.Group{
+				ID:               1,
+				UserID:           123,
+				WateringInterval: 7,
+				NextWateringDate: today,
+			},
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger, group entities.Group) {
+				storage.EXPECT().GetGroup(gomock.Any(), 1).Return(&group, nil).Times(1)
+				storage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+				storage.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
 
 				logger.
 					EXPECT().
 					Error(
-						"Failed to get Groups for Notify with limit=10 and offset=0",
+						"Failed to update Group with ID=1",
 						"Error", assert.AnError,
 						"Tracing", gomock.Any(),
 					).
 					Times(1)
 			},
-			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -748,7 +1733,7 @@ func TestGroupsUseCases_GetGroupsForNotify(t *testing.T) {
 			mockLogger := mocklogging.NewMockLogger(ctrl)
 
 			if tt.setupMocks != nil {
-				tt.setupMocks(mockStorage, mockLogger)
+				tt.setupMocks(mockStorage, mockLogger, tt.group)
 			}
 
 			useCases := &groupsUseCases{
@@ -756,10 +1741,18 @@ func TestGroupsUseCases_GetGroupsForNotify(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetGroupsForNotify(tt.limit, tt.offset)
+			got, err := useCases.SkipGroupWatering(context.Background(), 1)
 
-			assert.Equal(t, tt.want, got)
-			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+				assert.Equal(t, tt.wantNextWateringDate, got.NextWateringDate)
+				assert.Equal(t, lastWateringDate, got.LastWateringDate, "skip must not record watering")
+				assert.Nil(t, got.SnoozedUntil)
+			}
 		})
 	}
 }
@@ -777,7 +1770,7 @@ func TestGroupsUseCases_DeleteGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					DeleteGroup(1).
+					DeleteGroup(gomock.Any(), 1).
 					Return(nil).
 					Times(1)
 			},
@@ -789,7 +1782,7 @@ func TestGroupsUseCases_DeleteGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					DeleteGroup(1).
+					DeleteGroup(gomock.Any(), 1).
 					Return(assert.AnError).
 					Times(1)
 
@@ -821,7 +1814,7 @@ func TestGroupsUseCases_DeleteGroup(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.DeleteGroup(tt.id)
+			err := useCases.DeleteGroup(context.Background(), tt.id)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -859,22 +1852,22 @@ func TestGroupsUseCases_UpdateGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
-					DoAndReturn(func(group entities.Group) (bool, error) {
+					GroupExists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, group entities.Group) (bool, error) {
 						return false, nil // группа с таким названием не существует
 					}).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
-					DoAndReturn(func(group entities.Group) error {
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, group entities.Group) error {
 						assert.Equal(t, "Новые цветы", group.Title)
 						return nil
 					}).
@@ -895,7 +1888,7 @@ func TestGroupsUseCases_UpdateGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -918,14 +1911,14 @@ func TestGroupsUseCases_UpdateGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
-					DoAndReturn(func(group entities.Group) (bool, error) {
+					GroupExists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, group entities.Group) (bool, error) {
 						return true, nil // такая группа уже есть
 					}).
 					Times(1)
@@ -941,13 +1934,13 @@ func TestGroupsUseCases_UpdateGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
+					GroupExists(gomock.Any(), gomock.Any()).
 					Return(false, assert.AnError).
 					Times(1)
 
@@ -970,19 +1963,19 @@ func TestGroupsUseCases_UpdateGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
+					GroupExists(gomock.Any(), gomock.Any()).
 					Return(false, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
+					UpdateGroup(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1015,7 +2008,7 @@ func TestGroupsUseCases_UpdateGroupTitle(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.UpdateGroupTitle(tt.id, tt.title)
+			got, err := useCases.UpdateGroupTitle(context.Background(), tt.id, tt.title)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -1061,14 +2054,14 @@ func TestGroupsUseCases_UpdateGroupDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
-					DoAndReturn(func(group entities.Group) error {
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, group entities.Group) error {
 						assert.Equal(t, "Обновлённое описание", group.Description)
 						return nil
 					}).
@@ -1089,7 +2082,7 @@ func TestGroupsUseCases_UpdateGroupDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1112,13 +2105,13 @@ func TestGroupsUseCases_UpdateGroupDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroup(1).
+					GetGroup(gomock.Any(), 1).
 					Return(&baseGroup, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateGroup(gomock.Any()).
+					UpdateGroup(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1151,7 +2144,7 @@ func TestGroupsUseCases_UpdateGroupDescription(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.UpdateGroupDescription(tt.id, tt.description)
+			got, err := useCases.UpdateGroupDescription(context.Background(), tt.id, tt.description)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+const (
+	householdInviteTTL = 48 * time.Hour
+
+	// Токен передается в параметре /start, который ограничен 64 символами:
+	inviteTokenBytes = 16
+)
+
+type householdsUseCases struct {
+	storage interfaces.Storage
+	logger  logging.Logger
+}
+
+func (u *householdsUseCases) GetUserHousehold(ctx context.Context, userID int) (*entities.Household, error) {
+	household, err := u.storage.GetUserHousehold(ctx, userID)
+	if err != nil {
+		// Пользователь без дома - штатная ситуация, которую не нужно логировать:
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customerrors.ErrHouseholdNotFound
+		}
+
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Household for User with ID=%d", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return household, nil
+}
+
+// GetHouseholdUsers возвращает пользователя и всех участников его дома.
+func (u *householdsUseCases) GetHouseholdUsers(ctx context.Context, userID int) ([]entities.User, error) {
+	users, err := u.storage.GetHouseholdUsers(ctx, userID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Household Users for User with ID=%d", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return users, err
+}
+
+// CreateHouseholdInvite создает одноразовое приглашение в дом пользователя. Если пользователь еще не состоит
+// в доме, дом создается, а пользователь становится его владельцем.
+func (u *householdsUseCases) CreateHouseholdInvite(ctx context.Context, userID int) (*entities.HouseholdInvite, error) {
+	household, err := u.GetUserHousehold(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, customerrors.ErrHouseholdNotFound) {
+			return nil, err
+		}
+
+		if household, err = u.createHousehold(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := generateInviteToken()
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to generate HouseholdInvite token for User with ID=%d", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	// Время храним в UTC, так как колонка не содержит информации о часовом поясе:
+	invite := entities.HouseholdInvite{
+		HouseholdID: household.ID,
+		Token:       token,
+		CreatedBy:   userID,
+		ExpiresAt:   time.Now().UTC().Add(householdInviteTTL),
+	}
+
+	invite.ID, err = u.storage.CreateHouseholdInvite(ctx, invite)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to create HouseholdInvite for Household with ID=%d", household.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// JoinHousehold добавляет пользователя в дом по токену приглашения. Приглашение одноразовое.
+func (u *householdsUseCases) JoinHousehold(ctx context.Context, userID int, token string) error {
+	invite, err := u.storage.GetHouseholdInvite(ctx, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return customerrors.ErrHouseholdInviteNotFound
+		}
+
+		u.logger.Error(
+			"Failed to get HouseholdInvite by token",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	if invite.IsExpired(time.Now().UTC()) {
+		return customerrors.ErrHouseholdInviteExpired
+	}
+
+	// Приглашение удаляется вместе с добавлением участника, чтобы его нельзя было использовать повторно:
+	return u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			if err := tx.AddHouseholdMember(ctx, invite.HouseholdID, userID); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to add User with ID=%d to Household with ID=%d", userID, invite.HouseholdID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			if err := tx.DeleteHouseholdInvite(ctx, invite.ID); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to delete HouseholdInvite with ID=%d", invite.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		},
+	)
+}
+
+// LeaveHousehold исключает пользователя из дома. Сценарии пользователя остаются у него.
+func (u *householdsUseCases) LeaveHousehold(ctx context.Context, userID int) error {
+	err := u.storage.DeleteHouseholdMember(ctx, userID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to remove User with ID=%d from Household", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return err
+}
+
+func (u *householdsUseCases) createHousehold(ctx context.Context, ownerID int) (*entities.Household, error) {
+	var householdID int
+
+	// Владелец добавляется в участники в той же транзакции, чтобы дом не остался без участников:
+	err := u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			var err error
+
+			householdID, err = tx.CreateHousehold(ctx, ownerID)
+			if err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to create Household for User with ID=%d", ownerID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			if err = tx.AddHouseholdMember(ctx, householdID, ownerID); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to add User with ID=%d to Household with ID=%d", ownerID, householdID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Household{ID: householdID, OwnerID: ownerID}, nil
+}
+
+func generateInviteToken() (string, error) {
+	token := make([]byte, inviteTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
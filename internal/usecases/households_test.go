@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestHouseholdsUseCases_CreateHouseholdInvite(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    bool
+	}{
+		{
+			name: "Success - invite for existing household",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserHousehold(gomock.Any(), 1).
+					Return(&entities.Household{ID: 7, OwnerID: 1}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					CreateHouseholdInvite(
+						gomock.Any(),
+						gomock.Cond(func(invite entities.HouseholdInvite) bool {
+							return invite.HouseholdID == 7 &&
+								invite.CreatedBy == 1 &&
+								len(invite.Token) == inviteTokenBytes*2 &&
+								invite.ExpiresAt.After(time.Now().UTC())
+						}),
+					).
+					Return(3, nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success - household created for user without household",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserHousehold(gomock.Any(), 1).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+
+				storage.
+					EXPECT().
+					CreateHousehold(gomock.Any(), 1).
+					Return(7, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					AddHouseholdMember(gomock.Any(), 7, 1).
+					Return(nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					CreateHouseholdInvite(gomock.Any(), gomock.Any()).
+					Return(3, nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failure - create household error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserHousehold(gomock.Any(), 1).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+
+				storage.
+					EXPECT().
+					CreateHousehold(gomock.Any(), 1).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to create Household for User with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failure - get household error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserHousehold(gomock.Any(), 1).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Household for User with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &householdsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.CreateHouseholdInvite(context.Background(), 1)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, got.ID)
+				assert.Equal(t, 7, got.HouseholdID)
+			}
+		})
+	}
+}
+
+func TestHouseholdsUseCases_JoinHousehold(t *testing.T) {
+	invite := &entities.HouseholdInvite{
+		ID:          3,
+		HouseholdID: 7,
+		Token:       "token",
+		CreatedBy:   1,
+		ExpiresAt:   time.Now().UTC().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name: "Success - joined and invite deleted",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetHouseholdInvite(gomock.Any(), "token").Return(invite, nil).Times(1)
+				storage.EXPECT().AddHouseholdMember(gomock.Any(), 7, 2).Return(nil).Times(1)
+				storage.EXPECT().DeleteHouseholdInvite(gomock.Any(), 3).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Failure - invite not found",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetHouseholdInvite(gomock.Any(), "token").Return(nil, sql.ErrNoRows).Times(1)
+			},
+			wantErr: customerrors.ErrHouseholdInviteNotFound,
+		},
+		{
+			name: "Failure - invite expired",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				expired := *invite
+				expired.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+
+				storage.EXPECT().GetHouseholdInvite(gomock.Any(), "token").Return(&expired, nil).Times(1)
+			},
+			wantErr: customerrors.ErrHouseholdInviteExpired,
+		},
+		{
+			name: "Failure - add member error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetHouseholdInvite(gomock.Any(), "token").Return(invite, nil).Times(1)
+				storage.EXPECT().AddHouseholdMember(gomock.Any(), 7, 2).Return(assert.AnError).Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to add User with ID=2 to Household with ID=7",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &householdsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			err := useCases.JoinHousehold(context.Background(), 2, "token")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,670 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+)
+
+const (
+	// Ограничение длины названий сценариев и растений из migrations:
+	maxImportTitleLength = 100
+
+	renamedImportTitleFormat  = "%s (%d)"
+	importPlantConflictFormat = "%s / %s"
+)
+
+var importWateringSources = []string{
+	entities.WateringSourceNotification,
+	entities.WateringSourceManual,
+	entities.WateringSourceBackfill,
+}
+
+// ParseImport проверяет присланную выгрузку, не сохраняя ее. Используется, чтобы не загружать фотографии
+// из архива с некорректной выгрузкой.
+func (u *temporaryUseCases) ParseImport(data []byte) (*entities.Export, error) {
+	return parseExport(data)
+}
+
+// PrepareImport проверяет присланную выгрузку и сохраняет ее вместе с заново загруженными фотографиями из ZIP-архива
+// во временные данные до подтверждения загрузки. Возвращает описание того, что будет загружено, вместе с названиями
+// сценариев и растений, которые уже есть у пользователя.
+func (u *temporaryUseCases) PrepareImport(
+	ctx context.Context,
+	telegramID int,
+	data []byte,
+	photos map[string]entities.ImportPhoto,
+) (*entities.ImportPreview, error) {
+	export, err := parseExport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &entities.ImportPreview{
+		Groups: len(export.Groups),
+	}
+
+	// Во временных данных сохраняются только фотографии, на которые ссылается выгрузка:
+	importData := entities.Import{
+		Export: export,
+		Photos: make(map[string]entities.ImportPhoto),
+	}
+
+	for _, group := range export.Groups {
+		existing, err := getOwnGroup(ctx, u.storage, temp.UserID, group.Title)
+		if err != nil {
+			u.logger.Error(
+				fmt.Sprintf("Failed to get Group with Title=%s for User with ID=%d", group.Title, temp.UserID),
+				"Error", err,
+				"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+			)
+
+			return nil, err
+		}
+
+		if existing != nil {
+			preview.Conflicts = append(preview.Conflicts, group.Title)
+		}
+
+		preview.Plants += len(group.Plants)
+		preview.CareTasks += len(group.CareTasks)
+		preview.Waterings += len(group.Waterings)
+
+		for _, plant := range group.Plants {
+			preview.CareTasks += len(plant.CareTasks)
+
+			for _, path := range plant.GetPhotoPaths() {
+				photo, ok := photos[path]
+				if _, added := importData.Photos[path]; ok && !added {
+					importData.Photos[path] = photo
+					preview.Photos++
+				}
+			}
+
+			if existing == nil {
+				continue
+			}
+
+			exists, err := u.storage.PlantExists(ctx, entities.Plant{GroupID: existing.ID, Title: plant.Title})
+			if err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to check existence for Plant with Title=%s", plant.Title),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return nil, err
+			}
+
+			if exists {
+				preview.PlantConflicts = append(
+					preview.PlantConflicts,
+					fmt.Sprintf(importPlantConflictFormat, group.Title, plant.Title),
+				)
+			}
+		}
+	}
+
+	if temp.Data, err = json.Marshal(importData); err != nil {
+		u.logger.Error(
+			"Failed to marshal Import",
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	// Сообщение с просьбой прислать файл заменяется описанием загрузки, которое удаляется при подтверждении:
+	temp.Step = steps.ConfirmImport
+	temp.MessageID = nil
+
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// ImportUserData загружает выгрузку, сохраненную в PrepareImport, в одной транзакции: при ошибке не будет
+// создан ни один сценарий. Конфликты названий сценариев и растений разрешаются способом conflictResolution.
+func (u *temporaryUseCases) ImportUserData(
+	ctx context.Context,
+	telegramID int,
+	conflictResolution string,
+) (*entities.ImportResult, error) {
+	if !slices.Contains(
+		[]string{entities.ImportConflictSkip, entities.ImportConflictRename, entities.ImportConflictOverwrite},
+		conflictResolution,
+	) {
+		return nil, fmt.Errorf("%w: %q", customerrors.ErrUnknownImportConflictResolution, conflictResolution)
+	}
+
+	result := &entities.ImportResult{}
+
+	err := u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			temp, err := u.getUserTemporary(ctx, tx, telegramID)
+			if err != nil {
+				return err
+			}
+
+			// Кнопка подтверждения могла остаться от загрузки, после которой пользователь перешел к другим действиям:
+			if temp.Step != steps.ConfirmImport {
+				return fmt.Errorf("%w: import is not prepared", customerrors.ErrInvalidImport)
+			}
+
+			importData, err := temp.GetImport()
+			if err != nil {
+				u.logger.Error(
+					"Failed to get Import from Temporary",
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			importer := &groupImporter{
+				tx:                 tx,
+				logger:             u.logger,
+				userID:             temp.UserID,
+				photos:             importData.Photos,
+				conflictResolution: conflictResolution,
+				result:             result,
+			}
+
+			for _, group := range importData.Export.Groups {
+				if err = importer.importGroup(ctx, group); err != nil {
+					u.logger.Error(
+						fmt.Sprintf("Failed to import Group with Title=%s for User with ID=%d", group.Title, temp.UserID),
+						"Error", err,
+						"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+					)
+
+					return err
+				}
+			}
+
+			temp.Data = nil
+			temp.MessageID = nil
+			temp.Step = steps.Start
+
+			if err = tx.UpdateTemporary(ctx, *temp); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// groupImporter загружает сценарии выгрузки внутри транзакции tx и подсчитывает загруженное в result.
+type groupImporter struct {
+	tx                 interfaces.Storage
+	logger             logging.Logger
+	userID             int
+	photos             map[string]entities.ImportPhoto
+	conflictResolution string
+	result             *entities.ImportResult
+}
+
+// importGroup создает сценарий из выгрузки. Если у пользователя уже есть сценарий с таким названием, то при
+// переименовании сценарий создается под свободным названием, а в остальных случаях выгрузка объединяется
+// с существующим сценарием.
+func (i *groupImporter) importGroup(ctx context.Context, exportGroup entities.ExportGroup) error {
+	group := entities.Group{
+		UserID:           i.userID,
+		Title:            exportGroup.Title,
+		Description:      exportGroup.Description,
+		LastWateringDate: exportGroup.LastWateringDate,
+		NextWateringDate: exportGroup.NextWateringDate,
+		WateringInterval: exportGroup.WateringInterval,
+		SeasonSchedule:   exportGroup.SeasonSchedule,
+	}
+
+	existing, err := getOwnGroup(ctx, i.tx, i.userID, group.Title)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		switch i.conflictResolution {
+		case entities.ImportConflictSkip:
+			i.result.SkippedGroups++
+
+			return nil
+		case entities.ImportConflictOverwrite:
+			return i.overwriteGroup(ctx, *existing, exportGroup)
+		}
+
+		if group.Title, err = getFreeGroupTitle(ctx, i.tx, group); err != nil {
+			return err
+		}
+
+		i.result.RenamedGroups++
+	}
+
+	groupID, err := i.tx.CreateGroup(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	i.result.Groups++
+
+	if err = importCareTasks(ctx, i.tx, i.userID, &groupID, nil, exportGroup.CareTasks); err != nil {
+		return err
+	}
+
+	plantIDs, err := i.importPlants(ctx, groupID, exportGroup.Plants)
+	if err != nil {
+		return err
+	}
+
+	return i.importWaterings(ctx, groupID, exportGroup.Waterings, plantIDs, true)
+}
+
+// overwriteGroup заменяет настройки существующего сценария, его задачи ухода тех же видов и растения с совпадающими
+// названиями данными из выгрузки и добавляет растения, которых в сценарии нет. Поливы всего сценария не загружаются,
+// чтобы не дублировать историю существующего сценария.
+func (i *groupImporter) overwriteGroup(
+	ctx context.Context,
+	group entities.Group,
+	exportGroup entities.ExportGroup,
+) error {
+	group.Description = exportGroup.Description
+	group.LastWateringDate = exportGroup.LastWateringDate
+	group.WateringInterval = exportGroup.WateringInterval
+	group.SeasonSchedule = exportGroup.SeasonSchedule
+
+	if err := i.replaceGroupCareTasks(ctx, group.ID, exportGroup.CareTasks); err != nil {
+		return err
+	}
+
+	i.result.OverwrittenGroups++
+
+	plantIDs, err := i.importPlants(ctx, group.ID, exportGroup.Plants)
+	if err != nil {
+		return err
+	}
+
+	if err = i.importWaterings(ctx, group.ID, exportGroup.Waterings, plantIDs, false); err != nil {
+		return err
+	}
+
+	// Дата следующего полива зависит от настроек сценария и графиков его растений:
+	group.NextWateringDate, err = getGroupNextWateringDate(ctx, i.tx, i.logger, group)
+	if err != nil {
+		return err
+	}
+
+	return i.tx.UpdateGroup(ctx, group)
+}
+
+func (i *groupImporter) replaceGroupCareTasks(
+	ctx context.Context,
+	groupID int,
+	exportCareTasks []entities.ExportCareTask,
+) error {
+	careTasks, err := i.tx.GetGroupCareTasks(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, careTask := range careTasks {
+		isReplaced := slices.ContainsFunc(
+			exportCareTasks,
+			func(exportCareTask entities.ExportCareTask) bool { return exportCareTask.Type == careTask.Type },
+		)
+
+		if isReplaced {
+			if err = i.tx.DeleteCareTask(ctx, careTask.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return importCareTasks(ctx, i.tx, i.userID, &groupID, nil, exportCareTasks)
+}
+
+// importPlants создает растения из выгрузки вместе с фотографиями и возвращает идентификаторы созданных растений
+// по названиям. Растения, которые уже есть в сценарии, заменяются.
+func (i *groupImporter) importPlants(
+	ctx context.Context,
+	groupID int,
+	exportPlants []entities.ExportPlant,
+) (map[string]int, error) {
+	plantIDs := make(map[string]int, len(exportPlants))
+	for _, exportPlant := range exportPlants {
+		plant := entities.Plant{
+			GroupID:          groupID,
+			UserID:           i.userID,
+			Title:            exportPlant.Title,
+			Description:      exportPlant.Description,
+			WateringInterval: exportPlant.WateringInterval,
+			LastWateringDate: exportPlant.LastWateringDate,
+		}
+
+		if photo, ok := i.photos[exportPlant.Photo]; ok {
+			plant.PhotoFileID = photo.FileID
+			plant.PhotoFileUniqueID = photo.FileUniqueID
+		}
+
+		// Растения с совпадающими названиями бывают только в существующем сценарии, который заменяется:
+		plantID, err := createImportedPlant(ctx, i.tx, plant)
+		if errors.Is(err, customerrors.ErrPlantAlreadyExists) {
+			if err = deleteGroupPlant(ctx, i.tx, groupID, plant.Title); err != nil {
+				return nil, err
+			}
+
+			i.result.OverwrittenPlants++
+
+			plantID, err = createImportedPlant(ctx, i.tx, plant)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = importCareTasks(ctx, i.tx, i.userID, nil, &plantID, exportPlant.CareTasks); err != nil {
+			return nil, err
+		}
+
+		for _, exportPhoto := range exportPlant.Photos {
+			photo, ok := i.photos[exportPhoto.File]
+			if !ok {
+				continue
+			}
+
+			_, err = i.tx.SavePlantPhoto(
+				ctx,
+				entities.PlantPhoto{
+					PlantID:      plantID,
+					FileID:       photo.FileID,
+					FileUniqueID: photo.FileUniqueID,
+					Caption:      exportPhoto.Caption,
+					TakenAt:      exportPhoto.TakenAt,
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		plantIDs[exportPlant.Title] = plantID
+		i.result.Plants++
+	}
+
+	return plantIDs, nil
+}
+
+// importWaterings сохраняет поливы из выгрузки. Если withGroupWaterings не задан, сохраняются только поливы
+// созданных растений.
+func (i *groupImporter) importWaterings(
+	ctx context.Context,
+	groupID int,
+	exportWaterings []entities.ExportWatering,
+	plantIDs map[string]int,
+	withGroupWaterings bool,
+) error {
+	for _, exportWatering := range exportWaterings {
+		watering := entities.Watering{
+			GroupID:   groupID,
+			WateredAt: exportWatering.WateredAt,
+			Source:    exportWatering.Source,
+			UserID:    &i.userID,
+		}
+
+		// Поливы растений, перенесенных в другой сценарий, остаются в истории как поливы сценария:
+		if plantID, ok := plantIDs[exportWatering.Plant]; ok {
+			watering.PlantID = &plantID
+		} else if !withGroupWaterings {
+			continue
+		}
+
+		if _, err := i.tx.SaveWatering(ctx, watering); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createImportedPlant создает растение или возвращает ErrPlantAlreadyExists, если в сценарии есть растение
+// с таким же названием.
+func createImportedPlant(ctx context.Context, tx interfaces.Storage, plant entities.Plant) (int, error) {
+	exists, err := tx.PlantExists(ctx, plant)
+	if err != nil {
+		return 0, err
+	}
+
+	if exists {
+		return 0, customerrors.ErrPlantAlreadyExists
+	}
+
+	return tx.CreatePlant(ctx, plant)
+}
+
+// getOwnGroup возвращает собственный сценарий пользователя с указанным названием или nil, если такого сценария нет.
+// Общие сценарии дома принадлежат другим участникам и не меняются при загрузке.
+func getOwnGroup(ctx context.Context, storage interfaces.Storage, userID int, title string) (*entities.Group, error) {
+	groups, err := storage.GetUserGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.UserID == userID && group.Title == title {
+			return &group, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getFreeGroupTitle подбирает название вида "Кухня (2)", которого еще нет у пользователя.
+func getFreeGroupTitle(ctx context.Context, tx interfaces.Storage, group entities.Group) (string, error) {
+	title := group.Title
+
+	for i := 2; ; i++ {
+		group.Title = fmt.Sprintf(renamedImportTitleFormat, title, i)
+
+		// Укорачиваем исходное название, чтобы с номером оно помещалось в колонку:
+		for utf8.RuneCountInString(group.Title) > maxImportTitleLength {
+			runes := []rune(title)
+			title = string(runes[:len(runes)-1])
+			group.Title = fmt.Sprintf(renamedImportTitleFormat, title, i)
+		}
+
+		exists, err := tx.GroupExists(ctx, group)
+		if err != nil {
+			return "", err
+		}
+
+		if !exists {
+			return group.Title, nil
+		}
+	}
+}
+
+// deleteGroupPlant удаляет растение сценария с указанным названием вместе с его задачами ухода и фотографиями.
+func deleteGroupPlant(ctx context.Context, tx interfaces.Storage, groupID int, title string) error {
+	plants, err := tx.GetGroupPlants(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, plant := range plants {
+		if plant.Title == title {
+			return tx.DeletePlant(ctx, plant.ID)
+		}
+	}
+
+	return nil
+}
+
+func importCareTasks(
+	ctx context.Context,
+	tx interfaces.Storage,
+	userID int,
+	groupID, plantID *int,
+	exportCareTasks []entities.ExportCareTask,
+) error {
+	for _, exportCareTask := range exportCareTasks {
+		_, err := tx.CreateCareTask(
+			ctx,
+			entities.CareTask{
+				UserID:       userID,
+				GroupID:      groupID,
+				PlantID:      plantID,
+				Type:         exportCareTask.Type,
+				Interval:     exportCareTask.Interval,
+				LastCareDate: exportCareTask.LastCareDate,
+				NextCareDate: exportCareTask.NextCareDate,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseExport разбирает выгрузку и проверяет, что ее можно загрузить без нарушения ограничений схемы БД.
+func parseExport(data []byte) (*entities.Export, error) {
+	export := &entities.Export{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImport, err)
+	}
+
+	switch {
+	case export.Version <= 0:
+		return nil, fmt.Errorf("%w: missing version", customerrors.ErrInvalidImport)
+	case export.Version != entities.ExportVersion:
+		return nil, fmt.Errorf("%w: %d", customerrors.ErrUnsupportedImportVersion, export.Version)
+	case len(export.Groups) == 0:
+		return nil, fmt.Errorf("%w: no groups", customerrors.ErrInvalidImport)
+	}
+
+	groupTitles := make(map[string]bool, len(export.Groups))
+	for _, group := range export.Groups {
+		if err := validateExportGroup(group); err != nil {
+			return nil, fmt.Errorf("%w: group %q: %w", customerrors.ErrInvalidImport, group.Title, err)
+		}
+
+		if groupTitles[group.Title] {
+			return nil, fmt.Errorf("%w: duplicate group %q", customerrors.ErrInvalidImport, group.Title)
+		}
+
+		groupTitles[group.Title] = true
+	}
+
+	return export, nil
+}
+
+func validateExportGroup(group entities.ExportGroup) error {
+	switch {
+	case !isValidImportTitle(group.Title):
+		return errors.New("invalid title")
+	case group.WateringInterval <= 0:
+		return errors.New("invalid watering interval")
+	case group.LastWateringDate.IsZero() || group.NextWateringDate.IsZero():
+		return errors.New("missing watering dates")
+	}
+
+	for _, season := range group.SeasonSchedule {
+		if season.WateringInterval <= 0 ||
+			season.StartMonth < time.January || season.StartMonth > time.December ||
+			season.EndMonth < time.January || season.EndMonth > time.December {
+			return errors.New("invalid season schedule")
+		}
+	}
+
+	if err := validateExportCareTasks(group.CareTasks); err != nil {
+		return err
+	}
+
+	plantTitles := make(map[string]bool, len(group.Plants))
+	for _, plant := range group.Plants {
+		switch {
+		case !isValidImportTitle(plant.Title):
+			return fmt.Errorf("plant %q: invalid title", plant.Title)
+		case plantTitles[plant.Title]:
+			return fmt.Errorf("duplicate plant %q", plant.Title)
+		case plant.WateringInterval != nil && *plant.WateringInterval <= 0:
+			return fmt.Errorf("plant %q: invalid watering interval", plant.Title)
+		}
+
+		if err := validateExportCareTasks(plant.CareTasks); err != nil {
+			return fmt.Errorf("plant %q: %w", plant.Title, err)
+		}
+
+		plantTitles[plant.Title] = true
+	}
+
+	for _, watering := range group.Waterings {
+		if !slices.Contains(importWateringSources, watering.Source) || watering.WateredAt.IsZero() {
+			return errors.New("invalid watering")
+		}
+	}
+
+	return nil
+}
+
+// validateExportCareTasks проверяет задачи ухода сценария или растения. Задача каждого вида может быть только одна.
+func validateExportCareTasks(careTasks []entities.ExportCareTask) error {
+	careTaskTypes := make(map[string]bool, len(careTasks))
+	for _, careTask := range careTasks {
+		switch {
+		case !slices.Contains(entities.CareTaskTypes, careTask.Type):
+			return fmt.Errorf("invalid care task type %q", careTask.Type)
+		case careTaskTypes[careTask.Type]:
+			return fmt.Errorf("duplicate care task %q", careTask.Type)
+		case careTask.Interval <= 0:
+			return fmt.Errorf("care task %q: invalid interval", careTask.Type)
+		}
+
+		careTaskTypes[careTask.Type] = true
+	}
+
+	return nil
+}
+
+func isValidImportTitle(title string) bool {
+	return title != "" && utf8.RuneCountInString(title) <= maxImportTitleLength
+}
@@ -0,0 +1,645 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+const importTelegramID = 123
+
+var importWateringDate = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newImportExport() entities.Export {
+	return entities.Export{
+		Version:    entities.ExportVersion,
+		ExportedAt: importWateringDate,
+		Groups: []entities.ExportGroup{
+			{
+				Title:            "Кухня",
+				LastWateringDate: importWateringDate,
+				NextWateringDate: importWateringDate.AddDate(0, 0, 7),
+				WateringInterval: 7,
+				CareTasks: []entities.ExportCareTask{
+					{
+						Type:         entities.CareTaskTypeFertilizing,
+						Interval:     30,
+						LastCareDate: importWateringDate,
+						NextCareDate: importWateringDate.AddDate(0, 0, 30),
+					},
+				},
+				Plants: []entities.ExportPlant{
+					{
+						Title: "Фикус",
+						CareTasks: []entities.ExportCareTask{
+							{
+								Type:         entities.CareTaskTypeMisting,
+								Interval:     2,
+								LastCareDate: importWateringDate,
+								NextCareDate: importWateringDate.AddDate(0, 0, 2),
+							},
+						},
+					},
+					{Title: "Кактус"},
+				},
+				Waterings: []entities.ExportWatering{
+					{WateredAt: importWateringDate, Source: entities.WateringSourceManual, Plant: "Фикус"},
+					{WateredAt: importWateringDate, Source: entities.WateringSourceNotification, Plant: "Монстера"},
+				},
+			},
+			{
+				Title:            "Спальня",
+				LastWateringDate: importWateringDate,
+				NextWateringDate: importWateringDate.AddDate(0, 0, 5),
+				WateringInterval: 5,
+			},
+		},
+	}
+}
+
+func TestParseExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(export *entities.Export)
+		data    string
+		wantErr error
+	}{
+		{
+			name:   "Success - valid export",
+			modify: func(export *entities.Export) {},
+		},
+		{
+			name:    "Failure - not a json",
+			data:    "PK\x03\x04",
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - missing version",
+			modify:  func(export *entities.Export) { export.Version = 0 },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - unsupported version",
+			modify:  func(export *entities.Export) { export.Version = entities.ExportVersion + 1 },
+			wantErr: customerrors.ErrUnsupportedImportVersion,
+		},
+		{
+			name:    "Failure - no groups",
+			modify:  func(export *entities.Export) { export.Groups = nil },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - duplicate group",
+			modify:  func(export *entities.Export) { export.Groups[1].Title = export.Groups[0].Title },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - too long group title",
+			modify:  func(export *entities.Export) { export.Groups[0].Title = strings.Repeat("ы", maxImportTitleLength+1) },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - invalid watering interval",
+			modify:  func(export *entities.Export) { export.Groups[0].WateringInterval = 0 },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name: "Failure - invalid season schedule",
+			modify: func(export *entities.Export) {
+				export.Groups[0].SeasonSchedule = entities.SeasonSchedule{{StartMonth: 13, EndMonth: 1, WateringInterval: 3}}
+			},
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - duplicate plant",
+			modify:  func(export *entities.Export) { export.Groups[0].Plants[1].Title = "Фикус" },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name: "Failure - invalid plant watering interval",
+			modify: func(export *entities.Export) {
+				interval := -1
+				export.Groups[0].Plants[0].WateringInterval = &interval
+			},
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - unknown care task type",
+			modify:  func(export *entities.Export) { export.Groups[0].CareTasks[0].Type = "pruning" },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name: "Failure - duplicate plant care task",
+			modify: func(export *entities.Export) {
+				plant := &export.Groups[0].Plants[0]
+				plant.CareTasks = append(plant.CareTasks, plant.CareTasks[0])
+			},
+			wantErr: customerrors.ErrInvalidImport,
+		},
+		{
+			name:    "Failure - unknown watering source",
+			modify:  func(export *entities.Export) { export.Groups[0].Waterings[0].Source = "rain" },
+			wantErr: customerrors.ErrInvalidImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(tt.data)
+			if tt.modify != nil {
+				export := newImportExport()
+				tt.modify(&export)
+
+				var err error
+				data, err = json.Marshal(export)
+				require.NoError(t, err)
+			}
+
+			export, err := parseExport(data)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, export)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, export.Groups, 2)
+		})
+	}
+}
+
+func TestTemporaryUseCases_Import(t *testing.T) {
+	tests := []struct {
+		name                string
+		conflictResolution  string
+		wantTitles          []string
+		wantResult          entities.ImportResult
+		wantKitchenInterval int
+		wantKitchenPlants   int
+	}{
+		{
+			name:                "Skip - existing group is kept",
+			conflictResolution:  entities.ImportConflictSkip,
+			wantTitles:          []string{"Кухня", "Спальня"},
+			wantResult:          entities.ImportResult{Groups: 1, SkippedGroups: 1},
+			wantKitchenInterval: 3,
+			wantKitchenPlants:   1,
+		},
+		{
+			name:                "Rename - imported group gets free title",
+			conflictResolution:  entities.ImportConflictRename,
+			wantTitles:          []string{"Кухня", "Кухня (2)", "Спальня"},
+			wantResult:          entities.ImportResult{Groups: 2, Plants: 2, RenamedGroups: 1},
+			wantKitchenInterval: 3,
+			wantKitchenPlants:   1,
+		},
+		{
+			name:                "Overwrite - existing group settings are replaced and new plants are added",
+			conflictResolution:  entities.ImportConflictOverwrite,
+			wantTitles:          []string{"Кухня", "Спальня"},
+			wantResult:          entities.ImportResult{Groups: 1, Plants: 2, OverwrittenGroups: 1},
+			wantKitchenInterval: 7,
+			wantKitchenPlants:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := memory.New()
+			useCases := &temporaryUseCases{
+				storage: storage,
+				logger:  mocklogging.NewMockLogger(gomock.NewController(t)),
+			}
+
+			userID, existingGroupID := setupImportUser(t, storage)
+
+			data, err := json.Marshal(newImportExport())
+			require.NoError(t, err)
+
+			preview, err := useCases.PrepareImport(ctx, importTelegramID, data, nil)
+			require.NoError(t, err)
+			assert.Equal(
+				t,
+				&entities.ImportPreview{Groups: 2, Plants: 2, CareTasks: 2, Waterings: 2, Conflicts: []string{"Кухня"}},
+				preview,
+			)
+
+			result, err := useCases.ImportUserData(ctx, importTelegramID, tt.conflictResolution)
+			require.NoError(t, err)
+			assert.Equal(t, &tt.wantResult, result)
+
+			groups, err := storage.GetUserGroups(ctx, userID)
+			require.NoError(t, err)
+
+			titles := make([]string, 0, len(groups))
+			for _, group := range groups {
+				titles = append(titles, group.Title)
+			}
+
+			assert.ElementsMatch(t, tt.wantTitles, titles)
+
+			kitchen, err := storage.GetGroup(ctx, existingGroupID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKitchenInterval, kitchen.WateringInterval)
+
+			plantsCount, err := storage.CountGroupPlants(ctx, existingGroupID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKitchenPlants, plantsCount)
+
+			temp, err := storage.GetTemporaryByUserID(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, steps.Start, temp.Step)
+			assert.Nil(t, temp.Data)
+		})
+	}
+}
+
+func TestTemporaryUseCases_Import_PlantConflicts(t *testing.T) {
+	tests := []struct {
+		name               string
+		conflictResolution string
+		wantResult         entities.ImportResult
+		wantDescription    string
+		wantPlants         int
+		wantWaterings      int
+	}{
+		{
+			name:               "Skip - existing plant is kept",
+			conflictResolution: entities.ImportConflictSkip,
+			wantResult:         entities.ImportResult{Groups: 1, SkippedGroups: 1},
+			wantDescription:    "",
+			wantPlants:         1,
+			wantWaterings:      0,
+		},
+		{
+			name:               "Rename - existing group is not changed",
+			conflictResolution: entities.ImportConflictRename,
+			wantResult:         entities.ImportResult{Groups: 2, Plants: 3, RenamedGroups: 1},
+			wantDescription:    "",
+			wantPlants:         1,
+			wantWaterings:      0,
+		},
+		{
+			name:               "Overwrite - existing plant is replaced",
+			conflictResolution: entities.ImportConflictOverwrite,
+			wantResult: entities.ImportResult{
+				Groups:            1,
+				Plants:            3,
+				OverwrittenGroups: 1,
+				OverwrittenPlants: 1,
+			},
+			wantDescription: "Из файла",
+			wantPlants:      3,
+			wantWaterings:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := memory.New()
+			useCases := &temporaryUseCases{
+				storage: storage,
+				logger:  mocklogging.NewMockLogger(gomock.NewController(t)),
+			}
+
+			_, existingGroupID := setupImportUser(t, storage)
+
+			export := newImportExport()
+			export.Groups[0].Plants = append(
+				export.Groups[0].Plants,
+				entities.ExportPlant{Title: "Алоэ", Description: "Из файла"},
+			)
+			export.Groups[0].Waterings = append(
+				export.Groups[0].Waterings,
+				entities.ExportWatering{WateredAt: importWateringDate, Source: entities.WateringSourceManual, Plant: "Алоэ"},
+			)
+
+			data, err := json.Marshal(export)
+			require.NoError(t, err)
+
+			preview, err := useCases.PrepareImport(ctx, importTelegramID, data, nil)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Кухня / Алоэ"}, preview.PlantConflicts)
+
+			result, err := useCases.ImportUserData(ctx, importTelegramID, tt.conflictResolution)
+			require.NoError(t, err)
+			assert.Equal(t, &tt.wantResult, result)
+
+			plants, err := storage.GetGroupPlants(ctx, existingGroupID)
+			require.NoError(t, err)
+			require.Len(t, plants, tt.wantPlants)
+
+			for _, plant := range plants {
+				if plant.Title == "Алоэ" {
+					assert.Equal(t, tt.wantDescription, plant.Description)
+				}
+			}
+
+			// Поливы всего сценария не загружаются в существующий сценарий:
+			waterings, err := storage.CountGroupWaterings(ctx, existingGroupID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWaterings, waterings)
+		})
+	}
+}
+
+func TestTemporaryUseCases_Import_SkipLeavesExistingGroupUnchanged(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	useCases := &temporaryUseCases{
+		storage: storage,
+		logger:  mocklogging.NewMockLogger(gomock.NewController(t)),
+	}
+
+	userID, groupID := setupImportUser(t, storage)
+
+	plants, err := storage.GetGroupPlants(ctx, groupID)
+	require.NoError(t, err)
+	require.Len(t, plants, 1)
+
+	plantID := plants[0].ID
+
+	_, err = storage.CreateCareTask(
+		ctx,
+		entities.CareTask{UserID: userID, GroupID: &groupID, Type: entities.CareTaskTypeFertilizing, Interval: 14},
+	)
+	require.NoError(t, err)
+
+	_, err = storage.SaveWatering(
+		ctx,
+		entities.Watering{GroupID: groupID, WateredAt: importWateringDate, Source: entities.WateringSourceManual},
+	)
+	require.NoError(t, err)
+
+	_, err = storage.SavePlantPhoto(
+		ctx,
+		entities.PlantPhoto{PlantID: plantID, FileID: "file-id", FileUniqueID: "unique-id", TakenAt: importWateringDate},
+	)
+	require.NoError(t, err)
+
+	snapshot := func() []any {
+		group, err := storage.GetGroup(ctx, groupID)
+		require.NoError(t, err)
+
+		plants, err := storage.GetGroupPlants(ctx, groupID)
+		require.NoError(t, err)
+
+		careTasks, err := storage.GetGroupCareTasks(ctx, groupID)
+		require.NoError(t, err)
+
+		waterings, err := storage.GetGroupWaterings(ctx, groupID, 10, 0)
+		require.NoError(t, err)
+
+		photos, err := storage.GetPlantPhotos(ctx, plantID, 10, 0)
+		require.NoError(t, err)
+
+		return []any{group, plants, careTasks, waterings, photos}
+	}
+
+	before := snapshot()
+
+	// В выгрузке есть и новые растения, и растение с тем же названием, и фотографии:
+	export := newImportExport()
+	export.Groups[0].Plants = append(export.Groups[0].Plants, entities.ExportPlant{Title: "Алоэ", Description: "Из файла"})
+	export.Groups[0].Plants[0].Photo = "photos/cover.jpg"
+
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	photos := map[string]entities.ImportPhoto{"photos/cover.jpg": {FileID: "cover-file-id"}}
+
+	_, err = useCases.PrepareImport(ctx, importTelegramID, data, photos)
+	require.NoError(t, err)
+
+	result, err := useCases.ImportUserData(ctx, importTelegramID, entities.ImportConflictSkip)
+	require.NoError(t, err)
+	assert.Equal(t, &entities.ImportResult{Groups: 1, SkippedGroups: 1}, result)
+
+	assert.Equal(t, before, snapshot())
+}
+
+func TestTemporaryUseCases_Import_Photos(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	useCases := &temporaryUseCases{
+		storage: storage,
+		logger:  mocklogging.NewMockLogger(gomock.NewController(t)),
+	}
+
+	userID, _ := setupImportUser(t, storage)
+
+	export := newImportExport()
+	export.Groups[0].Plants[0].Photo = "photos/cover.jpg"
+	export.Groups[0].Plants[0].Photos = []entities.ExportPhoto{
+		{File: "photos/cover.jpg", Caption: "Весна", TakenAt: importWateringDate},
+		{File: "photos/summer.jpg", Caption: "Лето", TakenAt: importWateringDate.AddDate(0, 1, 0)},
+		{File: "photos/missing.jpg", TakenAt: importWateringDate.AddDate(0, 2, 0)},
+	}
+
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	photos := map[string]entities.ImportPhoto{
+		"photos/cover.jpg":  {FileID: "cover-file-id", FileUniqueID: "cover-unique-id"},
+		"photos/summer.jpg": {FileID: "summer-file-id", FileUniqueID: "summer-unique-id"},
+		"photos/other.jpg":  {FileID: "other-file-id", FileUniqueID: "other-unique-id"},
+	}
+
+	preview, err := useCases.PrepareImport(ctx, importTelegramID, data, photos)
+	require.NoError(t, err)
+	assert.Equal(t, 2, preview.Photos)
+
+	// Во временных данных остаются только фотографии, на которые ссылается выгрузка:
+	temp, err := storage.GetTemporaryByUserID(ctx, userID)
+	require.NoError(t, err)
+
+	importData, err := temp.GetImport()
+	require.NoError(t, err)
+	assert.Len(t, importData.Photos, 2)
+
+	_, err = useCases.ImportUserData(ctx, importTelegramID, entities.ImportConflictRename)
+	require.NoError(t, err)
+
+	groups, err := storage.GetUserGroups(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+
+	plants, err := storage.GetGroupPlants(ctx, groups[1].ID)
+	require.NoError(t, err)
+	require.Len(t, plants, 2)
+	assert.Equal(t, "cover-file-id", plants[0].PhotoFileID)
+	assert.Equal(t, "cover-unique-id", plants[0].PhotoFileUniqueID)
+	assert.Empty(t, plants[1].PhotoFileID)
+
+	plantPhotos, err := storage.GetPlantPhotos(ctx, plants[0].ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, plantPhotos, 2)
+	assert.ElementsMatch(
+		t,
+		[]string{"cover-file-id", "summer-file-id"},
+		[]string{plantPhotos[0].FileID, plantPhotos[1].FileID},
+	)
+	assert.ElementsMatch(
+		t,
+		[]string{"Весна", "Лето"},
+		[]string{plantPhotos[0].Caption, plantPhotos[1].Caption},
+	)
+}
+
+func TestTemporaryUseCases_ImportUserData_Contents(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	useCases := &temporaryUseCases{
+		storage: storage,
+		logger:  mocklogging.NewMockLogger(gomock.NewController(t)),
+	}
+
+	userID, _ := setupImportUser(t, storage)
+
+	data, err := json.Marshal(newImportExport())
+	require.NoError(t, err)
+
+	_, err = useCases.PrepareImport(ctx, importTelegramID, data, nil)
+	require.NoError(t, err)
+
+	_, err = useCases.ImportUserData(ctx, importTelegramID, entities.ImportConflictRename)
+	require.NoError(t, err)
+
+	groups, err := storage.GetUserGroups(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+
+	group := groups[1]
+	assert.Equal(t, "Кухня (2)", group.Title)
+	assert.Equal(t, 7, group.WateringInterval)
+	assert.Equal(t, importWateringDate.AddDate(0, 0, 7), group.NextWateringDate)
+
+	groupCareTasks, err := storage.GetGroupCareTasks(ctx, group.ID)
+	require.NoError(t, err)
+	require.Len(t, groupCareTasks, 1)
+	assert.Equal(t, entities.CareTaskTypeFertilizing, groupCareTasks[0].Type)
+
+	plants, err := storage.GetGroupPlants(ctx, group.ID)
+	require.NoError(t, err)
+	require.Len(t, plants, 2)
+	assert.Equal(t, userID, plants[0].UserID)
+
+	plantCareTasks, err := storage.GetPlantCareTasks(ctx, plants[0].ID)
+	require.NoError(t, err)
+	require.Len(t, plantCareTasks, 1)
+	assert.Equal(t, entities.CareTaskTypeMisting, plantCareTasks[0].Type)
+
+	// Полив растения из другого сценария сохраняется как полив сценария:
+	waterings, err := storage.GetGroupWaterings(ctx, group.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, waterings, 2)
+	assert.ElementsMatch(
+		t,
+		[]*int{&plants[0].ID, nil},
+		[]*int{waterings[0].PlantID, waterings[1].PlantID},
+	)
+}
+
+func TestTemporaryUseCases_ImportUserData_Failure(t *testing.T) {
+	t.Run("Failure - import is not prepared", func(t *testing.T) {
+		storage := memory.New()
+		useCases := &temporaryUseCases{
+			storage: storage,
+			logger:  mocklogging.NewMockLogger(gomock.NewController(t)),
+		}
+
+		setupImportUser(t, storage)
+
+		_, err := useCases.ImportUserData(context.Background(), importTelegramID, entities.ImportConflictSkip)
+		require.ErrorIs(t, err, customerrors.ErrInvalidImport)
+	})
+
+	t.Run("Failure - unknown conflict resolution", func(t *testing.T) {
+		useCases := &temporaryUseCases{
+			storage: memory.New(),
+			logger:  mocklogging.NewMockLogger(gomock.NewController(t)),
+		}
+
+		_, err := useCases.ImportUserData(context.Background(), importTelegramID, "merge")
+		require.ErrorIs(t, err, customerrors.ErrUnknownImportConflictResolution)
+	})
+
+	t.Run("Failure - storage error rolls back whole import", func(t *testing.T) {
+		ctx := context.Background()
+		storage := memory.New()
+		logger := mocklogging.NewMockLogger(gomock.NewController(t))
+		useCases := &temporaryUseCases{
+			storage: storage,
+			logger:  logger,
+		}
+
+		userID, _ := setupImportUser(t, storage)
+
+		// Второй сценарий нарушает ограничение схемы, поэтому первый тоже не должен сохраниться:
+		export := newImportExport()
+		export.Groups[1].CareTasks = []entities.ExportCareTask{{Type: "pruning", Interval: 1}}
+
+		temp, err := storage.GetTemporaryByUserID(ctx, userID)
+		require.NoError(t, err)
+
+		temp.Step = steps.ConfirmImport
+		temp.Data, err = json.Marshal(entities.Import{Export: &export})
+		require.NoError(t, err)
+		require.NoError(t, storage.UpdateTemporary(ctx, *temp))
+
+		logger.
+			EXPECT().
+			Error(
+				"Failed to import Group with Title=Спальня for User with ID=1",
+				"Error", gomock.Any(),
+				"Tracing", gomock.Any(),
+			).
+			Times(1)
+
+		_, err = useCases.ImportUserData(ctx, importTelegramID, entities.ImportConflictRename)
+		require.Error(t, err)
+
+		count, err := storage.CountUserGroups(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		temp, err = storage.GetTemporaryByUserID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, steps.ConfirmImport, temp.Step)
+	})
+}
+
+// setupImportUser создает пользователя с временными данными и сценарием "Кухня" с одним растением.
+func setupImportUser(t *testing.T, storage *memory.Storage) (userID, groupID int) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	userID, err := storage.SaveUser(ctx, entities.User{TelegramID: importTelegramID, Username: "user"})
+	require.NoError(t, err)
+	require.NoError(t, storage.CreateTemporary(ctx, entities.Temporary{UserID: userID, Step: steps.ImportData}))
+
+	groupID, err = storage.CreateGroup(
+		ctx,
+		entities.Group{UserID: userID, Title: "Кухня", WateringInterval: 3, LastWateringDate: importWateringDate},
+	)
+	require.NoError(t, err)
+
+	_, err = storage.CreatePlant(ctx, entities.Plant{GroupID: groupID, UserID: userID, Title: "Алоэ"})
+	require.NoError(t, err)
+
+	return userID, groupID
+}
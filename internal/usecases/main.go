@@ -12,6 +12,12 @@ type UseCases struct {
 	plantsUseCases
 	temporaryUseCases
 	notificationsUseCases
+	wateringsUseCases
+	careTasksUseCases
+	plantPhotosUseCases
+	householdsUseCases
+	delegationsUseCases
+	exportUseCases
 }
 
 const (
@@ -43,5 +49,29 @@ func New(
 			storage: storage,
 			logger:  logger,
 		},
+		wateringsUseCases: wateringsUseCases{
+			storage: storage,
+			logger:  logger,
+		},
+		careTasksUseCases: careTasksUseCases{
+			storage: storage,
+			logger:  logger,
+		},
+		plantPhotosUseCases: plantPhotosUseCases{
+			storage: storage,
+			logger:  logger,
+		},
+		householdsUseCases: householdsUseCases{
+			storage: storage,
+			logger:  logger,
+		},
+		delegationsUseCases: delegationsUseCases{
+			storage: storage,
+			logger:  logger,
+		},
+		exportUseCases: exportUseCases{
+			storage: storage,
+			logger:  logger,
+		},
 	}
 }
@@ -45,6 +45,12 @@ func TestNew_UseCasesInitialization(t *testing.T) {
 				assert.NotNil(t, uc.notificationsUseCases.storage, "notificationsUseCases.storage should be set")
 				assert.NotNil(t, uc.notificationsUseCases.logger, "notificationsUseCases.logger should be set")
 
+				assert.NotNil(t, uc.wateringsUseCases.storage, "wateringsUseCases.storage should be set")
+				assert.NotNil(t, uc.wateringsUseCases.logger, "wateringsUseCases.logger should be set")
+
+				assert.NotNil(t, uc.careTasksUseCases.storage, "careTasksUseCases.storage should be set")
+				assert.NotNil(t, uc.careTasksUseCases.logger, "careTasksUseCases.logger should be set")
+
 				// Проверяем, что зависимости переданы те же
 				assert.Same(t, mockStorage, uc.usersUseCases.storage, "Storage should be the same instance")
 				assert.Same(t, mockLogger, uc.usersUseCases.logger, "Logger should be the same instance")
@@ -1,11 +1,16 @@
 package usecases
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/DKhorkov/libs/logging"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
 )
 
@@ -14,8 +19,11 @@ type notificationsUseCases struct {
 	logger  logging.Logger
 }
 
-func (u *notificationsUseCases) SaveNotification(notification entities.Notification) (*entities.Notification, error) {
-	notificationID, err := u.storage.SaveNotification(notification)
+func (u *notificationsUseCases) SaveNotification(
+	ctx context.Context,
+	notification entities.Notification,
+) (*entities.Notification, error) {
+	notificationID, err := u.storage.SaveNotification(ctx, notification)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to save Notification for Group with ID=%d", notification.GroupID),
@@ -30,3 +38,112 @@ func (u *notificationsUseCases) SaveNotification(notification entities.Notificat
 
 	return &notification, err
 }
+
+func (u *notificationsUseCases) GetLastNotification(ctx context.Context, groupID int) (*entities.Notification, error) {
+	notification, err := u.storage.GetLastNotification(ctx, groupID)
+	if err != nil {
+		// Отсутствие уведомлений - штатная ситуация, которую не нужно логировать:
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customerrors.ErrNotificationNotFound
+		}
+
+		u.logger.Error(
+			fmt.Sprintf("Failed to get last Notification for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (u *notificationsUseCases) GetLastCareTaskNotification(
+	ctx context.Context,
+	careTaskID int,
+) (*entities.Notification, error) {
+	notification, err := u.storage.GetLastCareTaskNotification(ctx, careTaskID)
+	if err != nil {
+		// Отсутствие уведомлений - штатная ситуация, которую не нужно логировать:
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customerrors.ErrNotificationNotFound
+		}
+
+		u.logger.Error(
+			fmt.Sprintf("Failed to get last Notification for CareTask with ID=%d", careTaskID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (u *notificationsUseCases) GetGroupNotificationsSince(
+	ctx context.Context,
+	groupID int,
+	since time.Time,
+) ([]entities.Notification, error) {
+	// Время отправки хранится в UTC, так как колонка не содержит информации о часовом поясе:
+	notifications, err := u.storage.GetGroupNotificationsSince(ctx, groupID, since.UTC())
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Notifications for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return notifications, err
+}
+
+func (u *notificationsUseCases) GetLastCareTaskNotifications(
+	ctx context.Context,
+	careTaskID int,
+) ([]entities.Notification, error) {
+	notifications, err := u.storage.GetLastCareTaskNotifications(ctx, careTaskID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get last Notifications for CareTask with ID=%d", careTaskID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return notifications, err
+}
+
+func (u *notificationsUseCases) GetMessageNotifications(
+	ctx context.Context,
+	chatID int64,
+	messageID int,
+) ([]entities.Notification, error) {
+	notifications, err := u.storage.GetMessageNotifications(ctx, chatID, messageID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Notifications for Message with ID=%d in Chat with ID=%d", messageID, chatID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return notifications, err
+}
+
+func (u *notificationsUseCases) AcknowledgeGroupNotifications(ctx context.Context, groupID int) error {
+	// Время храним в UTC, так как колонка не содержит информации о часовом поясе:
+	if err := u.storage.AcknowledgeGroupNotifications(ctx, groupID, time.Now().UTC()); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to acknowledge Notifications for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
@@ -1,8 +1,11 @@
 package usecases
 
 import (
+	"context"
+	"database/sql"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -31,7 +34,7 @@ func TestNotificationsUseCases_SaveNotification(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					SaveNotification(inputNotification).
+					SaveNotification(gomock.Any(), inputNotification).
 					Return(42, nil).
 					Times(1)
 			},
@@ -50,7 +53,7 @@ func TestNotificationsUseCases_SaveNotification(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					SaveNotification(inputNotification).
+					SaveNotification(gomock.Any(), inputNotification).
 					Return(0, assert.AnError).
 					Times(1)
 
@@ -83,7 +86,7 @@ func TestNotificationsUseCases_SaveNotification(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.SaveNotification(tt.notification)
+			got, err := useCases.SaveNotification(context.Background(), tt.notification)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -100,3 +103,344 @@ func TestNotificationsUseCases_SaveNotification(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationsUseCases_GetLastNotification(t *testing.T) {
+	notification := &entities.Notification{
+		ID:        42,
+		GroupID:   1,
+		MessageID: 100,
+		Text:      "Не забудьте полить цветы!",
+		SentAt:    time.Date(2025, 9, 2, 9, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name        string
+		groupID     int
+		setupMocks  func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want        *entities.Notification
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:    "Success - notification found",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetLastNotification(gomock.Any(), 1).
+					Return(notification, nil).
+					Times(1)
+			},
+			want:    notification,
+			wantErr: false,
+		},
+		{
+			name:    "Failure - notification not found",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetLastNotification(gomock.Any(), 1).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: customerrors.ErrNotificationNotFound,
+		},
+		{
+			name:    "Failure - storage error",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetLastNotification(gomock.Any(), 1).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get last Notification for Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &notificationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetLastNotification(context.Background(), tt.groupID)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNotificationsUseCases_GetLastCareTaskNotification(t *testing.T) {
+	careTaskID := 7
+	notification := &entities.Notification{
+		ID:         43,
+		GroupID:    1,
+		MessageID:  101,
+		Text:       "Пора подкормить растения!",
+		SentAt:     time.Date(2025, 9, 2, 9, 0, 0, 0, time.UTC),
+		CareTaskID: &careTaskID,
+	}
+
+	tests := []struct {
+		name        string
+		careTaskID  int
+		setupMocks  func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want        *entities.Notification
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:       "Success - notification found",
+			careTaskID: 7,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetLastCareTaskNotification(gomock.Any(), 7).
+					Return(notification, nil).
+					Times(1)
+			},
+			want:    notification,
+			wantErr: false,
+		},
+		{
+			name:       "Failure - notification not found",
+			careTaskID: 7,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetLastCareTaskNotification(gomock.Any(), 7).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: customerrors.ErrNotificationNotFound,
+		},
+		{
+			name:       "Failure - storage error",
+			careTaskID: 7,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetLastCareTaskNotification(gomock.Any(), 7).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get last Notification for CareTask with ID=7",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &notificationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetLastCareTaskNotification(context.Background(), tt.careTaskID)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNotificationsUseCases_AcknowledgeGroupNotifications(t *testing.T) {
+	tests := []struct {
+		name       string
+		groupID    int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    bool
+	}{
+		{
+			name:    "Success - notifications acknowledged",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					AcknowledgeGroupNotifications(
+						gomock.Any(),
+						1,
+						gomock.Cond(func(acknowledgedAt time.Time) bool {
+							return acknowledgedAt.Location() == time.UTC
+						}),
+					).
+					Return(nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Failure - storage error",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					AcknowledgeGroupNotifications(gomock.Any(), 1, gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to acknowledge Notifications for Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &notificationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			err := useCases.AcknowledgeGroupNotifications(context.Background(), tt.groupID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationsUseCases_GetMessageNotifications(t *testing.T) {
+	notifications := []entities.Notification{
+		{ID: 1, GroupID: 10, MessageID: 100, ChatID: 500, Digest: true},
+		{ID: 2, GroupID: 11, MessageID: 100, ChatID: 500, Digest: true},
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       []entities.Notification
+		wantErr    bool
+	}{
+		{
+			name: "Success - notifications found",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetMessageNotifications(gomock.Any(), int64(500), 100).
+					Return(notifications, nil).
+					Times(1)
+			},
+			want: notifications,
+		},
+		{
+			name: "Failure - storage error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetMessageNotifications(gomock.Any(), int64(500), 100).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Notifications for Message with ID=100 in Chat with ID=500",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &notificationsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetMessageNotifications(context.Background(), 500, 100)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+type plantPhotosUseCases struct {
+	storage interfaces.Storage
+	logger  logging.Logger
+}
+
+func (u *plantPhotosUseCases) SavePlantPhoto(
+	ctx context.Context,
+	photo entities.PlantPhoto,
+) (*entities.PlantPhoto, error) {
+	photoID, err := u.storage.SavePlantPhoto(ctx, photo)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to save PlantPhoto for Plant with ID=%d", photo.PlantID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	photo.ID = photoID
+
+	return &photo, err
+}
+
+func (u *plantPhotosUseCases) GetPlantPhoto(ctx context.Context, id int) (*entities.PlantPhoto, error) {
+	photo, err := u.storage.GetPlantPhoto(ctx, id)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get PlantPhoto with ID=%d", id),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return photo, err
+}
+
+func (u *plantPhotosUseCases) GetPlantPhotos(
+	ctx context.Context,
+	plantID, limit, offset int,
+) ([]entities.PlantPhoto, error) {
+	photos, err := u.storage.GetPlantPhotos(ctx, plantID, limit, offset)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get PlantPhotos for Plant with ID=%d", plantID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return photos, err
+}
+
+func (u *plantPhotosUseCases) CountPlantPhotos(ctx context.Context, plantID int) (int, error) {
+	count, err := u.storage.CountPlantPhotos(ctx, plantID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to count PlantPhotos for Plant with ID=%d", plantID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return count, err
+}
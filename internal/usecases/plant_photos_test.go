@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestPlantPhotosUseCases_SavePlantPhoto(t *testing.T) {
+	photo := entities.PlantPhoto{
+		PlantID:      20,
+		FileID:       "file",
+		FileUniqueID: "file_unique",
+		Caption:      "Новый лист",
+		TakenAt:      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name       string
+		photo      entities.PlantPhoto
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantID     int
+		wantErr    bool
+	}{
+		{
+			name:  "Success - photo saved",
+			photo: photo,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					SavePlantPhoto(gomock.Any(), photo).
+					Return(5, nil).
+					Times(1)
+			},
+			wantID:  5,
+			wantErr: false,
+		},
+		{
+			name:  "Failure - storage error",
+			photo: photo,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					SavePlantPhoto(gomock.Any(), photo).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to save PlantPhoto for Plant with ID=20",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &plantPhotosUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.SavePlantPhoto(context.Background(), tt.photo)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantID, got.ID)
+				assert.Equal(t, tt.photo.Caption, got.Caption)
+			}
+		})
+	}
+}
+
+func TestPlantPhotosUseCases_GetPlantPhotos(t *testing.T) {
+	photos := []entities.PlantPhoto{
+		{ID: 1, PlantID: 20, FileID: "first"},
+		{ID: 2, PlantID: 20, FileID: "second"},
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       []entities.PlantPhoto
+		wantErr    bool
+	}{
+		{
+			name: "Success - photos found",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetPlantPhotos(gomock.Any(), 20, 2, 0).
+					Return(photos, nil).
+					Times(1)
+			},
+			want:    photos,
+			wantErr: false,
+		},
+		{
+			name: "Failure - storage error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetPlantPhotos(gomock.Any(), 20, 2, 0).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get PlantPhotos for Plant with ID=20",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &plantPhotosUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetPlantPhotos(context.Background(), 20, 2, 0)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
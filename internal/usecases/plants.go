@@ -1,7 +1,9 @@
 package usecases
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/DKhorkov/libs/logging"
 
@@ -15,8 +17,8 @@ type plantsUseCases struct {
 	logger  logging.Logger
 }
 
-func (u *plantsUseCases) CountUserPlants(userID int) (int, error) {
-	count, err := u.storage.CountUserPlants(userID)
+func (u *plantsUseCases) CountUserPlants(ctx context.Context, userID int) (int, error) {
+	count, err := u.storage.CountUserPlants(ctx, userID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to count Plants for User with ID=%d", userID),
@@ -30,8 +32,8 @@ func (u *plantsUseCases) CountUserPlants(userID int) (int, error) {
 	return count, err
 }
 
-func (u *plantsUseCases) GetGroupPlants(groupID int) ([]entities.Plant, error) {
-	plants, err := u.storage.GetGroupPlants(groupID)
+func (u *plantsUseCases) GetGroupPlants(ctx context.Context, groupID int) ([]entities.Plant, error) {
+	plants, err := u.storage.GetGroupPlants(ctx, groupID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get Plants for Group with ID=%d", groupID),
@@ -45,8 +47,8 @@ func (u *plantsUseCases) GetGroupPlants(groupID int) ([]entities.Plant, error) {
 	return plants, err
 }
 
-func (u *plantsUseCases) CountGroupPlants(groupID int) (int, error) {
-	count, err := u.storage.CountGroupPlants(groupID)
+func (u *plantsUseCases) CountGroupPlants(ctx context.Context, groupID int) (int, error) {
+	count, err := u.storage.CountGroupPlants(ctx, groupID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to count Plants for Group with ID=%d", groupID),
@@ -60,8 +62,8 @@ func (u *plantsUseCases) CountGroupPlants(groupID int) (int, error) {
 	return count, err
 }
 
-func (u *plantsUseCases) CreatePlant(plant entities.Plant) (*entities.Plant, error) {
-	plantID, err := u.storage.CreatePlant(plant)
+func (u *plantsUseCases) CreatePlant(ctx context.Context, plant entities.Plant) (*entities.Plant, error) {
+	plantID, err := u.storage.CreatePlant(ctx, plant)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to create Plant for Group with ID=%d", plant.GroupID),
@@ -77,8 +79,8 @@ func (u *plantsUseCases) CreatePlant(plant entities.Plant) (*entities.Plant, err
 	return &plant, err
 }
 
-func (u *plantsUseCases) GetPlant(id int) (*entities.Plant, error) {
-	plant, err := u.storage.GetPlant(id)
+func (u *plantsUseCases) GetPlant(ctx context.Context, id int) (*entities.Plant, error) {
+	plant, err := u.storage.GetPlant(ctx, id)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get Plant with ID=%d", id),
@@ -92,8 +94,8 @@ func (u *plantsUseCases) GetPlant(id int) (*entities.Plant, error) {
 	return plant, err
 }
 
-func (u *plantsUseCases) DeletePlant(id int) error {
-	err := u.storage.DeletePlant(id)
+func (u *plantsUseCases) DeletePlant(ctx context.Context, id int) error {
+	err := u.storage.DeletePlant(ctx, id)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to delete Plant with ID=%d", id),
@@ -105,15 +107,15 @@ func (u *plantsUseCases) DeletePlant(id int) error {
 	return err
 }
 
-func (u *plantsUseCases) UpdatePlantTitle(id int, title string) (*entities.Plant, error) {
-	plant, err := u.GetPlant(id)
+func (u *plantsUseCases) UpdatePlantTitle(ctx context.Context, id int, title string) (*entities.Plant, error) {
+	plant, err := u.GetPlant(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	plant.Title = title
 
-	exists, err := u.storage.PlantExists(*plant)
+	exists, err := u.storage.PlantExists(ctx, *plant)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to check existence for Plant with ID=%d", plant.ID),
@@ -128,7 +130,7 @@ func (u *plantsUseCases) UpdatePlantTitle(id int, title string) (*entities.Plant
 		return nil, customerrors.ErrPlantAlreadyExists
 	}
 
-	if err = u.storage.UpdatePlant(*plant); err != nil {
+	if err = u.storage.UpdatePlant(ctx, *plant); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Plant with ID=%d", plant.ID),
 			"Error", err,
@@ -141,14 +143,18 @@ func (u *plantsUseCases) UpdatePlantTitle(id int, title string) (*entities.Plant
 	return plant, err
 }
 
-func (u *plantsUseCases) UpdatePlantDescription(id int, description string) (*entities.Plant, error) {
-	plant, err := u.GetPlant(id)
+func (u *plantsUseCases) UpdatePlantDescription(
+	ctx context.Context,
+	id int,
+	description string,
+) (*entities.Plant, error) {
+	plant, err := u.GetPlant(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	plant.Description = description
-	if err = u.storage.UpdatePlant(*plant); err != nil {
+	if err = u.storage.UpdatePlant(ctx, *plant); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Plant with ID=%d", plant.ID),
 			"Error", err,
@@ -161,15 +167,15 @@ func (u *plantsUseCases) UpdatePlantDescription(id int, description string) (*en
 	return plant, err
 }
 
-func (u *plantsUseCases) UpdatePlantGroup(id, groupID int) (*entities.Plant, error) {
-	plant, err := u.GetPlant(id)
+func (u *plantsUseCases) UpdatePlantGroup(ctx context.Context, id, groupID int) (*entities.Plant, error) {
+	plant, err := u.GetPlant(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	plant.GroupID = groupID
 
-	exists, err := u.storage.PlantExists(*plant)
+	exists, err := u.storage.PlantExists(ctx, *plant)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to check existence for Plant with ID=%d", plant.ID),
@@ -184,7 +190,7 @@ func (u *plantsUseCases) UpdatePlantGroup(id, groupID int) (*entities.Plant, err
 		return nil, customerrors.ErrPlantAlreadyExists
 	}
 
-	if err = u.storage.UpdatePlant(*plant); err != nil {
+	if err = u.storage.UpdatePlant(ctx, *plant); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Plant with ID=%d", plant.ID),
 			"Error", err,
@@ -197,14 +203,22 @@ func (u *plantsUseCases) UpdatePlantGroup(id, groupID int) (*entities.Plant, err
 	return plant, err
 }
 
-func (u *plantsUseCases) UpdatePlantPhoto(id int, photo []byte) (*entities.Plant, error) {
-	plant, err := u.GetPlant(id)
+// UpdatePlantPhoto сохраняет ссылку на фотографию растения в Telegram. Байты фотографии, сохраненные до
+// перехода на file_id, больше не нужны и удаляются.
+func (u *plantsUseCases) UpdatePlantPhoto(
+	ctx context.Context,
+	id int,
+	fileID, fileUniqueID string,
+) (*entities.Plant, error) {
+	plant, err := u.GetPlant(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	plant.Photo = photo
-	if err = u.storage.UpdatePlant(*plant); err != nil {
+	plant.PhotoFileID = fileID
+	plant.PhotoFileUniqueID = fileUniqueID
+	plant.Photo = nil
+	if err = u.storage.UpdatePlant(ctx, *plant); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Plant with ID=%d", plant.ID),
 			"Error", err,
@@ -216,3 +230,133 @@ func (u *plantsUseCases) UpdatePlantPhoto(id int, photo []byte) (*entities.Plant
 
 	return plant, err
 }
+
+// UpdatePlantWateringInterval задает растению собственный интервал полива. Интервал nil возвращает растение
+// к интервалу сценария.
+func (u *plantsUseCases) UpdatePlantWateringInterval(
+	ctx context.Context,
+	id int,
+	wateringInterval *int,
+) (*entities.Plant, error) {
+	plant, err := u.GetPlant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plant.WateringInterval = wateringInterval
+
+	// Дата полива сценария пересчитывается по растениям внутри транзакции, чтобы учесть новый интервал:
+	err = u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			if err := tx.UpdatePlant(ctx, *plant); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to update Plant with ID=%d", plant.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return u.updateGroupNextWateringDate(ctx, tx, plant.GroupID)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return plant, nil
+}
+
+// UpdatePlantLastWateringDate отмечает полив отдельного растения участником дома с userID
+// без полива всего сценария.
+func (u *plantsUseCases) UpdatePlantLastWateringDate(
+	ctx context.Context,
+	id int,
+	lastWateringDate time.Time,
+	source string,
+	userID int,
+) (*entities.Plant, error) {
+	plant, err := u.GetPlant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plant.LastWateringDate = &lastWateringDate
+
+	err = u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			if err := tx.UpdatePlant(ctx, *plant); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to update Plant with ID=%d", plant.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			watering := entities.Watering{
+				GroupID:   plant.GroupID,
+				PlantID:   &plant.ID,
+				WateredAt: lastWateringDate,
+				Source:    source,
+				UserID:    &userID,
+			}
+
+			if _, err := tx.SaveWatering(ctx, watering); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to save Watering for Plant with ID=%d", plant.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return u.updateGroupNextWateringDate(ctx, tx, plant.GroupID)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return plant, nil
+}
+
+// updateGroupNextWateringDate пересчитывает дату следующего полива сценария после изменения графика растения.
+func (u *plantsUseCases) updateGroupNextWateringDate(
+	ctx context.Context,
+	storage interfaces.Storage,
+	groupID int,
+) error {
+	group, err := storage.GetGroup(ctx, groupID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	group.NextWateringDate, err = getGroupNextWateringDate(ctx, storage, u.logger, *group)
+	if err != nil {
+		return err
+	}
+
+	if err = storage.UpdateGroup(ctx, *group); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update Group with ID=%d", group.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
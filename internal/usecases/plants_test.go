@@ -1,9 +1,11 @@
 package usecases
 
 import (
+	"context"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
 	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -25,7 +27,7 @@ func TestPlantsUseCases_CountUserPlants(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CountUserPlants(123).
+					CountUserPlants(gomock.Any(), 123).
 					Return(5, nil).
 					Times(1)
 			},
@@ -38,7 +40,7 @@ func TestPlantsUseCases_CountUserPlants(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CountUserPlants(123).
+					CountUserPlants(gomock.Any(), 123).
 					Return(0, assert.AnError).
 					Times(1)
 				logger.
@@ -70,7 +72,7 @@ func TestPlantsUseCases_CountUserPlants(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.CountUserPlants(tt.userID)
+			got, err := useCases.CountUserPlants(context.Background(), tt.userID)
 
 			assert.Equal(t, tt.wantCount, got)
 			assert.Equal(t, tt.wantErr, err != nil)
@@ -106,7 +108,7 @@ func TestPlantsUseCases_GetGroupPlants(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroupPlants(10).
+					GetGroupPlants(gomock.Any(), 10).
 					Return(expectedPlants, nil).
 					Times(1)
 			},
@@ -119,7 +121,7 @@ func TestPlantsUseCases_GetGroupPlants(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetGroupPlants(10).
+					GetGroupPlants(gomock.Any(), 10).
 					Return(nil, assert.AnError).
 					Times(1)
 				logger.
@@ -151,7 +153,7 @@ func TestPlantsUseCases_GetGroupPlants(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetGroupPlants(tt.groupID)
+			got, err := useCases.GetGroupPlants(context.Background(), tt.groupID)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -180,7 +182,7 @@ func TestPlantsUseCases_CountGroupPlants(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CountGroupPlants(10).
+					CountGroupPlants(gomock.Any(), 10).
 					Return(3, nil).
 					Times(1)
 			},
@@ -193,7 +195,7 @@ func TestPlantsUseCases_CountGroupPlants(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CountGroupPlants(10).
+					CountGroupPlants(gomock.Any(), 10).
 					Return(0, assert.AnError).
 					Times(1)
 				logger.
@@ -225,7 +227,7 @@ func TestPlantsUseCases_CountGroupPlants(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.CountGroupPlants(tt.groupID)
+			got, err := useCases.CountGroupPlants(context.Background(), tt.groupID)
 
 			assert.Equal(t, tt.wantCount, got)
 			assert.Equal(t, tt.wantErr, err != nil)
@@ -255,7 +257,7 @@ func TestPlantsUseCases_CreatePlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CreatePlant(inputPlant).
+					CreatePlant(gomock.Any(), inputPlant).
 					Return(42, nil).
 					Times(1)
 			},
@@ -275,7 +277,7 @@ func TestPlantsUseCases_CreatePlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					CreatePlant(inputPlant).
+					CreatePlant(gomock.Any(), inputPlant).
 					Return(0, assert.AnError).
 					Times(1)
 				logger.
@@ -307,7 +309,7 @@ func TestPlantsUseCases_CreatePlant(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.CreatePlant(tt.plant)
+			got, err := useCases.CreatePlant(context.Background(), tt.plant)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -350,7 +352,7 @@ func TestPlantsUseCases_GetPlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(expectedPlant, nil).
 					Times(1)
 			},
@@ -363,7 +365,7 @@ func TestPlantsUseCases_GetPlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(nil, assert.AnError).
 					Times(1)
 				logger.
@@ -395,7 +397,7 @@ func TestPlantsUseCases_GetPlant(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetPlant(tt.plantID)
+			got, err := useCases.GetPlant(context.Background(), tt.plantID)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -422,7 +424,7 @@ func TestPlantsUseCases_DeletePlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					DeletePlant(1).
+					DeletePlant(gomock.Any(), 1).
 					Return(nil).
 					Times(1)
 			},
@@ -434,7 +436,7 @@ func TestPlantsUseCases_DeletePlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					DeletePlant(1).
+					DeletePlant(gomock.Any(), 1).
 					Return(assert.AnError).
 					Times(1)
 				logger.
@@ -465,7 +467,7 @@ func TestPlantsUseCases_DeletePlant(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.DeletePlant(tt.plantID)
+			err := useCases.DeletePlant(context.Background(), tt.plantID)
 
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
@@ -513,15 +515,15 @@ func TestPlantsUseCases_UpdatePlantTitle(t *testing.T) {
 				// Получение растения
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				// Проверка на дубль — не существует
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) (bool, error) {
+					PlantExists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) (bool, error) {
 						assert.Equal(t, "Новый заголовок", p.Title)
 						assert.Equal(t, 10, p.GroupID)
 						return false, nil
@@ -531,8 +533,8 @@ func TestPlantsUseCases_UpdatePlantTitle(t *testing.T) {
 				// Обновление
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) error {
+					UpdatePlant(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) error {
 						assert.Equal(t, "Новый заголовок", p.Title)
 						assert.Equal(t, 1, p.ID)
 						return nil
@@ -549,7 +551,7 @@ func TestPlantsUseCases_UpdatePlantTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(999).
+					GetPlant(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -572,13 +574,13 @@ func TestPlantsUseCases_UpdatePlantTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
+					PlantExists(gomock.Any(), gomock.Any()).
 					Return(false, assert.AnError).
 					Times(1)
 
@@ -601,13 +603,13 @@ func TestPlantsUseCases_UpdatePlantTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
+					PlantExists(gomock.Any(), gomock.Any()).
 					Return(true, nil).
 					Times(1)
 			},
@@ -622,19 +624,19 @@ func TestPlantsUseCases_UpdatePlantTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
+					PlantExists(gomock.Any(), gomock.Any()).
 					Return(false, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
+					UpdatePlant(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -667,7 +669,7 @@ func TestPlantsUseCases_UpdatePlantTitle(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.UpdatePlantTitle(tt.plantID, tt.title)
+			got, err := useCases.UpdatePlantTitle(context.Background(), tt.plantID, tt.title)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -725,14 +727,14 @@ func TestPlantsUseCases_UpdatePlantDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) error {
+					UpdatePlant(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) error {
 						assert.Equal(t, "Новое описание", p.Description)
 						return nil
 					}).
@@ -748,7 +750,7 @@ func TestPlantsUseCases_UpdatePlantDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(999).
+					GetPlant(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -771,13 +773,13 @@ func TestPlantsUseCases_UpdatePlantDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
+					UpdatePlant(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -810,7 +812,7 @@ func TestPlantsUseCases_UpdatePlantDescription(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.UpdatePlantDescription(tt.plantID, tt.description)
+			got, err := useCases.UpdatePlantDescription(context.Background(), tt.plantID, tt.description)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -854,14 +856,14 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) (bool, error) {
+					PlantExists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) (bool, error) {
 						assert.Equal(t, 20, p.GroupID)
 						return false, nil
 					}).
@@ -869,8 +871,8 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) error {
+					UpdatePlant(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) error {
 						assert.Equal(t, 20, p.GroupID)
 						return nil
 					}).
@@ -889,14 +891,14 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) (bool, error) {
+					PlantExists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) (bool, error) {
 						assert.Equal(t, 20, p.GroupID)
 						return false, nil
 					}).
@@ -904,7 +906,7 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
+					UpdatePlant(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -926,7 +928,7 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -949,13 +951,13 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
+					PlantExists(gomock.Any(), gomock.Any()).
 					Return(true, nil).
 					Times(1)
 			},
@@ -970,13 +972,13 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Any()).
+					PlantExists(gomock.Any(), gomock.Any()).
 					Return(false, assert.AnError).
 					Times(1)
 
@@ -1009,7 +1011,7 @@ func TestPlantsUseCases_UpdatePlantGroup(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.UpdatePlantGroup(tt.plantID, tt.groupID)
+			got, err := useCases.UpdatePlantGroup(context.Background(), tt.plantID, tt.groupID)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -1035,57 +1037,58 @@ func TestPlantsUseCases_UpdatePlantPhoto(t *testing.T) {
 		UserID:      123,
 		Title:       "Фикус",
 		Description: "Комнатное растение",
-		Photo:       []byte{0xFF, 0xD8}, // старое фото
+		Photo:       []byte{0xFF, 0xD8}, // фото, сохраненное до перехода на file_id
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
 
-	newPhoto := []byte{0xFF, 0xD9} // новое фото
+	newFileID := "new_file_id" // новое фото
 
 	tests := []struct {
 		name       string
 		plantID    int
-		photo      []byte
+		fileID     string
 		setupMocks func(*mockstorage.MockStorage, *mocklogging.MockLogger)
-		wantPhoto  []byte
+		wantFileID string
 		wantErr    bool
 	}{
 		{
 			name:    "Success - photo updated successfully",
 			plantID: 1,
-			photo:   newPhoto,
+			fileID:  newFileID,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				// Получение растения
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				// Проверка, что в UpdatePlant передаётся объект с новым фото
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) error {
+					UpdatePlant(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) error {
 						assert.Equal(t, 1, p.ID)
-						assert.Equal(t, newPhoto, p.Photo)
+						assert.Equal(t, newFileID, p.PhotoFileID)
+						assert.Nil(t, p.Photo)
 						assert.Equal(t, "Фикус", p.Title)
 						return nil
 					}).
 					Times(1)
 			},
-			wantPhoto: newPhoto,
-			wantErr:   false,
+			wantFileID: newFileID,
+			wantErr:    false,
 		},
 		{
 			name:    "Failure - plant not found",
 			plantID: 999,
-			photo:   newPhoto,
+			fileID:  newFileID,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				// Ошибка при получении
 				storage.
 					EXPECT().
-					GetPlant(999).
+					GetPlant(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1099,23 +1102,23 @@ func TestPlantsUseCases_UpdatePlantPhoto(t *testing.T) {
 					).
 					Times(1)
 			},
-			wantPhoto: nil,
-			wantErr:   true,
+			wantFileID: "",
+			wantErr:    true,
 		},
 		{
 			name:    "Failure - UpdatePlant returns error",
 			plantID: 1,
-			photo:   newPhoto,
+			fileID:  newFileID,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
+					UpdatePlant(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1128,31 +1131,32 @@ func TestPlantsUseCases_UpdatePlantPhoto(t *testing.T) {
 					).
 					Times(1)
 			},
-			wantPhoto: nil,
-			wantErr:   true,
+			wantFileID: "",
+			wantErr:    true,
 		},
 		{
-			name:    "Success - photo set to nil (clear photo)",
+			name:    "Success - photo reset to default image",
 			plantID: 1,
-			photo:   nil,
+			fileID:  "",
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetPlant(1).
+					GetPlant(gomock.Any(), 1).
 					Return(existingPlant, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdatePlant(gomock.Any()).
-					DoAndReturn(func(p entities.Plant) error {
+					UpdatePlant(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p entities.Plant) error {
+						assert.Empty(t, p.PhotoFileID)
 						assert.Nil(t, p.Photo)
 						return nil
 					}).
 					Times(1)
 			},
-			wantPhoto: nil,
-			wantErr:   false,
+			wantFileID: "",
+			wantErr:    false,
 		},
 	}
 
@@ -1171,7 +1175,7 @@ func TestPlantsUseCases_UpdatePlantPhoto(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.UpdatePlantPhoto(tt.plantID, tt.photo)
+			got, err := useCases.UpdatePlantPhoto(context.Background(), tt.plantID, tt.fileID, "")
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -1179,9 +1183,284 @@ func TestPlantsUseCases_UpdatePlantPhoto(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, got)
-				assert.Equal(t, tt.wantPhoto, got.Photo)
+				assert.Equal(t, tt.wantFileID, got.PhotoFileID)
 				assert.Equal(t, 1, got.ID)
 			}
 		})
 	}
 }
+
+func TestPlantsUseCases_UpdatePlantWateringInterval(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+	interval := 3
+
+	newPlant := func() *entities.Plant {
+		return &entities.Plant{ID: 1, GroupID: 10, UserID: 123, Title: "Папоротник"}
+	}
+
+	newGroup := func() *entities.Group {
+		return &entities.Group{
+			ID:               10,
+			UserID:           123,
+			WateringInterval: 7,
+			LastWateringDate: today,
+			NextWateringDate: today.AddDate(0, 0, 7),
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		wateringInterval     *int
+		setupMocks           func(*mockstorage.MockStorage, *mocklogging.MockLogger)
+		wantNextWateringDate time.Time
+		wantErr              bool
+	}{
+		{
+			name:             "Success - plant interval moves group watering date",
+			wateringInterval: &interval,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetPlant(gomock.Any(), 1).Return(newPlant(), nil).Times(1)
+				storage.EXPECT().UpdatePlant(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				storage.EXPECT().GetGroup(gomock.Any(), 10).Return(newGroup(), nil).Times(1)
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 10).
+					Return([]entities.Plant{{ID: 1, GroupID: 10, WateringInterval: &interval}}, nil).
+					Times(1)
+
+				storage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+				storage.
+					EXPECT().
+					UpdateGroup(
+						gomock.Any(),
+						gomock.Cond(func(g entities.Group) bool {
+							return g.NextWateringDate.Equal(today.AddDate(0, 0, interval))
+						}),
+					).
+					Return(nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name:             "Success - plant interval reset",
+			wateringInterval: nil,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetPlant(gomock.Any(), 1).Return(newPlant(), nil).Times(1)
+				storage.EXPECT().UpdatePlant(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				storage.EXPECT().GetGroup(gomock.Any(), 10).Return(newGroup(), nil).Times(1)
+				storage.EXPECT().GetGroupPlants(gomock.Any(), 10).Return([]entities.Plant{{ID: 1, GroupID: 10}}, nil).Times(1)
+				storage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+				storage.
+					EXPECT().
+					UpdateGroup(
+						gomock.Any(),
+						gomock.Cond(func(g entities.Group) bool {
+							return g.NextWateringDate.Equal(today.AddDate(0, 0, 7))
+						}),
+					).
+					Return(nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name:             "Failure - UpdatePlant returns error",
+			wateringInterval: &interval,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetPlant(gomock.Any(), 1).Return(newPlant(), nil).Times(1)
+				storage.EXPECT().UpdatePlant(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update Plant with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:             "Failure - group not found",
+			wateringInterval: &interval,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetPlant(gomock.Any(), 1).Return(newPlant(), nil).Times(1)
+				storage.EXPECT().UpdatePlant(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				storage.EXPECT().GetGroup(gomock.Any(), 10).Return(nil, assert.AnError).Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Group with ID=10",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &plantsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdatePlantWateringInterval(context.Background(), 1, tt.wateringInterval)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wateringInterval, got.WateringInterval)
+			}
+		})
+	}
+}
+
+func TestPlantsUseCases_UpdatePlantLastWateringDate(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	user := entities.User{ID: 123, Timezone: "UTC"}
+	interval := 3
+
+	newPlant := func() *entities.Plant {
+		return &entities.Plant{ID: 1, GroupID: 10, UserID: 123, Title: "Папоротник", WateringInterval: &interval}
+	}
+
+	group := entities.Group{
+		ID:               10,
+		UserID:           123,
+		WateringInterval: 7,
+		LastWateringDate: today.AddDate(0, 0, -5),
+		NextWateringDate: today,
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(*mockstorage.MockStorage, *mocklogging.MockLogger)
+		wantErr    bool
+	}{
+		{
+			name: "Success - plant watered",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetPlant(gomock.Any(), 1).Return(newPlant(), nil).Times(1)
+				storage.EXPECT().UpdatePlant(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				storage.
+					EXPECT().
+					SaveWatering(
+						gomock.Any(),
+						gomock.Cond(func(w entities.Watering) bool {
+							return w.GroupID == 10 && w.PlantID != nil && *w.PlantID == 1 &&
+								w.WateredAt.Equal(today) && w.Source == entities.WateringSourceNotification &&
+								w.UserID != nil && *w.UserID == 2
+						}),
+					).
+					Return(1, nil).
+					Times(1)
+
+				storage.EXPECT().GetGroup(gomock.Any(), 10).Return(&group, nil).Times(1)
+				storage.
+					EXPECT().
+					GetGroupPlants(gomock.Any(), 10).
+					Return([]entities.Plant{{ID: 1, GroupID: 10, WateringInterval: &interval, LastWateringDate: &today}}, nil).
+					Times(1)
+
+				storage.EXPECT().GetUserByID(gomock.Any(), 123).Return(&user, nil).Times(1)
+				storage.
+					EXPECT().
+					UpdateGroup(
+						gomock.Any(),
+						gomock.Cond(func(g entities.Group) bool {
+							return g.NextWateringDate.Equal(today.AddDate(0, 0, interval))
+						}),
+					).
+					Return(nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failure - SaveWatering returns error",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.EXPECT().GetPlant(gomock.Any(), 1).Return(newPlant(), nil).Times(1)
+				storage.EXPECT().UpdatePlant(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				storage.EXPECT().SaveWatering(gomock.Any(), gomock.Any()).Return(0, assert.AnError).Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to save Watering for Plant with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			// Изменения выполняются внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &plantsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdatePlantLastWateringDate(context.Background(), 1, today, entities.WateringSourceNotification, 2)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &today, got.LastWateringDate)
+			}
+		})
+	}
+}
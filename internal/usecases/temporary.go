@@ -1,8 +1,10 @@
 package usecases
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/DKhorkov/libs/logging"
@@ -18,8 +20,17 @@ type temporaryUseCases struct {
 	logger  logging.Logger
 }
 
-func (u *temporaryUseCases) GetUserTemporary(telegramID int) (*entities.Temporary, error) {
-	user, err := u.storage.GetUserByTelegramID(telegramID)
+func (u *temporaryUseCases) GetUserTemporary(ctx context.Context, telegramID int) (*entities.Temporary, error) {
+	return u.getUserTemporary(ctx, u.storage, telegramID)
+}
+
+// getUserTemporary получает временные данные через storage, чтобы их можно было прочитать внутри транзакции.
+func (u *temporaryUseCases) getUserTemporary(
+	ctx context.Context,
+	storage interfaces.Storage,
+	telegramID int,
+) (*entities.Temporary, error) {
+	user, err := storage.GetUserByTelegramID(ctx, telegramID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get Temporary User with telegramID=%d", telegramID),
@@ -30,7 +41,7 @@ func (u *temporaryUseCases) GetUserTemporary(telegramID int) (*entities.Temporar
 		return nil, err
 	}
 
-	temp, err := u.storage.GetTemporaryByUserID(user.ID)
+	temp, err := storage.GetTemporaryByUserID(ctx, user.ID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get Temporary for User with ID=%d", user.ID),
@@ -44,14 +55,14 @@ func (u *temporaryUseCases) GetUserTemporary(telegramID int) (*entities.Temporar
 	return temp, nil
 }
 
-func (u *temporaryUseCases) SetTemporaryStep(telegramID, step int) error {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) SetTemporaryStep(ctx context.Context, telegramID, step int) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return err
 	}
 
 	temp.Step = step
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -64,14 +75,14 @@ func (u *temporaryUseCases) SetTemporaryStep(telegramID, step int) error {
 	return nil
 }
 
-func (u *temporaryUseCases) SetTemporaryMessage(telegramID int, messageID *int) error {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) SetTemporaryMessage(ctx context.Context, telegramID int, messageID *int) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return err
 	}
 
 	temp.MessageID = messageID
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -84,8 +95,41 @@ func (u *temporaryUseCases) SetTemporaryMessage(telegramID int, messageID *int)
 	return nil
 }
 
-func (u *temporaryUseCases) AddGroupTitle(telegramID int, title string) (*entities.Group, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+// SetTemporaryStepAndMessage переводит пользователя на шаг step и запоминает сообщение для удаления в одной
+// транзакции, чтобы пользователь не оказался на новом шаге со ссылкой на сообщение предыдущего.
+func (u *temporaryUseCases) SetTemporaryStepAndMessage(
+	ctx context.Context,
+	telegramID, step int,
+	messageID *int,
+) error {
+	return u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			temp, err := u.getUserTemporary(ctx, tx, telegramID)
+			if err != nil {
+				return err
+			}
+
+			temp.Step = step
+			temp.MessageID = messageID
+
+			if err = tx.UpdateTemporary(ctx, *temp); err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return nil
+		},
+	)
+}
+
+func (u *temporaryUseCases) AddGroupTitle(ctx context.Context, telegramID int, title string) (*entities.Group, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +139,7 @@ func (u *temporaryUseCases) AddGroupTitle(telegramID int, title string) (*entiti
 		Title:  title,
 	}
 
-	exists, err := u.storage.GroupExists(*group)
+	exists, err := u.storage.GroupExists(ctx, *group)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to check existence for Group=%+v", group),
@@ -125,7 +169,7 @@ func (u *temporaryUseCases) AddGroupTitle(telegramID int, title string) (*entiti
 	temp.Step = steps.AddGroupDescription
 	temp.MessageID = nil // not to delete already deleted message
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -138,8 +182,12 @@ func (u *temporaryUseCases) AddGroupTitle(telegramID int, title string) (*entiti
 	return group, nil
 }
 
-func (u *temporaryUseCases) AddGroupDescription(telegramID int, description string) (*entities.Group, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) AddGroupDescription(
+	ctx context.Context,
+	telegramID int,
+	description string,
+) (*entities.Group, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +220,7 @@ func (u *temporaryUseCases) AddGroupDescription(telegramID int, description stri
 	temp.Step = steps.AddGroupLastWateringDate
 	temp.MessageID = nil // not to delete already deleted message
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -186,10 +234,11 @@ func (u *temporaryUseCases) AddGroupDescription(telegramID int, description stri
 }
 
 func (u *temporaryUseCases) AddGroupLastWateringDate(
+	ctx context.Context,
 	telegramID int,
 	lastWateringDate time.Time,
 ) (*entities.Group, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -221,7 +270,7 @@ func (u *temporaryUseCases) AddGroupLastWateringDate(
 	temp.Data = data
 	temp.Step = steps.AddGroupWateringInterval
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -234,8 +283,11 @@ func (u *temporaryUseCases) AddGroupLastWateringDate(
 	return group, nil
 }
 
-func (u *temporaryUseCases) AddGroupWateringInterval(telegramID, wateringInterval int) (*entities.Group, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) AddGroupWateringInterval(
+	ctx context.Context,
+	telegramID, wateringInterval int,
+) (*entities.Group, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -251,7 +303,9 @@ func (u *temporaryUseCases) AddGroupWateringInterval(telegramID, wateringInterva
 		return nil, err
 	}
 
-	nextWateringDate := group.LastWateringDate.AddDate(0, 0, wateringInterval)
+	group.WateringInterval = wateringInterval
+
+	nextWateringDate := group.LastWateringDate.AddDate(0, 0, group.GetWateringInterval(group.LastWateringDate))
 
 	now := time.Now()
 
@@ -260,7 +314,6 @@ func (u *temporaryUseCases) AddGroupWateringInterval(telegramID, wateringInterva
 		nextWateringDate = today
 	}
 
-	group.WateringInterval = wateringInterval
 	group.NextWateringDate = nextWateringDate
 
 	data, err := json.Marshal(group)
@@ -277,7 +330,7 @@ func (u *temporaryUseCases) AddGroupWateringInterval(telegramID, wateringInterva
 	temp.Data = data
 	temp.Step = steps.ConfirmAddGroup
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -290,8 +343,8 @@ func (u *temporaryUseCases) AddGroupWateringInterval(telegramID, wateringInterva
 	return group, nil
 }
 
-func (u *temporaryUseCases) ResetTemporary(telegramID int) error {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) ResetTemporary(ctx context.Context, telegramID int) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return err
 	}
@@ -300,7 +353,7 @@ func (u *temporaryUseCases) ResetTemporary(telegramID int) error {
 	temp.MessageID = nil
 	temp.Step = steps.Start
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -313,8 +366,8 @@ func (u *temporaryUseCases) ResetTemporary(telegramID int) error {
 	return nil
 }
 
-func (u *temporaryUseCases) AddPlantTitle(telegramID int, title string) (*entities.Plant, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) AddPlantTitle(ctx context.Context, telegramID int, title string) (*entities.Plant, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -339,7 +392,7 @@ func (u *temporaryUseCases) AddPlantTitle(telegramID int, title string) (*entiti
 	temp.Step = steps.AddPlantDescription
 	temp.MessageID = nil // not to delete already deleted message
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -352,8 +405,12 @@ func (u *temporaryUseCases) AddPlantTitle(telegramID int, title string) (*entiti
 	return plant, nil
 }
 
-func (u *temporaryUseCases) AddPlantDescription(telegramID int, description string) (*entities.Plant, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) AddPlantDescription(
+	ctx context.Context,
+	telegramID int,
+	description string,
+) (*entities.Plant, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -386,7 +443,7 @@ func (u *temporaryUseCases) AddPlantDescription(telegramID int, description stri
 	temp.Step = steps.AddPlantGroup
 	temp.MessageID = nil // not to delete already deleted message
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -399,8 +456,8 @@ func (u *temporaryUseCases) AddPlantDescription(telegramID int, description stri
 	return plant, nil
 }
 
-func (u *temporaryUseCases) AddPlantGroup(telegramID, groupID int) (*entities.Plant, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) AddPlantGroup(ctx context.Context, telegramID, groupID int) (*entities.Plant, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -418,7 +475,7 @@ func (u *temporaryUseCases) AddPlantGroup(telegramID, groupID int) (*entities.Pl
 
 	plant.GroupID = groupID
 
-	exists, err := u.storage.PlantExists(*plant)
+	exists, err := u.storage.PlantExists(ctx, *plant)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to check existence for Plant=%+v", plant),
@@ -447,7 +504,7 @@ func (u *temporaryUseCases) AddPlantGroup(telegramID, groupID int) (*entities.Pl
 	temp.Data = data
 	temp.Step = steps.AddPlantPhotoQuestion
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -460,8 +517,14 @@ func (u *temporaryUseCases) AddPlantGroup(telegramID, groupID int) (*entities.Pl
 	return plant, nil
 }
 
-func (u *temporaryUseCases) AddPlantPhoto(telegramID int, photo []byte) (*entities.Plant, error) {
-	temp, err := u.GetUserTemporary(telegramID)
+// AddPlantPhoto сохраняет ссылку на фотографию растения в Telegram. Пустой fileID означает, что растение
+// будет отображаться с изображением по умолчанию.
+func (u *temporaryUseCases) AddPlantPhoto(
+	ctx context.Context,
+	telegramID int,
+	fileID, fileUniqueID string,
+) (*entities.Plant, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return nil, err
 	}
@@ -477,7 +540,8 @@ func (u *temporaryUseCases) AddPlantPhoto(telegramID int, photo []byte) (*entiti
 		return nil, err
 	}
 
-	plant.Photo = photo
+	plant.PhotoFileID = fileID
+	plant.PhotoFileUniqueID = fileUniqueID
 
 	data, err := json.Marshal(plant)
 	if err != nil {
@@ -494,7 +558,7 @@ func (u *temporaryUseCases) AddPlantPhoto(telegramID int, photo []byte) (*entiti
 	temp.Step = steps.ConfirmAddPlant
 	temp.MessageID = nil // not to delete already deleted message
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -507,8 +571,8 @@ func (u *temporaryUseCases) AddPlantPhoto(telegramID int, photo []byte) (*entiti
 	return plant, nil
 }
 
-func (u *temporaryUseCases) ManagePlant(telegramID, plantID int) error {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) ManagePlant(ctx context.Context, telegramID, plantID int) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return err
 	}
@@ -532,7 +596,7 @@ func (u *temporaryUseCases) ManagePlant(telegramID, plantID int) error {
 
 	temp.Step = steps.ManagePlantAction
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -545,8 +609,8 @@ func (u *temporaryUseCases) ManagePlant(telegramID, plantID int) error {
 	return nil
 }
 
-func (u *temporaryUseCases) ManageGroup(telegramID, groupID int) error {
-	temp, err := u.GetUserTemporary(telegramID)
+func (u *temporaryUseCases) ManageGroup(ctx context.Context, telegramID, groupID int) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
 	if err != nil {
 		return err
 	}
@@ -570,7 +634,254 @@ func (u *temporaryUseCases) ManageGroup(telegramID, groupID int) error {
 
 	temp.Step = steps.ManageGroupAction
 
-	if err = u.storage.UpdateTemporary(*temp); err != nil {
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// ManageCareTasks запоминает владельца задач ухода (сценарий или растение) для последующих этапов.
+func (u *temporaryUseCases) ManageCareTasks(ctx context.Context, telegramID int, owner entities.CareTask) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return err
+	}
+
+	careTask := &entities.CareTask{
+		UserID:  temp.UserID,
+		GroupID: owner.GroupID,
+		PlantID: owner.PlantID,
+	}
+
+	return u.updateTemporaryCareTask(ctx, temp, careTask, steps.CareTasks)
+}
+
+func (u *temporaryUseCases) ManageCareTask(ctx context.Context, telegramID, careTaskID int) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return err
+	}
+
+	careTask, err := u.getTemporaryCareTask(ctx, temp)
+	if err != nil {
+		return err
+	}
+
+	careTask.ID = careTaskID
+
+	return u.updateTemporaryCareTask(ctx, temp, careTask, steps.ManageCareTask)
+}
+
+func (u *temporaryUseCases) AddCareTaskType(
+	ctx context.Context,
+	telegramID int,
+	careTaskType string,
+) (*entities.CareTask, error) {
+	if !slices.Contains(entities.CareTaskTypes, careTaskType) {
+		return nil, customerrors.ErrInvalidCareTaskType
+	}
+
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask, err := u.getTemporaryCareTask(ctx, temp)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask.ID = 0 // Новая задача, даже если ранее просматривалась существующая
+	careTask.Type = careTaskType
+
+	if err = u.updateTemporaryCareTask(ctx, temp, careTask, steps.AddCareTaskLastDate); err != nil {
+		return nil, err
+	}
+
+	return careTask, nil
+}
+
+func (u *temporaryUseCases) AddCareTaskLastDate(
+	ctx context.Context,
+	telegramID int,
+	lastCareDate time.Time,
+) (*entities.CareTask, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask, err := u.getTemporaryCareTask(ctx, temp)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask.LastCareDate = lastCareDate
+
+	if err = u.updateTemporaryCareTask(ctx, temp, careTask, steps.AddCareTaskInterval); err != nil {
+		return nil, err
+	}
+
+	return careTask, nil
+}
+
+func (u *temporaryUseCases) AddCareTaskInterval(
+	ctx context.Context,
+	telegramID, interval int,
+) (*entities.CareTask, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	careTask, err := u.getTemporaryCareTask(ctx, temp)
+	if err != nil {
+		return nil, err
+	}
+
+	nextCareDate := careTask.LastCareDate.AddDate(0, 0, interval)
+
+	today, err := getUserToday(ctx, u.storage, u.logger, temp.UserID, nextCareDate.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	if nextCareDate.Before(today) {
+		nextCareDate = today
+	}
+
+	careTask.Interval = interval
+	careTask.NextCareDate = nextCareDate
+
+	if err = u.updateTemporaryCareTask(ctx, temp, careTask, steps.ConfirmAddCareTask); err != nil {
+		return nil, err
+	}
+
+	return careTask, nil
+}
+
+func (u *temporaryUseCases) getTemporaryCareTask(
+	ctx context.Context,
+	temp *entities.Temporary,
+) (*entities.CareTask, error) {
+	careTask, err := temp.GetCareTask()
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to unmarshal data for User with ID=%d", temp.UserID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return careTask, nil
+}
+
+func (u *temporaryUseCases) updateTemporaryCareTask(
+	ctx context.Context,
+	temp *entities.Temporary,
+	careTask *entities.CareTask,
+	step int,
+) error {
+	data, err := json.Marshal(careTask)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to marshal data for User with ID=%d", temp.UserID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	temp.Data = data
+	temp.Step = step
+
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// ManageDelegation начинает выбор сценариев для передачи присматривающему.
+func (u *temporaryUseCases) ManageDelegation(ctx context.Context, telegramID int) error {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return err
+	}
+
+	delegation := &entities.Delegation{
+		OwnerID: temp.UserID,
+	}
+
+	return u.updateTemporaryDelegation(ctx, temp, delegation)
+}
+
+// ToggleDelegationGroup добавляет сценарий в передачу присматривающему или убирает его из нее.
+func (u *temporaryUseCases) ToggleDelegationGroup(
+	ctx context.Context,
+	telegramID, groupID int,
+) (*entities.Delegation, error) {
+	temp, err := u.GetUserTemporary(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+
+	delegation, err := temp.GetDelegation()
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to unmarshal data for User with ID=%d", temp.UserID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	delegation.GroupIDs = delegation.GroupIDs.Toggle(groupID)
+
+	if err = u.updateTemporaryDelegation(ctx, temp, delegation); err != nil {
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+func (u *temporaryUseCases) updateTemporaryDelegation(
+	ctx context.Context,
+	temp *entities.Temporary,
+	delegation *entities.Delegation,
+) error {
+	data, err := json.Marshal(delegation)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to marshal data for User with ID=%d", temp.UserID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return err
+	}
+
+	temp.Data = data
+	temp.Step = steps.HandoverGroups
+
+	if err = u.storage.UpdateTemporary(ctx, *temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to update Temporary with ID=%d", temp.ID),
 			"Error", err,
@@ -1,11 +1,13 @@
 package usecases
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
 	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
 	"github.com/stretchr/testify/assert"
@@ -31,13 +33,13 @@ func TestTemporaryUseCases_GetUserTemporary(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 			},
@@ -50,7 +52,7 @@ func TestTemporaryUseCases_GetUserTemporary(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -72,13 +74,13 @@ func TestTemporaryUseCases_GetUserTemporary(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -111,7 +113,7 @@ func TestTemporaryUseCases_GetUserTemporary(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetUserTemporary(tt.telegramID)
+			got, err := useCases.GetUserTemporary(context.Background(), tt.telegramID)
 
 			if tt.wantErr {
 				assert.Nil(t, got)
@@ -142,20 +144,20 @@ func TestTemporaryUseCases_SetTemporaryStep(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(&entities.User{ID: 123}, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, 5, temp.Step)
 						assert.Equal(t, 1, temp.ID)
 						return nil
@@ -171,7 +173,7 @@ func TestTemporaryUseCases_SetTemporaryStep(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -193,19 +195,19 @@ func TestTemporaryUseCases_SetTemporaryStep(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(&entities.User{ID: 123}, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -237,7 +239,7 @@ func TestTemporaryUseCases_SetTemporaryStep(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.SetTemporaryStep(tt.telegramID, tt.step)
+			err := useCases.SetTemporaryStep(context.Background(), tt.telegramID, tt.step)
 
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
@@ -261,20 +263,20 @@ func TestTemporaryUseCases_SetTemporaryMessage(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(&entities.User{ID: 123}, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, 100, *temp.MessageID)
 						return nil
 					}).
@@ -289,20 +291,20 @@ func TestTemporaryUseCases_SetTemporaryMessage(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(&entities.User{ID: 123}, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Nil(t, temp.MessageID)
 						return nil
 					}).
@@ -317,7 +319,7 @@ func TestTemporaryUseCases_SetTemporaryMessage(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -339,19 +341,19 @@ func TestTemporaryUseCases_SetTemporaryMessage(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(&entities.User{ID: 123}, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -383,7 +385,141 @@ func TestTemporaryUseCases_SetTemporaryMessage(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.SetTemporaryMessage(tt.telegramID, tt.messageID)
+			err := useCases.SetTemporaryMessage(context.Background(), tt.telegramID, tt.messageID)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestTemporaryUseCases_SetTemporaryStepAndMessage(t *testing.T) {
+	temp := &entities.Temporary{ID: 1, UserID: 123, Step: steps.Start, MessageID: nil}
+
+	tests := []struct {
+		name       string
+		telegramID int
+		step       int
+		messageID  *int
+		setupMocks func(*mockstorage.MockStorage, *mocklogging.MockLogger)
+		wantErr    bool
+	}{
+		{
+			name:       "Success - step and messageID updated together",
+			telegramID: 456,
+			step:       steps.AddGroupTitle,
+			messageID:  func() *int { i := 100; return &i }(),
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByTelegramID(gomock.Any(), 456).
+					Return(&entities.User{ID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetTemporaryByUserID(gomock.Any(), 123).
+					Return(temp, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
+						assert.Equal(t, steps.AddGroupTitle, temp.Step)
+						assert.Equal(t, 100, *temp.MessageID)
+						return nil
+					}).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Failure - GetUserTemporary error",
+			telegramID: 999,
+			step:       steps.AddGroupTitle,
+			messageID:  nil,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByTelegramID(gomock.Any(), 999).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Temporary User with telegramID=999",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "Failure - UpdateTemporary error",
+			telegramID: 456,
+			step:       steps.AddGroupTitle,
+			messageID:  nil,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByTelegramID(gomock.Any(), 456).
+					Return(&entities.User{ID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetTemporaryByUserID(gomock.Any(), 123).
+					Return(temp, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update Temporary with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			// Все изменения должны выполняться внутри транзакции:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				Times(1)
+
+			useCases := &temporaryUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			err := useCases.SetTemporaryStepAndMessage(context.Background(), tt.telegramID, tt.step, tt.messageID)
 
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
@@ -412,22 +548,22 @@ func TestTemporaryUseCases_AddGroupTitle(t *testing.T) {
 				// Получение пользователя
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				// Получение временной записи
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				// Проверка на существование группы
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
-					DoAndReturn(func(g entities.Group) (bool, error) {
+					GroupExists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, g entities.Group) (bool, error) {
 						assert.Equal(t, "Цветы", g.Title)
 						assert.Equal(t, 123, g.UserID)
 						return false, nil
@@ -437,8 +573,8 @@ func TestTemporaryUseCases_AddGroupTitle(t *testing.T) {
 				// Обновление временной записи
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.AddGroupDescription, temp.Step)
 						assert.Nil(t, temp.MessageID) // сбрасывается
 
@@ -465,7 +601,7 @@ func TestTemporaryUseCases_AddGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -487,19 +623,19 @@ func TestTemporaryUseCases_AddGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
+					GroupExists(gomock.Any(), gomock.Any()).
 					Return(false, assert.AnError).
 					Times(1)
 
@@ -521,19 +657,19 @@ func TestTemporaryUseCases_AddGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
+					GroupExists(gomock.Any(), gomock.Any()).
 					Return(true, nil).
 					Times(1)
 			},
@@ -547,25 +683,25 @@ func TestTemporaryUseCases_AddGroupTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GroupExists(gomock.Any()).
+					GroupExists(gomock.Any(), gomock.Any()).
 					Return(false, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -597,7 +733,7 @@ func TestTemporaryUseCases_AddGroupTitle(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotGroup, err := useCases.AddGroupTitle(tt.telegramID, tt.title)
+			gotGroup, err := useCases.AddGroupTitle(context.Background(), tt.telegramID, tt.title)
 
 			if tt.wantErr {
 				assert.Nil(t, gotGroup)
@@ -642,21 +778,21 @@ func TestTemporaryUseCases_AddGroupDescription(t *testing.T) {
 				// GetUserTemporary → GetUserByTelegramID + GetTemporaryByUserID
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				// UpdateTemporary — проверяем, что Step и Data обновились
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.AddGroupLastWateringDate, temp.Step)
 						assert.Nil(t, temp.MessageID) // сброшен
 
@@ -683,7 +819,7 @@ func TestTemporaryUseCases_AddGroupDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -705,19 +841,19 @@ func TestTemporaryUseCases_AddGroupDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -749,7 +885,7 @@ func TestTemporaryUseCases_AddGroupDescription(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotGroup, err := useCases.AddGroupDescription(tt.telegramID, tt.description)
+			gotGroup, err := useCases.AddGroupDescription(context.Background(), tt.telegramID, tt.description)
 
 			if tt.wantErr {
 				assert.Nil(t, gotGroup)
@@ -791,20 +927,20 @@ func TestTemporaryUseCases_AddGroupLastWateringDate(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.AddGroupWateringInterval, temp.Step)
 
 						var group entities.Group
@@ -834,7 +970,7 @@ func TestTemporaryUseCases_AddGroupLastWateringDate(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -856,19 +992,19 @@ func TestTemporaryUseCases_AddGroupLastWateringDate(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -900,7 +1036,7 @@ func TestTemporaryUseCases_AddGroupLastWateringDate(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotGroup, err := useCases.AddGroupLastWateringDate(tt.telegramID, tt.lastWateringDate)
+			gotGroup, err := useCases.AddGroupLastWateringDate(context.Background(), tt.telegramID, tt.lastWateringDate)
 
 			if tt.wantErr {
 				assert.Nil(t, gotGroup)
@@ -950,20 +1086,20 @@ func TestTemporaryUseCases_AddGroupWateringInterval(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.ConfirmAddGroup, temp.Step)
 
 						var group entities.Group
@@ -1001,20 +1137,20 @@ func TestTemporaryUseCases_AddGroupWateringInterval(t *testing.T) {
 
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(&entities.Temporary{Data: data}, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						var group entities.Group
 						assert.NoError(t, json.Unmarshal(temp.Data, &group))
 						assert.WithinDuration(t, today, group.NextWateringDate, time.Second)
@@ -1040,7 +1176,7 @@ func TestTemporaryUseCases_AddGroupWateringInterval(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1062,19 +1198,19 @@ func TestTemporaryUseCases_AddGroupWateringInterval(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1106,7 +1242,7 @@ func TestTemporaryUseCases_AddGroupWateringInterval(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotGroup, err := useCases.AddGroupWateringInterval(tt.telegramID, tt.wateringInterval)
+			gotGroup, err := useCases.AddGroupWateringInterval(context.Background(), tt.telegramID, tt.wateringInterval)
 
 			if tt.wantErr {
 				assert.Nil(t, gotGroup)
@@ -1142,20 +1278,20 @@ func TestTemporaryUseCases_ResetTemporary(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Nil(t, temp.Data)
 						assert.Nil(t, temp.MessageID)
 						assert.Equal(t, steps.Start, temp.Step)
@@ -1176,7 +1312,7 @@ func TestTemporaryUseCases_ResetTemporary(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1197,19 +1333,19 @@ func TestTemporaryUseCases_ResetTemporary(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1241,7 +1377,7 @@ func TestTemporaryUseCases_ResetTemporary(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.ResetTemporary(tt.telegramID)
+			err := useCases.ResetTemporary(context.Background(), tt.telegramID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -1276,20 +1412,20 @@ func TestTemporaryUseCases_AddPlantTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.AddPlantDescription, temp.Step)
 						assert.Nil(t, temp.MessageID) // сброшен
 
@@ -1317,7 +1453,7 @@ func TestTemporaryUseCases_AddPlantTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1339,19 +1475,19 @@ func TestTemporaryUseCases_AddPlantTitle(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1383,7 +1519,7 @@ func TestTemporaryUseCases_AddPlantTitle(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotPlant, err := useCases.AddPlantTitle(tt.telegramID, tt.title)
+			gotPlant, err := useCases.AddPlantTitle(context.Background(), tt.telegramID, tt.title)
 
 			if tt.wantErr {
 				assert.Nil(t, gotPlant)
@@ -1424,20 +1560,20 @@ func TestTemporaryUseCases_AddPlantDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.AddPlantGroup, temp.Step)
 						assert.Nil(t, temp.MessageID)
 
@@ -1468,7 +1604,7 @@ func TestTemporaryUseCases_AddPlantDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1490,19 +1626,19 @@ func TestTemporaryUseCases_AddPlantDescription(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1534,7 +1670,7 @@ func TestTemporaryUseCases_AddPlantDescription(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotPlant, err := useCases.AddPlantDescription(tt.telegramID, tt.description)
+			gotPlant, err := useCases.AddPlantDescription(context.Background(), tt.telegramID, tt.description)
 
 			if tt.wantErr {
 				assert.Nil(t, gotPlant)
@@ -1575,26 +1711,26 @@ func TestTemporaryUseCases_AddPlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
+					PlantExists(gomock.Any(), gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
 					Return(false, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.AddPlantPhotoQuestion, temp.Step)
 
 						var plant entities.Plant
@@ -1619,7 +1755,7 @@ func TestTemporaryUseCases_AddPlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1641,19 +1777,19 @@ func TestTemporaryUseCases_AddPlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
+					PlantExists(gomock.Any(), gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
 					Return(false, assert.AnError).
 					Times(1)
 
@@ -1675,26 +1811,26 @@ func TestTemporaryUseCases_AddPlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
+					PlantExists(gomock.Any(), gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
 					Return(true, nil).
 					Times(1)
 
 				// Не должно быть UpdateTemporary
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			wantErr: true,
@@ -1709,25 +1845,25 @@ func TestTemporaryUseCases_AddPlantGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					PlantExists(gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
+					PlantExists(gomock.Any(), gomock.Eq(entities.Plant{UserID: 123, Title: "Кактус", GroupID: 777})).
 					Return(false, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1759,7 +1895,7 @@ func TestTemporaryUseCases_AddPlantGroup(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotPlant, err := useCases.AddPlantGroup(tt.telegramID, tt.groupID)
+			gotPlant, err := useCases.AddPlantGroup(context.Background(), tt.telegramID, tt.groupID)
 
 			if tt.wantErr {
 				assert.Nil(t, gotPlant)
@@ -1788,12 +1924,12 @@ func TestTemporaryUseCases_AddPlantPhoto(t *testing.T) {
 	tempData, _ := json.Marshal(existingPlant)
 	temp := &entities.Temporary{ID: 1, UserID: 123, Data: tempData, Step: steps.AddPlantPhotoQuestion}
 
-	photo := []byte("photo_data")
+	fileID := "photo_file_id"
 
 	tests := []struct {
 		name        string
 		telegramID  int
-		photo       []byte
+		fileID      string
 		setupMocks  func(*mockstorage.MockStorage, *mocklogging.MockLogger)
 		wantPlant   *entities.Plant
 		wantErr     bool
@@ -1802,43 +1938,44 @@ func TestTemporaryUseCases_AddPlantPhoto(t *testing.T) {
 		{
 			name:       "Success - photo added and temporary updated",
 			telegramID: 456,
-			photo:      photo,
+			fileID:     fileID,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.ConfirmAddPlant, temp.Step)
 						assert.Nil(t, temp.MessageID)
 
 						var plant entities.Plant
 						assert.NoError(t, json.Unmarshal(temp.Data, &plant))
-						assert.Equal(t, photo, plant.Photo)
+						assert.Equal(t, fileID, plant.PhotoFileID)
+						assert.Equal(t, "photo_file_unique_id", plant.PhotoFileUniqueID)
 						return nil
 					}).
 					Times(1)
 			},
 			wantPlant: &entities.Plant{
-				UserID:  123,
-				Title:   "Кактус",
-				GroupID: 777,
-				Photo:   photo,
+				UserID:      123,
+				Title:       "Кактус",
+				GroupID:     777,
+				PhotoFileID: fileID,
 			},
 			wantErr: false,
 			checkResult: func(t *testing.T, got *entities.Plant) {
-				assert.Equal(t, photo, got.Photo)
+				assert.Equal(t, fileID, got.PhotoFileID)
 				assert.Equal(t, steps.ConfirmAddPlant, temp.Step)
 				assert.Nil(t, temp.MessageID)
 			},
@@ -1846,11 +1983,11 @@ func TestTemporaryUseCases_AddPlantPhoto(t *testing.T) {
 		{
 			name:       "Failure - GetUserTemporary error",
 			telegramID: 999,
-			photo:      photo,
+			fileID:     fileID,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -1868,23 +2005,23 @@ func TestTemporaryUseCases_AddPlantPhoto(t *testing.T) {
 		{
 			name:       "Failure - UpdateTemporary returns error",
 			telegramID: 456,
-			photo:      photo,
+			fileID:     fileID,
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -1916,7 +2053,7 @@ func TestTemporaryUseCases_AddPlantPhoto(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			gotPlant, err := useCases.AddPlantPhoto(tt.telegramID, tt.photo)
+			gotPlant, err := useCases.AddPlantPhoto(context.Background(), tt.telegramID, tt.fileID, "photo_file_unique_id")
 
 			if tt.wantErr {
 				assert.Nil(t, gotPlant)
@@ -1951,20 +2088,20 @@ func TestTemporaryUseCases_ManagePlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.ManagePlantAction, temp.Step)
 
 						var plant entities.Plant
@@ -1989,7 +2126,7 @@ func TestTemporaryUseCases_ManagePlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -2011,19 +2148,19 @@ func TestTemporaryUseCases_ManagePlant(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -2055,7 +2192,7 @@ func TestTemporaryUseCases_ManagePlant(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.ManagePlant(tt.telegramID, tt.plantID)
+			err := useCases.ManagePlant(context.Background(), tt.telegramID, tt.plantID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -2089,20 +2226,20 @@ func TestTemporaryUseCases_ManageGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
-					DoAndReturn(func(temp entities.Temporary) error {
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
 						assert.Equal(t, steps.ManageGroupAction, temp.Step)
 
 						var group entities.Group
@@ -2127,7 +2264,7 @@ func TestTemporaryUseCases_ManageGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(999).
+					GetUserByTelegramID(gomock.Any(), 999).
 					Return(nil, assert.AnError).
 					Times(1)
 
@@ -2149,19 +2286,19 @@ func TestTemporaryUseCases_ManageGroup(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(456).
+					GetUserByTelegramID(gomock.Any(), 456).
 					Return(user, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					GetTemporaryByUserID(123).
+					GetTemporaryByUserID(gomock.Any(), 123).
 					Return(temp, nil).
 					Times(1)
 
 				storage.
 					EXPECT().
-					UpdateTemporary(gomock.Any()).
+					UpdateTemporary(gomock.Any(), gomock.Any()).
 					Return(assert.AnError).
 					Times(1)
 
@@ -2193,7 +2330,7 @@ func TestTemporaryUseCases_ManageGroup(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			err := useCases.ManageGroup(tt.telegramID, tt.groupID)
+			err := useCases.ManageGroup(context.Background(), tt.telegramID, tt.groupID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -2207,3 +2344,248 @@ func TestTemporaryUseCases_ManageGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestTemporaryUseCases_ToggleDelegationGroup(t *testing.T) {
+	user := &entities.User{ID: 123, TelegramID: 456}
+	selected, _ := json.Marshal(entities.Delegation{OwnerID: 123, GroupIDs: entities.DelegationGroups{7}})
+
+	tests := []struct {
+		name       string
+		data       []byte
+		setupMocks func(*mockstorage.MockStorage, *mocklogging.MockLogger)
+		want       entities.DelegationGroups
+		wantErr    bool
+	}{
+		{
+			name: "Success - group added",
+			data: selected,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
+						assert.Equal(t, steps.HandoverGroups, temp.Step)
+
+						return nil
+					}).
+					Times(1)
+			},
+			want: entities.DelegationGroups{7, 8},
+		},
+		{
+			name: "Failure - invalid temporary data",
+			data: []byte{},
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				logger.
+					EXPECT().
+					Error(
+						"Failed to unmarshal data for User with ID=123",
+						"Error", gomock.Any(),
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			mockStorage.EXPECT().GetUserByTelegramID(gomock.Any(), 456).Return(user, nil).Times(1)
+			mockStorage.
+				EXPECT().
+				GetTemporaryByUserID(gomock.Any(), 123).
+				Return(&entities.Temporary{ID: 1, UserID: 123, Data: tt.data}, nil).
+				Times(1)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &temporaryUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.ToggleDelegationGroup(context.Background(), 456, 8)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.GroupIDs)
+			}
+		})
+	}
+}
+
+func TestTemporaryUseCases_AddCareTaskType(t *testing.T) {
+	user := &entities.User{ID: 123, TelegramID: 456}
+	groupID := 777
+
+	tests := []struct {
+		name         string
+		careTaskType string
+		setupMocks   func(*mockstorage.MockStorage, *mocklogging.MockLogger)
+		wantErr      bool
+		expectedErr  error
+	}{
+		{
+			name:         "Success - type stored and previous care task ID reset",
+			careTaskType: entities.CareTaskTypeMisting,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				data, _ := json.Marshal(entities.CareTask{ID: 5, UserID: 123, GroupID: &groupID})
+
+				storage.
+					EXPECT().
+					GetUserByTelegramID(gomock.Any(), 456).
+					Return(user, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					GetTemporaryByUserID(gomock.Any(), 123).
+					Return(&entities.Temporary{ID: 1, UserID: 123, Data: data}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateTemporary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
+						assert.Equal(t, steps.AddCareTaskLastDate, temp.Step)
+
+						var careTask entities.CareTask
+						assert.NoError(t, json.Unmarshal(temp.Data, &careTask))
+						assert.Equal(t, 0, careTask.ID)
+						assert.Equal(t, entities.CareTaskTypeMisting, careTask.Type)
+						assert.Equal(t, groupID, *careTask.GroupID)
+
+						return nil
+					}).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name:         "Failure - unknown care task type",
+			careTaskType: "pruning",
+			wantErr:      true,
+			expectedErr:  customerrors.ErrInvalidCareTaskType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &temporaryUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.AddCareTaskType(context.Background(), 456, tt.careTaskType)
+
+			if tt.wantErr {
+				assert.Nil(t, got)
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.careTaskType, got.Type)
+			}
+		})
+	}
+}
+
+func TestTemporaryUseCases_AddCareTaskInterval(t *testing.T) {
+	user := &entities.User{ID: 123, TelegramID: 456, Timezone: "UTC"}
+	plantID := 777
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		lastCareDate     time.Time
+		interval         int
+		wantNextCareDate time.Time
+	}{
+		{
+			name:             "Success - next care date after interval",
+			lastCareDate:     today,
+			interval:         30,
+			wantNextCareDate: today.AddDate(0, 0, 30),
+		},
+		{
+			name:             "Success - overdue next care date is set to today",
+			lastCareDate:     today.AddDate(-1, 0, 0),
+			interval:         7,
+			wantNextCareDate: today,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			data, _ := json.Marshal(
+				entities.CareTask{
+					UserID:       123,
+					PlantID:      &plantID,
+					Type:         entities.CareTaskTypeFertilizing,
+					LastCareDate: tt.lastCareDate,
+				},
+			)
+
+			mockStorage.
+				EXPECT().
+				GetUserByTelegramID(gomock.Any(), 456).
+				Return(user, nil).
+				Times(1)
+
+			mockStorage.
+				EXPECT().
+				GetTemporaryByUserID(gomock.Any(), 123).
+				Return(&entities.Temporary{ID: 1, UserID: 123, Data: data}, nil).
+				Times(1)
+
+			mockStorage.
+				EXPECT().
+				GetUserByID(gomock.Any(), 123).
+				Return(user, nil).
+				Times(1)
+
+			mockStorage.
+				EXPECT().
+				UpdateTemporary(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, temp entities.Temporary) error {
+					assert.Equal(t, steps.ConfirmAddCareTask, temp.Step)
+
+					return nil
+				}).
+				Times(1)
+
+			useCases := &temporaryUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.AddCareTaskInterval(context.Background(), 456, tt.interval)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.interval, got.Interval)
+			assert.Equal(t, tt.wantNextCareDate, got.NextCareDate)
+		})
+	}
+}
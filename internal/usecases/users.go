@@ -1,44 +1,98 @@
 package usecases
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/DKhorkov/libs/logging"
 
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 )
 
+const (
+	minNotifyHour = 0
+	maxNotifyHour = 23
+
+	// Маска всех дней недели, где бит с номером time.Weekday отвечает за день:
+	allQuietWeekdays = 1<<(time.Saturday+1) - 1
+)
+
 type usersUseCases struct {
 	storage interfaces.Storage
 	logger  logging.Logger
 }
 
-func (u *usersUseCases) SaveUser(user entities.User) (int, error) {
-	// Затенение, чтобы не переписывать реальный объект юзера, который нужно будет сохранить, если такого не существует:
-	if user, err := u.storage.GetUserByTelegramID(user.TelegramID); err == nil {
-		return user.ID, nil
-	}
+// SaveUser регистрирует пользователя вместе с его временными данными в одной транзакции,
+// поэтому у зарегистрированного пользователя всегда есть запись temporary.
+func (u *usersUseCases) SaveUser(ctx context.Context, user entities.User) (int, error) {
+	var userID int
+
+	err := u.storage.WithTx(
+		ctx,
+		func(tx interfaces.Storage) error {
+			// Затенение, чтобы не переписывать реальный объект юзера, который нужно будет сохранить, если такого не существует:
+			if user, err := tx.GetUserByTelegramID(ctx, user.TelegramID); err == nil {
+				userID = user.ID
 
-	userID, err := u.storage.SaveUser(user)
+				return u.restoreTemporary(ctx, tx, userID)
+			}
+
+			var err error
+
+			userID, err = tx.SaveUser(ctx, user)
+			if err != nil {
+				u.logger.Error(
+					fmt.Sprintf("Failed to save User with telegramID=%d", user.TelegramID),
+					"Error", err,
+					"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+				)
+
+				return err
+			}
+
+			return u.createTemporary(ctx, tx, userID)
+		},
+	)
 	if err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// restoreTemporary создает временные данные пользователю, который остался без них из-за сбоя регистрации
+// до того, как она стала выполняться в транзакции.
+func (u *usersUseCases) restoreTemporary(ctx context.Context, tx interfaces.Storage, userID int) error {
+	_, err := tx.GetTemporaryByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return u.createTemporary(ctx, tx, userID)
+	case err != nil:
 		u.logger.Error(
-			fmt.Sprintf("Failed to save User with telegramID=%d", user.TelegramID),
+			fmt.Sprintf("Failed to get Temporary for User with ID=%d", userID),
 			"Error", err,
 			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
 		)
 
-		return 0, err
+		return err
 	}
 
+	return nil
+}
+
+func (u *usersUseCases) createTemporary(ctx context.Context, tx interfaces.Storage, userID int) error {
 	temp := entities.Temporary{
 		UserID: userID,
 		Step:   steps.Start,
 	}
 
-	// TODO при проблемах логики следует сделать в рамках транзакции с сохранением пользователя
-	if err = u.storage.CreateTemporary(temp); err != nil {
+	if err := tx.CreateTemporary(ctx, temp); err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to save Temporary for User with ID=%d", userID),
 			"Temp", temp,
@@ -46,14 +100,14 @@ func (u *usersUseCases) SaveUser(user entities.User) (int, error) {
 			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
 		)
 
-		return 0, err
+		return err
 	}
 
-	return userID, nil
+	return nil
 }
 
-func (u *usersUseCases) GetUserByTelegramID(telegramID int) (*entities.User, error) {
-	user, err := u.storage.GetUserByTelegramID(telegramID)
+func (u *usersUseCases) GetUserByTelegramID(ctx context.Context, telegramID int) (*entities.User, error) {
+	user, err := u.storage.GetUserByTelegramID(ctx, telegramID)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get User with telegramID=%d", telegramID),
@@ -67,8 +121,8 @@ func (u *usersUseCases) GetUserByTelegramID(telegramID int) (*entities.User, err
 	return user, nil
 }
 
-func (u *usersUseCases) GetUserByID(id int) (*entities.User, error) {
-	user, err := u.storage.GetUserByID(id)
+func (u *usersUseCases) GetUserByID(ctx context.Context, id int) (*entities.User, error) {
+	user, err := u.storage.GetUserByID(ctx, id)
 	if err != nil {
 		u.logger.Error(
 			fmt.Sprintf("Failed to get User with ID=%d", id),
@@ -81,3 +135,215 @@ func (u *usersUseCases) GetUserByID(id int) (*entities.User, error) {
 
 	return user, nil
 }
+
+func (u *usersUseCases) UpdateUserTimezone(ctx context.Context, id int, timezone string) (*entities.User, error) {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, customerrors.ErrInvalidTimezone
+	}
+
+	user, err := u.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Timezone = timezone
+	if err = u.storage.UpdateUser(ctx, *user); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update User with ID=%d", user.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *usersUseCases) UpdateUserNotifyHour(ctx context.Context, id, notifyHour int) (*entities.User, error) {
+	if notifyHour < minNotifyHour || notifyHour > maxNotifyHour {
+		return nil, customerrors.ErrInvalidNotifyHour
+	}
+
+	user, err := u.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.NotifyHour = notifyHour
+	if err = u.storage.UpdateUser(ctx, *user); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update User with ID=%d", user.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *usersUseCases) UpdateUserNotificationsDigest(
+	ctx context.Context,
+	id int,
+	digest bool,
+) (*entities.User, error) {
+	user, err := u.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.NotificationsDigest = digest
+	if err = u.storage.UpdateUser(ctx, *user); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update User with ID=%d", user.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UpdateUserQuietHours задает тихие часы пользователя. Равные границы отключают тихие часы.
+func (u *usersUseCases) UpdateUserQuietHours(ctx context.Context, id, start, end int) (*entities.User, error) {
+	if start < minNotifyHour || start > maxNotifyHour || end < minNotifyHour || end > maxNotifyHour {
+		return nil, customerrors.ErrInvalidQuietHours
+	}
+
+	user, err := u.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.QuietHoursStart = start
+	user.QuietHoursEnd = end
+	if err = u.storage.UpdateUser(ctx, *user); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update User with ID=%d", user.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *usersUseCases) UpdateUserQuietWeekdays(ctx context.Context, id, weekdays int) (*entities.User, error) {
+	if weekdays < 0 || weekdays > allQuietWeekdays {
+		return nil, customerrors.ErrInvalidQuietWeekdays
+	}
+
+	user, err := u.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.QuietWeekdays = weekdays
+	if err = u.storage.UpdateUser(ctx, *user); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update User with ID=%d", user.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UpdateUserVacation задает отпуск пользователя. Отпуск без даты окончания считается еще не запланированным,
+// а пустые даты отменяют его.
+func (u *usersUseCases) UpdateUserVacation(ctx context.Context, id int, start, end *time.Time) (*entities.User, error) {
+	if (start == nil && end != nil) || (start != nil && end != nil && end.Before(*start)) {
+		return nil, customerrors.ErrInvalidVacation
+	}
+
+	user, err := u.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сохраняем даты в UTC:
+	user.VacationStart, user.VacationEnd = nil, nil
+	if start != nil {
+		vacationStart := start.UTC()
+		user.VacationStart = &vacationStart
+	}
+
+	if end != nil {
+		vacationEnd := end.UTC()
+		user.VacationEnd = &vacationEnd
+	}
+
+	if err = u.storage.UpdateUser(ctx, *user); err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to update User with ID=%d", user.ID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ClaimUserDigest захватывает сводку пользователя на время claimTTL. Возвращает false, если сводку уже
+// захватил другой воркер.
+func (u *usersUseCases) ClaimUserDigest(ctx context.Context, userID int, claimTTL time.Duration) (bool, error) {
+	claimed, err := u.storage.ClaimUserDigest(ctx, userID, claimTTL)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to claim digest for User with ID=%d", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+// getUserToday возвращает начало текущего дня в часовом поясе пользователя.
+// Дата строится в переданной локации, чтобы корректно сравниваться с хранимыми датами ухода.
+func getUserToday(
+	ctx context.Context,
+	storage interfaces.Storage,
+	logger logging.Logger,
+	userID int,
+	location *time.Location,
+) (time.Time, error) {
+	user, err := storage.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("Failed to get User with ID=%d", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return time.Time{}, err
+	}
+
+	userLocation, err := user.GetLocation()
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("Failed to load location for User with ID=%d", userID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+
+		return time.Time{}, err
+	}
+
+	now := time.Now().In(userLocation)
+
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location), nil
+}
@@ -1,8 +1,12 @@
 package usecases
 
 import (
+	"context"
+	"database/sql"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	customerrors "github.com/DKhorkov/plantsCareTelegramBot/internal/errors"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
 	"github.com/DKhorkov/plantsCareTelegramBot/internal/steps"
 	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
 	"github.com/stretchr/testify/assert"
@@ -47,14 +51,68 @@ func TestUsersUseCases_SaveUser(t *testing.T) {
 				// Пользователь уже существует
 				storage.
 					EXPECT().
-					GetUserByTelegramID(5559999).
+					GetUserByTelegramID(gomock.Any(), 5559999).
 					Return(&existingUser, nil).
 					Times(1)
-				// SaveUser и CreateTemporary не вызываются
+				// Временные данные на месте, SaveUser и CreateTemporary не вызываются
+				storage.
+					EXPECT().
+					GetTemporaryByUserID(gomock.Any(), 123).
+					Return(&entities.Temporary{UserID: 123}, nil).
+					Times(1)
 			},
 			wantID:  123,
 			wantErr: false,
 		},
+		{
+			name:      "Success - user already exists without temporary, temporary restored",
+			inputUser: newUser,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByTelegramID(gomock.Any(), 5559999).
+					Return(&existingUser, nil).
+					Times(1)
+				storage.
+					EXPECT().
+					GetTemporaryByUserID(gomock.Any(), 123).
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+				storage.
+					EXPECT().
+					CreateTemporary(gomock.Any(), entities.Temporary{UserID: 123, Step: steps.Start}).
+					Return(nil).
+					Times(1)
+			},
+			wantID:  123,
+			wantErr: false,
+		},
+		{
+			name:      "Failure - error on GetTemporaryByUserID for existing user",
+			inputUser: newUser,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByTelegramID(gomock.Any(), 5559999).
+					Return(&existingUser, nil).
+					Times(1)
+				storage.
+					EXPECT().
+					GetTemporaryByUserID(gomock.Any(), 123).
+					Return(nil, assert.AnError).
+					Times(1)
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Temporary for User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantID:  0,
+			wantErr: true,
+		},
 		{
 			name:      "Success - new user saved with temporary record",
 			inputUser: newUser,
@@ -62,19 +120,19 @@ func TestUsersUseCases_SaveUser(t *testing.T) {
 				// Пользователь не найден
 				storage.
 					EXPECT().
-					GetUserByTelegramID(5559999).
+					GetUserByTelegramID(gomock.Any(), 5559999).
 					Return(nil, assert.AnError).
 					Times(1)
 				// Сохранение нового
 				storage.
 					EXPECT().
-					SaveUser(newUser).
+					SaveUser(gomock.Any(), newUser).
 					Return(456, nil).
 					Times(1)
 				// Создание временной записи
 				storage.
 					EXPECT().
-					CreateTemporary(temp).
+					CreateTemporary(gomock.Any(), temp).
 					Return(nil).
 					Times(1)
 			},
@@ -87,12 +145,12 @@ func TestUsersUseCases_SaveUser(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(5559999).
+					GetUserByTelegramID(gomock.Any(), 5559999).
 					Return(nil, assert.AnError).
 					Times(1)
 				storage.
 					EXPECT().
-					SaveUser(newUser).
+					SaveUser(gomock.Any(), newUser).
 					Return(0, assert.AnError).
 					Times(1)
 				logger.
@@ -113,17 +171,17 @@ func TestUsersUseCases_SaveUser(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(5559999).
+					GetUserByTelegramID(gomock.Any(), 5559999).
 					Return(nil, assert.AnError).
 					Times(1)
 				storage.
 					EXPECT().
-					SaveUser(newUser).
+					SaveUser(gomock.Any(), newUser).
 					Return(456, nil).
 					Times(1)
 				storage.
 					EXPECT().
-					CreateTemporary(temp).
+					CreateTemporary(gomock.Any(), temp).
 					Return(assert.AnError).
 					Times(1)
 				logger.
@@ -151,12 +209,23 @@ func TestUsersUseCases_SaveUser(t *testing.T) {
 				tt.setupMocks(mockStorage, mockLogger)
 			}
 
+			// Транзакция выполняет переданную функцию на том же хранилище:
+			mockStorage.
+				EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(_ context.Context, fn func(interfaces.Storage) error) error {
+						return fn(mockStorage)
+					},
+				).
+				AnyTimes()
+
 			useCases := &usersUseCases{
 				storage: mockStorage,
 				logger:  mockLogger,
 			}
 
-			gotID, err := useCases.SaveUser(tt.inputUser)
+			gotID, err := useCases.SaveUser(context.Background(), tt.inputUser)
 
 			assert.Equal(t, tt.wantID, gotID)
 			assert.Equal(t, tt.wantErr, err != nil)
@@ -188,7 +257,7 @@ func TestUsersUseCases_GetUserByTelegramID(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(5551234).
+					GetUserByTelegramID(gomock.Any(), 5551234).
 					Return(expectedUser, nil).
 					Times(1)
 			},
@@ -201,7 +270,7 @@ func TestUsersUseCases_GetUserByTelegramID(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByTelegramID(5551234).
+					GetUserByTelegramID(gomock.Any(), 5551234).
 					Return(nil, assert.AnError).
 					Times(1)
 				logger.
@@ -233,7 +302,7 @@ func TestUsersUseCases_GetUserByTelegramID(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetUserByTelegramID(tt.telegramID)
+			got, err := useCases.GetUserByTelegramID(context.Background(), tt.telegramID)
 
 			assert.Equal(t, tt.wantUser, got)
 			assert.Equal(t, tt.wantErr, err != nil)
@@ -265,7 +334,7 @@ func TestUsersUseCases_GetUserByID(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByID(123).
+					GetUserByID(gomock.Any(), 123).
 					Return(expectedUser, nil).
 					Times(1)
 			},
@@ -278,7 +347,7 @@ func TestUsersUseCases_GetUserByID(t *testing.T) {
 			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
 				storage.
 					EXPECT().
-					GetUserByID(123).
+					GetUserByID(gomock.Any(), 123).
 					Return(nil, assert.AnError).
 					Times(1)
 				logger.
@@ -310,10 +379,584 @@ func TestUsersUseCases_GetUserByID(t *testing.T) {
 				logger:  mockLogger,
 			}
 
-			got, err := useCases.GetUserByID(tt.userID)
+			got, err := useCases.GetUserByID(context.Background(), tt.userID)
 
 			assert.Equal(t, tt.wantUser, got)
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
 }
+
+func TestUsersUseCases_UpdateUserTimezone(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       int
+		timezone     string
+		setupMocks   func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantTimezone string
+		wantErr      error
+	}{
+		{
+			name:     "Success - timezone updated",
+			userID:   123,
+			timezone: "Asia/Yekaterinburg",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, Timezone: "Europe/Moscow", NotifyHour: 12}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), entities.User{ID: 123, Timezone: "Asia/Yekaterinburg", NotifyHour: 12}).
+					Return(nil).
+					Times(1)
+			},
+			wantTimezone: "Asia/Yekaterinburg",
+		},
+		{
+			name:     "Failure - unknown timezone",
+			userID:   123,
+			timezone: "Mars/Olympus",
+			wantErr:  customerrors.ErrInvalidTimezone,
+		},
+		{
+			name:     "Failure - user not found",
+			userID:   123,
+			timezone: "Asia/Yekaterinburg",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name:     "Failure - storage update error",
+			userID:   123,
+			timezone: "Asia/Yekaterinburg",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, Timezone: "Europe/Moscow"}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &usersUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateUserTimezone(context.Background(), tt.userID, tt.timezone)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTimezone, got.Timezone)
+		})
+	}
+}
+
+func TestUsersUseCases_UpdateUserNotifyHour(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         int
+		notifyHour     int
+		setupMocks     func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantNotifyHour int
+		wantErr        error
+	}{
+		{
+			name:       "Success - notify hour updated",
+			userID:     123,
+			notifyHour: 9,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, Timezone: "Europe/Moscow", NotifyHour: 12}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), entities.User{ID: 123, Timezone: "Europe/Moscow", NotifyHour: 9}).
+					Return(nil).
+					Times(1)
+			},
+			wantNotifyHour: 9,
+		},
+		{
+			name:       "Failure - hour is negative",
+			userID:     123,
+			notifyHour: -1,
+			wantErr:    customerrors.ErrInvalidNotifyHour,
+		},
+		{
+			name:       "Failure - hour is out of day",
+			userID:     123,
+			notifyHour: 24,
+			wantErr:    customerrors.ErrInvalidNotifyHour,
+		},
+		{
+			name:       "Failure - storage update error",
+			userID:     123,
+			notifyHour: 9,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &usersUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateUserNotifyHour(context.Background(), tt.userID, tt.notifyHour)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNotifyHour, got.NotifyHour)
+		})
+	}
+}
+
+func TestUsersUseCases_UpdateUserNotificationsDigest(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		digest     bool
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name:   "Success - digest enabled",
+			userID: 123,
+			digest: true,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, Timezone: "Europe/Moscow", NotifyHour: 12}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(
+						gomock.Any(),
+						entities.User{ID: 123, Timezone: "Europe/Moscow", NotifyHour: 12, NotificationsDigest: true},
+					).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:   "Failure - get user error",
+			userID: 123,
+			digest: true,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name:   "Failure - storage update error",
+			userID: 123,
+			digest: false,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, NotificationsDigest: true}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), entities.User{ID: 123}).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &usersUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateUserNotificationsDigest(context.Background(), tt.userID, tt.digest)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.digest, got.NotificationsDigest)
+		})
+	}
+}
+
+func TestUsersUseCases_UpdateUserQuietHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      int
+		end        int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name:  "Success - quiet hours across midnight",
+			start: 22,
+			end:   8,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), entities.User{ID: 123, QuietHoursStart: 22, QuietHoursEnd: 8}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:    "Failure - start is out of day",
+			start:   24,
+			end:     8,
+			wantErr: customerrors.ErrInvalidQuietHours,
+		},
+		{
+			name:    "Failure - end is negative",
+			start:   22,
+			end:     -1,
+			wantErr: customerrors.ErrInvalidQuietHours,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &usersUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateUserQuietHours(context.Background(), 123, tt.start, tt.end)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.start, got.QuietHoursStart)
+			assert.Equal(t, tt.end, got.QuietHoursEnd)
+		})
+	}
+}
+
+func TestUsersUseCases_UpdateUserQuietWeekdays(t *testing.T) {
+	weekend := 1<<time.Saturday | 1<<time.Sunday
+
+	tests := []struct {
+		name       string
+		weekdays   int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name:     "Success - weekend is quiet",
+			weekdays: weekend,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), entities.User{ID: 123, QuietWeekdays: weekend}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "Failure - unknown weekday",
+			weekdays: 1 << 7,
+			wantErr:  customerrors.ErrInvalidQuietWeekdays,
+		},
+		{
+			name:     "Failure - storage update error",
+			weekdays: weekend,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to update User with ID=123",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &usersUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateUserQuietWeekdays(context.Background(), 123, tt.weekdays)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.weekdays, got.QuietWeekdays)
+		})
+	}
+}
+
+func TestUsersUseCases_UpdateUserVacation(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2026, 7, 10, 0, 0, 0, 0, moscow)
+	end := time.Date(2026, 7, 20, 0, 0, 0, 0, moscow)
+	startUTC := start.UTC()
+	endUTC := end.UTC()
+
+	tests := []struct {
+		name       string
+		start      *time.Time
+		end        *time.Time
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		wantErr    error
+	}{
+		{
+			name:  "Success - vacation planned in UTC",
+			start: &start,
+			end:   &end,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), entities.User{ID: 123, VacationStart: &startUTC, VacationEnd: &endUTC}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Success - vacation canceled",
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetUserByID(gomock.Any(), 123).
+					Return(&entities.User{ID: 123, VacationStart: &startUTC, VacationEnd: &endUTC}, nil).
+					Times(1)
+
+				storage.
+					EXPECT().
+					UpdateUser(gomock.Any(), entities.User{ID: 123}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:    "Failure - end before start",
+			start:   &end,
+			end:     &start,
+			wantErr: customerrors.ErrInvalidVacation,
+		},
+		{
+			name:    "Failure - end without start",
+			end:     &end,
+			wantErr: customerrors.ErrInvalidVacation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &usersUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.UpdateUserVacation(context.Background(), 123, tt.start, tt.end)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.start == nil, got.VacationStart == nil)
+			assert.Equal(t, tt.end == nil, got.VacationEnd == nil)
+		})
+	}
+}
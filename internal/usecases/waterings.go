@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
+)
+
+type wateringsUseCases struct {
+	storage interfaces.Storage
+	logger  logging.Logger
+}
+
+func (u *wateringsUseCases) GetGroupWaterings(
+	ctx context.Context,
+	groupID, limit, offset int,
+) ([]entities.Watering, error) {
+	waterings, err := u.storage.GetGroupWaterings(ctx, groupID, limit, offset)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to get Waterings for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return waterings, err
+}
+
+func (u *wateringsUseCases) CountGroupWaterings(ctx context.Context, groupID int) (int, error) {
+	count, err := u.storage.CountGroupWaterings(ctx, groupID)
+	if err != nil {
+		u.logger.Error(
+			fmt.Sprintf("Failed to count Waterings for Group with ID=%d", groupID),
+			"Error", err,
+			"Tracing", logging.GetLogTraceback(loggingTraceSkipLevel),
+		)
+	}
+
+	return count, err
+}
@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"context"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	mockstorage "github.com/DKhorkov/plantsCareTelegramBot/mocks/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestWateringsUseCases_GetGroupWaterings(t *testing.T) {
+	waterings := []entities.Watering{
+		{ID: 2, GroupID: 1, WateredAt: time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC), Source: entities.WateringSourceNotification},
+		{ID: 1, GroupID: 1, WateredAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Source: entities.WateringSourceManual},
+	}
+
+	tests := []struct {
+		name       string
+		groupID    int
+		limit      int
+		offset     int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       []entities.Watering
+		wantErr    bool
+	}{
+		{
+			name:    "Success - waterings found",
+			groupID: 1,
+			limit:   10,
+			offset:  0,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupWaterings(gomock.Any(), 1, 10, 0).
+					Return(waterings, nil).
+					Times(1)
+			},
+			want:    waterings,
+			wantErr: false,
+		},
+		{
+			name:    "Success - no waterings",
+			groupID: 1,
+			limit:   10,
+			offset:  10,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupWaterings(gomock.Any(), 1, 10, 10).
+					Return(nil, nil).
+					Times(1)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "Failure - storage error",
+			groupID: 1,
+			limit:   10,
+			offset:  0,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					GetGroupWaterings(gomock.Any(), 1, 10, 0).
+					Return(nil, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to get Waterings for Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &wateringsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.GetGroupWaterings(context.Background(), tt.groupID, tt.limit, tt.offset)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWateringsUseCases_CountGroupWaterings(t *testing.T) {
+	tests := []struct {
+		name       string
+		groupID    int
+		setupMocks func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger)
+		want       int
+		wantErr    bool
+	}{
+		{
+			name:    "Success - waterings counted",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CountGroupWaterings(gomock.Any(), 1).
+					Return(15, nil).
+					Times(1)
+			},
+			want:    15,
+			wantErr: false,
+		},
+		{
+			name:    "Failure - storage error",
+			groupID: 1,
+			setupMocks: func(storage *mockstorage.MockStorage, logger *mocklogging.MockLogger) {
+				storage.
+					EXPECT().
+					CountGroupWaterings(gomock.Any(), 1).
+					Return(0, assert.AnError).
+					Times(1)
+
+				logger.
+					EXPECT().
+					Error(
+						"Failed to count Waterings for Group with ID=1",
+						"Error", assert.AnError,
+						"Tracing", gomock.Any(),
+					).
+					Times(1)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := mockstorage.NewMockStorage(ctrl)
+			mockLogger := mocklogging.NewMockLogger(ctrl)
+
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStorage, mockLogger)
+			}
+
+			useCases := &wateringsUseCases{
+				storage: mockStorage,
+				logger:  mockLogger,
+			}
+
+			got, err := useCases.CountGroupWaterings(context.Background(), tt.groupID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,6 +2,10 @@ package utils
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 )
 
 // GetWateringInterval - отдает тектосове выражения интервала полива.
@@ -23,3 +27,110 @@ func GetWateringInterval(wateringInterval int) string {
 		return fmt.Sprintf("%d дней", wateringInterval)
 	}
 }
+
+// GetCareTaskType - отдает текстовое название вида ухода.
+func GetCareTaskType(careTaskType string) string {
+	switch careTaskType {
+	case entities.CareTaskTypeFertilizing:
+		return texts.CareTaskTypeFertilizing
+	case entities.CareTaskTypeMisting:
+		return texts.CareTaskTypeMisting
+	case entities.CareTaskTypeRepotting:
+		return texts.CareTaskTypeRepotting
+	default:
+		return careTaskType
+	}
+}
+
+// GetGroupWateringInterval - отдает текстовое выражение интервала полива сценария с учетом сезонного графика.
+// Интервал и сезон определяются на дату последнего полива, от которой рассчитывается следующий полив.
+func GetGroupWateringInterval(group entities.Group) string {
+	if len(group.SeasonSchedule) == 0 {
+		return GetWateringInterval(group.WateringInterval)
+	}
+
+	season := group.SeasonSchedule.GetSeason(group.LastWateringDate)
+	if season == nil {
+		return fmt.Sprintf(texts.DefaultSeasonWateringInterval, GetWateringInterval(group.WateringInterval))
+	}
+
+	return fmt.Sprintf(
+		texts.SeasonalWateringInterval,
+		GetWateringInterval(season.WateringInterval),
+		GetSeason(*season),
+	)
+}
+
+// GetSeason - отдает текстовое название сезона. Для времен года добавляется их название.
+func GetSeason(season entities.Season) string {
+	var title string
+
+	switch {
+	case season.SameMonths(entities.Season{StartMonth: time.December, EndMonth: time.February}):
+		title = texts.SeasonWinter
+	case season.SameMonths(entities.Season{StartMonth: time.March, EndMonth: time.May}):
+		title = texts.SeasonSpring
+	case season.SameMonths(entities.Season{StartMonth: time.June, EndMonth: time.August}):
+		title = texts.SeasonSummer
+	case season.SameMonths(entities.Season{StartMonth: time.September, EndMonth: time.November}):
+		title = texts.SeasonAutumn
+	default:
+		return fmt.Sprintf(texts.SeasonMonthsRange, GetMonth(season.StartMonth), GetMonth(season.EndMonth))
+	}
+
+	return fmt.Sprintf(texts.SeasonTitle, title, GetMonth(season.StartMonth), GetMonth(season.EndMonth))
+}
+
+// GetMonth - отдает текстовое название месяца.
+func GetMonth(month time.Month) string {
+	switch month {
+	case time.January:
+		return texts.MonthJanuary
+	case time.February:
+		return texts.MonthFebruary
+	case time.March:
+		return texts.MonthMarch
+	case time.April:
+		return texts.MonthApril
+	case time.May:
+		return texts.MonthMay
+	case time.June:
+		return texts.MonthJune
+	case time.July:
+		return texts.MonthJuly
+	case time.August:
+		return texts.MonthAugust
+	case time.September:
+		return texts.MonthSeptember
+	case time.October:
+		return texts.MonthOctober
+	case time.November:
+		return texts.MonthNovember
+	case time.December:
+		return texts.MonthDecember
+	default:
+		return month.String()
+	}
+}
+
+// GetWeekday - отдает текстовое название дня недели.
+func GetWeekday(weekday time.Weekday) string {
+	switch weekday {
+	case time.Monday:
+		return texts.WeekdayMonday
+	case time.Tuesday:
+		return texts.WeekdayTuesday
+	case time.Wednesday:
+		return texts.WeekdayWednesday
+	case time.Thursday:
+		return texts.WeekdayThursday
+	case time.Friday:
+		return texts.WeekdayFriday
+	case time.Saturday:
+		return texts.WeekdaySaturday
+	case time.Sunday:
+		return texts.WeekdaySunday
+	default:
+		return weekday.String()
+	}
+}
@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	"github.com/DKhorkov/plantsCareTelegramBot/internal/texts"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestGetWateringInterval(t *testing.T) {
@@ -42,3 +45,111 @@ func TestGetWateringInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCareTaskType(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Подкормка", input: entities.CareTaskTypeFertilizing, expected: texts.CareTaskTypeFertilizing},
+		{name: "Опрыскивание", input: entities.CareTaskTypeMisting, expected: texts.CareTaskTypeMisting},
+		{name: "Пересадка", input: entities.CareTaskTypeRepotting, expected: texts.CareTaskTypeRepotting},
+		{name: "Неизвестный вид ухода", input: "pruning", expected: "pruning"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetCareTaskType(tt.input))
+		})
+	}
+}
+
+func TestGetSeason(t *testing.T) {
+	tests := []struct {
+		name     string
+		season   entities.Season
+		expected string
+	}{
+		{
+			name:     "Зима",
+			season:   entities.Season{StartMonth: time.December, EndMonth: time.February},
+			expected: "зима (декабрь — февраль)",
+		},
+		{
+			name:     "Лето",
+			season:   entities.Season{StartMonth: time.June, EndMonth: time.August},
+			expected: "лето (июнь — август)",
+		},
+		{
+			name:     "Произвольный диапазон месяцев",
+			season:   entities.Season{StartMonth: time.November, EndMonth: time.March},
+			expected: "ноябрь — март",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetSeason(tt.season))
+		})
+	}
+}
+
+func TestGetGroupWateringInterval(t *testing.T) {
+	winter := entities.Season{StartMonth: time.December, EndMonth: time.February, WateringInterval: 21}
+
+	tests := []struct {
+		name     string
+		group    entities.Group
+		expected string
+	}{
+		{
+			name:     "Без сезонного графика",
+			group:    entities.Group{WateringInterval: 7},
+			expected: "7 дней",
+		},
+		{
+			name: "Полив в активный сезон",
+			group: entities.Group{
+				WateringInterval: 7,
+				LastWateringDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+				SeasonSchedule:   entities.SeasonSchedule{winter},
+			},
+			expected: "21 день, сезон: зима (декабрь — февраль)",
+		},
+		{
+			name: "Полив вне сезонов графика",
+			group: entities.Group{
+				WateringInterval: 7,
+				LastWateringDate: time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC),
+				SeasonSchedule:   entities.SeasonSchedule{winter},
+			},
+			expected: "7 дней, основной интервал",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetGroupWateringInterval(tt.group))
+		})
+	}
+}
+
+func TestGetWeekday(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    time.Weekday
+		expected string
+	}{
+		{name: "Понедельник", input: time.Monday, expected: texts.WeekdayMonday},
+		{name: "Суббота", input: time.Saturday, expected: texts.WeekdaySaturday},
+		{name: "Воскресенье", input: time.Sunday, expected: texts.WeekdaySunday},
+		{name: "Неизвестный день", input: time.Weekday(7), expected: time.Weekday(7).String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetWeekday(tt.input))
+		})
+	}
+}
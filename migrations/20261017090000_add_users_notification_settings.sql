@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone    VARCHAR(64) NOT NULL DEFAULT 'Europe/Moscow',
+    ADD COLUMN IF NOT EXISTS notify_hour INTEGER     NOT NULL DEFAULT 12 CHECK (notify_hour BETWEEN 0 AND 23);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS notify_hour,
+    DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
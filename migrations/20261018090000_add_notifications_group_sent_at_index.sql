@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS notifications_group_id_sent_at_idx ON notifications (group_id, sent_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS notifications_group_id_sent_at_idx;
+-- +goose StatementEnd
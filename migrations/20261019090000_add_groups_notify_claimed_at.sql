@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS notify_claimed_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE groups
+    DROP COLUMN IF EXISTS notify_claimed_at;
+-- +goose StatementEnd
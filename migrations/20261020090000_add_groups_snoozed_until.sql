@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE groups
+    DROP COLUMN IF EXISTS snoozed_until;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS waterings
+(
+    id         SERIAL PRIMARY KEY,
+    group_id   INTEGER     NOT NULL,
+    plant_id   INTEGER,
+    watered_at TIMESTAMP   NOT NULL,
+    source     VARCHAR(20) NOT NULL CHECK (source IN ('notification', 'manual', 'backfill')),
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE,
+    FOREIGN KEY (plant_id) REFERENCES plants (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS waterings_group_id_watered_at_idx
+    ON waterings (group_id, watered_at DESC);
+
+-- Переносим в историю последний известный полив уже существующих сценариев:
+INSERT INTO waterings (group_id, watered_at, source)
+SELECT id, last_watering_date, 'backfill'
+FROM groups;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS waterings;
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS care_tasks
+(
+    id                SERIAL PRIMARY KEY,
+    user_id           INTEGER     NOT NULL,
+    group_id          INTEGER,
+    plant_id          INTEGER,
+    type              VARCHAR(20) NOT NULL CHECK (type IN ('fertilizing', 'misting', 'repotting')),
+    interval_days     INTEGER     NOT NULL CHECK (interval_days > 0),
+    last_care_date    TIMESTAMP   NOT NULL,
+    next_care_date    TIMESTAMP   NOT NULL,
+    created_at        TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    notify_claimed_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE,
+    FOREIGN KEY (plant_id) REFERENCES plants (id) ON DELETE CASCADE,
+    -- Задача ухода привязывается либо к сценарию, либо к растению:
+    CHECK ((group_id IS NULL) <> (plant_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS care_tasks_group_id_type_idx
+    ON care_tasks (group_id, type)
+    WHERE group_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS care_tasks_plant_id_type_idx
+    ON care_tasks (plant_id, type)
+    WHERE plant_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS care_tasks_next_care_date_idx
+    ON care_tasks (next_care_date);
+
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS care_task_id INTEGER REFERENCES care_tasks (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS notifications_care_task_id_sent_at_idx
+    ON notifications (care_task_id, sent_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS notifications_care_task_id_sent_at_idx;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS care_task_id;
+
+DROP TABLE IF EXISTS care_tasks;
+-- +goose StatementEnd
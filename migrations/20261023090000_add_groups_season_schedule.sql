@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS season_schedule JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE groups
+    DROP COLUMN IF EXISTS season_schedule;
+-- +goose StatementEnd
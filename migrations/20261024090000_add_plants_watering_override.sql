@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE plants
+    ADD COLUMN IF NOT EXISTS watering_interval  INTEGER CHECK (watering_interval > 0),
+    ADD COLUMN IF NOT EXISTS last_watering_date TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE plants
+    DROP COLUMN IF EXISTS last_watering_date,
+    DROP COLUMN IF EXISTS watering_interval;
+-- +goose StatementEnd
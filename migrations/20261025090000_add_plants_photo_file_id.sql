@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Фотографии храним по ссылке на файл в Telegram. Байты существующих растений остаются в колонке photo
+-- и заменяются на file_id при первом открытии растения в боте:
+ALTER TABLE plants
+    ADD COLUMN IF NOT EXISTS photo_file_id        VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS photo_file_unique_id VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Фотографии, перенесенные в Telegram, хранятся только в photo_file_id, и откат удалил бы их без возможности
+-- восстановления. Такие фотографии нужно сначала выгрузить обратно в photo, поэтому откат прерывается:
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM plants WHERE (photo IS NULL OR octet_length(photo) = 0) AND photo_file_id <> '') THEN
+        RAISE EXCEPTION 'plants have photos stored only as Telegram file_id, downgrade would lose them';
+    END IF;
+END $$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE plants
+    DROP COLUMN IF EXISTS photo_file_unique_id,
+    DROP COLUMN IF EXISTS photo_file_id;
+-- +goose StatementEnd
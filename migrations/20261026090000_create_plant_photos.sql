@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS plant_photos
+(
+    id             SERIAL PRIMARY KEY,
+    plant_id       INTEGER      NOT NULL,
+    file_id        VARCHAR(255) NOT NULL,
+    file_unique_id VARCHAR(255) NOT NULL,
+    caption        TEXT         NOT NULL DEFAULT '',
+    taken_at       TIMESTAMP    NOT NULL,
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (plant_id) REFERENCES plants (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS plant_photos_plant_id_taken_at_idx
+    ON plant_photos (plant_id, taken_at);
+
+-- Текущие фотографии растений становятся первыми снимками в истории роста. Фотографии, которые еще хранятся
+-- в photo, попадают в историю при переносе в Telegram во время первого показа растения:
+INSERT INTO plant_photos (plant_id, file_id, file_unique_id, taken_at)
+SELECT id, photo_file_id, photo_file_unique_id, created_at
+FROM plants
+WHERE photo_file_id <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS plant_photos;
+-- +goose StatementEnd
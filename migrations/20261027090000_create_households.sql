@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS households
+(
+    id         SERIAL PRIMARY KEY,
+    owner_id   INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Пользователь может состоять только в одном доме:
+CREATE TABLE IF NOT EXISTS household_members
+(
+    household_id INTEGER   NOT NULL,
+    user_id      INTEGER   NOT NULL UNIQUE,
+    joined_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (household_id, user_id),
+    FOREIGN KEY (household_id) REFERENCES households (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS household_invites
+(
+    id           SERIAL PRIMARY KEY,
+    household_id INTEGER     NOT NULL,
+    token        VARCHAR(64) NOT NULL UNIQUE,
+    created_by   INTEGER     NOT NULL,
+    expires_at   TIMESTAMP   NOT NULL,
+    created_at   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (household_id) REFERENCES households (id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Напоминания отправляются всем участникам дома, поэтому запоминаем чат каждого сообщения:
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS chat_id BIGINT NOT NULL DEFAULT 0;
+
+UPDATE notifications
+SET chat_id = users.telegram_id
+FROM groups
+         JOIN users ON users.id = groups.user_id
+WHERE groups.id = notifications.group_id;
+
+-- Участник дома, отметивший полив:
+ALTER TABLE waterings
+    ADD COLUMN IF NOT EXISTS user_id INTEGER REFERENCES users (id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE waterings
+    DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS chat_id;
+
+DROP TABLE IF EXISTS household_invites;
+DROP TABLE IF EXISTS household_members;
+DROP TABLE IF EXISTS households;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Момент, когда пользователь отреагировал на напоминание. Неподтвержденные напоминания повторяются:
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS acknowledged_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS acknowledged_at;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Пользователь может получать одну сводку по всем сценариям вместо отдельного напоминания по каждому:
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS notifications_digest BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Одно сообщение сводки сохраняется отдельным уведомлением для каждого сценария:
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS digest BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS digest;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS notifications_digest;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Тихие часы задаются полуинтервалом [start, end) и могут переходить через полночь. Равные значения отключают их:
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS quiet_hours_start INTEGER NOT NULL DEFAULT 0 CHECK (quiet_hours_start BETWEEN 0 AND 23),
+    ADD COLUMN IF NOT EXISTS quiet_hours_end   INTEGER NOT NULL DEFAULT 0 CHECK (quiet_hours_end BETWEEN 0 AND 23);
+
+-- Дни недели без уведомлений хранятся битовой маской, где бит с номером time.Weekday отвечает за день:
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS quiet_weekdays INTEGER NOT NULL DEFAULT 0 CHECK (quiet_weekdays BETWEEN 0 AND 127);
+
+-- Во время отпуска напоминания не отправляются, а после него приходит одна сводка:
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS vacation_start TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS vacation_end   TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS vacation_end,
+    DROP COLUMN IF EXISTS vacation_start,
+    DROP COLUMN IF EXISTS quiet_weekdays,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS quiet_hours_start;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Передача напоминаний по сценариям присматривающему за растениями на время поездки.
+-- Присматривающий появляется, когда примет приглашение по ссылке:
+CREATE TABLE IF NOT EXISTS delegations
+(
+    id         SERIAL PRIMARY KEY,
+    owner_id   INTEGER     NOT NULL,
+    sitter_id  INTEGER,
+    group_ids  JSONB       NOT NULL,
+    token      VARCHAR(64) NOT NULL UNIQUE,
+    end_date   TIMESTAMP   NOT NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (sitter_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS delegations_owner_id_idx ON delegations (owner_id);
+CREATE INDEX IF NOT EXISTS delegations_group_ids_idx ON delegations USING GIN (group_ids);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS delegations;
+-- +goose StatementEnd
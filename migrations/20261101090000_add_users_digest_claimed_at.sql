@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Сводка собирается по всем сценариям пользователя, поэтому воркер захватывает ее для пользователя целиком:
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS digest_claimed_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS digest_claimed_at;
+-- +goose StatementEnd
@@ -1776,6 +1776,20 @@ func (mr *MockBotMockRecorder) UserBoosts(chat, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserBoosts", reflect.TypeOf((*MockBot)(nil).UserBoosts), chat, user)
 }
 
+// Username mocks base method.
+func (m *MockBot) Username() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Username")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Username indicates an expected call of Username.
+func (mr *MockBotMockRecorder) Username() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Username", reflect.TypeOf((*MockBot)(nil).Username))
+}
+
 // Webhook mocks base method.
 func (m *MockBot) Webhook() (*telebot.Webhook, error) {
 	m.ctrl.T.Helper()
@@ -10,6 +10,7 @@
 package mockcron
 
 import (
+	context "context"
 	reflect "reflect"
 
 	interfaces "github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
@@ -41,17 +42,17 @@ func (m *MockCron) EXPECT() *MockCronMockRecorder {
 }
 
 // Run mocks base method.
-func (m *MockCron) Run() error {
+func (m *MockCron) Run(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Run")
+	ret := m.ctrl.Call(m, "Run", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Run indicates an expected call of Run.
-func (mr *MockCronMockRecorder) Run() *gomock.Call {
+func (mr *MockCronMockRecorder) Run(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCron)(nil).Run))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCron)(nil).Run), ctx)
 }
 
 // Stop mocks base method.
@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: server.go
+//
+// Generated by this command:
+//
+//	mockgen -source=server.go -destination=../../mocks/server/server.go -package=mockserver
+//
+
+// Package mockserver is a generated GoMock package.
+package mockserver
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockServer is a mock of Server interface.
+type MockServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockServerMockRecorder
+	isgomock struct{}
+}
+
+// MockServerMockRecorder is the mock recorder for MockServer.
+type MockServerMockRecorder struct {
+	mock *MockServer
+}
+
+// NewMockServer creates a new mock instance.
+func NewMockServer(ctrl *gomock.Controller) *MockServer {
+	mock := &MockServer{ctrl: ctrl}
+	mock.recorder = &MockServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServer) EXPECT() *MockServerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockServer) Run() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockServerMockRecorder) Run() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockServer)(nil).Run))
+}
+
+// SetReady mocks base method.
+func (m *MockServer) SetReady(ready bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetReady", ready)
+}
+
+// SetReady indicates an expected call of SetReady.
+func (mr *MockServerMockRecorder) SetReady(ready any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReady", reflect.TypeOf((*MockServer)(nil).SetReady), ready)
+}
+
+// Stop mocks base method.
+func (m *MockServer) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockServerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockServer)(nil).Stop))
+}
+
+// MockDatabasePinger is a mock of DatabasePinger interface.
+type MockDatabasePinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabasePingerMockRecorder
+	isgomock struct{}
+}
+
+// MockDatabasePingerMockRecorder is the mock recorder for MockDatabasePinger.
+type MockDatabasePingerMockRecorder struct {
+	mock *MockDatabasePinger
+}
+
+// NewMockDatabasePinger creates a new mock instance.
+func NewMockDatabasePinger(ctrl *gomock.Controller) *MockDatabasePinger {
+	mock := &MockDatabasePinger{ctrl: ctrl}
+	mock.recorder = &MockDatabasePingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabasePinger) EXPECT() *MockDatabasePingerMockRecorder {
+	return m.recorder
+}
+
+// PingContext mocks base method.
+func (m *MockDatabasePinger) PingContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingContext indicates an expected call of PingContext.
+func (mr *MockDatabasePingerMockRecorder) PingContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingContext", reflect.TypeOf((*MockDatabasePinger)(nil).PingContext), ctx)
+}
+
+// MockTelegramChecker is a mock of TelegramChecker interface.
+type MockTelegramChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTelegramCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockTelegramCheckerMockRecorder is the mock recorder for MockTelegramChecker.
+type MockTelegramCheckerMockRecorder struct {
+	mock *MockTelegramChecker
+}
+
+// NewMockTelegramChecker creates a new mock instance.
+func NewMockTelegramChecker(ctrl *gomock.Controller) *MockTelegramChecker {
+	mock := &MockTelegramChecker{ctrl: ctrl}
+	mock.recorder = &MockTelegramCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTelegramChecker) EXPECT() *MockTelegramCheckerMockRecorder {
+	return m.recorder
+}
+
+// GetMe mocks base method.
+func (m *MockTelegramChecker) GetMe() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMe")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMe indicates an expected call of GetMe.
+func (mr *MockTelegramCheckerMockRecorder) GetMe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMe", reflect.TypeOf((*MockTelegramChecker)(nil).GetMe))
+}
+
+// LastGetMe mocks base method.
+func (m *MockTelegramChecker) LastGetMe() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastGetMe")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastGetMe indicates an expected call of LastGetMe.
+func (mr *MockTelegramCheckerMockRecorder) LastGetMe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastGetMe", reflect.TypeOf((*MockTelegramChecker)(nil).LastGetMe))
+}
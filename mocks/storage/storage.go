@@ -10,9 +10,12 @@
 package mockstorage
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/DKhorkov/plantsCareTelegramBot/internal/entities"
+	interfaces "github.com/DKhorkov/plantsCareTelegramBot/internal/interfaces"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,341 +43,916 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AcknowledgeGroupNotifications mocks base method.
+func (m *MockStorage) AcknowledgeGroupNotifications(ctx context.Context, groupID int, acknowledgedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeGroupNotifications", ctx, groupID, acknowledgedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcknowledgeGroupNotifications indicates an expected call of AcknowledgeGroupNotifications.
+func (mr *MockStorageMockRecorder) AcknowledgeGroupNotifications(ctx, groupID, acknowledgedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeGroupNotifications", reflect.TypeOf((*MockStorage)(nil).AcknowledgeGroupNotifications), ctx, groupID, acknowledgedAt)
+}
+
+// AddHouseholdMember mocks base method.
+func (m *MockStorage) AddHouseholdMember(ctx context.Context, householdID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHouseholdMember", ctx, householdID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddHouseholdMember indicates an expected call of AddHouseholdMember.
+func (mr *MockStorageMockRecorder) AddHouseholdMember(ctx, householdID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHouseholdMember", reflect.TypeOf((*MockStorage)(nil).AddHouseholdMember), ctx, householdID, userID)
+}
+
+// ClaimCareTasksForNotify mocks base method.
+func (m *MockStorage) ClaimCareTasksForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimCareTasksForNotify", ctx, limit, claimTTL)
+	ret0, _ := ret[0].([]entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimCareTasksForNotify indicates an expected call of ClaimCareTasksForNotify.
+func (mr *MockStorageMockRecorder) ClaimCareTasksForNotify(ctx, limit, claimTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimCareTasksForNotify", reflect.TypeOf((*MockStorage)(nil).ClaimCareTasksForNotify), ctx, limit, claimTTL)
+}
+
+// ClaimGroupsForNotify mocks base method.
+func (m *MockStorage) ClaimGroupsForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimGroupsForNotify", ctx, limit, claimTTL)
+	ret0, _ := ret[0].([]entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimGroupsForNotify indicates an expected call of ClaimGroupsForNotify.
+func (mr *MockStorageMockRecorder) ClaimGroupsForNotify(ctx, limit, claimTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimGroupsForNotify", reflect.TypeOf((*MockStorage)(nil).ClaimGroupsForNotify), ctx, limit, claimTTL)
+}
+
+// ClaimUserDigest mocks base method.
+func (m *MockStorage) ClaimUserDigest(ctx context.Context, userID int, claimTTL time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUserDigest", ctx, userID, claimTTL)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUserDigest indicates an expected call of ClaimUserDigest.
+func (mr *MockStorageMockRecorder) ClaimUserDigest(ctx, userID, claimTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUserDigest", reflect.TypeOf((*MockStorage)(nil).ClaimUserDigest), ctx, userID, claimTTL)
+}
+
 // CountGroupPlants mocks base method.
-func (m *MockStorage) CountGroupPlants(groupID int) (int, error) {
+func (m *MockStorage) CountGroupPlants(ctx context.Context, groupID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountGroupPlants", groupID)
+	ret := m.ctrl.Call(m, "CountGroupPlants", ctx, groupID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountGroupPlants indicates an expected call of CountGroupPlants.
-func (mr *MockStorageMockRecorder) CountGroupPlants(groupID any) *gomock.Call {
+func (mr *MockStorageMockRecorder) CountGroupPlants(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupPlants", reflect.TypeOf((*MockStorage)(nil).CountGroupPlants), ctx, groupID)
+}
+
+// CountGroupWaterings mocks base method.
+func (m *MockStorage) CountGroupWaterings(ctx context.Context, groupID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGroupWaterings", ctx, groupID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGroupWaterings indicates an expected call of CountGroupWaterings.
+func (mr *MockStorageMockRecorder) CountGroupWaterings(ctx, groupID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupPlants", reflect.TypeOf((*MockStorage)(nil).CountGroupPlants), groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupWaterings", reflect.TypeOf((*MockStorage)(nil).CountGroupWaterings), ctx, groupID)
+}
+
+// CountPlantPhotos mocks base method.
+func (m *MockStorage) CountPlantPhotos(ctx context.Context, plantID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPlantPhotos", ctx, plantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPlantPhotos indicates an expected call of CountPlantPhotos.
+func (mr *MockStorageMockRecorder) CountPlantPhotos(ctx, plantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPlantPhotos", reflect.TypeOf((*MockStorage)(nil).CountPlantPhotos), ctx, plantID)
 }
 
 // CountUserGroups mocks base method.
-func (m *MockStorage) CountUserGroups(userID int) (int, error) {
+func (m *MockStorage) CountUserGroups(ctx context.Context, userID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserGroups", userID)
+	ret := m.ctrl.Call(m, "CountUserGroups", ctx, userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUserGroups indicates an expected call of CountUserGroups.
-func (mr *MockStorageMockRecorder) CountUserGroups(userID any) *gomock.Call {
+func (mr *MockStorageMockRecorder) CountUserGroups(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserGroups", reflect.TypeOf((*MockStorage)(nil).CountUserGroups), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserGroups", reflect.TypeOf((*MockStorage)(nil).CountUserGroups), ctx, userID)
 }
 
 // CountUserPlants mocks base method.
-func (m *MockStorage) CountUserPlants(userID int) (int, error) {
+func (m *MockStorage) CountUserPlants(ctx context.Context, userID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserPlants", userID)
+	ret := m.ctrl.Call(m, "CountUserPlants", ctx, userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUserPlants indicates an expected call of CountUserPlants.
-func (mr *MockStorageMockRecorder) CountUserPlants(userID any) *gomock.Call {
+func (mr *MockStorageMockRecorder) CountUserPlants(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPlants", reflect.TypeOf((*MockStorage)(nil).CountUserPlants), ctx, userID)
+}
+
+// CreateCareTask mocks base method.
+func (m *MockStorage) CreateCareTask(ctx context.Context, careTask entities.CareTask) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCareTask", ctx, careTask)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCareTask indicates an expected call of CreateCareTask.
+func (mr *MockStorageMockRecorder) CreateCareTask(ctx, careTask any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCareTask", reflect.TypeOf((*MockStorage)(nil).CreateCareTask), ctx, careTask)
+}
+
+// CreateDelegation mocks base method.
+func (m *MockStorage) CreateDelegation(ctx context.Context, delegation entities.Delegation) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelegation", ctx, delegation)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelegation indicates an expected call of CreateDelegation.
+func (mr *MockStorageMockRecorder) CreateDelegation(ctx, delegation any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPlants", reflect.TypeOf((*MockStorage)(nil).CountUserPlants), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegation", reflect.TypeOf((*MockStorage)(nil).CreateDelegation), ctx, delegation)
 }
 
 // CreateGroup mocks base method.
-func (m *MockStorage) CreateGroup(group entities.Group) (int, error) {
+func (m *MockStorage) CreateGroup(ctx context.Context, group entities.Group) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGroup", group)
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, group)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateGroup indicates an expected call of CreateGroup.
-func (mr *MockStorageMockRecorder) CreateGroup(group any) *gomock.Call {
+func (mr *MockStorageMockRecorder) CreateGroup(ctx, group any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockStorage)(nil).CreateGroup), group)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockStorage)(nil).CreateGroup), ctx, group)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockStorage) CreateHousehold(ctx context.Context, ownerID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", ctx, ownerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockStorageMockRecorder) CreateHousehold(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockStorage)(nil).CreateHousehold), ctx, ownerID)
+}
+
+// CreateHouseholdInvite mocks base method.
+func (m *MockStorage) CreateHouseholdInvite(ctx context.Context, invite entities.HouseholdInvite) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHouseholdInvite", ctx, invite)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHouseholdInvite indicates an expected call of CreateHouseholdInvite.
+func (mr *MockStorageMockRecorder) CreateHouseholdInvite(ctx, invite any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHouseholdInvite", reflect.TypeOf((*MockStorage)(nil).CreateHouseholdInvite), ctx, invite)
 }
 
 // CreatePlant mocks base method.
-func (m *MockStorage) CreatePlant(plant entities.Plant) (int, error) {
+func (m *MockStorage) CreatePlant(ctx context.Context, plant entities.Plant) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePlant", plant)
+	ret := m.ctrl.Call(m, "CreatePlant", ctx, plant)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePlant indicates an expected call of CreatePlant.
-func (mr *MockStorageMockRecorder) CreatePlant(plant any) *gomock.Call {
+func (mr *MockStorageMockRecorder) CreatePlant(ctx, plant any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlant", reflect.TypeOf((*MockStorage)(nil).CreatePlant), plant)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlant", reflect.TypeOf((*MockStorage)(nil).CreatePlant), ctx, plant)
 }
 
 // CreateTemporary mocks base method.
-func (m *MockStorage) CreateTemporary(temp entities.Temporary) error {
+func (m *MockStorage) CreateTemporary(ctx context.Context, temp entities.Temporary) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTemporary", temp)
+	ret := m.ctrl.Call(m, "CreateTemporary", ctx, temp)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateTemporary indicates an expected call of CreateTemporary.
-func (mr *MockStorageMockRecorder) CreateTemporary(temp any) *gomock.Call {
+func (mr *MockStorageMockRecorder) CreateTemporary(ctx, temp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemporary", reflect.TypeOf((*MockStorage)(nil).CreateTemporary), ctx, temp)
+}
+
+// DeleteCareTask mocks base method.
+func (m *MockStorage) DeleteCareTask(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCareTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCareTask indicates an expected call of DeleteCareTask.
+func (mr *MockStorageMockRecorder) DeleteCareTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCareTask", reflect.TypeOf((*MockStorage)(nil).DeleteCareTask), ctx, id)
+}
+
+// DeleteDelegation mocks base method.
+func (m *MockStorage) DeleteDelegation(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDelegation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDelegation indicates an expected call of DeleteDelegation.
+func (mr *MockStorageMockRecorder) DeleteDelegation(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemporary", reflect.TypeOf((*MockStorage)(nil).CreateTemporary), temp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDelegation", reflect.TypeOf((*MockStorage)(nil).DeleteDelegation), ctx, id)
 }
 
 // DeleteGroup mocks base method.
-func (m *MockStorage) DeleteGroup(id int) error {
+func (m *MockStorage) DeleteGroup(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGroup", id)
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteGroup indicates an expected call of DeleteGroup.
-func (mr *MockStorageMockRecorder) DeleteGroup(id any) *gomock.Call {
+func (mr *MockStorageMockRecorder) DeleteGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockStorage)(nil).DeleteGroup), ctx, id)
+}
+
+// DeleteHouseholdInvite mocks base method.
+func (m *MockStorage) DeleteHouseholdInvite(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHouseholdInvite", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHouseholdInvite indicates an expected call of DeleteHouseholdInvite.
+func (mr *MockStorageMockRecorder) DeleteHouseholdInvite(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHouseholdInvite", reflect.TypeOf((*MockStorage)(nil).DeleteHouseholdInvite), ctx, id)
+}
+
+// DeleteHouseholdMember mocks base method.
+func (m *MockStorage) DeleteHouseholdMember(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHouseholdMember", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHouseholdMember indicates an expected call of DeleteHouseholdMember.
+func (mr *MockStorageMockRecorder) DeleteHouseholdMember(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockStorage)(nil).DeleteGroup), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHouseholdMember", reflect.TypeOf((*MockStorage)(nil).DeleteHouseholdMember), ctx, userID)
 }
 
 // DeletePlant mocks base method.
-func (m *MockStorage) DeletePlant(id int) error {
+func (m *MockStorage) DeletePlant(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePlant", id)
+	ret := m.ctrl.Call(m, "DeletePlant", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePlant indicates an expected call of DeletePlant.
-func (mr *MockStorageMockRecorder) DeletePlant(id any) *gomock.Call {
+func (mr *MockStorageMockRecorder) DeletePlant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlant", reflect.TypeOf((*MockStorage)(nil).DeletePlant), ctx, id)
+}
+
+// GetCareTask mocks base method.
+func (m *MockStorage) GetCareTask(ctx context.Context, id int) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCareTask", ctx, id)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCareTask indicates an expected call of GetCareTask.
+func (mr *MockStorageMockRecorder) GetCareTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCareTask", reflect.TypeOf((*MockStorage)(nil).GetCareTask), ctx, id)
+}
+
+// GetDelegation mocks base method.
+func (m *MockStorage) GetDelegation(ctx context.Context, id int) (*entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegation", ctx, id)
+	ret0, _ := ret[0].(*entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegation indicates an expected call of GetDelegation.
+func (mr *MockStorageMockRecorder) GetDelegation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegation", reflect.TypeOf((*MockStorage)(nil).GetDelegation), ctx, id)
+}
+
+// GetDelegationByToken mocks base method.
+func (m *MockStorage) GetDelegationByToken(ctx context.Context, token string) (*entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelegationByToken", ctx, token)
+	ret0, _ := ret[0].(*entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegationByToken indicates an expected call of GetDelegationByToken.
+func (mr *MockStorageMockRecorder) GetDelegationByToken(ctx, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlant", reflect.TypeOf((*MockStorage)(nil).DeletePlant), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegationByToken", reflect.TypeOf((*MockStorage)(nil).GetDelegationByToken), ctx, token)
 }
 
 // GetGroup mocks base method.
-func (m *MockStorage) GetGroup(id int) (*entities.Group, error) {
+func (m *MockStorage) GetGroup(ctx context.Context, id int) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGroup", id)
+	ret := m.ctrl.Call(m, "GetGroup", ctx, id)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroup indicates an expected call of GetGroup.
-func (mr *MockStorageMockRecorder) GetGroup(id any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockStorage)(nil).GetGroup), ctx, id)
+}
+
+// GetGroupCareTasks mocks base method.
+func (m *MockStorage) GetGroupCareTasks(ctx context.Context, groupID int) ([]entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupCareTasks", ctx, groupID)
+	ret0, _ := ret[0].([]entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupCareTasks indicates an expected call of GetGroupCareTasks.
+func (mr *MockStorageMockRecorder) GetGroupCareTasks(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupCareTasks", reflect.TypeOf((*MockStorage)(nil).GetGroupCareTasks), ctx, groupID)
+}
+
+// GetGroupDelegation mocks base method.
+func (m *MockStorage) GetGroupDelegation(ctx context.Context, groupID int, date time.Time) (*entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupDelegation", ctx, groupID, date)
+	ret0, _ := ret[0].(*entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupDelegation indicates an expected call of GetGroupDelegation.
+func (mr *MockStorageMockRecorder) GetGroupDelegation(ctx, groupID, date any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockStorage)(nil).GetGroup), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupDelegation", reflect.TypeOf((*MockStorage)(nil).GetGroupDelegation), ctx, groupID, date)
+}
+
+// GetGroupNotificationsSince mocks base method.
+func (m *MockStorage) GetGroupNotificationsSince(ctx context.Context, groupID int, since time.Time) ([]entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupNotificationsSince", ctx, groupID, since)
+	ret0, _ := ret[0].([]entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupNotificationsSince indicates an expected call of GetGroupNotificationsSince.
+func (mr *MockStorageMockRecorder) GetGroupNotificationsSince(ctx, groupID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupNotificationsSince", reflect.TypeOf((*MockStorage)(nil).GetGroupNotificationsSince), ctx, groupID, since)
 }
 
 // GetGroupPlants mocks base method.
-func (m *MockStorage) GetGroupPlants(groupID int) ([]entities.Plant, error) {
+func (m *MockStorage) GetGroupPlants(ctx context.Context, groupID int) ([]entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGroupPlants", groupID)
+	ret := m.ctrl.Call(m, "GetGroupPlants", ctx, groupID)
 	ret0, _ := ret[0].([]entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroupPlants indicates an expected call of GetGroupPlants.
-func (mr *MockStorageMockRecorder) GetGroupPlants(groupID any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetGroupPlants(ctx, groupID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPlants", reflect.TypeOf((*MockStorage)(nil).GetGroupPlants), groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPlants", reflect.TypeOf((*MockStorage)(nil).GetGroupPlants), ctx, groupID)
+}
+
+// GetGroupWaterings mocks base method.
+func (m *MockStorage) GetGroupWaterings(ctx context.Context, groupID, limit, offset int) ([]entities.Watering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupWaterings", ctx, groupID, limit, offset)
+	ret0, _ := ret[0].([]entities.Watering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupWaterings indicates an expected call of GetGroupWaterings.
+func (mr *MockStorageMockRecorder) GetGroupWaterings(ctx, groupID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupWaterings", reflect.TypeOf((*MockStorage)(nil).GetGroupWaterings), ctx, groupID, limit, offset)
 }
 
 // GetGroupsForNotify mocks base method.
-func (m *MockStorage) GetGroupsForNotify(limit, offset int) ([]entities.Group, error) {
+func (m *MockStorage) GetGroupsForNotify(ctx context.Context, limit, offset int) ([]entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGroupsForNotify", limit, offset)
+	ret := m.ctrl.Call(m, "GetGroupsForNotify", ctx, limit, offset)
 	ret0, _ := ret[0].([]entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroupsForNotify indicates an expected call of GetGroupsForNotify.
-func (mr *MockStorageMockRecorder) GetGroupsForNotify(limit, offset any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetGroupsForNotify(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsForNotify", reflect.TypeOf((*MockStorage)(nil).GetGroupsForNotify), ctx, limit, offset)
+}
+
+// GetHouseholdInvite mocks base method.
+func (m *MockStorage) GetHouseholdInvite(ctx context.Context, token string) (*entities.HouseholdInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdInvite", ctx, token)
+	ret0, _ := ret[0].(*entities.HouseholdInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdInvite indicates an expected call of GetHouseholdInvite.
+func (mr *MockStorageMockRecorder) GetHouseholdInvite(ctx, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsForNotify", reflect.TypeOf((*MockStorage)(nil).GetGroupsForNotify), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdInvite", reflect.TypeOf((*MockStorage)(nil).GetHouseholdInvite), ctx, token)
+}
+
+// GetHouseholdUsers mocks base method.
+func (m *MockStorage) GetHouseholdUsers(ctx context.Context, userID int) ([]entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdUsers", ctx, userID)
+	ret0, _ := ret[0].([]entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdUsers indicates an expected call of GetHouseholdUsers.
+func (mr *MockStorageMockRecorder) GetHouseholdUsers(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdUsers", reflect.TypeOf((*MockStorage)(nil).GetHouseholdUsers), ctx, userID)
+}
+
+// GetLastCareTaskNotification mocks base method.
+func (m *MockStorage) GetLastCareTaskNotification(ctx context.Context, careTaskID int) (*entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCareTaskNotification", ctx, careTaskID)
+	ret0, _ := ret[0].(*entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCareTaskNotification indicates an expected call of GetLastCareTaskNotification.
+func (mr *MockStorageMockRecorder) GetLastCareTaskNotification(ctx, careTaskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCareTaskNotification", reflect.TypeOf((*MockStorage)(nil).GetLastCareTaskNotification), ctx, careTaskID)
+}
+
+// GetLastCareTaskNotifications mocks base method.
+func (m *MockStorage) GetLastCareTaskNotifications(ctx context.Context, careTaskID int) ([]entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCareTaskNotifications", ctx, careTaskID)
+	ret0, _ := ret[0].([]entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCareTaskNotifications indicates an expected call of GetLastCareTaskNotifications.
+func (mr *MockStorageMockRecorder) GetLastCareTaskNotifications(ctx, careTaskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCareTaskNotifications", reflect.TypeOf((*MockStorage)(nil).GetLastCareTaskNotifications), ctx, careTaskID)
+}
+
+// GetLastNotification mocks base method.
+func (m *MockStorage) GetLastNotification(ctx context.Context, groupID int) (*entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastNotification", ctx, groupID)
+	ret0, _ := ret[0].(*entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastNotification indicates an expected call of GetLastNotification.
+func (mr *MockStorageMockRecorder) GetLastNotification(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastNotification", reflect.TypeOf((*MockStorage)(nil).GetLastNotification), ctx, groupID)
+}
+
+// GetMessageNotifications mocks base method.
+func (m *MockStorage) GetMessageNotifications(ctx context.Context, chatID int64, messageID int) ([]entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageNotifications", ctx, chatID, messageID)
+	ret0, _ := ret[0].([]entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageNotifications indicates an expected call of GetMessageNotifications.
+func (mr *MockStorageMockRecorder) GetMessageNotifications(ctx, chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageNotifications", reflect.TypeOf((*MockStorage)(nil).GetMessageNotifications), ctx, chatID, messageID)
+}
+
+// GetOwnerDelegations mocks base method.
+func (m *MockStorage) GetOwnerDelegations(ctx context.Context, ownerID int, date time.Time) ([]entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerDelegations", ctx, ownerID, date)
+	ret0, _ := ret[0].([]entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerDelegations indicates an expected call of GetOwnerDelegations.
+func (mr *MockStorageMockRecorder) GetOwnerDelegations(ctx, ownerID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerDelegations", reflect.TypeOf((*MockStorage)(nil).GetOwnerDelegations), ctx, ownerID, date)
 }
 
 // GetPlant mocks base method.
-func (m *MockStorage) GetPlant(id int) (*entities.Plant, error) {
+func (m *MockStorage) GetPlant(ctx context.Context, id int) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPlant", id)
+	ret := m.ctrl.Call(m, "GetPlant", ctx, id)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPlant indicates an expected call of GetPlant.
-func (mr *MockStorageMockRecorder) GetPlant(id any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetPlant(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlant", reflect.TypeOf((*MockStorage)(nil).GetPlant), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlant", reflect.TypeOf((*MockStorage)(nil).GetPlant), ctx, id)
+}
+
+// GetPlantCareTasks mocks base method.
+func (m *MockStorage) GetPlantCareTasks(ctx context.Context, plantID int) ([]entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlantCareTasks", ctx, plantID)
+	ret0, _ := ret[0].([]entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlantCareTasks indicates an expected call of GetPlantCareTasks.
+func (mr *MockStorageMockRecorder) GetPlantCareTasks(ctx, plantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlantCareTasks", reflect.TypeOf((*MockStorage)(nil).GetPlantCareTasks), ctx, plantID)
+}
+
+// GetPlantPhoto mocks base method.
+func (m *MockStorage) GetPlantPhoto(ctx context.Context, id int) (*entities.PlantPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlantPhoto", ctx, id)
+	ret0, _ := ret[0].(*entities.PlantPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlantPhoto indicates an expected call of GetPlantPhoto.
+func (mr *MockStorageMockRecorder) GetPlantPhoto(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlantPhoto", reflect.TypeOf((*MockStorage)(nil).GetPlantPhoto), ctx, id)
+}
+
+// GetPlantPhotos mocks base method.
+func (m *MockStorage) GetPlantPhotos(ctx context.Context, plantID, limit, offset int) ([]entities.PlantPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlantPhotos", ctx, plantID, limit, offset)
+	ret0, _ := ret[0].([]entities.PlantPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlantPhotos indicates an expected call of GetPlantPhotos.
+func (mr *MockStorageMockRecorder) GetPlantPhotos(ctx, plantID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlantPhotos", reflect.TypeOf((*MockStorage)(nil).GetPlantPhotos), ctx, plantID, limit, offset)
 }
 
 // GetTemporaryByUserID mocks base method.
-func (m *MockStorage) GetTemporaryByUserID(userID int) (*entities.Temporary, error) {
+func (m *MockStorage) GetTemporaryByUserID(ctx context.Context, userID int) (*entities.Temporary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTemporaryByUserID", userID)
+	ret := m.ctrl.Call(m, "GetTemporaryByUserID", ctx, userID)
 	ret0, _ := ret[0].(*entities.Temporary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTemporaryByUserID indicates an expected call of GetTemporaryByUserID.
-func (mr *MockStorageMockRecorder) GetTemporaryByUserID(userID any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetTemporaryByUserID(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemporaryByUserID", reflect.TypeOf((*MockStorage)(nil).GetTemporaryByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemporaryByUserID", reflect.TypeOf((*MockStorage)(nil).GetTemporaryByUserID), ctx, userID)
 }
 
 // GetUserByID mocks base method.
-func (m *MockStorage) GetUserByID(id int) (*entities.User, error) {
+func (m *MockStorage) GetUserByID(ctx context.Context, id int) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", id)
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockStorageMockRecorder) GetUserByID(id any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStorage)(nil).GetUserByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStorage)(nil).GetUserByID), ctx, id)
 }
 
 // GetUserByTelegramID mocks base method.
-func (m *MockStorage) GetUserByTelegramID(telegramID int) (*entities.User, error) {
+func (m *MockStorage) GetUserByTelegramID(ctx context.Context, telegramID int) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByTelegramID", telegramID)
+	ret := m.ctrl.Call(m, "GetUserByTelegramID", ctx, telegramID)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByTelegramID indicates an expected call of GetUserByTelegramID.
-func (mr *MockStorageMockRecorder) GetUserByTelegramID(telegramID any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetUserByTelegramID(ctx, telegramID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByTelegramID", reflect.TypeOf((*MockStorage)(nil).GetUserByTelegramID), telegramID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByTelegramID", reflect.TypeOf((*MockStorage)(nil).GetUserByTelegramID), ctx, telegramID)
 }
 
 // GetUserGroups mocks base method.
-func (m *MockStorage) GetUserGroups(userID int) ([]entities.Group, error) {
+func (m *MockStorage) GetUserGroups(ctx context.Context, userID int) ([]entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserGroups", userID)
+	ret := m.ctrl.Call(m, "GetUserGroups", ctx, userID)
 	ret0, _ := ret[0].([]entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserGroups indicates an expected call of GetUserGroups.
-func (mr *MockStorageMockRecorder) GetUserGroups(userID any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetUserGroups(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroups", reflect.TypeOf((*MockStorage)(nil).GetUserGroups), ctx, userID)
+}
+
+// GetUserHousehold mocks base method.
+func (m *MockStorage) GetUserHousehold(ctx context.Context, userID int) (*entities.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHousehold", ctx, userID)
+	ret0, _ := ret[0].(*entities.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHousehold indicates an expected call of GetUserHousehold.
+func (mr *MockStorageMockRecorder) GetUserHousehold(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroups", reflect.TypeOf((*MockStorage)(nil).GetUserGroups), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHousehold", reflect.TypeOf((*MockStorage)(nil).GetUserHousehold), ctx, userID)
 }
 
 // GroupExists mocks base method.
-func (m *MockStorage) GroupExists(group entities.Group) (bool, error) {
+func (m *MockStorage) GroupExists(ctx context.Context, group entities.Group) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupExists", group)
+	ret := m.ctrl.Call(m, "GroupExists", ctx, group)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GroupExists indicates an expected call of GroupExists.
-func (mr *MockStorageMockRecorder) GroupExists(group any) *gomock.Call {
+func (mr *MockStorageMockRecorder) GroupExists(ctx, group any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupExists", reflect.TypeOf((*MockStorage)(nil).GroupExists), group)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupExists", reflect.TypeOf((*MockStorage)(nil).GroupExists), ctx, group)
 }
 
 // PlantExists mocks base method.
-func (m *MockStorage) PlantExists(plant entities.Plant) (bool, error) {
+func (m *MockStorage) PlantExists(ctx context.Context, plant entities.Plant) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PlantExists", plant)
+	ret := m.ctrl.Call(m, "PlantExists", ctx, plant)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PlantExists indicates an expected call of PlantExists.
-func (mr *MockStorageMockRecorder) PlantExists(plant any) *gomock.Call {
+func (mr *MockStorageMockRecorder) PlantExists(ctx, plant any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlantExists", reflect.TypeOf((*MockStorage)(nil).PlantExists), plant)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlantExists", reflect.TypeOf((*MockStorage)(nil).PlantExists), ctx, plant)
 }
 
 // SaveNotification mocks base method.
-func (m *MockStorage) SaveNotification(notification entities.Notification) (int, error) {
+func (m *MockStorage) SaveNotification(ctx context.Context, notification entities.Notification) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveNotification", notification)
+	ret := m.ctrl.Call(m, "SaveNotification", ctx, notification)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveNotification indicates an expected call of SaveNotification.
-func (mr *MockStorageMockRecorder) SaveNotification(notification any) *gomock.Call {
+func (mr *MockStorageMockRecorder) SaveNotification(ctx, notification any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotification", reflect.TypeOf((*MockStorage)(nil).SaveNotification), notification)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotification", reflect.TypeOf((*MockStorage)(nil).SaveNotification), ctx, notification)
+}
+
+// SavePlantPhoto mocks base method.
+func (m *MockStorage) SavePlantPhoto(ctx context.Context, photo entities.PlantPhoto) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePlantPhoto", ctx, photo)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePlantPhoto indicates an expected call of SavePlantPhoto.
+func (mr *MockStorageMockRecorder) SavePlantPhoto(ctx, photo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlantPhoto", reflect.TypeOf((*MockStorage)(nil).SavePlantPhoto), ctx, photo)
 }
 
 // SaveUser mocks base method.
-func (m *MockStorage) SaveUser(user entities.User) (int, error) {
+func (m *MockStorage) SaveUser(ctx context.Context, user entities.User) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveUser", user)
+	ret := m.ctrl.Call(m, "SaveUser", ctx, user)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveUser indicates an expected call of SaveUser.
-func (mr *MockStorageMockRecorder) SaveUser(user any) *gomock.Call {
+func (mr *MockStorageMockRecorder) SaveUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockStorage)(nil).SaveUser), ctx, user)
+}
+
+// SaveWatering mocks base method.
+func (m *MockStorage) SaveWatering(ctx context.Context, watering entities.Watering) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWatering", ctx, watering)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveWatering indicates an expected call of SaveWatering.
+func (mr *MockStorageMockRecorder) SaveWatering(ctx, watering any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWatering", reflect.TypeOf((*MockStorage)(nil).SaveWatering), ctx, watering)
+}
+
+// UpdateCareTask mocks base method.
+func (m *MockStorage) UpdateCareTask(ctx context.Context, careTask entities.CareTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCareTask", ctx, careTask)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCareTask indicates an expected call of UpdateCareTask.
+func (mr *MockStorageMockRecorder) UpdateCareTask(ctx, careTask any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCareTask", reflect.TypeOf((*MockStorage)(nil).UpdateCareTask), ctx, careTask)
+}
+
+// UpdateDelegationSitter mocks base method.
+func (m *MockStorage) UpdateDelegationSitter(ctx context.Context, id, sitterID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelegationSitter", ctx, id, sitterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelegationSitter indicates an expected call of UpdateDelegationSitter.
+func (mr *MockStorageMockRecorder) UpdateDelegationSitter(ctx, id, sitterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockStorage)(nil).SaveUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelegationSitter", reflect.TypeOf((*MockStorage)(nil).UpdateDelegationSitter), ctx, id, sitterID)
 }
 
 // UpdateGroup mocks base method.
-func (m *MockStorage) UpdateGroup(group entities.Group) error {
+func (m *MockStorage) UpdateGroup(ctx context.Context, group entities.Group) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGroup", group)
+	ret := m.ctrl.Call(m, "UpdateGroup", ctx, group)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateGroup indicates an expected call of UpdateGroup.
-func (mr *MockStorageMockRecorder) UpdateGroup(group any) *gomock.Call {
+func (mr *MockStorageMockRecorder) UpdateGroup(ctx, group any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockStorage)(nil).UpdateGroup), group)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockStorage)(nil).UpdateGroup), ctx, group)
 }
 
 // UpdatePlant mocks base method.
-func (m *MockStorage) UpdatePlant(plant entities.Plant) error {
+func (m *MockStorage) UpdatePlant(ctx context.Context, plant entities.Plant) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePlant", plant)
+	ret := m.ctrl.Call(m, "UpdatePlant", ctx, plant)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdatePlant indicates an expected call of UpdatePlant.
-func (mr *MockStorageMockRecorder) UpdatePlant(plant any) *gomock.Call {
+func (mr *MockStorageMockRecorder) UpdatePlant(ctx, plant any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlant", reflect.TypeOf((*MockStorage)(nil).UpdatePlant), plant)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlant", reflect.TypeOf((*MockStorage)(nil).UpdatePlant), ctx, plant)
 }
 
 // UpdateTemporary mocks base method.
-func (m *MockStorage) UpdateTemporary(temp entities.Temporary) error {
+func (m *MockStorage) UpdateTemporary(ctx context.Context, temp entities.Temporary) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTemporary", temp)
+	ret := m.ctrl.Call(m, "UpdateTemporary", ctx, temp)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTemporary indicates an expected call of UpdateTemporary.
-func (mr *MockStorageMockRecorder) UpdateTemporary(temp any) *gomock.Call {
+func (mr *MockStorageMockRecorder) UpdateTemporary(ctx, temp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemporary", reflect.TypeOf((*MockStorage)(nil).UpdateTemporary), ctx, temp)
+}
+
+// UpdateUser mocks base method.
+func (m *MockStorage) UpdateUser(ctx context.Context, user entities.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockStorageMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStorage)(nil).UpdateUser), ctx, user)
+}
+
+// WithTx mocks base method.
+func (m *MockStorage) WithTx(ctx context.Context, fn func(interfaces.Storage) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockStorageMockRecorder) WithTx(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemporary", reflect.TypeOf((*MockStorage)(nil).UpdateTemporary), temp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockStorage)(nil).WithTx), ctx, fn)
 }
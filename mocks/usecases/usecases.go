@@ -10,6 +10,7 @@
 package mockusecases
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -41,565 +42,1381 @@ func (m *MockUseCases) EXPECT() *MockUseCasesMockRecorder {
 	return m.recorder
 }
 
+// AcceptDelegation mocks base method.
+func (m *MockUseCases) AcceptDelegation(ctx context.Context, sitterID int, token string) (*entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptDelegation", ctx, sitterID, token)
+	ret0, _ := ret[0].(*entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptDelegation indicates an expected call of AcceptDelegation.
+func (mr *MockUseCasesMockRecorder) AcceptDelegation(ctx, sitterID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptDelegation", reflect.TypeOf((*MockUseCases)(nil).AcceptDelegation), ctx, sitterID, token)
+}
+
+// AcknowledgeGroupNotifications mocks base method.
+func (m *MockUseCases) AcknowledgeGroupNotifications(ctx context.Context, groupID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeGroupNotifications", ctx, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcknowledgeGroupNotifications indicates an expected call of AcknowledgeGroupNotifications.
+func (mr *MockUseCasesMockRecorder) AcknowledgeGroupNotifications(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeGroupNotifications", reflect.TypeOf((*MockUseCases)(nil).AcknowledgeGroupNotifications), ctx, groupID)
+}
+
+// AddCareTaskInterval mocks base method.
+func (m *MockUseCases) AddCareTaskInterval(ctx context.Context, telegramID, interval int) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCareTaskInterval", ctx, telegramID, interval)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCareTaskInterval indicates an expected call of AddCareTaskInterval.
+func (mr *MockUseCasesMockRecorder) AddCareTaskInterval(ctx, telegramID, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCareTaskInterval", reflect.TypeOf((*MockUseCases)(nil).AddCareTaskInterval), ctx, telegramID, interval)
+}
+
+// AddCareTaskLastDate mocks base method.
+func (m *MockUseCases) AddCareTaskLastDate(ctx context.Context, telegramID int, lastCareDate time.Time) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCareTaskLastDate", ctx, telegramID, lastCareDate)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCareTaskLastDate indicates an expected call of AddCareTaskLastDate.
+func (mr *MockUseCasesMockRecorder) AddCareTaskLastDate(ctx, telegramID, lastCareDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCareTaskLastDate", reflect.TypeOf((*MockUseCases)(nil).AddCareTaskLastDate), ctx, telegramID, lastCareDate)
+}
+
+// AddCareTaskType mocks base method.
+func (m *MockUseCases) AddCareTaskType(ctx context.Context, telegramID int, careTaskType string) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCareTaskType", ctx, telegramID, careTaskType)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCareTaskType indicates an expected call of AddCareTaskType.
+func (mr *MockUseCasesMockRecorder) AddCareTaskType(ctx, telegramID, careTaskType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCareTaskType", reflect.TypeOf((*MockUseCases)(nil).AddCareTaskType), ctx, telegramID, careTaskType)
+}
+
 // AddGroupDescription mocks base method.
-func (m *MockUseCases) AddGroupDescription(telegramID int, description string) (*entities.Group, error) {
+func (m *MockUseCases) AddGroupDescription(ctx context.Context, telegramID int, description string) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddGroupDescription", telegramID, description)
+	ret := m.ctrl.Call(m, "AddGroupDescription", ctx, telegramID, description)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddGroupDescription indicates an expected call of AddGroupDescription.
-func (mr *MockUseCasesMockRecorder) AddGroupDescription(telegramID, description any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddGroupDescription(ctx, telegramID, description any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupDescription", reflect.TypeOf((*MockUseCases)(nil).AddGroupDescription), telegramID, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupDescription", reflect.TypeOf((*MockUseCases)(nil).AddGroupDescription), ctx, telegramID, description)
 }
 
 // AddGroupLastWateringDate mocks base method.
-func (m *MockUseCases) AddGroupLastWateringDate(telegramID int, lastWateringDate time.Time) (*entities.Group, error) {
+func (m *MockUseCases) AddGroupLastWateringDate(ctx context.Context, telegramID int, lastWateringDate time.Time) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddGroupLastWateringDate", telegramID, lastWateringDate)
+	ret := m.ctrl.Call(m, "AddGroupLastWateringDate", ctx, telegramID, lastWateringDate)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddGroupLastWateringDate indicates an expected call of AddGroupLastWateringDate.
-func (mr *MockUseCasesMockRecorder) AddGroupLastWateringDate(telegramID, lastWateringDate any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddGroupLastWateringDate(ctx, telegramID, lastWateringDate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupLastWateringDate", reflect.TypeOf((*MockUseCases)(nil).AddGroupLastWateringDate), telegramID, lastWateringDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupLastWateringDate", reflect.TypeOf((*MockUseCases)(nil).AddGroupLastWateringDate), ctx, telegramID, lastWateringDate)
 }
 
 // AddGroupTitle mocks base method.
-func (m *MockUseCases) AddGroupTitle(telegramID int, title string) (*entities.Group, error) {
+func (m *MockUseCases) AddGroupTitle(ctx context.Context, telegramID int, title string) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddGroupTitle", telegramID, title)
+	ret := m.ctrl.Call(m, "AddGroupTitle", ctx, telegramID, title)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddGroupTitle indicates an expected call of AddGroupTitle.
-func (mr *MockUseCasesMockRecorder) AddGroupTitle(telegramID, title any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddGroupTitle(ctx, telegramID, title any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupTitle", reflect.TypeOf((*MockUseCases)(nil).AddGroupTitle), telegramID, title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupTitle", reflect.TypeOf((*MockUseCases)(nil).AddGroupTitle), ctx, telegramID, title)
 }
 
 // AddGroupWateringInterval mocks base method.
-func (m *MockUseCases) AddGroupWateringInterval(telegramID, wateringInterval int) (*entities.Group, error) {
+func (m *MockUseCases) AddGroupWateringInterval(ctx context.Context, telegramID, wateringInterval int) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddGroupWateringInterval", telegramID, wateringInterval)
+	ret := m.ctrl.Call(m, "AddGroupWateringInterval", ctx, telegramID, wateringInterval)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddGroupWateringInterval indicates an expected call of AddGroupWateringInterval.
-func (mr *MockUseCasesMockRecorder) AddGroupWateringInterval(telegramID, wateringInterval any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddGroupWateringInterval(ctx, telegramID, wateringInterval any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupWateringInterval", reflect.TypeOf((*MockUseCases)(nil).AddGroupWateringInterval), telegramID, wateringInterval)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupWateringInterval", reflect.TypeOf((*MockUseCases)(nil).AddGroupWateringInterval), ctx, telegramID, wateringInterval)
 }
 
 // AddPlantDescription mocks base method.
-func (m *MockUseCases) AddPlantDescription(telegramID int, description string) (*entities.Plant, error) {
+func (m *MockUseCases) AddPlantDescription(ctx context.Context, telegramID int, description string) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPlantDescription", telegramID, description)
+	ret := m.ctrl.Call(m, "AddPlantDescription", ctx, telegramID, description)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddPlantDescription indicates an expected call of AddPlantDescription.
-func (mr *MockUseCasesMockRecorder) AddPlantDescription(telegramID, description any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddPlantDescription(ctx, telegramID, description any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantDescription", reflect.TypeOf((*MockUseCases)(nil).AddPlantDescription), telegramID, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantDescription", reflect.TypeOf((*MockUseCases)(nil).AddPlantDescription), ctx, telegramID, description)
 }
 
 // AddPlantGroup mocks base method.
-func (m *MockUseCases) AddPlantGroup(telegramID, groupID int) (*entities.Plant, error) {
+func (m *MockUseCases) AddPlantGroup(ctx context.Context, telegramID, groupID int) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPlantGroup", telegramID, groupID)
+	ret := m.ctrl.Call(m, "AddPlantGroup", ctx, telegramID, groupID)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddPlantGroup indicates an expected call of AddPlantGroup.
-func (mr *MockUseCasesMockRecorder) AddPlantGroup(telegramID, groupID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddPlantGroup(ctx, telegramID, groupID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantGroup", reflect.TypeOf((*MockUseCases)(nil).AddPlantGroup), telegramID, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantGroup", reflect.TypeOf((*MockUseCases)(nil).AddPlantGroup), ctx, telegramID, groupID)
 }
 
 // AddPlantPhoto mocks base method.
-func (m *MockUseCases) AddPlantPhoto(telegramID int, photo []byte) (*entities.Plant, error) {
+func (m *MockUseCases) AddPlantPhoto(ctx context.Context, telegramID int, fileID, fileUniqueID string) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPlantPhoto", telegramID, photo)
+	ret := m.ctrl.Call(m, "AddPlantPhoto", ctx, telegramID, fileID, fileUniqueID)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddPlantPhoto indicates an expected call of AddPlantPhoto.
-func (mr *MockUseCasesMockRecorder) AddPlantPhoto(telegramID, photo any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddPlantPhoto(ctx, telegramID, fileID, fileUniqueID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantPhoto", reflect.TypeOf((*MockUseCases)(nil).AddPlantPhoto), telegramID, photo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantPhoto", reflect.TypeOf((*MockUseCases)(nil).AddPlantPhoto), ctx, telegramID, fileID, fileUniqueID)
 }
 
 // AddPlantTitle mocks base method.
-func (m *MockUseCases) AddPlantTitle(telegramID int, title string) (*entities.Plant, error) {
+func (m *MockUseCases) AddPlantTitle(ctx context.Context, telegramID int, title string) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPlantTitle", telegramID, title)
+	ret := m.ctrl.Call(m, "AddPlantTitle", ctx, telegramID, title)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddPlantTitle indicates an expected call of AddPlantTitle.
-func (mr *MockUseCasesMockRecorder) AddPlantTitle(telegramID, title any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) AddPlantTitle(ctx, telegramID, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantTitle", reflect.TypeOf((*MockUseCases)(nil).AddPlantTitle), ctx, telegramID, title)
+}
+
+// CancelDelegation mocks base method.
+func (m *MockUseCases) CancelDelegation(ctx context.Context, ownerID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDelegation", ctx, ownerID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelDelegation indicates an expected call of CancelDelegation.
+func (mr *MockUseCasesMockRecorder) CancelDelegation(ctx, ownerID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDelegation", reflect.TypeOf((*MockUseCases)(nil).CancelDelegation), ctx, ownerID, id)
+}
+
+// ClaimCareTasksForNotify mocks base method.
+func (m *MockUseCases) ClaimCareTasksForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimCareTasksForNotify", ctx, limit, claimTTL)
+	ret0, _ := ret[0].([]entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimCareTasksForNotify indicates an expected call of ClaimCareTasksForNotify.
+func (mr *MockUseCasesMockRecorder) ClaimCareTasksForNotify(ctx, limit, claimTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimCareTasksForNotify", reflect.TypeOf((*MockUseCases)(nil).ClaimCareTasksForNotify), ctx, limit, claimTTL)
+}
+
+// ClaimGroupsForNotify mocks base method.
+func (m *MockUseCases) ClaimGroupsForNotify(ctx context.Context, limit int, claimTTL time.Duration) ([]entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimGroupsForNotify", ctx, limit, claimTTL)
+	ret0, _ := ret[0].([]entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimGroupsForNotify indicates an expected call of ClaimGroupsForNotify.
+func (mr *MockUseCasesMockRecorder) ClaimGroupsForNotify(ctx, limit, claimTTL any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlantTitle", reflect.TypeOf((*MockUseCases)(nil).AddPlantTitle), telegramID, title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimGroupsForNotify", reflect.TypeOf((*MockUseCases)(nil).ClaimGroupsForNotify), ctx, limit, claimTTL)
+}
+
+// ClaimUserDigest mocks base method.
+func (m *MockUseCases) ClaimUserDigest(ctx context.Context, userID int, claimTTL time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUserDigest", ctx, userID, claimTTL)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUserDigest indicates an expected call of ClaimUserDigest.
+func (mr *MockUseCasesMockRecorder) ClaimUserDigest(ctx, userID, claimTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUserDigest", reflect.TypeOf((*MockUseCases)(nil).ClaimUserDigest), ctx, userID, claimTTL)
 }
 
 // CountGroupPlants mocks base method.
-func (m *MockUseCases) CountGroupPlants(groupID int) (int, error) {
+func (m *MockUseCases) CountGroupPlants(ctx context.Context, groupID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountGroupPlants", groupID)
+	ret := m.ctrl.Call(m, "CountGroupPlants", ctx, groupID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountGroupPlants indicates an expected call of CountGroupPlants.
-func (mr *MockUseCasesMockRecorder) CountGroupPlants(groupID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) CountGroupPlants(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupPlants", reflect.TypeOf((*MockUseCases)(nil).CountGroupPlants), ctx, groupID)
+}
+
+// CountGroupWaterings mocks base method.
+func (m *MockUseCases) CountGroupWaterings(ctx context.Context, groupID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGroupWaterings", ctx, groupID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGroupWaterings indicates an expected call of CountGroupWaterings.
+func (mr *MockUseCasesMockRecorder) CountGroupWaterings(ctx, groupID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupPlants", reflect.TypeOf((*MockUseCases)(nil).CountGroupPlants), groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupWaterings", reflect.TypeOf((*MockUseCases)(nil).CountGroupWaterings), ctx, groupID)
+}
+
+// CountPlantPhotos mocks base method.
+func (m *MockUseCases) CountPlantPhotos(ctx context.Context, plantID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPlantPhotos", ctx, plantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPlantPhotos indicates an expected call of CountPlantPhotos.
+func (mr *MockUseCasesMockRecorder) CountPlantPhotos(ctx, plantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPlantPhotos", reflect.TypeOf((*MockUseCases)(nil).CountPlantPhotos), ctx, plantID)
 }
 
 // CountUserGroups mocks base method.
-func (m *MockUseCases) CountUserGroups(userID int) (int, error) {
+func (m *MockUseCases) CountUserGroups(ctx context.Context, userID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserGroups", userID)
+	ret := m.ctrl.Call(m, "CountUserGroups", ctx, userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUserGroups indicates an expected call of CountUserGroups.
-func (mr *MockUseCasesMockRecorder) CountUserGroups(userID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) CountUserGroups(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserGroups", reflect.TypeOf((*MockUseCases)(nil).CountUserGroups), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserGroups", reflect.TypeOf((*MockUseCases)(nil).CountUserGroups), ctx, userID)
 }
 
 // CountUserPlants mocks base method.
-func (m *MockUseCases) CountUserPlants(userID int) (int, error) {
+func (m *MockUseCases) CountUserPlants(ctx context.Context, userID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserPlants", userID)
+	ret := m.ctrl.Call(m, "CountUserPlants", ctx, userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUserPlants indicates an expected call of CountUserPlants.
-func (mr *MockUseCasesMockRecorder) CountUserPlants(userID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) CountUserPlants(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPlants", reflect.TypeOf((*MockUseCases)(nil).CountUserPlants), ctx, userID)
+}
+
+// CreateCareTask mocks base method.
+func (m *MockUseCases) CreateCareTask(ctx context.Context, careTask entities.CareTask) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCareTask", ctx, careTask)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCareTask indicates an expected call of CreateCareTask.
+func (mr *MockUseCasesMockRecorder) CreateCareTask(ctx, careTask any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCareTask", reflect.TypeOf((*MockUseCases)(nil).CreateCareTask), ctx, careTask)
+}
+
+// CreateDelegation mocks base method.
+func (m *MockUseCases) CreateDelegation(ctx context.Context, ownerID int, groupIDs []int, endDate time.Time) (*entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelegation", ctx, ownerID, groupIDs, endDate)
+	ret0, _ := ret[0].(*entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelegation indicates an expected call of CreateDelegation.
+func (mr *MockUseCasesMockRecorder) CreateDelegation(ctx, ownerID, groupIDs, endDate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPlants", reflect.TypeOf((*MockUseCases)(nil).CountUserPlants), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelegation", reflect.TypeOf((*MockUseCases)(nil).CreateDelegation), ctx, ownerID, groupIDs, endDate)
 }
 
 // CreateGroup mocks base method.
-func (m *MockUseCases) CreateGroup(group entities.Group) (*entities.Group, error) {
+func (m *MockUseCases) CreateGroup(ctx context.Context, group entities.Group) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGroup", group)
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, group)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateGroup indicates an expected call of CreateGroup.
-func (mr *MockUseCasesMockRecorder) CreateGroup(group any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) CreateGroup(ctx, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockUseCases)(nil).CreateGroup), ctx, group)
+}
+
+// CreateHouseholdInvite mocks base method.
+func (m *MockUseCases) CreateHouseholdInvite(ctx context.Context, userID int) (*entities.HouseholdInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHouseholdInvite", ctx, userID)
+	ret0, _ := ret[0].(*entities.HouseholdInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHouseholdInvite indicates an expected call of CreateHouseholdInvite.
+func (mr *MockUseCasesMockRecorder) CreateHouseholdInvite(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockUseCases)(nil).CreateGroup), group)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHouseholdInvite", reflect.TypeOf((*MockUseCases)(nil).CreateHouseholdInvite), ctx, userID)
 }
 
 // CreatePlant mocks base method.
-func (m *MockUseCases) CreatePlant(plant entities.Plant) (*entities.Plant, error) {
+func (m *MockUseCases) CreatePlant(ctx context.Context, plant entities.Plant) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePlant", plant)
+	ret := m.ctrl.Call(m, "CreatePlant", ctx, plant)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePlant indicates an expected call of CreatePlant.
-func (mr *MockUseCasesMockRecorder) CreatePlant(plant any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) CreatePlant(ctx, plant any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlant", reflect.TypeOf((*MockUseCases)(nil).CreatePlant), plant)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlant", reflect.TypeOf((*MockUseCases)(nil).CreatePlant), ctx, plant)
+}
+
+// DeleteCareTask mocks base method.
+func (m *MockUseCases) DeleteCareTask(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCareTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCareTask indicates an expected call of DeleteCareTask.
+func (mr *MockUseCasesMockRecorder) DeleteCareTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCareTask", reflect.TypeOf((*MockUseCases)(nil).DeleteCareTask), ctx, id)
 }
 
 // DeleteGroup mocks base method.
-func (m *MockUseCases) DeleteGroup(id int) error {
+func (m *MockUseCases) DeleteGroup(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGroup", id)
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteGroup indicates an expected call of DeleteGroup.
-func (mr *MockUseCasesMockRecorder) DeleteGroup(id any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) DeleteGroup(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockUseCases)(nil).DeleteGroup), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockUseCases)(nil).DeleteGroup), ctx, id)
 }
 
 // DeletePlant mocks base method.
-func (m *MockUseCases) DeletePlant(id int) error {
+func (m *MockUseCases) DeletePlant(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePlant", id)
+	ret := m.ctrl.Call(m, "DeletePlant", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePlant indicates an expected call of DeletePlant.
-func (mr *MockUseCasesMockRecorder) DeletePlant(id any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) DeletePlant(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlant", reflect.TypeOf((*MockUseCases)(nil).DeletePlant), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlant", reflect.TypeOf((*MockUseCases)(nil).DeletePlant), ctx, id)
+}
+
+// ExportUserData mocks base method.
+func (m *MockUseCases) ExportUserData(ctx context.Context, userID int) (*entities.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", ctx, userID)
+	ret0, _ := ret[0].(*entities.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockUseCasesMockRecorder) ExportUserData(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockUseCases)(nil).ExportUserData), ctx, userID)
+}
+
+// GetCareTask mocks base method.
+func (m *MockUseCases) GetCareTask(ctx context.Context, id int) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCareTask", ctx, id)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCareTask indicates an expected call of GetCareTask.
+func (mr *MockUseCasesMockRecorder) GetCareTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCareTask", reflect.TypeOf((*MockUseCases)(nil).GetCareTask), ctx, id)
 }
 
 // GetGroup mocks base method.
-func (m *MockUseCases) GetGroup(id int) (*entities.Group, error) {
+func (m *MockUseCases) GetGroup(ctx context.Context, id int) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGroup", id)
+	ret := m.ctrl.Call(m, "GetGroup", ctx, id)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroup indicates an expected call of GetGroup.
-func (mr *MockUseCasesMockRecorder) GetGroup(id any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetGroup(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockUseCases)(nil).GetGroup), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockUseCases)(nil).GetGroup), ctx, id)
+}
+
+// GetGroupCareTasks mocks base method.
+func (m *MockUseCases) GetGroupCareTasks(ctx context.Context, groupID int) ([]entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupCareTasks", ctx, groupID)
+	ret0, _ := ret[0].([]entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupCareTasks indicates an expected call of GetGroupCareTasks.
+func (mr *MockUseCasesMockRecorder) GetGroupCareTasks(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupCareTasks", reflect.TypeOf((*MockUseCases)(nil).GetGroupCareTasks), ctx, groupID)
+}
+
+// GetGroupDelegation mocks base method.
+func (m *MockUseCases) GetGroupDelegation(ctx context.Context, groupID int, date time.Time) (*entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupDelegation", ctx, groupID, date)
+	ret0, _ := ret[0].(*entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupDelegation indicates an expected call of GetGroupDelegation.
+func (mr *MockUseCasesMockRecorder) GetGroupDelegation(ctx, groupID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupDelegation", reflect.TypeOf((*MockUseCases)(nil).GetGroupDelegation), ctx, groupID, date)
+}
+
+// GetGroupNotificationsSince mocks base method.
+func (m *MockUseCases) GetGroupNotificationsSince(ctx context.Context, groupID int, since time.Time) ([]entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupNotificationsSince", ctx, groupID, since)
+	ret0, _ := ret[0].([]entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupNotificationsSince indicates an expected call of GetGroupNotificationsSince.
+func (mr *MockUseCasesMockRecorder) GetGroupNotificationsSince(ctx, groupID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupNotificationsSince", reflect.TypeOf((*MockUseCases)(nil).GetGroupNotificationsSince), ctx, groupID, since)
 }
 
 // GetGroupPlants mocks base method.
-func (m *MockUseCases) GetGroupPlants(groupID int) ([]entities.Plant, error) {
+func (m *MockUseCases) GetGroupPlants(ctx context.Context, groupID int) ([]entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGroupPlants", groupID)
+	ret := m.ctrl.Call(m, "GetGroupPlants", ctx, groupID)
 	ret0, _ := ret[0].([]entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroupPlants indicates an expected call of GetGroupPlants.
-func (mr *MockUseCasesMockRecorder) GetGroupPlants(groupID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetGroupPlants(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPlants", reflect.TypeOf((*MockUseCases)(nil).GetGroupPlants), ctx, groupID)
+}
+
+// GetGroupWaterings mocks base method.
+func (m *MockUseCases) GetGroupWaterings(ctx context.Context, groupID, limit, offset int) ([]entities.Watering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupWaterings", ctx, groupID, limit, offset)
+	ret0, _ := ret[0].([]entities.Watering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupWaterings indicates an expected call of GetGroupWaterings.
+func (mr *MockUseCasesMockRecorder) GetGroupWaterings(ctx, groupID, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPlants", reflect.TypeOf((*MockUseCases)(nil).GetGroupPlants), groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupWaterings", reflect.TypeOf((*MockUseCases)(nil).GetGroupWaterings), ctx, groupID, limit, offset)
 }
 
 // GetGroupsForNotify mocks base method.
-func (m *MockUseCases) GetGroupsForNotify(limit, offset int) ([]entities.Group, error) {
+func (m *MockUseCases) GetGroupsForNotify(ctx context.Context, limit, offset int) ([]entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGroupsForNotify", limit, offset)
+	ret := m.ctrl.Call(m, "GetGroupsForNotify", ctx, limit, offset)
 	ret0, _ := ret[0].([]entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGroupsForNotify indicates an expected call of GetGroupsForNotify.
-func (mr *MockUseCasesMockRecorder) GetGroupsForNotify(limit, offset any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetGroupsForNotify(ctx, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsForNotify", reflect.TypeOf((*MockUseCases)(nil).GetGroupsForNotify), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsForNotify", reflect.TypeOf((*MockUseCases)(nil).GetGroupsForNotify), ctx, limit, offset)
+}
+
+// GetHouseholdUsers mocks base method.
+func (m *MockUseCases) GetHouseholdUsers(ctx context.Context, userID int) ([]entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholdUsers", ctx, userID)
+	ret0, _ := ret[0].([]entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholdUsers indicates an expected call of GetHouseholdUsers.
+func (mr *MockUseCasesMockRecorder) GetHouseholdUsers(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholdUsers", reflect.TypeOf((*MockUseCases)(nil).GetHouseholdUsers), ctx, userID)
+}
+
+// GetLastCareTaskNotification mocks base method.
+func (m *MockUseCases) GetLastCareTaskNotification(ctx context.Context, careTaskID int) (*entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCareTaskNotification", ctx, careTaskID)
+	ret0, _ := ret[0].(*entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCareTaskNotification indicates an expected call of GetLastCareTaskNotification.
+func (mr *MockUseCasesMockRecorder) GetLastCareTaskNotification(ctx, careTaskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCareTaskNotification", reflect.TypeOf((*MockUseCases)(nil).GetLastCareTaskNotification), ctx, careTaskID)
+}
+
+// GetLastCareTaskNotifications mocks base method.
+func (m *MockUseCases) GetLastCareTaskNotifications(ctx context.Context, careTaskID int) ([]entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCareTaskNotifications", ctx, careTaskID)
+	ret0, _ := ret[0].([]entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCareTaskNotifications indicates an expected call of GetLastCareTaskNotifications.
+func (mr *MockUseCasesMockRecorder) GetLastCareTaskNotifications(ctx, careTaskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCareTaskNotifications", reflect.TypeOf((*MockUseCases)(nil).GetLastCareTaskNotifications), ctx, careTaskID)
+}
+
+// GetLastNotification mocks base method.
+func (m *MockUseCases) GetLastNotification(ctx context.Context, groupID int) (*entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastNotification", ctx, groupID)
+	ret0, _ := ret[0].(*entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastNotification indicates an expected call of GetLastNotification.
+func (mr *MockUseCasesMockRecorder) GetLastNotification(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastNotification", reflect.TypeOf((*MockUseCases)(nil).GetLastNotification), ctx, groupID)
+}
+
+// GetMessageNotifications mocks base method.
+func (m *MockUseCases) GetMessageNotifications(ctx context.Context, chatID int64, messageID int) ([]entities.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageNotifications", ctx, chatID, messageID)
+	ret0, _ := ret[0].([]entities.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageNotifications indicates an expected call of GetMessageNotifications.
+func (mr *MockUseCasesMockRecorder) GetMessageNotifications(ctx, chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageNotifications", reflect.TypeOf((*MockUseCases)(nil).GetMessageNotifications), ctx, chatID, messageID)
+}
+
+// GetOwnerDelegations mocks base method.
+func (m *MockUseCases) GetOwnerDelegations(ctx context.Context, ownerID int) ([]entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerDelegations", ctx, ownerID)
+	ret0, _ := ret[0].([]entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerDelegations indicates an expected call of GetOwnerDelegations.
+func (mr *MockUseCasesMockRecorder) GetOwnerDelegations(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerDelegations", reflect.TypeOf((*MockUseCases)(nil).GetOwnerDelegations), ctx, ownerID)
 }
 
 // GetPlant mocks base method.
-func (m *MockUseCases) GetPlant(id int) (*entities.Plant, error) {
+func (m *MockUseCases) GetPlant(ctx context.Context, id int) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPlant", id)
+	ret := m.ctrl.Call(m, "GetPlant", ctx, id)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPlant indicates an expected call of GetPlant.
-func (mr *MockUseCasesMockRecorder) GetPlant(id any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetPlant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlant", reflect.TypeOf((*MockUseCases)(nil).GetPlant), ctx, id)
+}
+
+// GetPlantCareTasks mocks base method.
+func (m *MockUseCases) GetPlantCareTasks(ctx context.Context, plantID int) ([]entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlantCareTasks", ctx, plantID)
+	ret0, _ := ret[0].([]entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlantCareTasks indicates an expected call of GetPlantCareTasks.
+func (mr *MockUseCasesMockRecorder) GetPlantCareTasks(ctx, plantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlant", reflect.TypeOf((*MockUseCases)(nil).GetPlant), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlantCareTasks", reflect.TypeOf((*MockUseCases)(nil).GetPlantCareTasks), ctx, plantID)
+}
+
+// GetPlantPhoto mocks base method.
+func (m *MockUseCases) GetPlantPhoto(ctx context.Context, id int) (*entities.PlantPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlantPhoto", ctx, id)
+	ret0, _ := ret[0].(*entities.PlantPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlantPhoto indicates an expected call of GetPlantPhoto.
+func (mr *MockUseCasesMockRecorder) GetPlantPhoto(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlantPhoto", reflect.TypeOf((*MockUseCases)(nil).GetPlantPhoto), ctx, id)
+}
+
+// GetPlantPhotos mocks base method.
+func (m *MockUseCases) GetPlantPhotos(ctx context.Context, plantID, limit, offset int) ([]entities.PlantPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlantPhotos", ctx, plantID, limit, offset)
+	ret0, _ := ret[0].([]entities.PlantPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlantPhotos indicates an expected call of GetPlantPhotos.
+func (mr *MockUseCasesMockRecorder) GetPlantPhotos(ctx, plantID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlantPhotos", reflect.TypeOf((*MockUseCases)(nil).GetPlantPhotos), ctx, plantID, limit, offset)
 }
 
 // GetUserByID mocks base method.
-func (m *MockUseCases) GetUserByID(id int) (*entities.User, error) {
+func (m *MockUseCases) GetUserByID(ctx context.Context, id int) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", id)
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockUseCasesMockRecorder) GetUserByID(id any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUseCases)(nil).GetUserByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUseCases)(nil).GetUserByID), ctx, id)
 }
 
 // GetUserByTelegramID mocks base method.
-func (m *MockUseCases) GetUserByTelegramID(telegramID int) (*entities.User, error) {
+func (m *MockUseCases) GetUserByTelegramID(ctx context.Context, telegramID int) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByTelegramID", telegramID)
+	ret := m.ctrl.Call(m, "GetUserByTelegramID", ctx, telegramID)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByTelegramID indicates an expected call of GetUserByTelegramID.
-func (mr *MockUseCasesMockRecorder) GetUserByTelegramID(telegramID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetUserByTelegramID(ctx, telegramID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByTelegramID", reflect.TypeOf((*MockUseCases)(nil).GetUserByTelegramID), telegramID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByTelegramID", reflect.TypeOf((*MockUseCases)(nil).GetUserByTelegramID), ctx, telegramID)
 }
 
 // GetUserGroups mocks base method.
-func (m *MockUseCases) GetUserGroups(userID int) ([]entities.Group, error) {
+func (m *MockUseCases) GetUserGroups(ctx context.Context, userID int) ([]entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserGroups", userID)
+	ret := m.ctrl.Call(m, "GetUserGroups", ctx, userID)
 	ret0, _ := ret[0].([]entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserGroups indicates an expected call of GetUserGroups.
-func (mr *MockUseCasesMockRecorder) GetUserGroups(userID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetUserGroups(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroups", reflect.TypeOf((*MockUseCases)(nil).GetUserGroups), ctx, userID)
+}
+
+// GetUserHousehold mocks base method.
+func (m *MockUseCases) GetUserHousehold(ctx context.Context, userID int) (*entities.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHousehold", ctx, userID)
+	ret0, _ := ret[0].(*entities.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHousehold indicates an expected call of GetUserHousehold.
+func (mr *MockUseCasesMockRecorder) GetUserHousehold(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroups", reflect.TypeOf((*MockUseCases)(nil).GetUserGroups), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHousehold", reflect.TypeOf((*MockUseCases)(nil).GetUserHousehold), ctx, userID)
 }
 
 // GetUserTemporary mocks base method.
-func (m *MockUseCases) GetUserTemporary(telegramID int) (*entities.Temporary, error) {
+func (m *MockUseCases) GetUserTemporary(ctx context.Context, telegramID int) (*entities.Temporary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserTemporary", telegramID)
+	ret := m.ctrl.Call(m, "GetUserTemporary", ctx, telegramID)
 	ret0, _ := ret[0].(*entities.Temporary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserTemporary indicates an expected call of GetUserTemporary.
-func (mr *MockUseCasesMockRecorder) GetUserTemporary(telegramID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) GetUserTemporary(ctx, telegramID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTemporary", reflect.TypeOf((*MockUseCases)(nil).GetUserTemporary), ctx, telegramID)
+}
+
+// ImportUserData mocks base method.
+func (m *MockUseCases) ImportUserData(ctx context.Context, telegramID int, conflictResolution string) (*entities.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUserData", ctx, telegramID, conflictResolution)
+	ret0, _ := ret[0].(*entities.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUserData indicates an expected call of ImportUserData.
+func (mr *MockUseCasesMockRecorder) ImportUserData(ctx, telegramID, conflictResolution any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUserData", reflect.TypeOf((*MockUseCases)(nil).ImportUserData), ctx, telegramID, conflictResolution)
+}
+
+// JoinHousehold mocks base method.
+func (m *MockUseCases) JoinHousehold(ctx context.Context, userID int, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinHousehold", ctx, userID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// JoinHousehold indicates an expected call of JoinHousehold.
+func (mr *MockUseCasesMockRecorder) JoinHousehold(ctx, userID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinHousehold", reflect.TypeOf((*MockUseCases)(nil).JoinHousehold), ctx, userID, token)
+}
+
+// LeaveHousehold mocks base method.
+func (m *MockUseCases) LeaveHousehold(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveHousehold", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveHousehold indicates an expected call of LeaveHousehold.
+func (mr *MockUseCasesMockRecorder) LeaveHousehold(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveHousehold", reflect.TypeOf((*MockUseCases)(nil).LeaveHousehold), ctx, userID)
+}
+
+// ManageCareTask mocks base method.
+func (m *MockUseCases) ManageCareTask(ctx context.Context, telegramID, careTaskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManageCareTask", ctx, telegramID, careTaskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ManageCareTask indicates an expected call of ManageCareTask.
+func (mr *MockUseCasesMockRecorder) ManageCareTask(ctx, telegramID, careTaskID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTemporary", reflect.TypeOf((*MockUseCases)(nil).GetUserTemporary), telegramID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManageCareTask", reflect.TypeOf((*MockUseCases)(nil).ManageCareTask), ctx, telegramID, careTaskID)
+}
+
+// ManageCareTasks mocks base method.
+func (m *MockUseCases) ManageCareTasks(ctx context.Context, telegramID int, owner entities.CareTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManageCareTasks", ctx, telegramID, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ManageCareTasks indicates an expected call of ManageCareTasks.
+func (mr *MockUseCasesMockRecorder) ManageCareTasks(ctx, telegramID, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManageCareTasks", reflect.TypeOf((*MockUseCases)(nil).ManageCareTasks), ctx, telegramID, owner)
+}
+
+// ManageDelegation mocks base method.
+func (m *MockUseCases) ManageDelegation(ctx context.Context, telegramID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManageDelegation", ctx, telegramID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ManageDelegation indicates an expected call of ManageDelegation.
+func (mr *MockUseCasesMockRecorder) ManageDelegation(ctx, telegramID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManageDelegation", reflect.TypeOf((*MockUseCases)(nil).ManageDelegation), ctx, telegramID)
 }
 
 // ManageGroup mocks base method.
-func (m *MockUseCases) ManageGroup(telegramID, groupID int) error {
+func (m *MockUseCases) ManageGroup(ctx context.Context, telegramID, groupID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ManageGroup", telegramID, groupID)
+	ret := m.ctrl.Call(m, "ManageGroup", ctx, telegramID, groupID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ManageGroup indicates an expected call of ManageGroup.
-func (mr *MockUseCasesMockRecorder) ManageGroup(telegramID, groupID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) ManageGroup(ctx, telegramID, groupID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManageGroup", reflect.TypeOf((*MockUseCases)(nil).ManageGroup), telegramID, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManageGroup", reflect.TypeOf((*MockUseCases)(nil).ManageGroup), ctx, telegramID, groupID)
 }
 
 // ManagePlant mocks base method.
-func (m *MockUseCases) ManagePlant(telegramID, plantID int) error {
+func (m *MockUseCases) ManagePlant(ctx context.Context, telegramID, plantID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ManagePlant", telegramID, plantID)
+	ret := m.ctrl.Call(m, "ManagePlant", ctx, telegramID, plantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ManagePlant indicates an expected call of ManagePlant.
-func (mr *MockUseCasesMockRecorder) ManagePlant(telegramID, plantID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) ManagePlant(ctx, telegramID, plantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagePlant", reflect.TypeOf((*MockUseCases)(nil).ManagePlant), ctx, telegramID, plantID)
+}
+
+// ParseImport mocks base method.
+func (m *MockUseCases) ParseImport(data []byte) (*entities.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseImport", data)
+	ret0, _ := ret[0].(*entities.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseImport indicates an expected call of ParseImport.
+func (mr *MockUseCasesMockRecorder) ParseImport(data any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagePlant", reflect.TypeOf((*MockUseCases)(nil).ManagePlant), telegramID, plantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseImport", reflect.TypeOf((*MockUseCases)(nil).ParseImport), data)
+}
+
+// PrepareImport mocks base method.
+func (m *MockUseCases) PrepareImport(ctx context.Context, telegramID int, data []byte, photos map[string]entities.ImportPhoto) (*entities.ImportPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareImport", ctx, telegramID, data, photos)
+	ret0, _ := ret[0].(*entities.ImportPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareImport indicates an expected call of PrepareImport.
+func (mr *MockUseCasesMockRecorder) PrepareImport(ctx, telegramID, data, photos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareImport", reflect.TypeOf((*MockUseCases)(nil).PrepareImport), ctx, telegramID, data, photos)
 }
 
 // ResetTemporary mocks base method.
-func (m *MockUseCases) ResetTemporary(telegramID int) error {
+func (m *MockUseCases) ResetTemporary(ctx context.Context, telegramID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResetTemporary", telegramID)
+	ret := m.ctrl.Call(m, "ResetTemporary", ctx, telegramID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ResetTemporary indicates an expected call of ResetTemporary.
-func (mr *MockUseCasesMockRecorder) ResetTemporary(telegramID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) ResetTemporary(ctx, telegramID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTemporary", reflect.TypeOf((*MockUseCases)(nil).ResetTemporary), telegramID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTemporary", reflect.TypeOf((*MockUseCases)(nil).ResetTemporary), ctx, telegramID)
 }
 
 // SaveNotification mocks base method.
-func (m *MockUseCases) SaveNotification(notification entities.Notification) (*entities.Notification, error) {
+func (m *MockUseCases) SaveNotification(ctx context.Context, notification entities.Notification) (*entities.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveNotification", notification)
+	ret := m.ctrl.Call(m, "SaveNotification", ctx, notification)
 	ret0, _ := ret[0].(*entities.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveNotification indicates an expected call of SaveNotification.
-func (mr *MockUseCasesMockRecorder) SaveNotification(notification any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) SaveNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotification", reflect.TypeOf((*MockUseCases)(nil).SaveNotification), ctx, notification)
+}
+
+// SavePlantPhoto mocks base method.
+func (m *MockUseCases) SavePlantPhoto(ctx context.Context, photo entities.PlantPhoto) (*entities.PlantPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePlantPhoto", ctx, photo)
+	ret0, _ := ret[0].(*entities.PlantPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePlantPhoto indicates an expected call of SavePlantPhoto.
+func (mr *MockUseCasesMockRecorder) SavePlantPhoto(ctx, photo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotification", reflect.TypeOf((*MockUseCases)(nil).SaveNotification), notification)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlantPhoto", reflect.TypeOf((*MockUseCases)(nil).SavePlantPhoto), ctx, photo)
 }
 
 // SaveUser mocks base method.
-func (m *MockUseCases) SaveUser(user entities.User) (int, error) {
+func (m *MockUseCases) SaveUser(ctx context.Context, user entities.User) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveUser", user)
+	ret := m.ctrl.Call(m, "SaveUser", ctx, user)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveUser indicates an expected call of SaveUser.
-func (mr *MockUseCasesMockRecorder) SaveUser(user any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) SaveUser(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockUseCases)(nil).SaveUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockUseCases)(nil).SaveUser), ctx, user)
 }
 
 // SetTemporaryMessage mocks base method.
-func (m *MockUseCases) SetTemporaryMessage(telegramID int, messageID *int) error {
+func (m *MockUseCases) SetTemporaryMessage(ctx context.Context, telegramID int, messageID *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetTemporaryMessage", telegramID, messageID)
+	ret := m.ctrl.Call(m, "SetTemporaryMessage", ctx, telegramID, messageID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetTemporaryMessage indicates an expected call of SetTemporaryMessage.
-func (mr *MockUseCasesMockRecorder) SetTemporaryMessage(telegramID, messageID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) SetTemporaryMessage(ctx, telegramID, messageID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemporaryMessage", reflect.TypeOf((*MockUseCases)(nil).SetTemporaryMessage), telegramID, messageID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemporaryMessage", reflect.TypeOf((*MockUseCases)(nil).SetTemporaryMessage), ctx, telegramID, messageID)
 }
 
 // SetTemporaryStep mocks base method.
-func (m *MockUseCases) SetTemporaryStep(telegramID, step int) error {
+func (m *MockUseCases) SetTemporaryStep(ctx context.Context, telegramID, step int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetTemporaryStep", telegramID, step)
+	ret := m.ctrl.Call(m, "SetTemporaryStep", ctx, telegramID, step)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetTemporaryStep indicates an expected call of SetTemporaryStep.
-func (mr *MockUseCasesMockRecorder) SetTemporaryStep(telegramID, step any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) SetTemporaryStep(ctx, telegramID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemporaryStep", reflect.TypeOf((*MockUseCases)(nil).SetTemporaryStep), ctx, telegramID, step)
+}
+
+// SetTemporaryStepAndMessage mocks base method.
+func (m *MockUseCases) SetTemporaryStepAndMessage(ctx context.Context, telegramID, step int, messageID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTemporaryStepAndMessage", ctx, telegramID, step, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTemporaryStepAndMessage indicates an expected call of SetTemporaryStepAndMessage.
+func (mr *MockUseCasesMockRecorder) SetTemporaryStepAndMessage(ctx, telegramID, step, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemporaryStepAndMessage", reflect.TypeOf((*MockUseCases)(nil).SetTemporaryStepAndMessage), ctx, telegramID, step, messageID)
+}
+
+// SkipGroupWatering mocks base method.
+func (m *MockUseCases) SkipGroupWatering(ctx context.Context, id int) (*entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipGroupWatering", ctx, id)
+	ret0, _ := ret[0].(*entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkipGroupWatering indicates an expected call of SkipGroupWatering.
+func (mr *MockUseCasesMockRecorder) SkipGroupWatering(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemporaryStep", reflect.TypeOf((*MockUseCases)(nil).SetTemporaryStep), telegramID, step)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipGroupWatering", reflect.TypeOf((*MockUseCases)(nil).SkipGroupWatering), ctx, id)
+}
+
+// SnoozeGroup mocks base method.
+func (m *MockUseCases) SnoozeGroup(ctx context.Context, id int, until time.Time) (*entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeGroup", ctx, id, until)
+	ret0, _ := ret[0].(*entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeGroup indicates an expected call of SnoozeGroup.
+func (mr *MockUseCasesMockRecorder) SnoozeGroup(ctx, id, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeGroup", reflect.TypeOf((*MockUseCases)(nil).SnoozeGroup), ctx, id, until)
+}
+
+// ToggleDelegationGroup mocks base method.
+func (m *MockUseCases) ToggleDelegationGroup(ctx context.Context, telegramID, groupID int) (*entities.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleDelegationGroup", ctx, telegramID, groupID)
+	ret0, _ := ret[0].(*entities.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleDelegationGroup indicates an expected call of ToggleDelegationGroup.
+func (mr *MockUseCasesMockRecorder) ToggleDelegationGroup(ctx, telegramID, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleDelegationGroup", reflect.TypeOf((*MockUseCases)(nil).ToggleDelegationGroup), ctx, telegramID, groupID)
+}
+
+// UpdateCareTaskInterval mocks base method.
+func (m *MockUseCases) UpdateCareTaskInterval(ctx context.Context, id, interval int) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCareTaskInterval", ctx, id, interval)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCareTaskInterval indicates an expected call of UpdateCareTaskInterval.
+func (mr *MockUseCasesMockRecorder) UpdateCareTaskInterval(ctx, id, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCareTaskInterval", reflect.TypeOf((*MockUseCases)(nil).UpdateCareTaskInterval), ctx, id, interval)
+}
+
+// UpdateCareTaskLastDate mocks base method.
+func (m *MockUseCases) UpdateCareTaskLastDate(ctx context.Context, id int, lastCareDate time.Time) (*entities.CareTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCareTaskLastDate", ctx, id, lastCareDate)
+	ret0, _ := ret[0].(*entities.CareTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCareTaskLastDate indicates an expected call of UpdateCareTaskLastDate.
+func (mr *MockUseCasesMockRecorder) UpdateCareTaskLastDate(ctx, id, lastCareDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCareTaskLastDate", reflect.TypeOf((*MockUseCases)(nil).UpdateCareTaskLastDate), ctx, id, lastCareDate)
 }
 
 // UpdateGroupDescription mocks base method.
-func (m *MockUseCases) UpdateGroupDescription(id int, description string) (*entities.Group, error) {
+func (m *MockUseCases) UpdateGroupDescription(ctx context.Context, id int, description string) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGroupDescription", id, description)
+	ret := m.ctrl.Call(m, "UpdateGroupDescription", ctx, id, description)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateGroupDescription indicates an expected call of UpdateGroupDescription.
-func (mr *MockUseCasesMockRecorder) UpdateGroupDescription(id, description any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdateGroupDescription(ctx, id, description any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupDescription", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupDescription), id, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupDescription", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupDescription), ctx, id, description)
 }
 
 // UpdateGroupLastWateringDate mocks base method.
-func (m *MockUseCases) UpdateGroupLastWateringDate(id int, lastWateringDate time.Time) (*entities.Group, error) {
+func (m *MockUseCases) UpdateGroupLastWateringDate(ctx context.Context, id int, lastWateringDate time.Time, source string, userID int) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGroupLastWateringDate", id, lastWateringDate)
+	ret := m.ctrl.Call(m, "UpdateGroupLastWateringDate", ctx, id, lastWateringDate, source, userID)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateGroupLastWateringDate indicates an expected call of UpdateGroupLastWateringDate.
-func (mr *MockUseCasesMockRecorder) UpdateGroupLastWateringDate(id, lastWateringDate any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdateGroupLastWateringDate(ctx, id, lastWateringDate, source, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupLastWateringDate", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupLastWateringDate), ctx, id, lastWateringDate, source, userID)
+}
+
+// UpdateGroupSeasonSchedule mocks base method.
+func (m *MockUseCases) UpdateGroupSeasonSchedule(ctx context.Context, id int, seasonSchedule entities.SeasonSchedule) (*entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupSeasonSchedule", ctx, id, seasonSchedule)
+	ret0, _ := ret[0].(*entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroupSeasonSchedule indicates an expected call of UpdateGroupSeasonSchedule.
+func (mr *MockUseCasesMockRecorder) UpdateGroupSeasonSchedule(ctx, id, seasonSchedule any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupLastWateringDate", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupLastWateringDate), id, lastWateringDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupSeasonSchedule", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupSeasonSchedule), ctx, id, seasonSchedule)
 }
 
 // UpdateGroupTitle mocks base method.
-func (m *MockUseCases) UpdateGroupTitle(id int, title string) (*entities.Group, error) {
+func (m *MockUseCases) UpdateGroupTitle(ctx context.Context, id int, title string) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGroupTitle", id, title)
+	ret := m.ctrl.Call(m, "UpdateGroupTitle", ctx, id, title)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateGroupTitle indicates an expected call of UpdateGroupTitle.
-func (mr *MockUseCasesMockRecorder) UpdateGroupTitle(id, title any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdateGroupTitle(ctx, id, title any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupTitle", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupTitle), id, title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupTitle", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupTitle), ctx, id, title)
 }
 
 // UpdateGroupWateringInterval mocks base method.
-func (m *MockUseCases) UpdateGroupWateringInterval(id, wateringInterval int) (*entities.Group, error) {
+func (m *MockUseCases) UpdateGroupWateringInterval(ctx context.Context, id, wateringInterval int) (*entities.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGroupWateringInterval", id, wateringInterval)
+	ret := m.ctrl.Call(m, "UpdateGroupWateringInterval", ctx, id, wateringInterval)
 	ret0, _ := ret[0].(*entities.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateGroupWateringInterval indicates an expected call of UpdateGroupWateringInterval.
-func (mr *MockUseCasesMockRecorder) UpdateGroupWateringInterval(id, wateringInterval any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdateGroupWateringInterval(ctx, id, wateringInterval any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupWateringInterval", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupWateringInterval), id, wateringInterval)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupWateringInterval", reflect.TypeOf((*MockUseCases)(nil).UpdateGroupWateringInterval), ctx, id, wateringInterval)
 }
 
 // UpdatePlantDescription mocks base method.
-func (m *MockUseCases) UpdatePlantDescription(id int, description string) (*entities.Plant, error) {
+func (m *MockUseCases) UpdatePlantDescription(ctx context.Context, id int, description string) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePlantDescription", id, description)
+	ret := m.ctrl.Call(m, "UpdatePlantDescription", ctx, id, description)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePlantDescription indicates an expected call of UpdatePlantDescription.
-func (mr *MockUseCasesMockRecorder) UpdatePlantDescription(id, description any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdatePlantDescription(ctx, id, description any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantDescription", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantDescription), id, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantDescription", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantDescription), ctx, id, description)
 }
 
 // UpdatePlantGroup mocks base method.
-func (m *MockUseCases) UpdatePlantGroup(id, groupID int) (*entities.Plant, error) {
+func (m *MockUseCases) UpdatePlantGroup(ctx context.Context, id, groupID int) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePlantGroup", id, groupID)
+	ret := m.ctrl.Call(m, "UpdatePlantGroup", ctx, id, groupID)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePlantGroup indicates an expected call of UpdatePlantGroup.
-func (mr *MockUseCasesMockRecorder) UpdatePlantGroup(id, groupID any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdatePlantGroup(ctx, id, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantGroup", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantGroup), ctx, id, groupID)
+}
+
+// UpdatePlantLastWateringDate mocks base method.
+func (m *MockUseCases) UpdatePlantLastWateringDate(ctx context.Context, id int, lastWateringDate time.Time, source string, userID int) (*entities.Plant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlantLastWateringDate", ctx, id, lastWateringDate, source, userID)
+	ret0, _ := ret[0].(*entities.Plant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePlantLastWateringDate indicates an expected call of UpdatePlantLastWateringDate.
+func (mr *MockUseCasesMockRecorder) UpdatePlantLastWateringDate(ctx, id, lastWateringDate, source, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantGroup", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantGroup), id, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantLastWateringDate", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantLastWateringDate), ctx, id, lastWateringDate, source, userID)
 }
 
 // UpdatePlantPhoto mocks base method.
-func (m *MockUseCases) UpdatePlantPhoto(id int, photo []byte) (*entities.Plant, error) {
+func (m *MockUseCases) UpdatePlantPhoto(ctx context.Context, id int, fileID, fileUniqueID string) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePlantPhoto", id, photo)
+	ret := m.ctrl.Call(m, "UpdatePlantPhoto", ctx, id, fileID, fileUniqueID)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePlantPhoto indicates an expected call of UpdatePlantPhoto.
-func (mr *MockUseCasesMockRecorder) UpdatePlantPhoto(id, photo any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdatePlantPhoto(ctx, id, fileID, fileUniqueID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantPhoto", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantPhoto), id, photo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantPhoto", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantPhoto), ctx, id, fileID, fileUniqueID)
 }
 
 // UpdatePlantTitle mocks base method.
-func (m *MockUseCases) UpdatePlantTitle(id int, title string) (*entities.Plant, error) {
+func (m *MockUseCases) UpdatePlantTitle(ctx context.Context, id int, title string) (*entities.Plant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePlantTitle", id, title)
+	ret := m.ctrl.Call(m, "UpdatePlantTitle", ctx, id, title)
 	ret0, _ := ret[0].(*entities.Plant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePlantTitle indicates an expected call of UpdatePlantTitle.
-func (mr *MockUseCasesMockRecorder) UpdatePlantTitle(id, title any) *gomock.Call {
+func (mr *MockUseCasesMockRecorder) UpdatePlantTitle(ctx, id, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantTitle", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantTitle), ctx, id, title)
+}
+
+// UpdatePlantWateringInterval mocks base method.
+func (m *MockUseCases) UpdatePlantWateringInterval(ctx context.Context, id int, wateringInterval *int) (*entities.Plant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlantWateringInterval", ctx, id, wateringInterval)
+	ret0, _ := ret[0].(*entities.Plant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePlantWateringInterval indicates an expected call of UpdatePlantWateringInterval.
+func (mr *MockUseCasesMockRecorder) UpdatePlantWateringInterval(ctx, id, wateringInterval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantWateringInterval", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantWateringInterval), ctx, id, wateringInterval)
+}
+
+// UpdateUserNotificationsDigest mocks base method.
+func (m *MockUseCases) UpdateUserNotificationsDigest(ctx context.Context, id int, digest bool) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserNotificationsDigest", ctx, id, digest)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserNotificationsDigest indicates an expected call of UpdateUserNotificationsDigest.
+func (mr *MockUseCasesMockRecorder) UpdateUserNotificationsDigest(ctx, id, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserNotificationsDigest", reflect.TypeOf((*MockUseCases)(nil).UpdateUserNotificationsDigest), ctx, id, digest)
+}
+
+// UpdateUserNotifyHour mocks base method.
+func (m *MockUseCases) UpdateUserNotifyHour(ctx context.Context, id, notifyHour int) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserNotifyHour", ctx, id, notifyHour)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserNotifyHour indicates an expected call of UpdateUserNotifyHour.
+func (mr *MockUseCasesMockRecorder) UpdateUserNotifyHour(ctx, id, notifyHour any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserNotifyHour", reflect.TypeOf((*MockUseCases)(nil).UpdateUserNotifyHour), ctx, id, notifyHour)
+}
+
+// UpdateUserQuietHours mocks base method.
+func (m *MockUseCases) UpdateUserQuietHours(ctx context.Context, id, start, end int) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserQuietHours", ctx, id, start, end)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserQuietHours indicates an expected call of UpdateUserQuietHours.
+func (mr *MockUseCasesMockRecorder) UpdateUserQuietHours(ctx, id, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserQuietHours", reflect.TypeOf((*MockUseCases)(nil).UpdateUserQuietHours), ctx, id, start, end)
+}
+
+// UpdateUserQuietWeekdays mocks base method.
+func (m *MockUseCases) UpdateUserQuietWeekdays(ctx context.Context, id, weekdays int) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserQuietWeekdays", ctx, id, weekdays)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserQuietWeekdays indicates an expected call of UpdateUserQuietWeekdays.
+func (mr *MockUseCasesMockRecorder) UpdateUserQuietWeekdays(ctx, id, weekdays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserQuietWeekdays", reflect.TypeOf((*MockUseCases)(nil).UpdateUserQuietWeekdays), ctx, id, weekdays)
+}
+
+// UpdateUserTimezone mocks base method.
+func (m *MockUseCases) UpdateUserTimezone(ctx context.Context, id int, timezone string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTimezone", ctx, id, timezone)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTimezone indicates an expected call of UpdateUserTimezone.
+func (mr *MockUseCasesMockRecorder) UpdateUserTimezone(ctx, id, timezone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTimezone", reflect.TypeOf((*MockUseCases)(nil).UpdateUserTimezone), ctx, id, timezone)
+}
+
+// UpdateUserVacation mocks base method.
+func (m *MockUseCases) UpdateUserVacation(ctx context.Context, id int, start, end *time.Time) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserVacation", ctx, id, start, end)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserVacation indicates an expected call of UpdateUserVacation.
+func (mr *MockUseCasesMockRecorder) UpdateUserVacation(ctx, id, start, end any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlantTitle", reflect.TypeOf((*MockUseCases)(nil).UpdatePlantTitle), id, title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserVacation", reflect.TypeOf((*MockUseCases)(nil).UpdateUserVacation), ctx, id, start, end)
 }